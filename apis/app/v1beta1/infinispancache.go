@@ -0,0 +1,99 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	api "github.com/kiegroup/kogito-operator/apis"
+)
+
+// InfinispanCacheTemplate defines the configuration of an Infinispan Cache created for the Kogito services.
+// +k8s:openapi-gen=true
+type InfinispanCacheTemplate struct {
+	// Name of a cache template defined in the Infinispan server, for example org.infinispan.DIST_SYNC. Ignored when template is set.
+	// +optional
+	TemplateName string `json:"templateName,omitempty"`
+
+	// Configuration of the cache in XML, JSON or YAML, as accepted by the spec.template field of the Infinispan Cache CR.
+	// +optional
+	Template string `json:"template,omitempty"`
+}
+
+// GetTemplateName ...
+func (i *InfinispanCacheTemplate) GetTemplateName() string {
+	return i.TemplateName
+}
+
+// GetTemplate ...
+func (i *InfinispanCacheTemplate) GetTemplate() string {
+	return i.Template
+}
+
+// IsEmpty checks if neither a template nor a template name is set
+func (i *InfinispanCacheTemplate) IsEmpty() bool {
+	return len(i.TemplateName) == 0 && len(i.Template) == 0
+}
+
+// InfinispanCachesConfig defines the Infinispan Caches created for the Kogito services bound to an Infinispan infra instance:
+// the process instances and Data Index domain caches of the processes declared in the protobuf files of the KogitoRuntimes, and the jobs cache.
+// +k8s:openapi-gen=true
+type InfinispanCachesConfig struct {
+	// Disables the creation of the Caches, the Kogito services create their caches with the Infinispan default configuration.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Template of all the caches without a template of their own.
+	// Defaults to a distributed cache with protostream encoding, a file store and at most 100000 entries in memory.
+	// +optional
+	Defaults InfinispanCacheTemplate `json:"defaults,omitempty"`
+
+	// Template of the caches storing the process instances of the KogitoRuntimes.
+	// +optional
+	ProcessInstances InfinispanCacheTemplate `json:"processInstances,omitempty"`
+
+	// Template of the cache storing the jobs of the Jobs Service.
+	// +optional
+	Jobs InfinispanCacheTemplate `json:"jobs,omitempty"`
+
+	// Template of the caches storing the domain data indexed by Data Index.
+	// +optional
+	DataIndexDomain InfinispanCacheTemplate `json:"dataIndexDomain,omitempty"`
+}
+
+// IsDisabled ...
+func (i *InfinispanCachesConfig) IsDisabled() bool {
+	return i.Disabled
+}
+
+// GetDefaults ...
+func (i *InfinispanCachesConfig) GetDefaults() api.InfinispanCacheTemplateInterface {
+	return &i.Defaults
+}
+
+// GetTemplate returns the template of the given kind of cache, the defaults when it has none
+func (i *InfinispanCachesConfig) GetTemplate(kind api.InfinispanCacheKind) api.InfinispanCacheTemplateInterface {
+	var template *InfinispanCacheTemplate
+	switch kind {
+	case api.ProcessInstancesCacheKind:
+		template = &i.ProcessInstances
+	case api.JobsCacheKind:
+		template = &i.Jobs
+	case api.DataIndexDomainCacheKind:
+		template = &i.DataIndexDomain
+	}
+	if template == nil || template.IsEmpty() {
+		return &i.Defaults
+	}
+	return template
+}
@@ -56,13 +56,12 @@ type KogitoBuildSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kogito Git Source"
 	GitSource GitSource `json:"gitSource,omitempty"`
 
-	// Which runtime Kogito service base image to use when building the Kogito service.
+	// Which runtime Kogito service base image to use when building the Kogito service, either quarkus, springboot or a runtime profile registered in the operator.
 	// If "BuildImage" is set, this value is ignored by the operator.
 	// Default value: quarkus.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Runtime"
 	// +optional
-	// +kubebuilder:validation:Enum=quarkus;springboot
 	Runtime api.RuntimeType `json:"runtime,omitempty"`
 
 	// WebHooks secrets for source to image builds based on Git repositories (Remote Sources).
@@ -70,6 +69,12 @@ type KogitoBuildSpec struct {
 	// +optional
 	WebHooks []WebHookSecret `json:"webHooks,omitempty"`
 
+	// Patches applied to the objects generated by the operator before they're compared with the deployed ones.
+	// Each patch targets the generated objects by kind and, optionally, by name.
+	// +listType=atomic
+	// +optional
+	Patches []KogitoPatch `json:"patches,omitempty"`
+
 	// Native indicates if the Kogito Service built should be compiled to run on native mode when Runtime is Quarkus (Source to Image build only).
 	//
 	// For more information, see https://www.graalvm.org/docs/reference-manual/aot-compilation/.
@@ -242,6 +247,26 @@ func (k *KogitoBuildSpec) SetWebHooks(webhooks []api.WebHookSecretInterface) {
 	k.WebHooks = newWebHooks
 }
 
+// GetPatches ...
+func (k *KogitoBuildSpec) GetPatches() []api.KogitoPatchInterface {
+	patches := make([]api.KogitoPatchInterface, len(k.Patches))
+	for i, v := range k.Patches {
+		patches[i] = api.KogitoPatchInterface(v)
+	}
+	return patches
+}
+
+// SetPatches ...
+func (k *KogitoBuildSpec) SetPatches(patches []api.KogitoPatchInterface) {
+	var newPatches []KogitoPatch
+	for _, patch := range patches {
+		if newPatch, ok := patch.(KogitoPatch); ok {
+			newPatches = append(newPatches, newPatch)
+		}
+	}
+	k.Patches = newPatches
+}
+
 // IsNative ...
 func (k *KogitoBuildSpec) IsNative() bool {
 	return k.Native
@@ -513,6 +538,16 @@ type KogitoBuildList struct {
 	Items []KogitoBuild `json:"items"`
 }
 
+// GetItems ...
+func (k *KogitoBuildList) GetItems() []api.KogitoBuildInterface {
+	models := make([]api.KogitoBuildInterface, len(k.Items))
+	for i, v := range k.Items {
+		item := v
+		models[i] = &item
+	}
+	return models
+}
+
 func init() {
 	SchemeBuilder.Register(&KogitoBuild{}, &KogitoBuildList{})
 }
@@ -0,0 +1,232 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	api "github.com/kiegroup/kogito-operator/apis"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KogitoEventTopologySpec defines the desired state of KogitoEventTopology.
+// The topology covers every Kogito service of the namespace, so there's nothing to configure yet.
+// +k8s:openapi-gen=true
+type KogitoEventTopologySpec struct {
+}
+
+// KogitoEventTopologyStatus defines the observed state of KogitoEventTopology.
+// +k8s:openapi-gen=true
+type KogitoEventTopologyStatus struct {
+	// CloudEvents exchanged by the Kogito services of the namespace, with the services producing and consuming them.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Events []KogitoEventTopologyEvent `json:"events,omitempty"`
+
+	// Types of the CloudEvents consumed by a Kogito service but produced by none.
+	// +optional
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	OrphanConsumers []string `json:"orphanConsumers,omitempty"`
+
+	// Types of the CloudEvents produced by a Kogito service but consumed by none.
+	// +optional
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	UnconsumedProducers []string `json:"unconsumedProducers,omitempty"`
+}
+
+// GetEvents ...
+func (k *KogitoEventTopologyStatus) GetEvents() []api.KogitoEventTopologyEventInterface {
+	events := make([]api.KogitoEventTopologyEventInterface, len(k.Events))
+	for i := range k.Events {
+		events[i] = &k.Events[i]
+	}
+	return events
+}
+
+// SetEvents ...
+func (k *KogitoEventTopologyStatus) SetEvents(events []api.KogitoEventTopologyEventInterface) {
+	var newEvents []KogitoEventTopologyEvent
+	for _, event := range events {
+		newEvents = append(newEvents, KogitoEventTopologyEvent{
+			Type:      event.GetType(),
+			Producers: toKogitoEventEndpoints(event.GetProducers()),
+			Consumers: toKogitoEventEndpoints(event.GetConsumers()),
+		})
+	}
+	k.Events = newEvents
+}
+
+// GetOrphanConsumers ...
+func (k *KogitoEventTopologyStatus) GetOrphanConsumers() []string {
+	return k.OrphanConsumers
+}
+
+// SetOrphanConsumers ...
+func (k *KogitoEventTopologyStatus) SetOrphanConsumers(orphanConsumers []string) {
+	k.OrphanConsumers = orphanConsumers
+}
+
+// GetUnconsumedProducers ...
+func (k *KogitoEventTopologyStatus) GetUnconsumedProducers() []string {
+	return k.UnconsumedProducers
+}
+
+// SetUnconsumedProducers ...
+func (k *KogitoEventTopologyStatus) SetUnconsumedProducers(unconsumedProducers []string) {
+	k.UnconsumedProducers = unconsumedProducers
+}
+
+// KogitoEventTopologyEvent describes the Kogito services producing and consuming a given CloudEvent type.
+// +k8s:openapi-gen=true
+type KogitoEventTopologyEvent struct {
+	// CloudEvent type.
+	Type string `json:"type"`
+
+	// Kogito services producing the CloudEvent.
+	// +optional
+	// +listType=atomic
+	Producers []KogitoEventEndpoint `json:"producers,omitempty"`
+
+	// Kogito services consuming the CloudEvent.
+	// +optional
+	// +listType=atomic
+	Consumers []KogitoEventEndpoint `json:"consumers,omitempty"`
+}
+
+// GetType ...
+func (k *KogitoEventTopologyEvent) GetType() string {
+	return k.Type
+}
+
+// GetProducers ...
+func (k *KogitoEventTopologyEvent) GetProducers() []api.KogitoEventEndpointInterface {
+	return fromKogitoEventEndpoints(k.Producers)
+}
+
+// GetConsumers ...
+func (k *KogitoEventTopologyEvent) GetConsumers() []api.KogitoEventEndpointInterface {
+	return fromKogitoEventEndpoints(k.Consumers)
+}
+
+// KogitoEventEndpoint describes a Kogito service producing or consuming a CloudEvent, and the channel it uses.
+// +k8s:openapi-gen=true
+type KogitoEventEndpoint struct {
+	// Name of the Kogito service.
+	Service string `json:"service"`
+
+	// CloudEvent source attribute declared by the service.
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Kind of the channel through which the CloudEvent is exchanged, either KafkaTopic or KnativeBroker.
+	// +kubebuilder:validation:Enum=KafkaTopic;KnativeBroker
+	// +optional
+	ChannelKind api.KogitoEventChannelKind `json:"channelKind,omitempty"`
+
+	// Name of the Kafka topic or of the Knative Eventing Broker.
+	// +optional
+	Channel string `json:"channel,omitempty"`
+}
+
+// GetService ...
+func (k KogitoEventEndpoint) GetService() string {
+	return k.Service
+}
+
+// GetSource ...
+func (k KogitoEventEndpoint) GetSource() string {
+	return k.Source
+}
+
+// GetChannelKind ...
+func (k KogitoEventEndpoint) GetChannelKind() api.KogitoEventChannelKind {
+	return k.ChannelKind
+}
+
+// GetChannel ...
+func (k KogitoEventEndpoint) GetChannel() string {
+	return k.Channel
+}
+
+func fromKogitoEventEndpoints(endpoints []KogitoEventEndpoint) []api.KogitoEventEndpointInterface {
+	result := make([]api.KogitoEventEndpointInterface, len(endpoints))
+	for i, endpoint := range endpoints {
+		result[i] = endpoint
+	}
+	return result
+}
+
+func toKogitoEventEndpoints(endpoints []api.KogitoEventEndpointInterface) []KogitoEventEndpoint {
+	var result []KogitoEventEndpoint
+	for _, endpoint := range endpoints {
+		result = append(result, KogitoEventEndpoint{
+			Service:     endpoint.GetService(),
+			Source:      endpoint.GetSource(),
+			ChannelKind: endpoint.GetChannelKind(),
+			Channel:     endpoint.GetChannel(),
+		})
+	}
+	return result
+}
+
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +genclient
+// +kubebuilder:resource:path=kogitoeventtopologies,scope=Namespaced
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Orphan Consumers",type="string",JSONPath=".status.orphanConsumers",description="CloudEvents consumed but not produced"
+// +kubebuilder:printcolumn:name="Unconsumed Producers",type="string",JSONPath=".status.unconsumedProducers",description="CloudEvents produced but not consumed"
+// +operator-sdk:csv:customresourcedefinitions:displayName="Kogito Event Topology"
+
+// KogitoEventTopology is the resource exposing in its status the CloudEvents exchanged by the Kogito services of its namespace:
+// which services produce each CloudEvent type, which services consume it, and through which Kafka topic or Knative Eventing Broker.
+//
+// Please refer to the Kogito Operator documentation (https://docs.jboss.org/kogito/release/latest/html_single/) for more information.
+type KogitoEventTopology struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KogitoEventTopologySpec   `json:"spec,omitempty"`
+	Status KogitoEventTopologyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KogitoEventTopologyList contains a list of KogitoEventTopology.
+type KogitoEventTopologyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KogitoEventTopology `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KogitoEventTopology{}, &KogitoEventTopologyList{})
+}
+
+// GetStatus provide status of Kogito event topology
+func (k *KogitoEventTopology) GetStatus() api.KogitoEventTopologyStatusInterface {
+	return &k.Status
+}
+
+// GetItems ...
+func (k *KogitoEventTopologyList) GetItems() []api.KogitoEventTopologyInterface {
+	items := make([]api.KogitoEventTopologyInterface, len(k.Items))
+	for i := range k.Items {
+		items[i] = &k.Items[i]
+	}
+	return items
+}
@@ -26,6 +26,7 @@ type KogitoInfraSpec struct {
 	// Add custom validation using kubebuilder tags: https://book-v1.book.kubebuilder.io/beyond_basics/generating_crd.html
 
 	// Resource for the service. Example: Infinispan/Kafka/Keycloak.
+	// When the resource name is not given and a provision template is set, the operator creates the resource.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Resource *InfraResource `json:"resource,omitempty"`
@@ -35,6 +36,12 @@ type KogitoInfraSpec struct {
 	// Optional properties which would be needed to setup correct runtime/service configuration, based on the resource type.
 	//
 	// For example, MongoDB will require `username` and `database` as properties for a correct setup, else it will fail
+	//
+	// Kafka accepts `listener` and `listener-type` to select the listener of the cluster, `kafka-user` to reference the KafkaUser
+	// of the services on listeners with authentication and `create-kafka-user` set to `true` to create it when missing.
+	//
+	// Infinispan and MongoDB accept `credential-rotation-grace-period`, a duration like `10m` during which the services keep
+	// the previous credentials once the credential Secret of the resource is rotated, for resources accepting both meanwhile.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	InfraProperties map[string]string `json:"infraProperties,omitempty"`
 
@@ -67,6 +74,34 @@ type KogitoInfraSpec struct {
 	// List of secret that should be munted to the services bound to this infra instance
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	SecretVolumeReferences []VolumeReference `json:"secretVolumeReferences,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	// List of secrets stored in HashiCorp Vault that should be added to the services as envs or volumes once materialized in the namespace
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	VaultSecrets []VaultSecretReference `json:"vaultSecrets,omitempty"`
+
+	// Settings of the KafkaTopics created for the topics of the services bound to this Kafka infra instance.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	KafkaTopics KafkaTopicsConfig `json:"kafkaTopics,omitempty"`
+
+	// Settings of the Knative Triggers created for the events consumed by the services bound to this Knative Eventing infra instance.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	KnativeTriggers KnativeTriggersConfig `json:"knativeTriggers,omitempty"`
+
+	// Settings of the Infinispan Caches created for the services bound to this Infinispan infra instance.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	InfinispanCaches InfinispanCachesConfig `json:"infinispanCaches,omitempty"`
+
+	// Template of the Infinispan, Kafka, MongoDB or Keycloak resource created by the operator when the resource name is not given.
+	// The created resource is named after this KogitoInfra, owned by it and deleted with it.
+	// Meant for development and test namespaces, use an existing resource otherwise.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Provision *InfraProvision `json:"provision,omitempty"`
 }
 
 // GetResource ...
@@ -84,6 +119,29 @@ func (k *KogitoInfraSpec) GetInfraProperties() map[string]string {
 	return k.InfraProperties
 }
 
+// GetKafkaTopics ...
+func (k *KogitoInfraSpec) GetKafkaTopics() api.KafkaTopicsConfigInterface {
+	return &k.KafkaTopics
+}
+
+// GetKnativeTriggers ...
+func (k *KogitoInfraSpec) GetKnativeTriggers() api.KnativeTriggersConfigInterface {
+	return &k.KnativeTriggers
+}
+
+// GetInfinispanCaches ...
+func (k *KogitoInfraSpec) GetInfinispanCaches() api.InfinispanCachesConfigInterface {
+	return &k.InfinispanCaches
+}
+
+// GetProvision ...
+func (k *KogitoInfraSpec) GetProvision() api.InfraProvisionInterface {
+	if k.Provision == nil {
+		return nil
+	}
+	return k.Provision
+}
+
 // GetEnvs ...
 func (k *KogitoInfraSpec) GetEnvs() []corev1.EnvVar {
 	return k.Envs
@@ -131,6 +189,16 @@ func (k *KogitoInfraSpec) GetSecretVolumeReferences() []api.VolumeReferenceInter
 	return newSecretVolumeReferences
 }
 
+// GetVaultSecrets ...
+func (k *KogitoInfraSpec) GetVaultSecrets() []api.VaultSecretReferenceInterface {
+	vaultSecrets := make([]api.VaultSecretReferenceInterface, len(k.VaultSecrets))
+	for i, v := range k.VaultSecrets {
+		item := v
+		vaultSecrets[i] = &item
+	}
+	return vaultSecrets
+}
+
 // KogitoInfraStatus defines the observed state of KogitoInfra.
 // +k8s:openapi-gen=true
 type KogitoInfraStatus struct {
@@ -169,6 +237,11 @@ type KogitoInfraStatus struct {
 	// List of secret that should be added as volume mount to this infra instance
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	SecretVolumeReferences []VolumeReference `json:"secretVolumeReferences,omitempty"`
+
+	// +optional
+	// Credentials of the infrastructure resource copied in the Secrets provided to the services
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Credential *CredentialStatus `json:"credential,omitempty"`
 }
 
 // GetConditions ...
@@ -290,6 +363,65 @@ func (k *KogitoInfraStatus) AddSecretVolumeReference(name string, mountPath stri
 	k.SecretVolumeReferences = append(k.SecretVolumeReferences, volumeReference)
 }
 
+// GetCredential ...
+func (k *KogitoInfraStatus) GetCredential() api.CredentialStatusInterface {
+	if k.Credential == nil {
+		return nil
+	}
+	return k.Credential
+}
+
+// SetCredential ...
+func (k *KogitoInfraStatus) SetCredential(secretName, secretNamespace string, revision int64, hash string, rotationPendingSince *metav1.Time) {
+	k.Credential = &CredentialStatus{
+		SecretName:           secretName,
+		SecretNamespace:      secretNamespace,
+		Revision:             revision,
+		Hash:                 hash,
+		RotationPendingSince: rotationPendingSince,
+	}
+}
+
+// CredentialStatus reports the credentials copied from the Secret of the infrastructure resource
+type CredentialStatus struct {
+	// Name of the Secret the credentials are copied from
+	SecretName string `json:"secretName"`
+	// Namespace of the Secret the credentials are copied from
+	SecretNamespace string `json:"secretNamespace"`
+	// Revision of the credentials provided to the services, incremented every time the source Secret is rotated
+	Revision int64 `json:"revision"`
+	// Hash of the credentials provided to the services
+	Hash string `json:"hash"`
+	// Time the rotation of the source Secret was detected, set while the services keep the previous credentials during the grace period
+	// +optional
+	RotationPendingSince *metav1.Time `json:"rotationPendingSince,omitempty"`
+}
+
+// GetSecretName ...
+func (c *CredentialStatus) GetSecretName() string {
+	return c.SecretName
+}
+
+// GetSecretNamespace ...
+func (c *CredentialStatus) GetSecretNamespace() string {
+	return c.SecretNamespace
+}
+
+// GetRevision ...
+func (c *CredentialStatus) GetRevision() int64 {
+	return c.Revision
+}
+
+// GetHash ...
+func (c *CredentialStatus) GetHash() string {
+	return c.Hash
+}
+
+// GetRotationPendingSince ...
+func (c *CredentialStatus) GetRotationPendingSince() *metav1.Time {
+	return c.RotationPendingSince
+}
+
 // InfraResource provide reference infra resource
 type InfraResource struct {
 
@@ -309,10 +441,11 @@ type InfraResource struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Namespace"
 	Namespace string `json:"namespace,omitempty"`
 
-	// Name of referred resource.
+	// +optional
+	// Name of referred resource. Required unless the resource is provisioned by the operator.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name"
-	Name string `json:"name"`
+	Name string `json:"name,omitempty"`
 }
 
 // GetAPIVersion ...
@@ -394,6 +527,16 @@ type KogitoInfraList struct {
 	Items           []KogitoInfra `json:"items"`
 }
 
+// GetItems ...
+func (k *KogitoInfraList) GetItems() []api.KogitoInfraInterface {
+	models := make([]api.KogitoInfraInterface, len(k.Items))
+	for i, v := range k.Items {
+		item := v
+		models[i] = &item
+	}
+	return models
+}
+
 func init() {
 	SchemeBuilder.Register(&KogitoInfra{}, &KogitoInfraList{})
 }
@@ -407,3 +550,30 @@ func (k *KogitoInfra) GetSpec() api.KogitoInfraSpecInterface {
 func (k *KogitoInfra) GetStatus() api.KogitoInfraStatusInterface {
 	return &k.Status
 }
+
+// InfraProvision is the template of the infrastructure resource provisioned by the operator
+type InfraProvision struct {
+	// Number of replicas of the provisioned Infinispan or Kafka cluster, members of the MongoDB replica set or instances of Keycloak.
+	//
+	// Default value: 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Version of the provisioned Kafka or MongoDB, the default version of the operator managing the resource when not set.
+	// MongoDB requires it, 4.4.6 is provisioned when not set.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Version string `json:"version,omitempty"`
+}
+
+// GetReplicas ...
+func (p *InfraProvision) GetReplicas() *int32 {
+	return p.Replicas
+}
+
+// GetVersion ...
+func (p *InfraProvision) GetVersion() string {
+	return p.Version
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import "github.com/kiegroup/kogito-operator/apis"
+
+// KogitoPatch patch applied to an object generated by the operator before it's compared with the deployed one.
+// +k8s:openapi-gen=true
+type KogitoPatch struct {
+	// Kind of the generated objects to patch, for example Deployment, Service, Route, ServiceMonitor, KafkaTopic, Trigger, SinkBinding or BuildConfig.
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
+	// Name of the generated object to patch. If empty, every generated object of the given kind is patched.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Type of the patch, either StrategicMerge, Merge or JSON. Defaults to StrategicMerge.
+	// +kubebuilder:validation:Enum=StrategicMerge;Merge;JSON
+	// +optional
+	Type api.PatchType `json:"type,omitempty"`
+	// Patch in JSON or YAML format.
+	// +kubebuilder:validation:Required
+	Patch string `json:"patch"`
+}
+
+// GetKind ...
+func (p KogitoPatch) GetKind() string {
+	return p.Kind
+}
+
+// GetName ...
+func (p KogitoPatch) GetName() string {
+	return p.Name
+}
+
+// GetType ...
+func (p KogitoPatch) GetType() api.PatchType {
+	if len(p.Type) == 0 {
+		return api.StrategicMergePatchType
+	}
+	return p.Type
+}
+
+// GetPatch ...
+func (p KogitoPatch) GetPatch() string {
+	return p.Patch
+}
@@ -0,0 +1,149 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import corev1 "k8s.io/api/core/v1"
+
+// KogitoPodTemplate customizes the pod generated by the operator for the service.
+// It's merged with the generated pod: the lists are appended to the generated ones, replacing the items with the same name.
+type KogitoPodTemplate struct {
+	// Containers added to the pod next to the service container, for example an OAuth proxy or a log shipper.
+	// +optional
+	Containers []corev1.Container `json:"containers,omitempty"`
+
+	// Init containers run before the service container, for example to wait for a database or to pre-load models.
+	// +optional
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// Volumes added to the pod.
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// Volume mounts added to the service container.
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// Ports exposed by the service container next to the default http port.
+	// +optional
+	Ports []corev1.ContainerPort `json:"ports,omitempty"`
+
+	// Image pull policy of the service container.
+	// If not provided, defaults to 'Always'.
+	// +optional
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// Secrets used to pull the images of the pod.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// Service account used to run the pod.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// Priority class of the pod.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// GetContainers ...
+func (p *KogitoPodTemplate) GetContainers() []corev1.Container {
+	return p.Containers
+}
+
+// SetContainers ...
+func (p *KogitoPodTemplate) SetContainers(containers []corev1.Container) {
+	p.Containers = containers
+}
+
+// GetInitContainers ...
+func (p *KogitoPodTemplate) GetInitContainers() []corev1.Container {
+	return p.InitContainers
+}
+
+// SetInitContainers ...
+func (p *KogitoPodTemplate) SetInitContainers(initContainers []corev1.Container) {
+	p.InitContainers = initContainers
+}
+
+// GetVolumes ...
+func (p *KogitoPodTemplate) GetVolumes() []corev1.Volume {
+	return p.Volumes
+}
+
+// SetVolumes ...
+func (p *KogitoPodTemplate) SetVolumes(volumes []corev1.Volume) {
+	p.Volumes = volumes
+}
+
+// GetVolumeMounts ...
+func (p *KogitoPodTemplate) GetVolumeMounts() []corev1.VolumeMount {
+	return p.VolumeMounts
+}
+
+// SetVolumeMounts ...
+func (p *KogitoPodTemplate) SetVolumeMounts(volumeMounts []corev1.VolumeMount) {
+	p.VolumeMounts = volumeMounts
+}
+
+// GetPorts ...
+func (p *KogitoPodTemplate) GetPorts() []corev1.ContainerPort {
+	return p.Ports
+}
+
+// SetPorts ...
+func (p *KogitoPodTemplate) SetPorts(ports []corev1.ContainerPort) {
+	p.Ports = ports
+}
+
+// GetImagePullPolicy ...
+func (p *KogitoPodTemplate) GetImagePullPolicy() corev1.PullPolicy {
+	return p.ImagePullPolicy
+}
+
+// SetImagePullPolicy ...
+func (p *KogitoPodTemplate) SetImagePullPolicy(imagePullPolicy corev1.PullPolicy) {
+	p.ImagePullPolicy = imagePullPolicy
+}
+
+// GetImagePullSecrets ...
+func (p *KogitoPodTemplate) GetImagePullSecrets() []corev1.LocalObjectReference {
+	return p.ImagePullSecrets
+}
+
+// SetImagePullSecrets ...
+func (p *KogitoPodTemplate) SetImagePullSecrets(imagePullSecrets []corev1.LocalObjectReference) {
+	p.ImagePullSecrets = imagePullSecrets
+}
+
+// GetServiceAccountName ...
+func (p *KogitoPodTemplate) GetServiceAccountName() string {
+	return p.ServiceAccountName
+}
+
+// SetServiceAccountName ...
+func (p *KogitoPodTemplate) SetServiceAccountName(serviceAccountName string) {
+	p.ServiceAccountName = serviceAccountName
+}
+
+// GetPriorityClassName ...
+func (p *KogitoPodTemplate) GetPriorityClassName() string {
+	return p.PriorityClassName
+}
+
+// SetPriorityClassName ...
+func (p *KogitoPodTemplate) SetPriorityClassName(priorityClassName string) {
+	p.PriorityClassName = priorityClassName
+}
@@ -0,0 +1,265 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	api "github.com/kiegroup/kogito-operator/apis"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultCanaryWeight    = int32(10)
+	defaultAnalysisSeconds = int32(60)
+	defaultMaxErrorRate    = int32(5)
+)
+
+// KogitoRollout defines how a new revision of the service replaces the running one.
+// +k8s:openapi-gen=true
+type KogitoRollout struct {
+	// Strategy used to roll out a new revision, either RollingUpdate, BlueGreen or Canary.
+	// BlueGreen and Canary deploy the new revision in a separate Deployment and promote or abort it based on its readiness and error rate.
+	//
+	// Default value: RollingUpdate
+	// +kubebuilder:validation:Enum=RollingUpdate;BlueGreen;Canary
+	// +optional
+	Strategy api.RolloutStrategyType `json:"strategy,omitempty"`
+
+	// Percentage of the external traffic sent to the new revision while it's analyzed with the Canary strategy.
+	//
+	// Default value: 10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +optional
+	CanaryWeight int32 `json:"canaryWeight,omitempty"`
+
+	// Seconds over which the error rate of the new revision is analyzed once ready, before deciding to promote or abort it.
+	// With Canary, the analysis goes on until the new revision serves at least one request. With BlueGreen, the new revision only
+	// serves the requests sent to its own Service, it's promoted once ready over this period unless it answered them with server errors.
+	//
+	// Default value: 60
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AnalysisSeconds int32 `json:"analysisSeconds,omitempty"`
+
+	// Maximum percentage of HTTP requests answered with a server error by the new revision during the analysis. Above it, the rollout is aborted.
+	//
+	// Default value: 5
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxErrorRate int32 `json:"maxErrorRate,omitempty"`
+
+	// Set to true to wait for a manual promotion once the new revision passes the analysis.
+	// Promote it by annotating the CR with kogito.kie.org/promote-revision set to the candidate revision reported in the status.
+	// +optional
+	DisableAutoPromotion bool `json:"disableAutoPromotion,omitempty"`
+}
+
+// GetStrategy ...
+func (r *KogitoRollout) GetStrategy() api.RolloutStrategyType {
+	if len(r.Strategy) == 0 {
+		return api.RollingUpdateRolloutStrategy
+	}
+	return r.Strategy
+}
+
+// SetStrategy ...
+func (r *KogitoRollout) SetStrategy(strategy api.RolloutStrategyType) {
+	r.Strategy = strategy
+}
+
+// GetCanaryWeight ...
+func (r *KogitoRollout) GetCanaryWeight() int32 {
+	if r.CanaryWeight == 0 {
+		return defaultCanaryWeight
+	}
+	return r.CanaryWeight
+}
+
+// SetCanaryWeight ...
+func (r *KogitoRollout) SetCanaryWeight(canaryWeight int32) {
+	r.CanaryWeight = canaryWeight
+}
+
+// GetAnalysisSeconds ...
+func (r *KogitoRollout) GetAnalysisSeconds() int32 {
+	if r.AnalysisSeconds == 0 {
+		return defaultAnalysisSeconds
+	}
+	return r.AnalysisSeconds
+}
+
+// SetAnalysisSeconds ...
+func (r *KogitoRollout) SetAnalysisSeconds(analysisSeconds int32) {
+	r.AnalysisSeconds = analysisSeconds
+}
+
+// GetMaxErrorRate ...
+func (r *KogitoRollout) GetMaxErrorRate() int32 {
+	if r.MaxErrorRate == 0 {
+		return defaultMaxErrorRate
+	}
+	return r.MaxErrorRate
+}
+
+// SetMaxErrorRate ...
+func (r *KogitoRollout) SetMaxErrorRate(maxErrorRate int32) {
+	r.MaxErrorRate = maxErrorRate
+}
+
+// IsAutoPromotionDisabled ...
+func (r *KogitoRollout) IsAutoPromotionDisabled() bool {
+	return r.DisableAutoPromotion
+}
+
+// SetDisableAutoPromotion ...
+func (r *KogitoRollout) SetDisableAutoPromotion(disableAutoPromotion bool) {
+	r.DisableAutoPromotion = disableAutoPromotion
+}
+
+// KogitoRolloutStatus reports the progress of the rollout of a new revision.
+// +k8s:openapi-gen=true
+type KogitoRolloutStatus struct {
+	// Phase of the rollout.
+	// +optional
+	Phase api.RolloutPhase `json:"phase,omitempty"`
+	// Revision running in the Deployment of the service.
+	// +optional
+	StableRevision string `json:"stableRevision,omitempty"`
+	// Revision being rolled out.
+	// +optional
+	CandidateRevision string `json:"candidateRevision,omitempty"`
+	// Revision selected by the Service of the service.
+	// +optional
+	ActiveRevision string `json:"activeRevision,omitempty"`
+	// Last revision that failed the analysis, it won't be rolled out again.
+	// +optional
+	AbortedRevision string `json:"abortedRevision,omitempty"`
+	// Time when every pod of the candidate revision became ready.
+	// +optional
+	CandidateReadyTime *metav1.Time `json:"candidateReadyTime,omitempty"`
+	// Time when the analysis window of the candidate revision started, its error rate is computed over the requests served since.
+	// +optional
+	AnalysisStartTime *metav1.Time `json:"analysisStartTime,omitempty"`
+	// Requests served by the candidate revision when its analysis window started.
+	// +optional
+	AnalysisStartRequests int64 `json:"analysisStartRequests,omitempty"`
+	// Requests answered with a server error by the candidate revision when its analysis window started.
+	// +optional
+	AnalysisStartServerErrors int64 `json:"analysisStartServerErrors,omitempty"`
+	// Percentage of the external traffic currently sent to the candidate revision.
+	// +optional
+	CanaryWeight int32 `json:"canaryWeight,omitempty"`
+}
+
+// GetPhase ...
+func (r *KogitoRolloutStatus) GetPhase() api.RolloutPhase {
+	return r.Phase
+}
+
+// SetPhase ...
+func (r *KogitoRolloutStatus) SetPhase(phase api.RolloutPhase) {
+	r.Phase = phase
+}
+
+// GetStableRevision ...
+func (r *KogitoRolloutStatus) GetStableRevision() string {
+	return r.StableRevision
+}
+
+// SetStableRevision ...
+func (r *KogitoRolloutStatus) SetStableRevision(stableRevision string) {
+	r.StableRevision = stableRevision
+}
+
+// GetCandidateRevision ...
+func (r *KogitoRolloutStatus) GetCandidateRevision() string {
+	return r.CandidateRevision
+}
+
+// SetCandidateRevision ...
+func (r *KogitoRolloutStatus) SetCandidateRevision(candidateRevision string) {
+	r.CandidateRevision = candidateRevision
+}
+
+// GetActiveRevision ...
+func (r *KogitoRolloutStatus) GetActiveRevision() string {
+	return r.ActiveRevision
+}
+
+// SetActiveRevision ...
+func (r *KogitoRolloutStatus) SetActiveRevision(activeRevision string) {
+	r.ActiveRevision = activeRevision
+}
+
+// GetAbortedRevision ...
+func (r *KogitoRolloutStatus) GetAbortedRevision() string {
+	return r.AbortedRevision
+}
+
+// SetAbortedRevision ...
+func (r *KogitoRolloutStatus) SetAbortedRevision(abortedRevision string) {
+	r.AbortedRevision = abortedRevision
+}
+
+// GetCandidateReadyTime ...
+func (r *KogitoRolloutStatus) GetCandidateReadyTime() *metav1.Time {
+	return r.CandidateReadyTime
+}
+
+// SetCandidateReadyTime ...
+func (r *KogitoRolloutStatus) SetCandidateReadyTime(candidateReadyTime *metav1.Time) {
+	r.CandidateReadyTime = candidateReadyTime
+}
+
+// GetAnalysisStartTime ...
+func (r *KogitoRolloutStatus) GetAnalysisStartTime() *metav1.Time {
+	return r.AnalysisStartTime
+}
+
+// SetAnalysisStartTime ...
+func (r *KogitoRolloutStatus) SetAnalysisStartTime(analysisStartTime *metav1.Time) {
+	r.AnalysisStartTime = analysisStartTime
+}
+
+// GetAnalysisStartRequests ...
+func (r *KogitoRolloutStatus) GetAnalysisStartRequests() int64 {
+	return r.AnalysisStartRequests
+}
+
+// SetAnalysisStartRequests ...
+func (r *KogitoRolloutStatus) SetAnalysisStartRequests(analysisStartRequests int64) {
+	r.AnalysisStartRequests = analysisStartRequests
+}
+
+// GetAnalysisStartServerErrors ...
+func (r *KogitoRolloutStatus) GetAnalysisStartServerErrors() int64 {
+	return r.AnalysisStartServerErrors
+}
+
+// SetAnalysisStartServerErrors ...
+func (r *KogitoRolloutStatus) SetAnalysisStartServerErrors(analysisStartServerErrors int64) {
+	r.AnalysisStartServerErrors = analysisStartServerErrors
+}
+
+// GetCanaryWeight ...
+func (r *KogitoRolloutStatus) GetCanaryWeight() int32 {
+	return r.CanaryWeight
+}
+
+// SetCanaryWeight ...
+func (r *KogitoRolloutStatus) SetCanaryWeight(canaryWeight int32) {
+	r.CanaryWeight = canaryWeight
+}
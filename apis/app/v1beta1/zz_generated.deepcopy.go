@@ -21,8 +21,8 @@ package v1beta1
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
-	"k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -91,6 +91,55 @@ func (in *Builds) DeepCopy() *Builds {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialStatus) DeepCopyInto(out *CredentialStatus) {
+	*out = *in
+	if in.RotationPendingSince != nil {
+		in, out := &in.RotationPendingSince, &out.RotationPendingSince
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialStatus.
+func (in *CredentialStatus) DeepCopy() *CredentialStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataIndexSpec) DeepCopyInto(out *DataIndexSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataIndexSpec.
+func (in *DataIndexSpec) DeepCopy() *DataIndexSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataIndexSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSupportingService) DeepCopyInto(out *ExternalSupportingService) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSupportingService.
+func (in *ExternalSupportingService) DeepCopy() *ExternalSupportingService {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSupportingService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitSource) DeepCopyInto(out *GitSource) {
 	*out = *in
@@ -106,6 +155,60 @@ func (in *GitSource) DeepCopy() *GitSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanCacheTemplate) DeepCopyInto(out *InfinispanCacheTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanCacheTemplate.
+func (in *InfinispanCacheTemplate) DeepCopy() *InfinispanCacheTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanCacheTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfinispanCachesConfig) DeepCopyInto(out *InfinispanCachesConfig) {
+	*out = *in
+	out.Defaults = in.Defaults
+	out.ProcessInstances = in.ProcessInstances
+	out.Jobs = in.Jobs
+	out.DataIndexDomain = in.DataIndexDomain
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfinispanCachesConfig.
+func (in *InfinispanCachesConfig) DeepCopy() *InfinispanCachesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(InfinispanCachesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraProvision) DeepCopyInto(out *InfraProvision) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraProvision.
+func (in *InfraProvision) DeepCopy() *InfraProvision {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraProvision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InfraResource) DeepCopyInto(out *InfraResource) {
 	*out = *in
@@ -121,6 +224,132 @@ func (in *InfraResource) DeepCopy() *InfraResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobsServiceSpec) DeepCopyInto(out *JobsServiceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobsServiceSpec.
+func (in *JobsServiceSpec) DeepCopy() *JobsServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JobsServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTopicConfig) DeepCopyInto(out *KafkaTopicConfig) {
+	*out = *in
+	in.KafkaTopicSettings.DeepCopyInto(&out.KafkaTopicSettings)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopicConfig.
+func (in *KafkaTopicConfig) DeepCopy() *KafkaTopicConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTopicConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTopicSettings) DeepCopyInto(out *KafkaTopicSettings) {
+	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopicSettings.
+func (in *KafkaTopicSettings) DeepCopy() *KafkaTopicSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTopicSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTopicsConfig) DeepCopyInto(out *KafkaTopicsConfig) {
+	*out = *in
+	in.Defaults.DeepCopyInto(&out.Defaults)
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]KafkaTopicConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopicsConfig.
+func (in *KafkaTopicsConfig) DeepCopy() *KafkaTopicsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTopicsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KnativeDestination) DeepCopyInto(out *KnativeDestination) {
+	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KnativeDestination.
+func (in *KnativeDestination) DeepCopy() *KnativeDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(KnativeDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KnativeTriggersConfig) DeepCopyInto(out *KnativeTriggersConfig) {
+	*out = *in
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DeadLetterSink != nil {
+		in, out := &in.DeadLetterSink, &out.DeadLetterSink
+		*out = new(KnativeDestination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KnativeTriggersConfig.
+func (in *KnativeTriggersConfig) DeepCopy() *KnativeTriggersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KnativeTriggersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KogitoBuild) DeepCopyInto(out *KogitoBuild) {
 	*out = *in
@@ -185,7 +414,7 @@ func (in *KogitoBuildSpec) DeepCopyInto(out *KogitoBuildSpec) {
 	*out = *in
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -196,6 +425,11 @@ func (in *KogitoBuildSpec) DeepCopyInto(out *KogitoBuildSpec) {
 		*out = make([]WebHookSecret, len(*in))
 		copy(*out, *in)
 	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]KogitoPatch, len(*in))
+		copy(*out, *in)
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 	out.Artifact = in.Artifact
 }
@@ -215,10 +449,10 @@ func (in *KogitoBuildStatus) DeepCopyInto(out *KogitoBuildStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = new([]metav1.Condition)
+		*out = new([]v1.Condition)
 		if **in != nil {
 			in, out := *in, *out
-			*out = make([]metav1.Condition, len(*in))
+			*out = make([]v1.Condition, len(*in))
 			for i := range *in {
 				(*in)[i].DeepCopyInto(&(*out)[i])
 			}
@@ -277,6 +511,152 @@ func (in *KogitoCloudEventsStatus) DeepCopy() *KogitoCloudEventsStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KogitoEventEndpoint) DeepCopyInto(out *KogitoEventEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoEventEndpoint.
+func (in *KogitoEventEndpoint) DeepCopy() *KogitoEventEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(KogitoEventEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KogitoEventTopology) DeepCopyInto(out *KogitoEventTopology) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoEventTopology.
+func (in *KogitoEventTopology) DeepCopy() *KogitoEventTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(KogitoEventTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KogitoEventTopology) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KogitoEventTopologyEvent) DeepCopyInto(out *KogitoEventTopologyEvent) {
+	*out = *in
+	if in.Producers != nil {
+		in, out := &in.Producers, &out.Producers
+		*out = make([]KogitoEventEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = make([]KogitoEventEndpoint, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoEventTopologyEvent.
+func (in *KogitoEventTopologyEvent) DeepCopy() *KogitoEventTopologyEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(KogitoEventTopologyEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KogitoEventTopologyList) DeepCopyInto(out *KogitoEventTopologyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KogitoEventTopology, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoEventTopologyList.
+func (in *KogitoEventTopologyList) DeepCopy() *KogitoEventTopologyList {
+	if in == nil {
+		return nil
+	}
+	out := new(KogitoEventTopologyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KogitoEventTopologyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KogitoEventTopologySpec) DeepCopyInto(out *KogitoEventTopologySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoEventTopologySpec.
+func (in *KogitoEventTopologySpec) DeepCopy() *KogitoEventTopologySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KogitoEventTopologySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KogitoEventTopologyStatus) DeepCopyInto(out *KogitoEventTopologyStatus) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]KogitoEventTopologyEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OrphanConsumers != nil {
+		in, out := &in.OrphanConsumers, &out.OrphanConsumers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnconsumedProducers != nil {
+		in, out := &in.UnconsumedProducers, &out.UnconsumedProducers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoEventTopologyStatus.
+func (in *KogitoEventTopologyStatus) DeepCopy() *KogitoEventTopologyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KogitoEventTopologyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KogitoInfra) DeepCopyInto(out *KogitoInfra) {
 	*out = *in
@@ -353,7 +733,7 @@ func (in *KogitoInfraSpec) DeepCopyInto(out *KogitoInfraSpec) {
 	}
 	if in.Envs != nil {
 		in, out := &in.Envs, &out.Envs
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -382,6 +762,19 @@ func (in *KogitoInfraSpec) DeepCopyInto(out *KogitoInfraSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.VaultSecrets != nil {
+		in, out := &in.VaultSecrets, &out.VaultSecrets
+		*out = make([]VaultSecretReference, len(*in))
+		copy(*out, *in)
+	}
+	in.KafkaTopics.DeepCopyInto(&out.KafkaTopics)
+	in.KnativeTriggers.DeepCopyInto(&out.KnativeTriggers)
+	out.InfinispanCaches = in.InfinispanCaches
+	if in.Provision != nil {
+		in, out := &in.Provision, &out.Provision
+		*out = new(InfraProvision)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoInfraSpec.
@@ -399,10 +792,10 @@ func (in *KogitoInfraStatus) DeepCopyInto(out *KogitoInfraStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = new([]metav1.Condition)
+		*out = new([]v1.Condition)
 		if **in != nil {
 			in, out := *in, *out
-			*out = make([]metav1.Condition, len(*in))
+			*out = make([]v1.Condition, len(*in))
 			for i := range *in {
 				(*in)[i].DeepCopyInto(&(*out)[i])
 			}
@@ -410,7 +803,7 @@ func (in *KogitoInfraStatus) DeepCopyInto(out *KogitoInfraStatus) {
 	}
 	if in.Envs != nil {
 		in, out := &in.Envs, &out.Envs
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -439,6 +832,11 @@ func (in *KogitoInfraStatus) DeepCopyInto(out *KogitoInfraStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Credential != nil {
+		in, out := &in.Credential, &out.Credential
+		*out = new(CredentialStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoInfraStatus.
@@ -451,6 +849,74 @@ func (in *KogitoInfraStatus) DeepCopy() *KogitoInfraStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KogitoPatch) DeepCopyInto(out *KogitoPatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoPatch.
+func (in *KogitoPatch) DeepCopy() *KogitoPatch {
+	if in == nil {
+		return nil
+	}
+	out := new(KogitoPatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KogitoPodTemplate) DeepCopyInto(out *KogitoPodTemplate) {
+	*out = *in
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]corev1.ContainerPort, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoPodTemplate.
+func (in *KogitoPodTemplate) DeepCopy() *KogitoPodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(KogitoPodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KogitoProbe) DeepCopyInto(out *KogitoProbe) {
 	*out = *in
@@ -469,6 +935,44 @@ func (in *KogitoProbe) DeepCopy() *KogitoProbe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KogitoRollout) DeepCopyInto(out *KogitoRollout) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoRollout.
+func (in *KogitoRollout) DeepCopy() *KogitoRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(KogitoRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KogitoRolloutStatus) DeepCopyInto(out *KogitoRolloutStatus) {
+	*out = *in
+	if in.CandidateReadyTime != nil {
+		in, out := &in.CandidateReadyTime, &out.CandidateReadyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.AnalysisStartTime != nil {
+		in, out := &in.AnalysisStartTime, &out.AnalysisStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoRolloutStatus.
+func (in *KogitoRolloutStatus) DeepCopy() *KogitoRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KogitoRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KogitoRuntime) DeepCopyInto(out *KogitoRuntime) {
 	*out = *in
@@ -532,6 +1036,8 @@ func (in *KogitoRuntimeList) DeepCopyObject() runtime.Object {
 func (in *KogitoRuntimeSpec) DeepCopyInto(out *KogitoRuntimeSpec) {
 	*out = *in
 	in.KogitoServiceSpec.DeepCopyInto(&out.KogitoServiceSpec)
+	out.Rollout = in.Rollout
+	in.KafkaTopics.DeepCopyInto(&out.KafkaTopics)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoRuntimeSpec.
@@ -548,6 +1054,7 @@ func (in *KogitoRuntimeSpec) DeepCopy() *KogitoRuntimeSpec {
 func (in *KogitoRuntimeStatus) DeepCopyInto(out *KogitoRuntimeStatus) {
 	*out = *in
 	in.KogitoServiceStatus.DeepCopyInto(&out.KogitoServiceStatus)
+	in.Rollout.DeepCopyInto(&out.Rollout)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoRuntimeStatus.
@@ -570,7 +1077,7 @@ func (in *KogitoServiceSpec) DeepCopyInto(out *KogitoServiceSpec) {
 	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -604,6 +1111,17 @@ func (in *KogitoServiceSpec) DeepCopyInto(out *KogitoServiceSpec) {
 		}
 	}
 	in.Probes.DeepCopyInto(&out.Probes)
+	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]KogitoPatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.VaultSecrets != nil {
+		in, out := &in.VaultSecrets, &out.VaultSecrets
+		*out = make([]VaultSecretReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoServiceSpec.
@@ -621,10 +1139,10 @@ func (in *KogitoServiceStatus) DeepCopyInto(out *KogitoServiceStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = new([]metav1.Condition)
+		*out = new([]v1.Condition)
 		if **in != nil {
 			in, out := *in, *out
-			*out = make([]metav1.Condition, len(*in))
+			*out = make([]v1.Condition, len(*in))
 			for i := range *in {
 				(*in)[i].DeepCopyInto(&(*out)[i])
 			}
@@ -639,10 +1157,10 @@ func (in *KogitoServiceStatus) DeepCopyInto(out *KogitoServiceStatus) {
 	}
 	if in.RouteConditions != nil {
 		in, out := &in.RouteConditions, &out.RouteConditions
-		*out = new([]metav1.Condition)
+		*out = new([]v1.Condition)
 		if **in != nil {
 			in, out := *in, *out
-			*out = make([]metav1.Condition, len(*in))
+			*out = make([]v1.Condition, len(*in))
 			for i := range *in {
 				(*in)[i].DeepCopyInto(&(*out)[i])
 			}
@@ -724,6 +1242,21 @@ func (in *KogitoSupportingServiceList) DeepCopyObject() runtime.Object {
 func (in *KogitoSupportingServiceSpec) DeepCopyInto(out *KogitoSupportingServiceSpec) {
 	*out = *in
 	in.KogitoServiceSpec.DeepCopyInto(&out.KogitoServiceSpec)
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalSupportingService)
+		**out = **in
+	}
+	if in.DataIndex != nil {
+		in, out := &in.DataIndex, &out.DataIndex
+		*out = new(DataIndexSpec)
+		**out = **in
+	}
+	if in.JobsService != nil {
+		in, out := &in.JobsService, &out.JobsService
+		*out = new(JobsServiceSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoSupportingServiceSpec.
@@ -767,6 +1300,21 @@ func (in *Monitoring) DeepCopy() *Monitoring {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultSecretReference) DeepCopyInto(out *VaultSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretReference.
+func (in *VaultSecretReference) DeepCopy() *VaultSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeReference) DeepCopyInto(out *VolumeReference) {
 	*out = *in
@@ -37,3 +37,15 @@ func (i *Image) String() string {
 	}
 	return fmt.Sprintf("%s/%s:%s", i.Domain, i.Name, i.Tag)
 }
+
+// ImageUpdatePolicyType defines when the digest pinned for the image tag of a service is resolved again against the registry
+type ImageUpdatePolicyType string
+
+const (
+	// OnTagChangeImageUpdatePolicy the digest is resolved once and kept until the image tag of the service is changed
+	OnTagChangeImageUpdatePolicy ImageUpdatePolicyType = "OnTagChange"
+	// PeriodicImageUpdatePolicy the registry is polled in background, the service is rolled out as soon as the tag points to a new digest
+	PeriodicImageUpdatePolicy ImageUpdatePolicyType = "Periodic"
+	// NeverImageUpdatePolicy the digest is resolved once and kept, even when the image tag of the service is changed
+	NeverImageUpdatePolicy ImageUpdatePolicyType = "Never"
+)
@@ -0,0 +1,42 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+// InfinispanCacheKind is the kind of cache created for the Kogito services bound to an Infinispan infra
+type InfinispanCacheKind string
+
+const (
+	// ProcessInstancesCacheKind the caches storing the process instances of a KogitoRuntime, one per process
+	ProcessInstancesCacheKind InfinispanCacheKind = "ProcessInstances"
+	// JobsCacheKind the cache storing the jobs scheduled by the KogitoRuntimes in the Jobs Service
+	JobsCacheKind InfinispanCacheKind = "Jobs"
+	// DataIndexDomainCacheKind the caches storing the domain data indexed by Data Index, one per process
+	DataIndexDomainCacheKind InfinispanCacheKind = "DataIndexDomain"
+)
+
+// InfinispanCacheTemplateInterface ...
+type InfinispanCacheTemplateInterface interface {
+	GetTemplateName() string
+	GetTemplate() string
+	IsEmpty() bool
+}
+
+// InfinispanCachesConfigInterface ...
+type InfinispanCachesConfigInterface interface {
+	IsDisabled() bool
+	GetDefaults() InfinispanCacheTemplateInterface
+	// GetTemplate returns the template of the given kind of cache, the defaults when it has none
+	GetTemplate(kind InfinispanCacheKind) InfinispanCacheTemplateInterface
+}
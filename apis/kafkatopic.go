@@ -0,0 +1,70 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// KafkaTopicCleanupPolicy is the cleanup.policy of a Kafka topic.
+type KafkaTopicCleanupPolicy string
+
+const (
+	// DeleteKafkaTopicCleanupPolicy discards the messages older than the retention
+	DeleteKafkaTopicCleanupPolicy KafkaTopicCleanupPolicy = "delete"
+	// CompactKafkaTopicCleanupPolicy keeps the last message of each key
+	CompactKafkaTopicCleanupPolicy KafkaTopicCleanupPolicy = "compact"
+	// CompactDeleteKafkaTopicCleanupPolicy keeps the last message of each key and discards the messages older than the retention
+	CompactDeleteKafkaTopicCleanupPolicy KafkaTopicCleanupPolicy = "compact,delete"
+)
+
+// KafkaTopicDeletionPolicy defines what happens to the KafkaTopic of a topic that a KogitoRuntime no longer declares.
+type KafkaTopicDeletionPolicy string
+
+const (
+	// RetainKafkaTopicDeletionPolicy keeps the KafkaTopic
+	RetainKafkaTopicDeletionPolicy KafkaTopicDeletionPolicy = "Retain"
+	// DeleteKafkaTopicDeletionPolicy deletes the KafkaTopic created by the operator once no Kogito service declares the topic anymore
+	DeleteKafkaTopicDeletionPolicy KafkaTopicDeletionPolicy = "Delete"
+)
+
+// KafkaTopicSettingsInterface ...
+type KafkaTopicSettingsInterface interface {
+	GetPartitions() int32
+	SetPartitions(partitions int32)
+	GetReplicas() int32
+	SetReplicas(replicas int32)
+	GetRetention() *metav1.Duration
+	SetRetention(retention *metav1.Duration)
+	GetCleanupPolicy() KafkaTopicCleanupPolicy
+	SetCleanupPolicy(cleanupPolicy KafkaTopicCleanupPolicy)
+	GetMinInSyncReplicas() int32
+	SetMinInSyncReplicas(minInSyncReplicas int32)
+}
+
+// KafkaTopicConfigInterface ...
+type KafkaTopicConfigInterface interface {
+	KafkaTopicSettingsInterface
+	GetName() string
+	SetName(name string)
+}
+
+// KafkaTopicsConfigInterface ...
+type KafkaTopicsConfigInterface interface {
+	GetDefaults() KafkaTopicSettingsInterface
+	GetTopics() []KafkaTopicConfigInterface
+	GetTopic(name string) KafkaTopicConfigInterface
+	AddTopic(topic KafkaTopicConfigInterface)
+	GetDeletionPolicy() KafkaTopicDeletionPolicy
+	SetDeletionPolicy(deletionPolicy KafkaTopicDeletionPolicy)
+}
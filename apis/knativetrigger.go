@@ -0,0 +1,50 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import corev1 "k8s.io/api/core/v1"
+
+// KnativeBackoffPolicy is the policy used to compute the delay between the delivery retries of a Knative Trigger.
+type KnativeBackoffPolicy string
+
+const (
+	// LinearKnativeBackoffPolicy waits backoffDelay * <retry number> between the retries
+	LinearKnativeBackoffPolicy KnativeBackoffPolicy = "linear"
+	// ExponentialKnativeBackoffPolicy waits backoffDelay * 2^<retry number> between the retries
+	ExponentialKnativeBackoffPolicy KnativeBackoffPolicy = "exponential"
+)
+
+// KnativeDestinationInterface ...
+type KnativeDestinationInterface interface {
+	GetRef() *corev1.ObjectReference
+	SetRef(ref *corev1.ObjectReference)
+	GetURI() string
+	SetURI(uri string)
+}
+
+// KnativeTriggersConfigInterface ...
+type KnativeTriggersConfigInterface interface {
+	GetFilters() map[string]string
+	SetFilters(filters map[string]string)
+	// GetDeadLetterSink returns nil when no dead-letter sink is configured
+	GetDeadLetterSink() KnativeDestinationInterface
+	SetDeadLetterSink(deadLetterSink KnativeDestinationInterface)
+	GetRetry() *int32
+	SetRetry(retry *int32)
+	GetBackoffPolicy() KnativeBackoffPolicy
+	SetBackoffPolicy(backoffPolicy KnativeBackoffPolicy)
+	GetBackoffDelay() string
+	SetBackoffDelay(backoffDelay string)
+}
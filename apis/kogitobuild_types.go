@@ -17,6 +17,7 @@ package api
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -77,6 +78,13 @@ const (
 	BuildNotStartedReason KogitoBuildConditionReason = "NotYetStarted"
 )
 
+// KogitoBuildListInterface ...
+type KogitoBuildListInterface interface {
+	runtime.Object
+	// GetItems gets all items
+	GetItems() []KogitoBuildInterface
+}
+
 // KogitoBuildInterface ...
 type KogitoBuildInterface interface {
 	client.Object
@@ -100,6 +108,8 @@ type KogitoBuildSpecInterface interface {
 	SetRuntime(runtime RuntimeType)
 	GetWebHooks() []WebHookSecretInterface
 	SetWebHooks(webhooks []WebHookSecretInterface)
+	GetPatches() []KogitoPatchInterface
+	SetPatches(patches []KogitoPatchInterface)
 	IsNative() bool
 	SetNative(native bool)
 	GetResources() corev1.ResourceRequirements
@@ -0,0 +1,69 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// KogitoEventChannelKind is the kind of channel through which the Kogito services exchange a CloudEvent.
+type KogitoEventChannelKind string
+
+const (
+	// KafkaTopicEventChannel the CloudEvent is exchanged through a Kafka topic
+	KafkaTopicEventChannel KogitoEventChannelKind = "KafkaTopic"
+	// KnativeBrokerEventChannel the CloudEvent is exchanged through a Knative Eventing Broker
+	KnativeBrokerEventChannel KogitoEventChannelKind = "KnativeBroker"
+)
+
+// KogitoEventTopologyInterface ...
+type KogitoEventTopologyInterface interface {
+	client.Object
+	// GetStatus gets the Kogito Event Topology Status structure.
+	GetStatus() KogitoEventTopologyStatusInterface
+}
+
+// KogitoEventTopologyListInterface ...
+type KogitoEventTopologyListInterface interface {
+	runtime.Object
+	// GetItems gets all items
+	GetItems() []KogitoEventTopologyInterface
+}
+
+// KogitoEventTopologyStatusInterface ...
+type KogitoEventTopologyStatusInterface interface {
+	GetEvents() []KogitoEventTopologyEventInterface
+	SetEvents(events []KogitoEventTopologyEventInterface)
+	GetOrphanConsumers() []string
+	SetOrphanConsumers(orphanConsumers []string)
+	GetUnconsumedProducers() []string
+	SetUnconsumedProducers(unconsumedProducers []string)
+}
+
+// KogitoEventTopologyEventInterface ...
+type KogitoEventTopologyEventInterface interface {
+	GetType() string
+	GetProducers() []KogitoEventEndpointInterface
+	GetConsumers() []KogitoEventEndpointInterface
+}
+
+// KogitoEventEndpointInterface ...
+type KogitoEventEndpointInterface interface {
+	GetService() string
+	GetSource() string
+	GetChannelKind() KogitoEventChannelKind
+	GetChannel() string
+}
@@ -17,6 +17,7 @@ package api
 import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -26,6 +27,8 @@ type KogitoInfraConditionType string
 const (
 	// KogitoInfraConfigured ...
 	KogitoInfraConfigured KogitoInfraConditionType = "Configured"
+	// KogitoInfraResourceHealthy the referenced infrastructure resource is found and ready, it's checked again whenever its status changes
+	KogitoInfraResourceHealthy KogitoInfraConditionType = "ResourceHealthy"
 )
 
 // KogitoInfraConditionReason describes the reasons for reconciliation failure
@@ -46,6 +49,10 @@ const (
 	ResourceConfigError KogitoInfraConditionReason = "ResourceConfigError"
 	// ResourceMissingResourceConfig related resource is missing a config information to continue
 	ResourceMissingResourceConfig KogitoInfraConditionReason = "ResourceMissingConfig"
+	// VaultSecretNotMaterialized a referenced Vault secret is not materialized in the namespace yet
+	VaultSecretNotMaterialized KogitoInfraConditionReason = "VaultSecretNotMaterialized"
+	// ResourceReady related resource is found and ready
+	ResourceReady KogitoInfraConditionReason = "ResourceReady"
 	// ResourceSuccessfullyConfigured ..
 	ResourceSuccessfullyConfigured KogitoInfraConditionReason = "ResourceSuccessfullyConfigured"
 )
@@ -59,17 +66,29 @@ type KogitoInfraInterface interface {
 	GetStatus() KogitoInfraStatusInterface
 }
 
+// KogitoInfraListInterface ...
+type KogitoInfraListInterface interface {
+	runtime.Object
+	// GetItems gets all items
+	GetItems() []KogitoInfraInterface
+}
+
 // KogitoInfraSpecInterface ...
 type KogitoInfraSpecInterface interface {
 	GetResource() ResourceInterface
 	IsResourceEmpty() bool
 	GetInfraProperties() map[string]string
 	AddInfraProperties(infraProperties map[string]string)
+	GetKafkaTopics() KafkaTopicsConfigInterface
+	GetKnativeTriggers() KnativeTriggersConfigInterface
+	GetInfinispanCaches() InfinispanCachesConfigInterface
+	GetProvision() InfraProvisionInterface
 	GetEnvs() []v1.EnvVar
 	GetConfigMapEnvFromReferences() []string
 	GetConfigMapVolumeReferences() []VolumeReferenceInterface
 	GetSecretEnvFromReferences() []string
 	GetSecretVolumeReferences() []VolumeReferenceInterface
+	GetVaultSecrets() []VaultSecretReferenceInterface
 }
 
 // ResourceInterface ...
@@ -84,6 +103,12 @@ type ResourceInterface interface {
 	SetName(name string)
 }
 
+// InfraProvisionInterface ...
+type InfraProvisionInterface interface {
+	GetReplicas() *int32
+	GetVersion() string
+}
+
 // KogitoInfraStatusInterface ...
 type KogitoInfraStatusInterface interface {
 	GetConditions() *[]metav1.Condition
@@ -103,6 +128,17 @@ type KogitoInfraStatusInterface interface {
 	GetSecretVolumeReferences() []VolumeReferenceInterface
 	SetSecretVolumeReferences(volumeReferences []VolumeReferenceInterface)
 	AddSecretVolumeReference(name string, mountPath string, fileMode *int32, optional *bool)
+	GetCredential() CredentialStatusInterface
+	SetCredential(secretName, secretNamespace string, revision int64, hash string, rotationPendingSince *metav1.Time)
+}
+
+// CredentialStatusInterface ...
+type CredentialStatusInterface interface {
+	GetSecretName() string
+	GetSecretNamespace() string
+	GetRevision() int64
+	GetHash() string
+	GetRotationPendingSince() *metav1.Time
 }
 
 // RuntimePropertiesMap defines the map that KogitoInfraStatus
@@ -39,9 +39,24 @@ type KogitoRuntimeSpecInterface interface {
 	KogitoServiceSpecInterface
 	IsEnableIstio() bool
 	SetEnableIstio(enableIstio bool)
+	GetProtoBufCompatibility() ProtoBufCompatibilityPolicy
+	GetRollout() RolloutInterface
+	GetKafkaTopics() KafkaTopicsConfigInterface
 }
 
+// ProtoBufCompatibilityPolicy defines what happens when a new version of a runtime publishes protobuf files
+// that are not backward compatible with the ones already provided to Data Index.
+type ProtoBufCompatibilityPolicy string
+
+const (
+	// WarnProtoBufCompatibilityPolicy reports incompatible protobuf files but still provides them to Data Index, they are reported until they change
+	WarnProtoBufCompatibilityPolicy ProtoBufCompatibilityPolicy = "Warn"
+	// BlockProtoBufCompatibilityPolicy keeps the previous protobuf files on Data Index until the incompatibility is solved
+	BlockProtoBufCompatibilityPolicy ProtoBufCompatibilityPolicy = "Block"
+)
+
 // KogitoRuntimeStatusInterface ...
 type KogitoRuntimeStatusInterface interface {
 	KogitoServiceStatusInterface
+	GetRollout() RolloutStatusInterface
 }
@@ -32,6 +32,20 @@ const (
 	ProvisioningConditionType KogitoServiceConditionType = "Provisioning"
 	// FailedConditionType - The KogitoService is in a failed state
 	FailedConditionType KogitoServiceConditionType = "Failed"
+	// HealthyConditionType - The KogitoService endpoint answered to the last health check
+	HealthyConditionType KogitoServiceConditionType = "Healthy"
+	// ProtoBufCompatibleConditionType - The protobuf files published by the KogitoService are backward compatible with the previous ones
+	ProtoBufCompatibleConditionType KogitoServiceConditionType = "ProtoBufCompatible"
+	// StorageReadyConditionType - The KogitoService runs with the requested storage, it's false while the storage is being migrated
+	StorageReadyConditionType KogitoServiceConditionType = "StorageReady"
+	// TrustyStackReadyConditionType - All the services of the Trusty stack are deployed
+	TrustyStackReadyConditionType KogitoServiceConditionType = "TrustyStackReady"
+	// RolloutConditionType - The last revision of the KogitoRuntime is rolled out, the reason reports the rollout phase
+	RolloutConditionType KogitoServiceConditionType = "Rollout"
+	// InfraDegradedConditionType - A resource referenced by a KogitoInfra used by the KogitoService is not healthy anymore
+	InfraDegradedConditionType KogitoServiceConditionType = "InfraDegraded"
+	// HighAvailabilityConditionType - The replicas requested for the KogitoService can run together, false when they are reduced to one
+	HighAvailabilityConditionType KogitoServiceConditionType = "HighAvailability"
 )
 
 // KogitoService defines the interface for any Kogito service that the operator can handle, e.g. Data Index, Jobs Service, Runtimes, etc.
@@ -73,7 +87,13 @@ type KogitoServiceSpecInterface interface {
 	GetRuntime() RuntimeType
 	IsRouteDisabled() bool
 	SetDisableRoute(disableRoute bool)
+	IsConfigRolloutDisabled() bool
+	SetDisableConfigRollout(disableConfigRollout bool)
 	IsInsecureImageRegistry() bool
+	IsImageDigestPinned() bool
+	SetPinImageDigest(pinImageDigest bool)
+	GetImageUpdatePolicy() ImageUpdatePolicyType
+	SetImageUpdatePolicy(policy ImageUpdatePolicyType)
 	GetPropertiesConfigMap() string
 	GetInfra() []string
 	AddInfra(name string)
@@ -82,8 +102,13 @@ type KogitoServiceSpecInterface interface {
 	GetConfig() map[string]string
 	GetProbes() KogitoProbeInterface
 	SetProbes(probes KogitoProbeInterface)
+	GetPodTemplate() KogitoPodTemplateInterface
+	SetPodTemplate(podTemplate KogitoPodTemplateInterface)
+	GetPatches() []KogitoPatchInterface
+	SetPatches(patches []KogitoPatchInterface)
 	GetTrustStoreSecret() string
 	SetTrustStoreSecret(trustStore string)
+	GetVaultSecrets() []VaultSecretReferenceInterface
 }
 
 // KogitoServiceStatusInterface defines the basic interface for the Kogito Service status.
@@ -114,4 +139,5 @@ type KogitoCloudEventsStatusInterface interface {
 type KogitoCloudEventInfoInterface interface {
 	GetType() string
 	GetSource() string
+	GetTopic() string
 }
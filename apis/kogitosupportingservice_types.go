@@ -36,6 +36,8 @@ const (
 	TrustyAI ServiceType = "TrustyAI"
 	// TrustyUI supporting service resource type
 	TrustyUI ServiceType = "TrustyUI"
+	// TrustyStack supporting service resource type, deploys TrustyAI, Explainability and TrustyUI together
+	TrustyStack ServiceType = "TrustyStack"
 )
 
 // KogitoSupportingServiceInterface ...
@@ -52,11 +54,61 @@ type KogitoSupportingServiceSpecInterface interface {
 	KogitoServiceSpecInterface
 	GetServiceType() ServiceType
 	SetServiceType(serviceType ServiceType)
+	GetExternal() ExternalSupportingServiceInterface
+	IsExternal() bool
+	GetDataIndex() DataIndexSpecInterface
+	GetJobsService() JobsServiceSpecInterface
+}
+
+// DataIndexSpecInterface describes the configuration specific to the Data Index service.
+type DataIndexSpecInterface interface {
+	GetStorage() DataIndexStorageType
+}
+
+// DataIndexStorageType is the storage backend used by Data Index
+type DataIndexStorageType string
+
+const (
+	// InfinispanDataIndexStorage stores Data Index data on Infinispan
+	InfinispanDataIndexStorage DataIndexStorageType = "infinispan"
+	// MongoDBDataIndexStorage stores Data Index data on MongoDB
+	MongoDBDataIndexStorage DataIndexStorageType = "mongodb"
+	// PostgreSQLDataIndexStorage stores Data Index data on PostgreSQL
+	PostgreSQLDataIndexStorage DataIndexStorageType = "postgresql"
+	// EphemeralDataIndexStorage keeps Data Index data in memory
+	EphemeralDataIndexStorage DataIndexStorageType = "ephemeral"
+)
+
+// JobsServiceSpecInterface describes the configuration specific to the Jobs Service.
+type JobsServiceSpecInterface interface {
+	GetStorage() JobsServiceStorageType
+}
+
+// JobsServiceStorageType is the persistent storage shared by the Jobs Service replicas
+type JobsServiceStorageType string
+
+const (
+	// MongoDBJobsServiceStorage stores the jobs on MongoDB
+	MongoDBJobsServiceStorage JobsServiceStorageType = "mongodb"
+	// PostgreSQLJobsServiceStorage stores the jobs on PostgreSQL
+	PostgreSQLJobsServiceStorage JobsServiceStorageType = "postgresql"
+)
+
+// ExternalSupportingServiceInterface describes a supporting service managed outside the cluster.
+type ExternalSupportingServiceInterface interface {
+	GetURL() string
+	GetWSURL() string
+	GetCredentialsSecret() string
+	GetCABundleSecret() string
 }
 
 // KogitoSupportingServiceStatusInterface ...
 type KogitoSupportingServiceStatusInterface interface {
 	KogitoServiceStatusInterface
+	GetDataIndexStorage() DataIndexStorageType
+	SetDataIndexStorage(storage DataIndexStorageType)
+	GetLeader() string
+	SetLeader(leader string)
 }
 
 // KogitoSupportingServiceListInterface ...
@@ -0,0 +1,35 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+// PatchType literal type to distinguish between the supported patch formats.
+type PatchType string
+
+const (
+	// StrategicMergePatchType Kubernetes strategic merge patch, merges lists by their patch merge key when the object supports it.
+	StrategicMergePatchType PatchType = "StrategicMerge"
+	// MergePatchType JSON merge patch (RFC 7386).
+	MergePatchType PatchType = "Merge"
+	// JSONPatchType JSON patch (RFC 6902).
+	JSONPatchType PatchType = "JSON"
+)
+
+// KogitoPatchInterface ...
+type KogitoPatchInterface interface {
+	GetKind() string
+	GetName() string
+	GetType() PatchType
+	GetPatch() string
+}
@@ -0,0 +1,39 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import corev1 "k8s.io/api/core/v1"
+
+// KogitoPodTemplateInterface ...
+type KogitoPodTemplateInterface interface {
+	GetContainers() []corev1.Container
+	SetContainers(containers []corev1.Container)
+	GetInitContainers() []corev1.Container
+	SetInitContainers(initContainers []corev1.Container)
+	GetVolumes() []corev1.Volume
+	SetVolumes(volumes []corev1.Volume)
+	GetVolumeMounts() []corev1.VolumeMount
+	SetVolumeMounts(volumeMounts []corev1.VolumeMount)
+	GetPorts() []corev1.ContainerPort
+	SetPorts(ports []corev1.ContainerPort)
+	GetImagePullPolicy() corev1.PullPolicy
+	SetImagePullPolicy(imagePullPolicy corev1.PullPolicy)
+	GetImagePullSecrets() []corev1.LocalObjectReference
+	SetImagePullSecrets(imagePullSecrets []corev1.LocalObjectReference)
+	GetServiceAccountName() string
+	SetServiceAccountName(serviceAccountName string)
+	GetPriorityClassName() string
+	SetPriorityClassName(priorityClassName string)
+}
@@ -0,0 +1,192 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	api "github.com/kiegroup/kogito-operator/apis"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KafkaTopicSettings overrides the settings of the KafkaTopic created for a topic of the Kogito services.
+// +k8s:openapi-gen=true
+type KafkaTopicSettings struct {
+	// Number of partitions of the topic. The partitions of an existing topic are never decreased.
+	//
+	// Default value: 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Partitions int32 `json:"partitions,omitempty"`
+
+	// Replication factor of the topic, only set when the topic is created.
+	//
+	// Default value: 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Time the messages are retained, sets the retention.ms configuration of the topic. For example: 168h.
+	// +optional
+	Retention *metav1.Duration `json:"retention,omitempty"`
+
+	// Sets the cleanup.policy configuration of the topic, either delete, compact or compact,delete.
+	// +kubebuilder:validation:Enum=delete;compact;"compact,delete"
+	// +optional
+	CleanupPolicy api.KafkaTopicCleanupPolicy `json:"cleanupPolicy,omitempty"`
+
+	// Sets the min.insync.replicas configuration of the topic.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinInSyncReplicas int32 `json:"minInSyncReplicas,omitempty"`
+}
+
+// GetPartitions ...
+func (k *KafkaTopicSettings) GetPartitions() int32 {
+	return k.Partitions
+}
+
+// SetPartitions ...
+func (k *KafkaTopicSettings) SetPartitions(partitions int32) {
+	k.Partitions = partitions
+}
+
+// GetReplicas ...
+func (k *KafkaTopicSettings) GetReplicas() int32 {
+	return k.Replicas
+}
+
+// SetReplicas ...
+func (k *KafkaTopicSettings) SetReplicas(replicas int32) {
+	k.Replicas = replicas
+}
+
+// GetRetention ...
+func (k *KafkaTopicSettings) GetRetention() *metav1.Duration {
+	return k.Retention
+}
+
+// SetRetention ...
+func (k *KafkaTopicSettings) SetRetention(retention *metav1.Duration) {
+	k.Retention = retention
+}
+
+// GetCleanupPolicy ...
+func (k *KafkaTopicSettings) GetCleanupPolicy() api.KafkaTopicCleanupPolicy {
+	return k.CleanupPolicy
+}
+
+// SetCleanupPolicy ...
+func (k *KafkaTopicSettings) SetCleanupPolicy(cleanupPolicy api.KafkaTopicCleanupPolicy) {
+	k.CleanupPolicy = cleanupPolicy
+}
+
+// GetMinInSyncReplicas ...
+func (k *KafkaTopicSettings) GetMinInSyncReplicas() int32 {
+	return k.MinInSyncReplicas
+}
+
+// SetMinInSyncReplicas ...
+func (k *KafkaTopicSettings) SetMinInSyncReplicas(minInSyncReplicas int32) {
+	k.MinInSyncReplicas = minInSyncReplicas
+}
+
+// KafkaTopicConfig overrides the settings of a given topic.
+// +k8s:openapi-gen=true
+type KafkaTopicConfig struct {
+	// Name of the topic.
+	Name string `json:"name"`
+
+	KafkaTopicSettings `json:",inline"`
+}
+
+// GetName ...
+func (k *KafkaTopicConfig) GetName() string {
+	return k.Name
+}
+
+// SetName ...
+func (k *KafkaTopicConfig) SetName(name string) {
+	k.Name = name
+}
+
+// KafkaTopicsConfig defines the settings of the KafkaTopics created for the topics of the Kogito services,
+// including the topics discovered from the services.
+// +k8s:openapi-gen=true
+type KafkaTopicsConfig struct {
+	// Settings of all the topics without their own settings.
+	// +optional
+	Defaults KafkaTopicSettings `json:"defaults,omitempty"`
+
+	// Settings of given topics, they take precedence over the defaults.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Topics []KafkaTopicConfig `json:"topics,omitempty"`
+
+	// Defines what happens to the KafkaTopic of a topic that the KogitoRuntime no longer declares, either Retain or Delete.
+	// Delete removes the KafkaTopic created by the operator once no Kogito service declares the topic anymore, including when the services are deleted.
+	//
+	// Default value: Retain
+	// +kubebuilder:validation:Enum=Retain;Delete
+	// +optional
+	DeletionPolicy api.KafkaTopicDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// GetDefaults ...
+func (k *KafkaTopicsConfig) GetDefaults() api.KafkaTopicSettingsInterface {
+	return &k.Defaults
+}
+
+// GetTopics ...
+func (k *KafkaTopicsConfig) GetTopics() []api.KafkaTopicConfigInterface {
+	topics := make([]api.KafkaTopicConfigInterface, len(k.Topics))
+	for i := range k.Topics {
+		topics[i] = &k.Topics[i]
+	}
+	return topics
+}
+
+// GetTopic returns the settings of the given topic, nil when it has none
+func (k *KafkaTopicsConfig) GetTopic(name string) api.KafkaTopicConfigInterface {
+	for i := range k.Topics {
+		if k.Topics[i].Name == name {
+			return &k.Topics[i]
+		}
+	}
+	return nil
+}
+
+// AddTopic ...
+func (k *KafkaTopicsConfig) AddTopic(topic api.KafkaTopicConfigInterface) {
+	k.Topics = append(k.Topics, KafkaTopicConfig{
+		Name: topic.GetName(),
+		KafkaTopicSettings: KafkaTopicSettings{
+			Partitions:        topic.GetPartitions(),
+			Replicas:          topic.GetReplicas(),
+			Retention:         topic.GetRetention(),
+			CleanupPolicy:     topic.GetCleanupPolicy(),
+			MinInSyncReplicas: topic.GetMinInSyncReplicas(),
+		},
+	})
+}
+
+// GetDeletionPolicy ...
+func (k *KafkaTopicsConfig) GetDeletionPolicy() api.KafkaTopicDeletionPolicy {
+	return k.DeletionPolicy
+}
+
+// SetDeletionPolicy ...
+func (k *KafkaTopicsConfig) SetDeletionPolicy(deletionPolicy api.KafkaTopicDeletionPolicy) {
+	k.DeletionPolicy = deletionPolicy
+}
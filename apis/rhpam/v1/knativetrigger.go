@@ -0,0 +1,139 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	api "github.com/kiegroup/kogito-operator/apis"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// KnativeDestination is the addressable receiving the events, either a Kubernetes object or a URI.
+// When both are set, the URI is resolved relative to the object address.
+// +k8s:openapi-gen=true
+type KnativeDestination struct {
+	// Reference to an addressable Kubernetes object, for example a Knative Service or a Channel.
+	// When the namespace is empty, the namespace of the Kogito service is used.
+	// +optional
+	Ref *corev1.ObjectReference `json:"ref,omitempty"`
+
+	// Absolute URI, or URI relative to the address of the referenced object.
+	// +optional
+	URI string `json:"uri,omitempty"`
+}
+
+// GetRef ...
+func (k *KnativeDestination) GetRef() *corev1.ObjectReference {
+	return k.Ref
+}
+
+// SetRef ...
+func (k *KnativeDestination) SetRef(ref *corev1.ObjectReference) {
+	k.Ref = ref
+}
+
+// GetURI ...
+func (k *KnativeDestination) GetURI() string {
+	return k.URI
+}
+
+// SetURI ...
+func (k *KnativeDestination) SetURI(uri string) {
+	k.URI = uri
+}
+
+// KnativeTriggersConfig defines the settings of the Knative Triggers created for the events consumed by the Kogito services
+// bound to a Knative Eventing Broker.
+// +k8s:openapi-gen=true
+type KnativeTriggersConfig struct {
+	// Extra CloudEvents attributes, or extensions, that the events must have to be delivered to the services.
+	// The type and source attributes are always set from the events declared by the services.
+	// +optional
+	Filters map[string]string `json:"filters,omitempty"`
+
+	// Sink receiving the events that could not be delivered to the services.
+	// +optional
+	DeadLetterSink *KnativeDestination `json:"deadLetterSink,omitempty"`
+
+	// Minimum number of retries of an event delivery before sending it to the dead-letter sink.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Retry *int32 `json:"retry,omitempty"`
+
+	// Policy used to compute the delay between the retries, either linear or exponential.
+	// +kubebuilder:validation:Enum=linear;exponential
+	// +optional
+	BackoffPolicy api.KnativeBackoffPolicy `json:"backoffPolicy,omitempty"`
+
+	// Delay before retrying a delivery, as an ISO 8601 duration. For example: PT0.5S.
+	// +optional
+	BackoffDelay string `json:"backoffDelay,omitempty"`
+}
+
+// GetFilters ...
+func (k *KnativeTriggersConfig) GetFilters() map[string]string {
+	return k.Filters
+}
+
+// SetFilters ...
+func (k *KnativeTriggersConfig) SetFilters(filters map[string]string) {
+	k.Filters = filters
+}
+
+// GetDeadLetterSink ...
+func (k *KnativeTriggersConfig) GetDeadLetterSink() api.KnativeDestinationInterface {
+	if k.DeadLetterSink == nil {
+		return nil
+	}
+	return k.DeadLetterSink
+}
+
+// SetDeadLetterSink ...
+func (k *KnativeTriggersConfig) SetDeadLetterSink(deadLetterSink api.KnativeDestinationInterface) {
+	if deadLetterSink == nil {
+		k.DeadLetterSink = nil
+		return
+	}
+	k.DeadLetterSink = &KnativeDestination{Ref: deadLetterSink.GetRef(), URI: deadLetterSink.GetURI()}
+}
+
+// GetRetry ...
+func (k *KnativeTriggersConfig) GetRetry() *int32 {
+	return k.Retry
+}
+
+// SetRetry ...
+func (k *KnativeTriggersConfig) SetRetry(retry *int32) {
+	k.Retry = retry
+}
+
+// GetBackoffPolicy ...
+func (k *KnativeTriggersConfig) GetBackoffPolicy() api.KnativeBackoffPolicy {
+	return k.BackoffPolicy
+}
+
+// SetBackoffPolicy ...
+func (k *KnativeTriggersConfig) SetBackoffPolicy(backoffPolicy api.KnativeBackoffPolicy) {
+	k.BackoffPolicy = backoffPolicy
+}
+
+// GetBackoffDelay ...
+func (k *KnativeTriggersConfig) GetBackoffDelay() string {
+	return k.BackoffDelay
+}
+
+// SetBackoffDelay ...
+func (k *KnativeTriggersConfig) SetBackoffDelay(backoffDelay string) {
+	k.BackoffDelay = backoffDelay
+}
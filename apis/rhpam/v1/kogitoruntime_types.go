@@ -29,13 +29,34 @@ type KogitoRuntimeSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	EnableIstio bool `json:"enableIstio,omitempty"`
 
-	// The name of the runtime used, either Quarkus or SpringBoot.
+	// The name of the runtime used, either quarkus, springboot or a runtime profile registered in the operator.
 	//
 	// Default value: quarkus
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Runtime"
-	// +kubebuilder:validation:Enum=quarkus;springboot
 	Runtime api.RuntimeType `json:"runtime,omitempty"`
+
+	// Defines what to do when a new version of the service publishes protobuf files that are not backward compatible
+	// with the previous ones, either Warn or Block. Block keeps the previous protobuf files on Data Index until the issue is solved.
+	//
+	// Default value: Warn
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Protobuf Compatibility"
+	// +kubebuilder:validation:Enum=Warn;Block
+	ProtoBufCompatibility api.ProtoBufCompatibilityPolicy `json:"protoBufCompatibility,omitempty"`
+
+	// Defines how a new revision of the service replaces the running one.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rollout"
+	// +optional
+	Rollout KogitoRollout `json:"rollout,omitempty"`
+
+	// Settings of the KafkaTopics created for the topics of the service when it's bound to a Kafka KogitoInfra.
+	// They take precedence over the settings of the KogitoInfra.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kafka Topics"
+	// +optional
+	KafkaTopics KafkaTopicsConfig `json:"kafkaTopics,omitempty"`
 }
 
 // GetRuntime ...
@@ -56,9 +77,36 @@ func (k *KogitoRuntimeSpec) SetEnableIstio(enableIstio bool) {
 	k.EnableIstio = enableIstio
 }
 
+// GetProtoBufCompatibility ...
+func (k *KogitoRuntimeSpec) GetProtoBufCompatibility() api.ProtoBufCompatibilityPolicy {
+	if len(k.ProtoBufCompatibility) == 0 {
+		return api.WarnProtoBufCompatibilityPolicy
+	}
+	return k.ProtoBufCompatibility
+}
+
+// GetRollout ...
+func (k *KogitoRuntimeSpec) GetRollout() api.RolloutInterface {
+	return &k.Rollout
+}
+
+// GetKafkaTopics ...
+func (k *KogitoRuntimeSpec) GetKafkaTopics() api.KafkaTopicsConfigInterface {
+	return &k.KafkaTopics
+}
+
 // KogitoRuntimeStatus defines the observed state of KogitoRuntime.
 type KogitoRuntimeStatus struct {
 	KogitoServiceStatus `json:",inline"`
+
+	// Progress of the rollout of a new revision when the BlueGreen or Canary strategy is used.
+	// +optional
+	Rollout KogitoRolloutStatus `json:"rollout,omitempty"`
+}
+
+// GetRollout ...
+func (k *KogitoRuntimeStatus) GetRollout() api.RolloutStatusInterface {
+	return &k.Rollout
 }
 
 // +kubebuilder:object:root=true
@@ -40,6 +40,7 @@ type KogitoServiceStatus struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
 	RouteConditions *[]metav1.Condition `json:"routeConditions,omitempty"`
 	// Image is the resolved image for this service.
+	// When the image digest is pinned, it references both the image tag and the digest deployed for it, e.g. "quay.io/org/app:1.0@sha256:...".
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	Image string `json:"image,omitempty"`
 	// URI is where the service is exposed.
@@ -163,6 +164,9 @@ type KogitoCloudEventInfo struct {
 	Type string `json:"type"`
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	Source string `json:"source,omitempty"`
+	// Messaging topic through which the CloudEvent is exchanged.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Topic string `json:"topic,omitempty"`
 }
 
 // GetType ...
@@ -175,6 +179,11 @@ func (k KogitoCloudEventInfo) GetSource() string {
 	return k.Source
 }
 
+// GetTopic ...
+func (k KogitoCloudEventInfo) GetTopic() string {
+	return k.Topic
+}
+
 // KogitoServiceSpec is the basic structure for the Kogito Service specification.
 type KogitoServiceSpec struct {
 
@@ -204,7 +213,7 @@ type KogitoServiceSpec struct {
 
 	// +optional
 	// A flag indicating that image streams created by Kogito Operator should be configured to allow pulling from insecure registries.
-	// Usable just on OpenShift.
+	// On Kubernetes, it skips the verification of the registry certificates when the image digest is pinned.
 	//
 	// Defaults to 'false'.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -212,6 +221,28 @@ type KogitoServiceSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	InsecureImageRegistry bool `json:"insecureImageRegistry,omitempty"`
 
+	// +optional
+	// Resolve the image tag to its digest against the registry and deploy the image by digest, so the running image only
+	// changes when the operator rolls out a new digest. The image pull secrets of the service are used to authenticate.
+	// Usable just on Kubernetes, on OpenShift the image is resolved by the ImageStream.
+	//
+	// Defaults to 'false'.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pin Image Digest"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	PinImageDigest bool `json:"pinImageDigest,omitempty"`
+
+	// +optional
+	// When the pinned image digest is resolved again: 'OnTagChange' resolves it only when the image tag of the service
+	// is changed, 'Periodic' also polls the registry in background, to follow a tag moved to a new digest, and 'Never' keeps
+	// the first pinned image even when the image tag is changed. A new digest is rolled out like any other change of the service.
+	//
+	// Defaults to 'OnTagChange'.
+	// +kubebuilder:validation:Enum=OnTagChange;Periodic;Never
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Update Policy"
+	ImageUpdatePolicy api.ImageUpdatePolicyType `json:"imageUpdatePolicy,omitempty"`
+
 	// Defined compute resource requirements for the deployed service.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -260,6 +291,17 @@ type KogitoServiceSpec struct {
 	// +optional
 	Probes KogitoProbe `json:"probes,omitempty"`
 
+	// Customize the pod generated for the service: extra containers, init containers, volumes, ports, image pull settings,
+	// service account and priority class
+	// +optional
+	PodTemplate KogitoPodTemplate `json:"podTemplate,omitempty"`
+
+	// Patches applied to the objects generated by the operator before they're compared with the deployed ones.
+	// Each patch targets the generated objects by kind and, optionally, by name.
+	// +listType=atomic
+	// +optional
+	Patches []KogitoPatch `json:"patches,omitempty"`
+
 	// Custom JKS TrustStore that will be used by this service to make calls to TLS endpoints.
 	//
 	// It's expected that the secret has two keys: `keyStorePassword` containing the password for the KeyStore
@@ -277,6 +319,21 @@ type KogitoServiceSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="DisableRoute"
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	DisableRoute bool `json:"disableRoute,omitempty"`
+
+	// A flag indicating that the pods aren't restarted when the content of the ConfigMaps and Secrets they reference changes.
+	//
+	// If not provided, defaults to 'false'.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="DisableConfigRollout"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	DisableConfigRollout bool `json:"disableConfigRollout,omitempty"`
+
+	// Secrets stored in HashiCorp Vault provided to the service as environment variables, mounted as volumes or as its truststore.
+	// The service isn't deployed until the External Secrets Operator materialized them in the namespace.
+	// +optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	VaultSecrets []VaultSecretReference `json:"vaultSecrets,omitempty"`
 }
 
 // GetReplicas ...
@@ -383,6 +440,25 @@ func (k *KogitoServiceSpec) AddServiceLabel(name, value string) {
 // IsInsecureImageRegistry ...
 func (k *KogitoServiceSpec) IsInsecureImageRegistry() bool { return k.InsecureImageRegistry }
 
+// IsImageDigestPinned ...
+func (k *KogitoServiceSpec) IsImageDigestPinned() bool { return k.PinImageDigest }
+
+// SetPinImageDigest ...
+func (k *KogitoServiceSpec) SetPinImageDigest(pinImageDigest bool) { k.PinImageDigest = pinImageDigest }
+
+// GetImageUpdatePolicy ...
+func (k *KogitoServiceSpec) GetImageUpdatePolicy() api.ImageUpdatePolicyType {
+	if len(k.ImageUpdatePolicy) == 0 {
+		return api.OnTagChangeImageUpdatePolicy
+	}
+	return k.ImageUpdatePolicy
+}
+
+// SetImageUpdatePolicy ...
+func (k *KogitoServiceSpec) SetImageUpdatePolicy(policy api.ImageUpdatePolicyType) {
+	k.ImageUpdatePolicy = policy
+}
+
 // GetPropertiesConfigMap ...
 func (k *KogitoServiceSpec) GetPropertiesConfigMap() string {
 	return k.PropertiesConfigMap
@@ -425,6 +501,38 @@ func (k *KogitoServiceSpec) SetProbes(probes api.KogitoProbeInterface) {
 	}
 }
 
+// GetPodTemplate ...
+func (k *KogitoServiceSpec) GetPodTemplate() api.KogitoPodTemplateInterface {
+	return &k.PodTemplate
+}
+
+// SetPodTemplate ...
+func (k *KogitoServiceSpec) SetPodTemplate(podTemplate api.KogitoPodTemplateInterface) {
+	if newPodTemplate, ok := podTemplate.(*KogitoPodTemplate); ok {
+		k.PodTemplate = *newPodTemplate
+	}
+}
+
+// GetPatches ...
+func (k *KogitoServiceSpec) GetPatches() []api.KogitoPatchInterface {
+	patches := make([]api.KogitoPatchInterface, len(k.Patches))
+	for i, v := range k.Patches {
+		patches[i] = api.KogitoPatchInterface(v)
+	}
+	return patches
+}
+
+// SetPatches ...
+func (k *KogitoServiceSpec) SetPatches(patches []api.KogitoPatchInterface) {
+	var newPatches []KogitoPatch
+	for _, patch := range patches {
+		if newPatch, ok := patch.(KogitoPatch); ok {
+			newPatches = append(newPatches, newPatch)
+		}
+	}
+	k.Patches = newPatches
+}
+
 // GetTrustStoreSecret ...
 func (k *KogitoServiceSpec) GetTrustStoreSecret() string {
 	return k.TrustStoreSecret
@@ -444,3 +552,23 @@ func (k *KogitoServiceSpec) IsRouteDisabled() bool {
 func (k *KogitoServiceSpec) SetDisableRoute(disableRoute bool) {
 	k.DisableRoute = disableRoute
 }
+
+// IsConfigRolloutDisabled ...
+func (k *KogitoServiceSpec) IsConfigRolloutDisabled() bool {
+	return k.DisableConfigRollout
+}
+
+// SetDisableConfigRollout ...
+func (k *KogitoServiceSpec) SetDisableConfigRollout(disableConfigRollout bool) {
+	k.DisableConfigRollout = disableConfigRollout
+}
+
+// GetVaultSecrets ...
+func (k *KogitoServiceSpec) GetVaultSecrets() []api.VaultSecretReferenceInterface {
+	vaultSecrets := make([]api.VaultSecretReferenceInterface, len(k.VaultSecrets))
+	for i, v := range k.VaultSecrets {
+		item := v
+		vaultSecrets[i] = &item
+	}
+	return vaultSecrets
+}
@@ -24,13 +24,35 @@ import (
 type KogitoSupportingServiceSpec struct {
 	KogitoServiceSpec `json:",inline"`
 
-	// Defines the type for the supporting service, eg: DataIndex, JobsService
+	// Defines the type for the supporting service, eg: DataIndex, JobsService.
+	// TrustyStack deploys TrustyAI, Explainability and TrustyUI together, sharing the infra of this service.
 	// Default value: JobsService
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Type"
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=DataIndex;Explainability;JobsService;MgmtConsole;TaskConsole;TrustyAI;TrustyUI
+	// +kubebuilder:validation:Enum=DataIndex;Explainability;JobsService;MgmtConsole;TaskConsole;TrustyAI;TrustyUI;TrustyStack
 	ServiceType api.ServiceType `json:"serviceType"`
+
+	// External points to a supporting service instance managed outside of this cluster, eg: a Data Index run by another team.
+	//
+	// When set, the operator won't deploy any resource for this service. It only publishes the service endpoint to the Kogito services
+	// and checks its health.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="External Service"
+	External *ExternalSupportingService `json:"external,omitempty"`
+
+	// DataIndex holds the configuration specific to the Data Index service, it's ignored by the other service types.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Data Index"
+	DataIndex *DataIndexSpec `json:"dataIndex,omitempty"`
+
+	// JobsService holds the configuration specific to the Jobs Service, it's ignored by the other service types.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Jobs Service"
+	JobsService *JobsServiceSpec `json:"jobsService,omitempty"`
 }
 
 // GetRuntime ...
@@ -48,10 +70,161 @@ func (k *KogitoSupportingServiceSpec) SetServiceType(serviceType api.ServiceType
 	k.ServiceType = serviceType
 }
 
+// GetExternal ...
+func (k *KogitoSupportingServiceSpec) GetExternal() api.ExternalSupportingServiceInterface {
+	if k.External == nil {
+		return nil
+	}
+	return k.External
+}
+
+// IsExternal ...
+func (k *KogitoSupportingServiceSpec) IsExternal() bool {
+	return k.External != nil
+}
+
+// GetDataIndex ...
+func (k *KogitoSupportingServiceSpec) GetDataIndex() api.DataIndexSpecInterface {
+	if k.DataIndex == nil {
+		return nil
+	}
+	return k.DataIndex
+}
+
+// DataIndexSpec defines the configuration specific to the Data Index service.
+type DataIndexSpec struct {
+	// Storage used by Data Index, either infinispan, mongodb, postgresql or ephemeral.
+	// The matching Data Index image is used, and the infra required by the storage must be referenced in the infra field.
+	// Infinispan and MongoDB require the KogitoInfra of the respective kind, PostgreSQL requires a KogitoInfra
+	// without resource holding the connection properties. A KogitoInfra without resource doesn't tell the storage kind,
+	// Data Index referencing it without other storage infra is rejected until the storage is set.
+	//
+	// Changing the storage of a running Data Index scales it down, runs the schema migration for the new storage and scales it up again.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage"
+	// +kubebuilder:validation:Enum=infinispan;mongodb;postgresql;ephemeral
+	Storage api.DataIndexStorageType `json:"storage,omitempty"`
+}
+
+// GetStorage ...
+func (d *DataIndexSpec) GetStorage() api.DataIndexStorageType {
+	return d.Storage
+}
+
+// GetJobsService ...
+func (k *KogitoSupportingServiceSpec) GetJobsService() api.JobsServiceSpecInterface {
+	if k.JobsService == nil {
+		return nil
+	}
+	return k.JobsService
+}
+
+// JobsServiceSpec defines the configuration specific to the Jobs Service.
+type JobsServiceSpec struct {
+	// Storage shared by the Jobs Service replicas, either mongodb or postgresql, required to run more than one replica.
+	// MongoDB requires a KogitoInfra of this kind, PostgreSQL requires a KogitoInfra without resource holding the connection properties.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage"
+	// +kubebuilder:validation:Enum=mongodb;postgresql
+	Storage api.JobsServiceStorageType `json:"storage,omitempty"`
+}
+
+// GetStorage ...
+func (j *JobsServiceSpec) GetStorage() api.JobsServiceStorageType {
+	return j.Storage
+}
+
+// ExternalSupportingService defines how to reach a supporting service managed outside of the cluster.
+type ExternalSupportingService struct {
+	// HTTP URL of the external service, for example: https://data-index.example.com
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="URL"
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// WebSocket URL of the external service. If not provided, it's derived from the HTTP URL.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="WebSocket URL"
+	WSURL string `json:"wsURL,omitempty"`
+
+	// Secret holding the credentials to access the external service.
+	//
+	// It's expected that the secret has two keys: `username` and `password`. They are used as basic authentication
+	// while checking the service health.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+
+	// Secret holding the PEM encoded CA bundle used to verify the external service certificate.
+	//
+	// It's expected that the secret has the key `ca.crt`.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CABundleSecret string `json:"caBundleSecret,omitempty"`
+}
+
+// GetURL ...
+func (e *ExternalSupportingService) GetURL() string {
+	return e.URL
+}
+
+// GetWSURL ...
+func (e *ExternalSupportingService) GetWSURL() string {
+	return e.WSURL
+}
+
+// GetCredentialsSecret ...
+func (e *ExternalSupportingService) GetCredentialsSecret() string {
+	return e.CredentialsSecret
+}
+
+// GetCABundleSecret ...
+func (e *ExternalSupportingService) GetCABundleSecret() string {
+	return e.CABundleSecret
+}
+
 // KogitoSupportingServiceStatus defines the observed state of KogitoSupportingService.
 // +k8s:openapi-gen=true
 type KogitoSupportingServiceStatus struct {
 	KogitoServiceStatus `json:",inline"`
+
+	// Storage currently used by Data Index, it differs from the requested storage while a migration is in progress.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Data Index Storage"
+	DataIndexStorage api.DataIndexStorageType `json:"dataIndexStorage,omitempty"`
+
+	// Pod currently holding the leadership, only set for services running more than one replica with leader election, eg: Jobs Service.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Leader"
+	Leader string `json:"leader,omitempty"`
+}
+
+// GetDataIndexStorage ...
+func (k *KogitoSupportingServiceStatus) GetDataIndexStorage() api.DataIndexStorageType {
+	return k.DataIndexStorage
+}
+
+// SetDataIndexStorage ...
+func (k *KogitoSupportingServiceStatus) SetDataIndexStorage(storage api.DataIndexStorageType) {
+	k.DataIndexStorage = storage
+}
+
+// GetLeader ...
+func (k *KogitoSupportingServiceStatus) GetLeader() string {
+	return k.Leader
+}
+
+// SetLeader ...
+func (k *KogitoSupportingServiceStatus) SetLeader(leader string) {
+	k.Leader = leader
 }
 
 // +kubebuilder:object:root=true
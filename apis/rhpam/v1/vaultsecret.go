@@ -0,0 +1,61 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+// VaultSecretReference references a secret stored in HashiCorp Vault.
+// The operator renders an ExternalSecret materializing it in a Secret of the namespace, provided to the services as environment variables
+// or mounted as a volume. The services and infras referencing the same Vault secret share its ExternalSecret.
+type VaultSecretReference struct {
+	// Name of the Secret the Vault secret is materialized in. The Secret has a key for each key of the Vault secret.
+	// A service naming its trustStoreSecret after it mounts it as its truststore.
+	Name string `json:"name"`
+	// Path of the secret in Vault, e.g. kogito/travels for the KV secrets engine.
+	Path string `json:"path"`
+	// Key of the Vault secret to materialize. If not provided, all the keys of the secret are materialized.
+	// +optional
+	Key string `json:"key,omitempty"`
+	// Name of the SecretStore of the namespace the ExternalSecret reads the secret through, e.g. a store authenticating to Vault
+	// with a role dedicated to the namespace. If not provided, the ClusterSecretStore configured in the operator is used.
+	// +optional
+	SecretStore string `json:"secretStore,omitempty"`
+	// Path within the container at which the Secret is mounted, a file for each key. If not provided, the keys are environment variables.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// GetName ...
+func (v *VaultSecretReference) GetName() string {
+	return v.Name
+}
+
+// GetPath ...
+func (v *VaultSecretReference) GetPath() string {
+	return v.Path
+}
+
+// GetKey ...
+func (v *VaultSecretReference) GetKey() string {
+	return v.Key
+}
+
+// GetSecretStore ...
+func (v *VaultSecretReference) GetSecretStore() string {
+	return v.SecretStore
+}
+
+// GetMountPath ...
+func (v *VaultSecretReference) GetMountPath() string {
+	return v.MountPath
+}
@@ -0,0 +1,85 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// RolloutStrategyType defines how a new revision of a KogitoRuntime replaces the running one.
+type RolloutStrategyType string
+
+const (
+	// RollingUpdateRolloutStrategy replaces the pods of the Deployment with a rolling update
+	RollingUpdateRolloutStrategy RolloutStrategyType = "RollingUpdate"
+	// BlueGreenRolloutStrategy deploys the new revision next to the running one and switches the Service to it once it's healthy
+	BlueGreenRolloutStrategy RolloutStrategyType = "BlueGreen"
+	// CanaryRolloutStrategy deploys the new revision next to the running one and sends it a share of the external traffic until it's promoted
+	CanaryRolloutStrategy RolloutStrategyType = "Canary"
+)
+
+// RolloutPhase is the phase of the rollout of a new revision.
+type RolloutPhase string
+
+const (
+	// StableRolloutPhase - Every pod runs the current revision
+	StableRolloutPhase RolloutPhase = "Stable"
+	// ProgressingRolloutPhase - The candidate revision is being deployed
+	ProgressingRolloutPhase RolloutPhase = "Progressing"
+	// AnalyzingRolloutPhase - The candidate revision is ready and its error rate is being analyzed
+	AnalyzingRolloutPhase RolloutPhase = "Analyzing"
+	// PausedRolloutPhase - The candidate revision passed the analysis and waits to be promoted manually
+	PausedRolloutPhase RolloutPhase = "Paused"
+	// PromotingRolloutPhase - The traffic is switched to the candidate revision while the Deployment is updated
+	PromotingRolloutPhase RolloutPhase = "Promoting"
+	// AbortedRolloutPhase - The candidate revision failed the analysis and was removed, the previous revision keeps serving
+	AbortedRolloutPhase RolloutPhase = "Aborted"
+)
+
+// RolloutInterface ...
+type RolloutInterface interface {
+	GetStrategy() RolloutStrategyType
+	SetStrategy(strategy RolloutStrategyType)
+	GetCanaryWeight() int32
+	SetCanaryWeight(canaryWeight int32)
+	GetAnalysisSeconds() int32
+	SetAnalysisSeconds(analysisSeconds int32)
+	GetMaxErrorRate() int32
+	SetMaxErrorRate(maxErrorRate int32)
+	IsAutoPromotionDisabled() bool
+	SetDisableAutoPromotion(disableAutoPromotion bool)
+}
+
+// RolloutStatusInterface ...
+type RolloutStatusInterface interface {
+	GetPhase() RolloutPhase
+	SetPhase(phase RolloutPhase)
+	GetStableRevision() string
+	SetStableRevision(stableRevision string)
+	GetCandidateRevision() string
+	SetCandidateRevision(candidateRevision string)
+	GetActiveRevision() string
+	SetActiveRevision(activeRevision string)
+	GetAbortedRevision() string
+	SetAbortedRevision(abortedRevision string)
+	GetCandidateReadyTime() *metav1.Time
+	SetCandidateReadyTime(candidateReadyTime *metav1.Time)
+	GetAnalysisStartTime() *metav1.Time
+	SetAnalysisStartTime(analysisStartTime *metav1.Time)
+	GetAnalysisStartRequests() int64
+	SetAnalysisStartRequests(analysisStartRequests int64)
+	GetAnalysisStartServerErrors() int64
+	SetAnalysisStartServerErrors(analysisStartServerErrors int64)
+	GetCanaryWeight() int32
+	SetCanaryWeight(canaryWeight int32)
+}
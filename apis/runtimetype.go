@@ -14,7 +14,9 @@
 
 package api
 
-// RuntimeType - type of condition.
+// RuntimeType - name of the runtime profile of the services, quarkus and springboot are built in.
+// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+// +kubebuilder:validation:MaxLength=63
 type RuntimeType string
 
 const (
@@ -24,6 +24,7 @@ import (
 type AppV1beta1Interface interface {
 	RESTClient() rest.Interface
 	KogitoBuildsGetter
+	KogitoEventTopologiesGetter
 	KogitoInfrasGetter
 	KogitoRuntimesGetter
 	KogitoSupportingServicesGetter
@@ -38,6 +39,10 @@ func (c *AppV1beta1Client) KogitoBuilds(namespace string) KogitoBuildInterface {
 	return newKogitoBuilds(c, namespace)
 }
 
+func (c *AppV1beta1Client) KogitoEventTopologies(namespace string) KogitoEventTopologyInterface {
+	return newKogitoEventTopologies(c, namespace)
+}
+
 func (c *AppV1beta1Client) KogitoInfras(namespace string) KogitoInfraInterface {
 	return newKogitoInfras(c, namespace)
 }
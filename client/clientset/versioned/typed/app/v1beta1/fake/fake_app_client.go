@@ -29,6 +29,10 @@ func (c *FakeAppV1beta1) KogitoBuilds(namespace string) v1beta1.KogitoBuildInter
 	return &FakeKogitoBuilds{c, namespace}
 }
 
+func (c *FakeAppV1beta1) KogitoEventTopologies(namespace string) v1beta1.KogitoEventTopologyInterface {
+	return &FakeKogitoEventTopologies{c, namespace}
+}
+
 func (c *FakeAppV1beta1) KogitoInfras(namespace string) v1beta1.KogitoInfraInterface {
 	return &FakeKogitoInfras{c, namespace}
 }
@@ -0,0 +1,139 @@
+// Copyright 2021 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeKogitoEventTopologies implements KogitoEventTopologyInterface
+type FakeKogitoEventTopologies struct {
+	Fake *FakeAppV1beta1
+	ns   string
+}
+
+var kogitoeventtopologiesResource = schema.GroupVersionResource{Group: "app.kiegroup.org", Version: "v1beta1", Resource: "kogitoeventtopologies"}
+
+var kogitoeventtopologiesKind = schema.GroupVersionKind{Group: "app.kiegroup.org", Version: "v1beta1", Kind: "KogitoEventTopology"}
+
+// Get takes name of the kogitoEventTopology, and returns the corresponding kogitoEventTopology object, and an error if there is any.
+func (c *FakeKogitoEventTopologies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.KogitoEventTopology, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(kogitoeventtopologiesResource, c.ns, name), &v1beta1.KogitoEventTopology{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.KogitoEventTopology), err
+}
+
+// List takes label and field selectors, and returns the list of KogitoEventTopologies that match those selectors.
+func (c *FakeKogitoEventTopologies) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.KogitoEventTopologyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(kogitoeventtopologiesResource, kogitoeventtopologiesKind, c.ns, opts), &v1beta1.KogitoEventTopologyList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.KogitoEventTopologyList{ListMeta: obj.(*v1beta1.KogitoEventTopologyList).ListMeta}
+	for _, item := range obj.(*v1beta1.KogitoEventTopologyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested kogitoEventTopologys.
+func (c *FakeKogitoEventTopologies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(kogitoeventtopologiesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a kogitoEventTopology and creates it.  Returns the server's representation of the kogitoEventTopology, and an error, if there is any.
+func (c *FakeKogitoEventTopologies) Create(ctx context.Context, kogitoEventTopology *v1beta1.KogitoEventTopology, opts v1.CreateOptions) (result *v1beta1.KogitoEventTopology, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(kogitoeventtopologiesResource, c.ns, kogitoEventTopology), &v1beta1.KogitoEventTopology{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.KogitoEventTopology), err
+}
+
+// Update takes the representation of a kogitoEventTopology and updates it. Returns the server's representation of the kogitoEventTopology, and an error, if there is any.
+func (c *FakeKogitoEventTopologies) Update(ctx context.Context, kogitoEventTopology *v1beta1.KogitoEventTopology, opts v1.UpdateOptions) (result *v1beta1.KogitoEventTopology, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(kogitoeventtopologiesResource, c.ns, kogitoEventTopology), &v1beta1.KogitoEventTopology{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.KogitoEventTopology), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeKogitoEventTopologies) UpdateStatus(ctx context.Context, kogitoEventTopology *v1beta1.KogitoEventTopology, opts v1.UpdateOptions) (*v1beta1.KogitoEventTopology, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(kogitoeventtopologiesResource, "status", c.ns, kogitoEventTopology), &v1beta1.KogitoEventTopology{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.KogitoEventTopology), err
+}
+
+// Delete takes name of the kogitoEventTopology and deletes it. Returns an error if one occurs.
+func (c *FakeKogitoEventTopologies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(kogitoeventtopologiesResource, c.ns, name), &v1beta1.KogitoEventTopology{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeKogitoEventTopologies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(kogitoeventtopologiesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.KogitoEventTopologyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched kogitoEventTopology.
+func (c *FakeKogitoEventTopologies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.KogitoEventTopology, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(kogitoeventtopologiesResource, c.ns, name, pt, data, subresources...), &v1beta1.KogitoEventTopology{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.KogitoEventTopology), err
+}
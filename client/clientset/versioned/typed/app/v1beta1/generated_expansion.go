@@ -17,6 +17,8 @@ package v1beta1
 
 type KogitoBuildExpansion interface{}
 
+type KogitoEventTopologyExpansion interface{}
+
 type KogitoInfraExpansion interface{}
 
 type KogitoRuntimeExpansion interface{}
@@ -0,0 +1,192 @@
+// Copyright 2021 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	v1beta1 "github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	scheme "github.com/kiegroup/kogito-operator/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// KogitoEventTopologiesGetter has a method to return a KogitoEventTopologyInterface.
+// A group's client should implement this interface.
+type KogitoEventTopologiesGetter interface {
+	KogitoEventTopologies(namespace string) KogitoEventTopologyInterface
+}
+
+// KogitoEventTopologyInterface has methods to work with KogitoEventTopology resources.
+type KogitoEventTopologyInterface interface {
+	Create(ctx context.Context, kogitoEventTopology *v1beta1.KogitoEventTopology, opts v1.CreateOptions) (*v1beta1.KogitoEventTopology, error)
+	Update(ctx context.Context, kogitoEventTopology *v1beta1.KogitoEventTopology, opts v1.UpdateOptions) (*v1beta1.KogitoEventTopology, error)
+	UpdateStatus(ctx context.Context, kogitoEventTopology *v1beta1.KogitoEventTopology, opts v1.UpdateOptions) (*v1beta1.KogitoEventTopology, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.KogitoEventTopology, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.KogitoEventTopologyList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.KogitoEventTopology, err error)
+	KogitoEventTopologyExpansion
+}
+
+// kogitoEventTopologys implements KogitoEventTopologyInterface
+type kogitoEventTopologys struct {
+	client rest.Interface
+	ns     string
+}
+
+// newKogitoEventTopologies returns a KogitoEventTopologies
+func newKogitoEventTopologies(c *AppV1beta1Client, namespace string) *kogitoEventTopologys {
+	return &kogitoEventTopologys{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the kogitoEventTopology, and returns the corresponding kogitoEventTopology object, and an error if there is any.
+func (c *kogitoEventTopologys) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.KogitoEventTopology, err error) {
+	result = &v1beta1.KogitoEventTopology{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("kogitoeventtopologies").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of KogitoEventTopologies that match those selectors.
+func (c *kogitoEventTopologys) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.KogitoEventTopologyList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.KogitoEventTopologyList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("kogitoeventtopologies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested kogitoEventTopologys.
+func (c *kogitoEventTopologys) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("kogitoeventtopologies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a kogitoEventTopology and creates it.  Returns the server's representation of the kogitoEventTopology, and an error, if there is any.
+func (c *kogitoEventTopologys) Create(ctx context.Context, kogitoEventTopology *v1beta1.KogitoEventTopology, opts v1.CreateOptions) (result *v1beta1.KogitoEventTopology, err error) {
+	result = &v1beta1.KogitoEventTopology{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("kogitoeventtopologies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(kogitoEventTopology).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a kogitoEventTopology and updates it. Returns the server's representation of the kogitoEventTopology, and an error, if there is any.
+func (c *kogitoEventTopologys) Update(ctx context.Context, kogitoEventTopology *v1beta1.KogitoEventTopology, opts v1.UpdateOptions) (result *v1beta1.KogitoEventTopology, err error) {
+	result = &v1beta1.KogitoEventTopology{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("kogitoeventtopologies").
+		Name(kogitoEventTopology.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(kogitoEventTopology).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *kogitoEventTopologys) UpdateStatus(ctx context.Context, kogitoEventTopology *v1beta1.KogitoEventTopology, opts v1.UpdateOptions) (result *v1beta1.KogitoEventTopology, err error) {
+	result = &v1beta1.KogitoEventTopology{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("kogitoeventtopologies").
+		Name(kogitoEventTopology.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(kogitoEventTopology).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the kogitoEventTopology and deletes it. Returns an error if one occurs.
+func (c *kogitoEventTopologys) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("kogitoeventtopologies").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *kogitoEventTopologys) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("kogitoeventtopologies").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched kogitoEventTopology.
+func (c *kogitoEventTopologys) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.KogitoEventTopology, err error) {
+	result = &v1beta1.KogitoEventTopology{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("kogitoeventtopologies").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -23,6 +23,8 @@ import (
 type Interface interface {
 	// KogitoBuilds returns a KogitoBuildInformer.
 	KogitoBuilds() KogitoBuildInformer
+	// KogitoEventTopologies returns a KogitoEventTopologyInformer.
+	KogitoEventTopologies() KogitoEventTopologyInformer
 	// KogitoInfras returns a KogitoInfraInformer.
 	KogitoInfras() KogitoInfraInformer
 	// KogitoRuntimes returns a KogitoRuntimeInformer.
@@ -47,6 +49,11 @@ func (v *version) KogitoBuilds() KogitoBuildInformer {
 	return &kogitoBuildInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// KogitoEventTopologies returns a KogitoEventTopologyInformer.
+func (v *version) KogitoEventTopologies() KogitoEventTopologyInformer {
+	return &kogitoEventTopologyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // KogitoInfras returns a KogitoInfraInformer.
 func (v *version) KogitoInfras() KogitoInfraInformer {
 	return &kogitoInfraInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
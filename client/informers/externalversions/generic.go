@@ -52,6 +52,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=app.kiegroup.org, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("kogitobuilds"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.App().V1beta1().KogitoBuilds().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("kogitoeventtopologies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.App().V1beta1().KogitoEventTopologies().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("kogitoinfras"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.App().V1beta1().KogitoInfras().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("kogitoruntimes"):
@@ -23,6 +23,14 @@ type KogitoBuildListerExpansion interface{}
 // KogitoBuildNamespaceLister.
 type KogitoBuildNamespaceListerExpansion interface{}
 
+// KogitoEventTopologyListerExpansion allows custom methods to be added to
+// KogitoEventTopologyLister.
+type KogitoEventTopologyListerExpansion interface{}
+
+// KogitoEventTopologyNamespaceListerExpansion allows custom methods to be added to
+// KogitoEventTopologyNamespaceLister.
+type KogitoEventTopologyNamespaceListerExpansion interface{}
+
 // KogitoInfraListerExpansion allows custom methods to be added to
 // KogitoInfraLister.
 type KogitoInfraListerExpansion interface{}
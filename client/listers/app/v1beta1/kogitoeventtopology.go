@@ -0,0 +1,96 @@
+// Copyright 2021 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// KogitoEventTopologyLister helps list KogitoEventTopologies.
+// All objects returned here must be treated as read-only.
+type KogitoEventTopologyLister interface {
+	// List lists all KogitoEventTopologies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.KogitoEventTopology, err error)
+	// KogitoEventTopologies returns an object that can list and get KogitoEventTopologies.
+	KogitoEventTopologies(namespace string) KogitoEventTopologyNamespaceLister
+	KogitoEventTopologyListerExpansion
+}
+
+// kogitoEventTopologyLister implements the KogitoEventTopologyLister interface.
+type kogitoEventTopologyLister struct {
+	indexer cache.Indexer
+}
+
+// NewKogitoEventTopologyLister returns a new KogitoEventTopologyLister.
+func NewKogitoEventTopologyLister(indexer cache.Indexer) KogitoEventTopologyLister {
+	return &kogitoEventTopologyLister{indexer: indexer}
+}
+
+// List lists all KogitoEventTopologies in the indexer.
+func (s *kogitoEventTopologyLister) List(selector labels.Selector) (ret []*v1beta1.KogitoEventTopology, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.KogitoEventTopology))
+	})
+	return ret, err
+}
+
+// KogitoEventTopologies returns an object that can list and get KogitoEventTopologies.
+func (s *kogitoEventTopologyLister) KogitoEventTopologies(namespace string) KogitoEventTopologyNamespaceLister {
+	return kogitoEventTopologyNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// KogitoEventTopologyNamespaceLister helps list and get KogitoEventTopologies.
+// All objects returned here must be treated as read-only.
+type KogitoEventTopologyNamespaceLister interface {
+	// List lists all KogitoEventTopologies in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.KogitoEventTopology, err error)
+	// Get retrieves the KogitoEventTopology from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.KogitoEventTopology, error)
+	KogitoEventTopologyNamespaceListerExpansion
+}
+
+// kogitoEventTopologyNamespaceLister implements the KogitoEventTopologyNamespaceLister
+// interface.
+type kogitoEventTopologyNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all KogitoEventTopologies in the indexer for a given namespace.
+func (s kogitoEventTopologyNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.KogitoEventTopology, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.KogitoEventTopology))
+	})
+	return ret, err
+}
+
+// Get retrieves the KogitoEventTopology from the indexer for a given namespace and name.
+func (s kogitoEventTopologyNamespaceLister) Get(name string) (*v1beta1.KogitoEventTopology, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("kogitoeventtopology"), name)
+	}
+	return obj.(*v1beta1.KogitoEventTopology), nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/kiegroup/kogito-operator/cmd/kogito/command/completion"
 	"github.com/kiegroup/kogito-operator/cmd/kogito/command/context"
 	"github.com/kiegroup/kogito-operator/cmd/kogito/command/deploy"
+	"github.com/kiegroup/kogito-operator/cmd/kogito/command/events"
 	"github.com/kiegroup/kogito-operator/cmd/kogito/command/install"
 	"github.com/kiegroup/kogito-operator/cmd/kogito/command/project"
 	"github.com/kiegroup/kogito-operator/cmd/kogito/command/remove"
@@ -43,6 +44,7 @@ func BuildCommands(kubeClient *client.Client, output io.Writer) *cobra.Command {
 	install.BuildCommands(ctx, rootCommand.Command())
 	remove.BuildCommands(ctx, rootCommand.Command())
 	project.BuildCommands(ctx, rootCommand.Command())
+	events.BuildCommands(ctx, rootCommand.Command())
 
 	return rootCommand.Command()
 }
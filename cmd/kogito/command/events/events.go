@@ -0,0 +1,52 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"github.com/kiegroup/kogito-operator/cmd/kogito/command/context"
+	"github.com/spf13/cobra"
+)
+
+type eventsCommand struct {
+	context.CommandContext
+	command *cobra.Command
+	Parent  *cobra.Command
+}
+
+func initEventsCommand(ctx *context.CommandContext, parent *cobra.Command) context.KogitoCommand {
+	cmd := eventsCommand{
+		CommandContext: *ctx,
+		Parent:         parent,
+	}
+	cmd.RegisterHook()
+	cmd.InitHook()
+	return &cmd
+}
+
+func (i *eventsCommand) Command() *cobra.Command {
+	return i.command
+}
+
+func (i *eventsCommand) RegisterHook() {
+	i.command = &cobra.Command{
+		Use:    "events",
+		Short:  "inspect the CloudEvents exchanged by the Kogito services of your Kogito project",
+		PreRun: i.CommonPreRun,
+	}
+}
+
+func (i *eventsCommand) InitHook() {
+	i.Parent.AddCommand(i.command)
+}
@@ -0,0 +1,156 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kiegroup/kogito-operator/cmd/kogito/command/context"
+	"github.com/kiegroup/kogito-operator/cmd/kogito/command/message"
+	"github.com/kiegroup/kogito-operator/cmd/kogito/command/shared"
+	"github.com/kiegroup/kogito-operator/core/eventtopology"
+	"github.com/kiegroup/kogito-operator/core/logger"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/internal/app"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/spf13/cobra"
+)
+
+const (
+	textGraphFormat    = "text"
+	dotGraphFormat     = "dot"
+	mermaidGraphFormat = "mermaid"
+)
+
+type graphFlags struct {
+	project string
+	format  string
+}
+
+func initGraphCommand(ctx *context.CommandContext, parent *cobra.Command) context.KogitoCommand {
+	cmd := &graphCommand{
+		CommandContext:       *ctx,
+		Parent:               parent,
+		resourceCheckService: shared.NewResourceCheckService(),
+	}
+	cmd.RegisterHook()
+	cmd.InitHook()
+	return cmd
+}
+
+type graphCommand struct {
+	context.CommandContext
+	command              *cobra.Command
+	flags                *graphFlags
+	Parent               *cobra.Command
+	resourceCheckService shared.ResourceCheckService
+}
+
+func (i *graphCommand) RegisterHook() {
+	i.command = &cobra.Command{
+		Example: "events graph --project kogito --format mermaid",
+		Use:     "graph [flags]",
+		Short:   "Displays which Kogito service produces and consumes each CloudEvent in the given Project context",
+		Long: `events graph displays, for each CloudEvent type, the Kogito services producing it, the Kogito services consuming it,
+		and the Kafka topic or Knative Eventing Broker through which it is exchanged.
+		CloudEvents consumed but never produced (orphan consumers) and produced but never consumed (unconsumed producers) are flagged.
+		The graph is rendered as text, or exported in the Graphviz DOT language or as a Mermaid flowchart.
+		The CloudEvents are the ones reported by the Kogito services in their status, once they are deployed.`,
+		RunE:    i.Exec,
+		PreRun:  i.CommonPreRun,
+		PostRun: i.CommonPostRun,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("requires 0 arg, received %v", len(args))
+			}
+			return nil
+		},
+	}
+}
+
+func (i *graphCommand) Command() *cobra.Command {
+	return i.command
+}
+
+func (i *graphCommand) InitHook() {
+	i.flags = &graphFlags{}
+	i.Parent.AddCommand(i.command)
+	i.command.Flags().StringVarP(&i.flags.project, "project", "p", "", "The project name from where the CloudEvents graph is built")
+	i.command.Flags().StringVar(&i.flags.format, "format", textGraphFormat, "Format of the graph: text, dot or mermaid")
+}
+
+func (i *graphCommand) Exec(cmd *cobra.Command, args []string) (err error) {
+	if i.flags.format != textGraphFormat && i.flags.format != dotGraphFormat && i.flags.format != mermaidGraphFormat {
+		return fmt.Errorf(message.EventsUnsupportedFormat, i.flags.format)
+	}
+	if i.flags.project, err = i.resourceCheckService.EnsureProject(i.Client, i.flags.project); err != nil {
+		return err
+	}
+	kogitoContext := operator.Context{
+		Client: i.Client,
+		Scheme: meta.GetRegisteredSchema(),
+		Log:    logger.GetLogger("events_graph"),
+	}
+	topologyBuilder := eventtopology.NewBuilder(kogitoContext, app.NewKogitoRuntimeHandler(kogitoContext), app.NewKogitoSupportingServiceHandler(kogitoContext), app.NewKogitoInfraHandler(kogitoContext))
+	graph, err := topologyBuilder.Build(i.flags.project)
+	if err != nil {
+		return err
+	}
+	switch i.flags.format {
+	case dotGraphFormat:
+		_, err = io.WriteString(cmd.OutOrStdout(), graph.DOT())
+	case mermaidGraphFormat:
+		_, err = io.WriteString(cmd.OutOrStdout(), graph.Mermaid())
+	default:
+		err = writeTextGraph(cmd.OutOrStdout(), graph, i.flags.project)
+	}
+	return err
+}
+
+// writeTextGraph writes the producers and consumers of each event, followed by the flagged events
+func writeTextGraph(out io.Writer, graph *eventtopology.Graph, project string) error {
+	events := graph.GetEvents()
+	if len(events) == 0 {
+		_, err := fmt.Fprintf(out, message.EventsNoEventFound+"\n", project)
+		return err
+	}
+	b := &strings.Builder{}
+	for _, event := range events {
+		fmt.Fprintf(b, "%s\n", event.Type)
+		for _, producer := range event.Producers {
+			fmt.Fprintf(b, "  produced by %s%s\n", producer.Service, describeChannel(producer))
+		}
+		for _, consumer := range event.Consumers {
+			fmt.Fprintf(b, "  consumed by %s%s\n", consumer.Service, describeChannel(consumer))
+		}
+	}
+	if orphanConsumers := graph.GetOrphanConsumers(); len(orphanConsumers) > 0 {
+		fmt.Fprintf(b, "\nOrphan consumers, consumed but not produced: %s\n", strings.Join(orphanConsumers, ", "))
+	}
+	if unconsumedProducers := graph.GetUnconsumedProducers(); len(unconsumedProducers) > 0 {
+		fmt.Fprintf(b, "\nUnconsumed producers, produced but not consumed: %s\n", strings.Join(unconsumedProducers, ", "))
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func describeChannel(endpoint eventtopology.Endpoint) string {
+	if len(endpoint.Channel) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" through %s %s", endpoint.ChannelKind, endpoint.Channel)
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/cmd/kogito/command/context"
+	"github.com/kiegroup/kogito-operator/cmd/kogito/command/test"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestRuntimes(ns string) []*v1beta1.KogitoRuntime {
+	return []*v1beta1.KogitoRuntime{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "travels", Namespace: ns},
+			Status: v1beta1.KogitoRuntimeStatus{KogitoServiceStatus: v1beta1.KogitoServiceStatus{CloudEvents: v1beta1.KogitoCloudEventsStatus{
+				Produces: []v1beta1.KogitoCloudEventInfo{{Type: "travels", Topic: "kogito-travels"}},
+			}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "visas", Namespace: ns},
+			Status: v1beta1.KogitoRuntimeStatus{KogitoServiceStatus: v1beta1.KogitoServiceStatus{CloudEvents: v1beta1.KogitoCloudEventsStatus{
+				Consumes: []v1beta1.KogitoCloudEventInfo{{Type: "travels", Topic: "kogito-travels"}, {Type: "approvals", Topic: "kogito-approvals"}},
+			}}},
+		},
+	}
+}
+
+func Test_graphCommand_Text(t *testing.T) {
+	ns := t.Name()
+	runtimes := newTestRuntimes(ns)
+	cli := fmt.Sprintf("events graph -p %s", ns)
+	ctx := test.SetupCliTest(cli, context.CommandFactory{BuildCommands: BuildCommands}, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}}, runtimes[0], runtimes[1])
+
+	lines, _, err := ctx.ExecuteCli()
+	assert.NoError(t, err)
+	assert.Contains(t, lines, "travels\n  produced by travels through KafkaTopic kogito-travels\n  consumed by visas through KafkaTopic kogito-travels\n")
+	assert.Contains(t, lines, "Orphan consumers, consumed but not produced: approvals")
+	assert.NotContains(t, lines, "Unconsumed producers")
+}
+
+func Test_graphCommand_DOT(t *testing.T) {
+	ns := t.Name()
+	runtimes := newTestRuntimes(ns)
+	cli := fmt.Sprintf("events graph -p %s --format dot", ns)
+	ctx := test.SetupCliTest(cli, context.CommandFactory{BuildCommands: BuildCommands}, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}}, runtimes[0], runtimes[1])
+
+	lines, _, err := ctx.ExecuteCli()
+	assert.NoError(t, err)
+	assert.Contains(t, lines, "digraph kogito_events {")
+	assert.Contains(t, lines, "style=dashed, color=red")
+}
+
+func Test_graphCommand_Mermaid(t *testing.T) {
+	ns := t.Name()
+	runtimes := newTestRuntimes(ns)
+	cli := fmt.Sprintf("events graph -p %s --format mermaid", ns)
+	ctx := test.SetupCliTest(cli, context.CommandFactory{BuildCommands: BuildCommands}, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}}, runtimes[0], runtimes[1])
+
+	lines, _, err := ctx.ExecuteCli()
+	assert.NoError(t, err)
+	assert.Contains(t, lines, "flowchart LR")
+	assert.Contains(t, lines, "-. \"approvals\" .->")
+}
+
+func Test_graphCommand_NoEvents(t *testing.T) {
+	ns := t.Name()
+	cli := fmt.Sprintf("events graph -p %s", ns)
+	ctx := test.SetupCliTest(cli, context.CommandFactory{BuildCommands: BuildCommands}, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})
+
+	lines, _, err := ctx.ExecuteCli()
+	assert.NoError(t, err)
+	assert.Contains(t, lines, "No CloudEvent produced or consumed by the Kogito services")
+}
+
+func Test_graphCommand_UnsupportedFormat(t *testing.T) {
+	ns := t.Name()
+	cli := fmt.Sprintf("events graph -p %s --format svg", ns)
+	ctx := test.SetupCliTest(cli, context.CommandFactory{BuildCommands: BuildCommands}, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})
+
+	_, _, err := ctx.ExecuteCli()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "'svg' is not a supported graph format")
+}
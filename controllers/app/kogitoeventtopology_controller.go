@@ -0,0 +1,47 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/controllers/common"
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	app2 "github.com/kiegroup/kogito-operator/internal/app"
+	"github.com/kiegroup/kogito-operator/version/app"
+	"k8s.io/apimachinery/pkg/runtime"
+	controllerclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=app.kiegroup.org,resources=kogitoeventtopologies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=app.kiegroup.org,resources=kogitoeventtopologies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=app.kiegroup.org,resources=kogitoeventtopologies/finalizers,verbs=get;update;patch
+
+// NewKogitoEventTopologyReconciler ...
+func NewKogitoEventTopologyReconciler(client *kogitocli.Client, scheme *runtime.Scheme, config *operator.Config) *common.KogitoEventTopologyReconciler {
+	return &common.KogitoEventTopologyReconciler{
+		Client:                   client,
+		Scheme:                   scheme,
+		Version:                  app.Version,
+		EventTopologyHandler:     app2.NewKogitoEventTopologyHandler,
+		RuntimeHandler:           app2.NewKogitoRuntimeHandler,
+		SupportingServiceHandler: app2.NewKogitoSupportingServiceHandler,
+		InfraHandler:             app2.NewKogitoInfraHandler,
+		ReconcilingObject:        &v1beta1.KogitoEventTopology{},
+		WatchedObjects:           []controllerclient.Object{&v1beta1.KogitoRuntime{}, &v1beta1.KogitoSupportingService{}, &v1beta1.KogitoInfra{}},
+		Config:                   config,
+		Backoff:                  operator.NewRequeueBackoff(config),
+	}
+}
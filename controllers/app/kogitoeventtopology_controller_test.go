@@ -0,0 +1,59 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcileKogitoEventTopology(t *testing.T) {
+	ns := t.Name()
+	topology := &v1beta1.KogitoEventTopology{ObjectMeta: v1.ObjectMeta{Name: "kogito-event-topology", Namespace: ns}}
+	travels := test.CreateFakeKogitoRuntime(ns)
+	travels.Name = "travels"
+	travels.Status.CloudEvents = v1beta1.KogitoCloudEventsStatus{
+		Produces: []v1beta1.KogitoCloudEventInfo{{Type: "travels", Topic: "kogito-travels"}},
+		Consumes: []v1beta1.KogitoCloudEventInfo{{Type: "approvals", Topic: "kogito-approvals"}},
+	}
+	visas := test.CreateFakeKogitoRuntime(ns)
+	visas.Name = "visas"
+	visas.Status.CloudEvents = v1beta1.KogitoCloudEventsStatus{
+		Consumes: []v1beta1.KogitoCloudEventInfo{{Type: "travels", Topic: "kogito-travels"}},
+	}
+	client := test.NewFakeClientBuilder().AddK8sObjects(topology, travels, visas).Build()
+
+	r := NewKogitoEventTopologyReconciler(client, meta.GetRegisteredSchema(), operator.DefaultConfig())
+	test.AssertReconcileMustNotRequeue(t, r, topology)
+
+	exists, err := kubernetes.ResourceC(client).Fetch(topology)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Len(t, topology.Status.Events, 2)
+	assert.Equal(t, "travels", topology.Status.Events[1].Type)
+	assert.Equal(t, "travels", topology.Status.Events[1].Producers[0].Service)
+	assert.Equal(t, api.KafkaTopicEventChannel, topology.Status.Events[1].Producers[0].ChannelKind)
+	assert.Equal(t, "visas", topology.Status.Events[1].Consumers[0].Service)
+	assert.Equal(t, []string{"approvals"}, topology.Status.OrphanConsumers)
+	assert.Empty(t, topology.Status.UnconsumedProducers)
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/operator"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -43,7 +44,7 @@ func Test_Reconcile_ResourceNotFound(t *testing.T) {
 	}
 	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra).Build()
 	scheme := meta.GetRegisteredSchema()
-	r := NewKogitoInfraReconciler(client, scheme)
+	r := NewKogitoInfraReconciler(client, scheme, operator.DefaultConfig())
 	// basic checks
 	test.AssertReconcileMustNotRequeue(t, r, kogitoInfra)
 	exists, err := kubernetes.ResourceC(client).Fetch(kogitoInfra)
@@ -92,7 +93,7 @@ func Test_Reconcile_KafkaResource(t *testing.T) {
 	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfra, deployedKafkaInstance).Build()
 
 	scheme := meta.GetRegisteredSchema()
-	r := NewKogitoInfraReconciler(client, scheme)
+	r := NewKogitoInfraReconciler(client, scheme, operator.DefaultConfig())
 	// basic checks
 	test.AssertReconcile(t, r, kogitoInfra)
 
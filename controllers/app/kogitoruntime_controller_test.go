@@ -57,7 +57,7 @@ func TestReconcileKogitoRuntime_Reconcile(t *testing.T) {
 	}
 
 	cli := test.NewFakeClientBuilder().AddK8sObjects(instance, kogitoKafka, kogitoInfinispan).Build()
-	r := NewKogitoRuntimeReconciler(cli, meta.GetRegisteredSchema())
+	r := NewKogitoRuntimeReconciler(cli, meta.GetRegisteredSchema(), operator.DefaultConfig())
 
 	// first reconciliation
 	test.AssertReconcileMustNotRequeue(t, r, instance)
@@ -108,7 +108,7 @@ func TestReconcileKogitoRuntime_CustomImage(t *testing.T) {
 
 	cli := test.NewFakeClientBuilder().AddK8sObjects(instance, is).AddImageObjects(tag).OnOpenShift().Build()
 
-	r := NewKogitoRuntimeReconciler(cli, meta.GetRegisteredSchema())
+	r := NewKogitoRuntimeReconciler(cli, meta.GetRegisteredSchema(), operator.DefaultConfig())
 	test.AssertReconcileMustNotRequeue(t, r, instance)
 
 	_, err = kubernetes.ResourceC(cli).Fetch(instance)
@@ -173,7 +173,7 @@ func TestReconcileKogitoRuntime_InvalidCustomImage(t *testing.T) {
 	err := framework.AddOwnerReference(instance, meta.GetRegisteredSchema(), imageStream)
 	assert.NoError(t, err)
 	cli := test.NewFakeClientBuilder().AddK8sObjects(instance, imageStream).OnOpenShift().Build()
-	r := NewKogitoRuntimeReconciler(cli, meta.GetRegisteredSchema())
+	r := NewKogitoRuntimeReconciler(cli, meta.GetRegisteredSchema(), operator.DefaultConfig())
 
 	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}})
 	assert.Error(t, err)
@@ -209,7 +209,7 @@ func TestReconcileKogitoRuntime_CustomConfigMap(t *testing.T) {
 		},
 	}
 	cli := test.NewFakeClientBuilder().AddK8sObjects(instance, cm).Build()
-	r := NewKogitoRuntimeReconciler(cli, meta.GetRegisteredSchema())
+	r := NewKogitoRuntimeReconciler(cli, meta.GetRegisteredSchema(), operator.DefaultConfig())
 	test.AssertReconcileMustNotRequeue(t, r, instance)
 
 	_, err := kubernetes.ResourceC(cli).Fetch(cm)
@@ -17,21 +17,24 @@ package app
 import (
 	"github.com/kiegroup/kogito-operator/controllers/common"
 	kogitocli "github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/operator"
 	app2 "github.com/kiegroup/kogito-operator/internal/app"
 	"github.com/kiegroup/kogito-operator/version/app"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
-//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;delete;update
+//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;delete;update;patch
 //+kubebuilder:rbac:groups=core,resources=configmaps;events;pods;secrets;serviceaccounts;services,verbs=create;delete;get;list;patch;update;watch
 
 // NewKogitoRuntimeDeploymentReconciler ...
-func NewKogitoRuntimeDeploymentReconciler(client *kogitocli.Client, scheme *runtime.Scheme) *common.RuntimeDeploymentReconciler {
+func NewKogitoRuntimeDeploymentReconciler(client *kogitocli.Client, scheme *runtime.Scheme, config *operator.Config) *common.RuntimeDeploymentReconciler {
 	return &common.RuntimeDeploymentReconciler{
 		Client:                client,
 		Scheme:                scheme,
 		Version:               app.Version,
 		RuntimeHandler:        app2.NewKogitoRuntimeHandler,
 		SupportServiceHandler: app2.NewKogitoSupportingServiceHandler,
+		Config:                config,
+		Backoff:               operator.NewRequeueBackoff(config),
 	}
 }
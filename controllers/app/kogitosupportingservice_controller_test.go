@@ -40,7 +40,7 @@ func TestReconcileKogitoSupportingService_Reconcile(t *testing.T) {
 	}
 	cli := test.NewFakeClientBuilder().AddK8sObjects(instance).Build()
 
-	r := NewKogitoSupportingServiceReconciler(cli, meta.GetRegisteredSchema())
+	r := NewKogitoSupportingServiceReconciler(cli, meta.GetRegisteredSchema(), operator.DefaultConfig())
 	test.AssertReconcileMustNotRequeue(t, r, instance)
 	deployment := &appsv1.Deployment{ObjectMeta: v1.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}}
 	_, err := kubernetes.ResourceC(cli).Fetch(deployment)
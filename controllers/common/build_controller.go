@@ -24,6 +24,7 @@ import (
 	"github.com/kiegroup/kogito-operator/core/logger"
 	"github.com/kiegroup/kogito-operator/core/manager"
 	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	buildv1 "github.com/openshift/api/build/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -33,6 +34,7 @@ import (
 
 	kogitocli "github.com/kiegroup/kogito-operator/core/client"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -48,6 +50,8 @@ type KogitoBuildReconciler struct {
 	BuildHandler      func(context operator.Context) manager.KogitoBuildHandler
 	ReconcilingObject client.Object
 	Labels            map[string]string
+	// Config tunes the controller, the default configuration is used if nil
+	Config *operator.Config
 }
 
 // Reconcile reads that state of the cluster for a KogitoBuild object and makes changes based on the state read
@@ -76,11 +80,18 @@ func (r *KogitoBuildReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	buildStatusHandler := kogitobuild.NewStatusHandler(buildContext, buildHandler)
-	defer buildStatusHandler.HandleStatusChange(instance, resultErr)
+	defer func() {
+		buildStatusHandler.HandleStatusChange(instance, resultErr)
+	}()
 
 	if len(instance.GetSpec().GetRuntime()) == 0 {
 		instance.GetSpec().SetRuntime(api.QuarkusRuntimeType)
 	}
+	// the build is reconciled again once the profile of its runtime is registered
+	if !runtimeprofile.GetRegistry().IsRegistered(instance.GetSpec().GetRuntime()) {
+		resultErr = fmt.Errorf("Runtime %s has no runtime profile in the operator ", instance.GetSpec().GetRuntime())
+		return
+	}
 	envs := instance.GetSpec().GetEnv()
 	instance.GetSpec().SetEnv(framework.EnvOverride(envs, corev1.EnvVar{Name: infrastructure.RuntimeTypeKey, Value: string(instance.GetSpec().GetRuntime())}))
 	if len(instance.GetSpec().GetTargetKogitoRuntime()) == 0 {
@@ -109,9 +120,30 @@ func (r *KogitoBuildReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 // SetupWithManager registers the controller with manager
 func (r *KogitoBuildReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	b := ctrl.NewControllerManagedBy(mgr).For(r.ReconcilingObject)
+	b := ctrl.NewControllerManagedBy(mgr).WithOptions(controllerOptions(r.Config, KogitoBuildControllerName)).For(r.ReconcilingObject)
 	if r.IsOpenshift() {
 		b.Owns(&buildv1.BuildConfig{}).Owns(&imagev1.ImageStream{})
 	}
+	// the builds take their images from the profile of their runtime
+	watchRuntimeProfiles(b, r.mapAllKogitoBuilds)
 	return b.Complete(r)
 }
+
+// mapAllKogitoBuilds maps an object to all the KogitoBuild instances
+func (r *KogitoBuildReconciler) mapAllKogitoBuilds(object client.Object) []reconcile.Request {
+	buildContext := operator.Context{
+		Client: r.Client,
+		Log:    logger.GetLogger("kogitobuild_controller"),
+		Scheme: r.Scheme,
+	}
+	builds, err := r.BuildHandler(buildContext).FetchAllKogitoBuildInstances("")
+	if err != nil {
+		buildContext.Log.Error(err, "Failed to list KogitoBuild instances")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, build := range builds.GetItems() {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: build.GetName(), Namespace: build.GetNamespace()}})
+	}
+	return requests
+}
@@ -0,0 +1,90 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/logger"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// CapabilitiesReconciler refreshes the cluster capabilities cached by the client when the CRDs of the integrated operators
+// are installed or removed, eg: Strimzi installed after the Kogito Operator
+type CapabilitiesReconciler struct {
+	*kogitocli.Client
+	// Config tunes the controller, the default configuration is used if nil
+	Config *operator.Config
+}
+
+// Reconcile ...
+func (r *CapabilitiesReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	log := logger.FromContext(ctx)
+	if r.Capabilities == nil {
+		return
+	}
+	log.Info("CustomResourceDefinition changed, refreshing cluster capabilities", "crd", req.Name)
+	r.Capabilities.Invalidate()
+	r.Capabilities.LogCapabilities()
+	return
+}
+
+// SetupWithManager registers the controller with manager
+func (r *CapabilitiesReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	isCapabilityCRD := func(object client.Object) bool {
+		crd, ok := object.(*apiextensionsv1.CustomResourceDefinition)
+		return ok && kogitocli.IsCapabilityGroup(crd.Spec.Group)
+	}
+	pred := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isCapabilityCRD(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			// the API is only served once the CRD is established
+			return isCapabilityCRD(e.ObjectNew) && isEstablished(e.ObjectOld) != isEstablished(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isCapabilityCRD(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("capabilities").
+		WithOptions(controllerOptions(r.Config, CapabilitiesControllerName)).
+		For(&apiextensionsv1.CustomResourceDefinition{}, builder.WithPredicates(pred)).
+		Complete(r)
+}
+
+func isEstablished(object client.Object) bool {
+	crd, ok := object.(*apiextensionsv1.CustomResourceDefinition)
+	if !ok {
+		return false
+	}
+	for _, condition := range crd.Status.Conditions {
+		if condition.Type == apiextensionsv1.Established {
+			return condition.Status == apiextensionsv1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"reflect"
+
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/kogitoservice"
+	"github.com/kiegroup/kogito-operator/core/logger"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var configWatchLog = logger.GetLogger("config_watch")
+
+// watchReferencedConfig reconciles the services of the given kind when a ConfigMap or Secret referenced by their pods changes,
+// so the config hash of the pod template is updated and the pods rolled out
+func watchReferencedConfig(b *builder.Builder, cli *kogitocli.Client, ownerKind string) {
+	contentChangedPred := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(getConfigContent(e.ObjectOld), getConfigContent(e.ObjectNew))
+		},
+		// pods fail to start without their required config, they're restarted by Kubernetes once it's created again
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+	}
+	b.Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(newReferencedConfigMapper(cli, ownerKind)), builder.WithPredicates(contentChangedPred)).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(newReferencedConfigMapper(cli, ownerKind)), builder.WithPredicates(contentChangedPred))
+}
+
+func getConfigContent(object client.Object) []interface{} {
+	switch config := object.(type) {
+	case *corev1.ConfigMap:
+		return []interface{}{config.Data, config.BinaryData}
+	case *corev1.Secret:
+		return []interface{}{config.Data}
+	}
+	return nil
+}
+
+// newReferencedConfigMapper maps a ConfigMap or Secret to the services of the given kind controlling a Deployment referencing it
+func newReferencedConfigMapper(cli *kogitocli.Client, ownerKind string) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		deployments := &appsv1.DeploymentList{}
+		if err := kubernetes.ResourceC(cli).ListWithNamespace(object.GetNamespace(), deployments); err != nil {
+			configWatchLog.Error(err, "Failed to list deployments referencing config", "name", object.GetName(), "namespace", object.GetNamespace())
+			return nil
+		}
+		var requests []reconcile.Request
+		for i := range deployments.Items {
+			deployment := &deployments.Items[i]
+			owner := metav1.GetControllerOf(deployment)
+			if owner == nil || owner.Kind != ownerKind || !isConfigReferenced(object, &deployment.Spec.Template.Spec) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: owner.Name, Namespace: deployment.Namespace}})
+		}
+		return requests
+	}
+}
+
+func isConfigReferenced(object client.Object, podSpec *corev1.PodSpec) bool {
+	var names []string
+	switch object.(type) {
+	case *corev1.ConfigMap:
+		names = kogitoservice.GetReferencedConfigMaps(podSpec)
+	case *corev1.Secret:
+		names = kogitoservice.GetReferencedSecrets(podSpec)
+	}
+	for _, name := range names {
+		if name == object.GetName() {
+			return true
+		}
+	}
+	return false
+}
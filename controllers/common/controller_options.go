@@ -0,0 +1,61 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+const (
+	// KogitoRuntimeControllerName ...
+	KogitoRuntimeControllerName = "KogitoRuntime"
+	// KogitoSupportingServiceControllerName ...
+	KogitoSupportingServiceControllerName = "KogitoSupportingService"
+	// KogitoBuildControllerName ...
+	KogitoBuildControllerName = "KogitoBuild"
+	// KogitoInfraControllerName ...
+	KogitoInfraControllerName = "KogitoInfra"
+	// KogitoRuntimeDeploymentControllerName ...
+	KogitoRuntimeDeploymentControllerName = "KogitoRuntimeDeployment"
+	// CapabilitiesControllerName ...
+	CapabilitiesControllerName = "Capabilities"
+	// KogitoEventTopologyControllerName ...
+	KogitoEventTopologyControllerName = "KogitoEventTopology"
+	// RuntimeProfilesControllerName ...
+	RuntimeProfilesControllerName = "RuntimeProfiles"
+)
+
+// ControllerNames are the names of the controllers in the operator configuration
+var ControllerNames = []string{
+	KogitoRuntimeControllerName,
+	KogitoSupportingServiceControllerName,
+	KogitoBuildControllerName,
+	KogitoInfraControllerName,
+	KogitoRuntimeDeploymentControllerName,
+	CapabilitiesControllerName,
+	KogitoEventTopologyControllerName,
+	RuntimeProfilesControllerName,
+}
+
+// controllerOptions returns the options of the given controller from the given operator configuration, the default one if nil
+func controllerOptions(config *operator.Config, name string) controller.Options {
+	if config == nil {
+		config = operator.DefaultConfig()
+	}
+	return controller.Options{
+		MaxConcurrentReconciles: config.GetMaxConcurrentReconciles(name),
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/eventtopology"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/logger"
+	"github.com/kiegroup/kogito-operator/core/manager"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// KogitoEventTopologyReconciler reconciles a KogitoEventTopology object
+type KogitoEventTopologyReconciler struct {
+	*kogitocli.Client
+	Scheme                   *runtime.Scheme
+	Version                  string
+	EventTopologyHandler     func(context operator.Context) manager.KogitoEventTopologyHandler
+	RuntimeHandler           func(context operator.Context) manager.KogitoRuntimeHandler
+	SupportingServiceHandler func(context operator.Context) manager.KogitoSupportingServiceHandler
+	InfraHandler             func(context operator.Context) manager.KogitoInfraHandler
+	ReconcilingObject        client.Object
+	// WatchedObjects are the Kogito services and infra whose changes update the topology of their namespace
+	WatchedObjects []client.Object
+	// Config tunes the controller, the default configuration is used if nil
+	Config *operator.Config
+	// Backoff delays the requeues of the objects failing repeatedly, shared by the reconciliations of the controller
+	Backoff *operator.RequeueBackoff
+}
+
+// Reconcile builds the topology of the CloudEvents exchanged by the Kogito services of the namespace
+// and sets it in the KogitoEventTopology status
+func (r *KogitoEventTopologyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logger.FromContext(ctx)
+	log.Info("Reconciling KogitoEventTopology")
+
+	// create kogitoContext
+	kogitoContext := operator.Context{
+		Client:  r.Client,
+		Log:     log,
+		Scheme:  r.Scheme,
+		Version: r.Version,
+		Backoff: r.Backoff,
+	}
+	// backs off the requeues while the object keeps failing with the same reason
+	errorHandler := infrastructure.NewReconciliationErrorHandlerFor(kogitoContext, KogitoEventTopologyControllerName, req.NamespacedName)
+
+	instance, err := r.EventTopologyHandler(kogitoContext).FetchKogitoEventTopologyInstance(req.NamespacedName)
+	if err != nil {
+		return errorHandler.GetReconcileResultFor(err)
+	}
+	if instance == nil {
+		log.Debug("KogitoEventTopology instance not found")
+		return errorHandler.GetReconcileResultFor(nil)
+	}
+
+	topologyBuilder := eventtopology.NewBuilder(kogitoContext, r.RuntimeHandler(kogitoContext), r.SupportingServiceHandler(kogitoContext), r.InfraHandler(kogitoContext))
+	graph, err := topologyBuilder.Build(req.Namespace)
+	if err != nil {
+		return errorHandler.GetReconcileResultFor(err)
+	}
+	deployed := instance.DeepCopyObject()
+	graph.SetStatus(instance.GetStatus())
+	if !reflect.DeepEqual(deployed, instance) {
+		log.Debug("Updating KogitoEventTopology status", "orphanConsumers", graph.GetOrphanConsumers(), "unconsumedProducers", graph.GetUnconsumedProducers())
+		if err = kubernetes.ResourceC(r.Client).UpdateStatus(instance); err != nil {
+			return errorHandler.GetReconcileResultFor(err)
+		}
+	}
+	return errorHandler.GetReconcileResultFor(nil)
+}
+
+// SetupWithManager registers the controller with manager
+func (r *KogitoEventTopologyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	topologyChangedPred := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(getTopologyContent(e.ObjectOld), getTopologyContent(e.ObjectNew))
+		},
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controllerOptions(r.Config, KogitoEventTopologyControllerName)).
+		For(r.ReconcilingObject, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	for _, watchedObject := range r.WatchedObjects {
+		b = b.Watches(&source.Kind{Type: watchedObject}, handler.EnqueueRequestsFromMapFunc(r.mapToEventTopologies), builder.WithPredicates(topologyChangedPred))
+	}
+	return b.Complete(r)
+}
+
+// getTopologyContent returns the fields of the Kogito services and infra the topology is built from
+func getTopologyContent(object client.Object) []interface{} {
+	switch instance := object.(type) {
+	case api.KogitoService:
+		return []interface{}{instance.GetSpec().GetInfra(), instance.GetStatus().GetCloudEvents()}
+	case api.KogitoInfraInterface:
+		return []interface{}{instance.GetSpec().GetResource()}
+	}
+	return nil
+}
+
+// mapToEventTopologies maps a Kogito service or infra to the KogitoEventTopology instances of its namespace
+func (r *KogitoEventTopologyReconciler) mapToEventTopologies(object client.Object) []reconcile.Request {
+	kogitoContext := operator.Context{
+		Client: r.Client,
+		Log:    logger.GetLogger("eventtopology_controller"),
+		Scheme: r.Scheme,
+	}
+	eventTopologies, err := r.EventTopologyHandler(kogitoContext).FetchAllKogitoEventTopologyInstances(object.GetNamespace())
+	if err != nil {
+		kogitoContext.Log.Error(err, "Failed to list event topologies", "namespace", object.GetNamespace())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, eventTopology := range eventTopologies.GetItems() {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: eventTopology.GetName(), Namespace: eventTopology.GetNamespace()}})
+	}
+	return requests
+}
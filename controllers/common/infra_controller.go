@@ -30,6 +30,7 @@ import (
 
 	kogitocli "github.com/kiegroup/kogito-operator/core/client"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -40,21 +41,26 @@ type KogitoInfraReconciler struct {
 	Version           string
 	InfraHandler      func(context operator.Context) manager.KogitoInfraHandler
 	ReconcilingObject client.Object
+	// Config tunes the controller, the default configuration is used if nil
+	Config *operator.Config
+	// Backoff delays the requeues of the objects failing repeatedly, shared by the reconciliations of the controller
+	Backoff *operator.RequeueBackoff
 }
 
 //+kubebuilder:rbac:groups=app.kiegroup.org,resources=kogitoinfras,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=app.kiegroup.org,resources=kogitoinfras/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=app.kiegroup.org,resources=kogitoinfras/finalizers,verbs=get;update;patch
-//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;create;delete;update
+//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;create;delete;update;patch
 //+kubebuilder:rbac:groups=infinispan.org,resources=infinispans,verbs=get;create;list;delete;watch
 //+kubebuilder:rbac:groups=kafka.strimzi.io,resources=kafkas;kafkatopics,verbs=get;create;list;delete;watch
 //+kubebuilder:rbac:groups=keycloak.org,resources=keycloaks,verbs=get;create;list;delete;watch
 //+kubebuilder:rbac:groups=apps,resources=deployments/finalizers,verbs=update
 //+kubebuilder:rbac:groups=eventing.knative.dev,resources=brokers,verbs=get;list;watch
-//+kubebuilder:rbac:groups=eventing.knative.dev,resources=triggers,verbs=get;list;watch;create;delete;update
-//+kubebuilder:rbac:groups=sources.knative.dev,resources=sinkbindings,verbs=get;list;watch;create;delete;update
-//+kubebuilder:rbac:groups=integreatly.org,resources=grafanadashboards,verbs=get;create;list;watch;create;delete;update
+//+kubebuilder:rbac:groups=eventing.knative.dev,resources=triggers,verbs=get;list;watch;create;delete;update;patch
+//+kubebuilder:rbac:groups=sources.knative.dev,resources=sinkbindings,verbs=get;list;watch;create;delete;update;patch
+//+kubebuilder:rbac:groups=integreatly.org,resources=grafanadashboards,verbs=get;create;list;watch;create;delete;update;patch
 //+kubebuilder:rbac:groups=mongodbcommunity.mongodb.com,resources=mongodbcommunity,verbs=get;create;list;watch;delete
+//+kubebuilder:rbac:groups=external-secrets.io,resources=externalsecrets,verbs=get;create;list;watch;delete;update;patch
 
 // Reconcile reads that state of the cluster for a KogitoInfra object and makes changes based on the state read
 // and what is in the KogitoInfra.Spec
@@ -68,6 +74,7 @@ func (r *KogitoInfraReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		Log:     log,
 		Scheme:  r.Scheme,
 		Version: r.Version,
+		Backoff: r.Backoff,
 	}
 
 	// Fetch the KogitoInfra instance
@@ -76,9 +83,10 @@ func (r *KogitoInfraReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	reconcilerHandler := kogitoinfra.NewReconcilerHandler(kogitoContext, req.NamespacedName)
 	if instance == nil {
 		log.Debug("KogitoInfra instance not found")
-		return reconcile.Result{}, nil
+		return reconcilerHandler.GetReconcileResultFor(nil, false)
 	}
 	var resultErr error
 	statusHandler := kogitoinfra.NewStatusHandler(kogitoContext, infraHandler)
@@ -90,7 +98,6 @@ func (r *KogitoInfraReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	instance.GetStatus().SetSecretEnvFromReferences(nil)
 	instance.GetStatus().SetSecretVolumeReferences(nil)
 
-	reconcilerHandler := kogitoinfra.NewReconcilerHandler(kogitoContext)
 	if !instance.GetSpec().IsResourceEmpty() {
 		var reconciler kogitoinfra.Reconciler
 		reconciler, resultErr = reconcilerHandler.GetInfraReconciler(instance)
@@ -124,7 +131,13 @@ func (r *KogitoInfraReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return reconcilerHandler.GetReconcileResultFor(resultErr, false)
 	}
 
-	return reconcile.Result{}, nil
+	vaultSecretReconciler := reconcilerHandler.GetVaultSecretReconciler(instance)
+	if resultErr = vaultSecretReconciler.Reconcile(); resultErr != nil {
+		return reconcilerHandler.GetReconcileResultFor(resultErr, false)
+	}
+
+	// the services are switched to the rotated credentials once the grace period elapsed
+	return reconcilerHandler.GetReconcileResultFor(nil, kogitoinfra.IsCredentialRotationPending(instance))
 }
 
 // SetupWithManager registers the controller with manager
@@ -135,12 +148,42 @@ func (r *KogitoInfraReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			return reflect.DeepEqual(e.ObjectNew.GetOwnerReferences(), e.ObjectOld.GetOwnerReferences())
 		},
 	}
-	b := ctrl.NewControllerManagedBy(mgr).For(r.ReconcilingObject, builder.WithPredicates(pred))
+	b := ctrl.NewControllerManagedBy(mgr).WithOptions(controllerOptions(r.Config, KogitoInfraControllerName)).For(r.ReconcilingObject, builder.WithPredicates(pred))
 	b = kogitoinfra.AppendInfinispanWatchedObjects(b)
 	b = kogitoinfra.AppendKafkaWatchedObjects(b)
 	b = kogitoinfra.AppendKeycloakWatchedObjects(b)
 	b = kogitoinfra.AppendMongoDBWatchedObjects(b)
 	b = kogitoinfra.AppendConfigMapWatchedObjects(b)
 	b = kogitoinfra.AppendSecretWatchedObjects(b)
+	kogitoContext := operator.Context{
+		Client: r.Client,
+		Log:    logger.GetLogger("kogitoinfra_controller"),
+		Scheme: r.Scheme,
+	}
+	b = kogitoinfra.AppendInfraResourceWatchedObjects(b, kogitoContext, r.InfraHandler(kogitoContext))
+	b = kogitoinfra.AppendCredentialSecretWatchedObjects(b, kogitoContext, r.InfraHandler(kogitoContext))
+	b = kogitoinfra.AppendKafkaSecretWatchedObjects(b, kogitoContext, r.InfraHandler(kogitoContext))
+	b = kogitoinfra.AppendExternalSecretWatchedObjects(b, kogitoContext, r.ReconcilingObject)
+	// the infras write the properties of every runtime profile
+	watchRuntimeProfiles(b, r.mapAllKogitoInfras)
 	return b.Complete(r)
 }
+
+// mapAllKogitoInfras maps an object to all the KogitoInfra instances
+func (r *KogitoInfraReconciler) mapAllKogitoInfras(object client.Object) []reconcile.Request {
+	kogitoContext := operator.Context{
+		Client: r.Client,
+		Log:    logger.GetLogger("kogitoinfra_controller"),
+		Scheme: r.Scheme,
+	}
+	infras, err := r.InfraHandler(kogitoContext).FetchAllKogitoInfraInstances("")
+	if err != nil {
+		kogitoContext.Log.Error(err, "Failed to list KogitoInfra instances")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, infra := range infras.GetItems() {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: infra.GetName(), Namespace: infra.GetNamespace()}})
+	}
+	return requests
+}
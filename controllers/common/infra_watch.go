@@ -0,0 +1,86 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"reflect"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/logger"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var infraWatchLog = logger.GetLogger("infra_watch")
+
+// watchReferencedInfra reconciles the services listing a KogitoInfra in their spec when its health changes,
+// so their InfraDegraded condition follows the resource referenced by the infra
+func watchReferencedInfra(b *builder.Builder, infraObject client.Object, listServices func(namespace string) ([]api.KogitoService, error)) {
+	healthChangedPred := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(getInfraHealthContent(e.ObjectOld), getInfraHealthContent(e.ObjectNew))
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+	}
+	b.Watches(&source.Kind{Type: infraObject}, handler.EnqueueRequestsFromMapFunc(newReferencedInfraMapper(listServices)), builder.WithPredicates(healthChangedPred))
+}
+
+// getInfraHealthContent returns the status and message of the Configured and ResourceHealthy conditions of the infra
+func getInfraHealthContent(object client.Object) []interface{} {
+	infra, ok := object.(api.KogitoInfraInterface)
+	if !ok || infra.GetStatus().GetConditions() == nil {
+		return nil
+	}
+	var content []interface{}
+	for _, conditionType := range []api.KogitoInfraConditionType{api.KogitoInfraConfigured, api.KogitoInfraResourceHealthy} {
+		if condition := meta.FindStatusCondition(*infra.GetStatus().GetConditions(), string(conditionType)); condition != nil {
+			content = append(content, condition.Type, condition.Status, condition.Message)
+		}
+	}
+	return content
+}
+
+// newReferencedInfraMapper maps a KogitoInfra to the services of its namespace listing it in their spec
+func newReferencedInfraMapper(listServices func(namespace string) ([]api.KogitoService, error)) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		services, err := listServices(object.GetNamespace())
+		if err != nil {
+			infraWatchLog.Error(err, "Failed to list services referencing infra", "name", object.GetName(), "namespace", object.GetNamespace())
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, service := range services {
+			for _, infraName := range service.GetSpec().GetInfra() {
+				if infraName == object.GetName() {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: service.GetName(), Namespace: service.GetNamespace()}})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
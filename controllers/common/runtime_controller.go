@@ -17,8 +17,12 @@ package common
 import (
 	"context"
 
+	"github.com/kiegroup/kogito-operator/apis"
 	kogitocli "github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/imagedigest"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	externalsecrets "github.com/kiegroup/kogito-operator/core/infrastructure/externalsecrets/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/introspection"
 	"github.com/kiegroup/kogito-operator/core/kogitoservice"
 	"github.com/kiegroup/kogito-operator/core/logger"
 	"github.com/kiegroup/kogito-operator/core/manager"
@@ -29,11 +33,17 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // KogitoRuntimeReconciler reconciles a KogitoRuntime object
@@ -45,20 +55,34 @@ type KogitoRuntimeReconciler struct {
 	SupportServiceHandler func(context operator.Context) manager.KogitoSupportingServiceHandler
 	InfraHandler          func(context operator.Context) manager.KogitoInfraHandler
 	ReconcilingObject     client.Object
-	Labels                map[string]string
-	DeploymentIdentifier  string
+	// InfraObject is the KogitoInfra kind referenced by the runtimes, they're reconciled when the health of their infra changes
+	InfraObject          client.Object
+	Labels               map[string]string
+	DeploymentIdentifier string
+	// Introspector polls the runtimes endpoints, created from the operator environment when the controller is set up if nil
+	Introspector *introspection.Introspector
+	// DigestResolver polls the registries for the image tags of the runtimes with the Periodic image update policy, created when the controller is set up if nil
+	DigestResolver *imagedigest.Resolver
+	// Config tunes the controller, the default configuration is used if nil
+	Config *operator.Config
+	// Backoff delays the requeues of the objects failing repeatedly, shared by the reconciliations of the controller
+	Backoff *operator.RequeueBackoff
 }
 
 //+kubebuilder:rbac:groups=app.kiegroup.org,resources=kogitoruntimes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=app.kiegroup.org,resources=kogitoruntimes/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=app.kiegroup.org,resources=kogitoruntimes/finalizers,verbs=get;update;patch
-//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;create;list;delete
+//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;create;list;delete;update;patch
 //+kubebuilder:rbac:groups=apps,resources=deployments/finalizers,verbs=update
-//+kubebuilder:rbac:groups=integreatly.org,resources=grafanadashboards,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams;imagestreamtags,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings;roles,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;create;list;watch;delete;update
+//+kubebuilder:rbac:groups=integreatly.org,resources=grafanadashboards,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams;imagestreamtags,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings;roles,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=infinispan.org,resources=caches,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=external-secrets.io,resources=externalsecrets,verbs=get;create;list;watch;delete;update;patch
 //+kubebuilder:rbac:groups=core,resources=configmaps;events;pods;secrets;serviceaccounts;services,verbs=create;delete;get;list;patch;update;watch
 
 // Reconcile reads that state of the cluster for a KogitoRuntime object and makes changes based on the state read
@@ -75,7 +99,10 @@ func (r *KogitoRuntimeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		Version:              r.Version,
 		Labels:               r.Labels,
 		DeploymentIdentifier: r.DeploymentIdentifier,
+		Backoff:              r.Backoff,
 	}
+	// backs off the requeues while the object keeps failing with the same reason
+	errorHandler := infrastructure.NewReconciliationErrorHandlerFor(kogitoContext, KogitoRuntimeControllerName, req.NamespacedName)
 
 	// fetch the requested instance
 	runtimeHandler := r.RuntimeHandler(kogitoContext)
@@ -85,7 +112,20 @@ func (r *KogitoRuntimeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	}
 	if instance == nil {
 		log.Debug("KogitoRuntime instance not found")
-		return
+		if r.Introspector != nil {
+			r.Introspector.Forget(req.NamespacedName)
+		}
+		if r.DigestResolver != nil {
+			r.DigestResolver.Forget(req.NamespacedName)
+		}
+		return errorHandler.GetReconcileResultFor(nil)
+	}
+	if !instance.GetDeletionTimestamp().IsZero() {
+		// the owned resources are garbage collected, the Kafka topics and Infinispan caches are released
+		if err = kogitoservice.ReleaseKafkaTopics(kogitoContext, instance, r.InfraHandler(kogitoContext)); err != nil {
+			return errorHandler.GetReconcileResultFor(err)
+		}
+		return errorHandler.GetReconcileResultFor(shared.ReleaseInfinispanCaches(kogitoContext, instance, r.InfraHandler(kogitoContext)))
 	}
 
 	rbacHandler := infrastructure.NewRBACHandler(kogitoContext)
@@ -101,27 +141,42 @@ func (r *KogitoRuntimeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		SingleReplica:      false,
 		OnDeploymentCreate: deploymentHandler.OnDeploymentCreate,
 		CustomService:      true,
+		Introspector:       r.Introspector,
+		DigestResolver:     r.DigestResolver,
 	}
 	infraHandler := r.InfraHandler(kogitoContext)
 	err = kogitoservice.NewServiceDeployer(kogitoContext, definition, instance, infraHandler).Deploy()
 	if err != nil {
-		return infrastructure.NewReconciliationErrorHandler(kogitoContext).GetReconcileResultFor(err)
+		return errorHandler.GetReconcileResultFor(err)
 	}
 
 	protoBufConfigMapReconciler := shared.NewProtoBufConfigMapReconciler(kogitoContext, instance)
 	err = protoBufConfigMapReconciler.Reconcile()
 	if err != nil {
 		log.Error(err, "Fail to create Proto Buf config map of Kogito runtime")
-		return infrastructure.NewReconciliationErrorHandler(kogitoContext).GetReconcileResultFor(err)
+		return errorHandler.GetReconcileResultFor(err)
+	}
+
+	// the domain caches are created before Data Index receives the protobuf files
+	infinispanCacheReconciler := shared.NewInfinispanCacheReconciler(kogitoContext, instance, infraHandler)
+	err = infinispanCacheReconciler.Reconcile()
+	if err != nil {
+		log.Error(err, "Fail to create Infinispan caches of Kogito runtime")
+		return errorHandler.GetReconcileResultFor(err)
 	}
 
 	protoBufHandler := shared.NewProtoBufHandler(kogitoContext, supportingServiceHandler)
 	err = protoBufHandler.MountProtoBufConfigMapOnDataIndex(instance)
 	if err != nil {
 		log.Error(err, "Fail to mount Proto Buf config map of Kogito runtime on DataIndex")
-		return infrastructure.NewReconciliationErrorHandler(kogitoContext).GetReconcileResultFor(err)
+		return errorHandler.GetReconcileResultFor(err)
 	}
 
+	result, err = errorHandler.GetReconcileResultFor(nil)
+	// the rollout of a new revision moves forward while the candidate is being deployed, analyzed or promoted
+	if requeueAfter := kogitoservice.GetRolloutRequeueAfter(instance); requeueAfter > 0 {
+		result.RequeueAfter = requeueAfter
+	}
 	log.Debug("Finish reconciliation", "requeue", result.Requeue, "requeueAfter", result.RequeueAfter)
 	return
 }
@@ -134,16 +189,86 @@ func (r *KogitoRuntimeReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			return false
 		},
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			return e.ObjectNew.GetDeletionTimestamp().IsZero()
+			// the deleted services declaring Kafka topics or using Infinispan caches are reconciled to release them
+			return e.ObjectNew.GetDeletionTimestamp().IsZero() || controllerutil.ContainsFinalizer(e.ObjectNew, kogitoservice.KafkaTopicsFinalizer) ||
+				controllerutil.ContainsFinalizer(e.ObjectNew, shared.InfinispanCachesFinalizer)
 		},
 	}
+	if r.Introspector == nil {
+		tlsConfig, err := introspection.NewTLSConfigFromEnv()
+		if err != nil {
+			return err
+		}
+		r.Introspector = introspection.NewIntrospector(tlsConfig)
+	}
+	if err := mgr.Add(r.Introspector); err != nil {
+		return err
+	}
+	if r.DigestResolver == nil {
+		r.DigestResolver = imagedigest.NewResolver()
+	}
+	if err := mgr.Add(r.DigestResolver); err != nil {
+		return err
+	}
+
 	b := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controllerOptions(r.Config, KogitoRuntimeControllerName)).
 		For(r.ReconcilingObject, builder.WithPredicates(pred)).
-		Owns(&corev1.Service{}).Owns(&appsv1.Deployment{}).Owns(&corev1.ConfigMap{})
+		Owns(&corev1.Service{}).Owns(&appsv1.Deployment{}).Owns(&corev1.ConfigMap{}).
+		// reconcile again once the topics or dashboards exposed by the runtime change
+		Watches(&source.Channel{Source: r.Introspector.Events()}, &handler.EnqueueRequestForObject{}).
+		// reconcile again once the image tag points to a new digest
+		Watches(&source.Channel{Source: r.DigestResolver.Events()}, &handler.EnqueueRequestForObject{})
 
 	if r.IsOpenshift() {
 		b.Owns(&routev1.Route{}).Owns(&imagev1.ImageStream{})
 	}
+	// the services are deployed once the ExternalSecrets of their Vault secrets are synced, shared by the services referencing them
+	if r.HasCapability(kogitocli.ExternalSecretsCapability) {
+		b.Watches(&source.Kind{Type: &externalsecrets.ExternalSecret{}}, &handler.EnqueueRequestForOwner{OwnerType: r.ReconcilingObject, IsController: false})
+	}
+
+	gvk, err := apiutil.GVKForObject(r.ReconcilingObject, mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	watchReferencedConfig(b, r.Client, gvk.Kind)
+	if r.InfraObject != nil {
+		watchReferencedInfra(b, r.InfraObject, r.listKogitoRuntimes)
+	}
+	watchRuntimeProfiles(b, r.mapAllKogitoRuntimes)
 
 	return b.Complete(r)
 }
+
+// listKogitoRuntimes lists the KogitoRuntime instances of the given namespace
+func (r *KogitoRuntimeReconciler) listKogitoRuntimes(namespace string) ([]api.KogitoService, error) {
+	kogitoContext := operator.Context{
+		Client: r.Client,
+		Log:    logger.GetLogger("kogitoruntime_controller"),
+		Scheme: r.Scheme,
+	}
+	runtimes, err := r.RuntimeHandler(kogitoContext).FetchAllKogitoRuntimeInstances(namespace)
+	if err != nil {
+		return nil, err
+	}
+	var services []api.KogitoService
+	for _, kogitoRuntime := range runtimes.GetItems() {
+		services = append(services, kogitoRuntime)
+	}
+	return services, nil
+}
+
+// mapAllKogitoRuntimes maps an object to all the KogitoRuntime instances
+func (r *KogitoRuntimeReconciler) mapAllKogitoRuntimes(object client.Object) []reconcile.Request {
+	runtimes, err := r.listKogitoRuntimes("")
+	if err != nil {
+		logger.GetLogger("kogitoruntime_controller").Error(err, "Failed to list KogitoRuntime instances")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, kogitoRuntime := range runtimes {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: kogitoRuntime.GetName(), Namespace: kogitoRuntime.GetNamespace()}})
+	}
+	return requests
+}
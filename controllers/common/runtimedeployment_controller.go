@@ -39,6 +39,10 @@ type RuntimeDeploymentReconciler struct {
 	RuntimeHandler        func(context operator.Context) manager.KogitoRuntimeHandler
 	SupportServiceHandler func(context operator.Context) manager.KogitoSupportingServiceHandler
 	Labels                map[string]string
+	// Config tunes the controller, the default configuration is used if nil
+	Config *operator.Config
+	// Backoff delays the requeues of the objects failing repeatedly, shared by the reconciliations of the controller
+	Backoff *operator.RequeueBackoff
 }
 
 // Reconcile ...
@@ -53,7 +57,10 @@ func (r *RuntimeDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		Scheme:  r.Scheme,
 		Version: r.Version,
 		Labels:  r.Labels,
+		Backoff: r.Backoff,
 	}
+	// backs off the requeues while the object keeps failing with the same reason
+	errorHandler := infrastructure.NewReconciliationErrorHandlerFor(kogitoContext, KogitoRuntimeDeploymentControllerName, req.NamespacedName)
 
 	deploymentHandler := infrastructure.NewDeploymentHandler(kogitoContext)
 	deployment, err := deploymentHandler.FetchDeployment(req.NamespacedName)
@@ -62,7 +69,7 @@ func (r *RuntimeDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 	if deployment == nil {
 		log.Debug("KogitoDeployment instance not found")
-		return
+		return errorHandler.GetReconcileResultFor(nil)
 	}
 
 	runtimeHandler := r.RuntimeHandler(kogitoContext)
@@ -70,10 +77,10 @@ func (r *RuntimeDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	depProcessor := dep.NewDeploymentProcessor(kogitoContext, deployment, runtimeHandler, supportingServiceHandler)
 	err = depProcessor.Process()
 	if err != nil {
-		return infrastructure.NewReconciliationErrorHandler(kogitoContext).GetReconcileResultFor(err)
+		return errorHandler.GetReconcileResultFor(err)
 	}
 	log.Debug("Finish reconciliation", "requeue", result.Requeue, "requeueAfter", result.RequeueAfter)
-	return
+	return errorHandler.GetReconcileResultFor(nil)
 }
 
 // SetupWithManager registers the controller with manager
@@ -93,6 +100,7 @@ func (r *RuntimeDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}
 
 	b := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controllerOptions(r.Config, KogitoRuntimeDeploymentControllerName)).
 		For(&appsv1.Deployment{}, builder.WithPredicates(pred))
 	return b.Complete(r)
 }
@@ -0,0 +1,152 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kiegroup/kogito-operator/core/logger"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// runtimeProfilesSubscribers are the channels of the controllers reconciling their objects again when the runtime profiles are reloaded
+var runtimeProfilesSubscribers = &profilesSubscribers{}
+
+type profilesSubscribers struct {
+	mutex    sync.Mutex
+	channels []chan event.GenericEvent
+}
+
+func (p *profilesSubscribers) subscribe() <-chan event.GenericEvent {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	// one pending event is enough, the subscribers reconcile all their objects with the profiles loaded last
+	channel := make(chan event.GenericEvent, 1)
+	p.channels = append(p.channels, channel)
+	return channel
+}
+
+func (p *profilesSubscribers) notify(object client.Object) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, channel := range p.channels {
+		select {
+		case channel <- event.GenericEvent{Object: object}:
+		default:
+		}
+	}
+}
+
+// watchRuntimeProfiles reconciles the objects returned by the given function once the runtime profiles are reloaded
+func watchRuntimeProfiles(b *builder.Builder, mapper handler.MapFunc) {
+	b.Watches(&source.Channel{Source: runtimeProfilesSubscribers.subscribe()}, handler.EnqueueRequestsFromMapFunc(mapper))
+}
+
+// ParseRuntimeProfilesConfigMap parses the namespace/name of the ConfigMap holding the runtime profiles
+func ParseRuntimeProfilesConfigMap(value string) (types.NamespacedName, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return types.NamespacedName{}, fmt.Errorf("invalid runtime profiles ConfigMap %q, expected namespace/name", value)
+	}
+	return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, nil
+}
+
+// RuntimeProfilesReconciler reloads the runtime profiles when their ConfigMap changes, the runtimes, builds and infras are then
+// reconciled again with the new profiles. The ConfigMap is watched on its own, whatever the namespaces watched by the operator.
+type RuntimeProfilesReconciler struct {
+	// ConfigMap holds the profiles under the runtimeprofile.ProfilesKey, the built-in profiles are used while it doesn't exist
+	ConfigMap types.NamespacedName
+	// Config tunes the controller, the default configuration is used if nil
+	Config *operator.Config
+
+	reader client.Reader
+}
+
+// Reconcile ...
+func (r *RuntimeProfilesReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	log := logger.FromContext(ctx)
+	configMap := &corev1.ConfigMap{}
+	if err = r.reader.Get(ctx, r.ConfigMap, configMap); err != nil && !errors.IsNotFound(err) {
+		return
+	}
+	registry, err := runtimeprofile.LoadRegistry(configMap.Data[runtimeprofile.ProfilesKey])
+	if err != nil {
+		// the profiles loaded before are kept until the ConfigMap is fixed
+		log.Error(err, "Invalid runtime profiles, keeping the current ones", "configMap", r.ConfigMap)
+		return result, nil
+	}
+	runtimeprofile.SetRegistry(registry)
+	log.Info("Runtime profiles reloaded", "profiles", registry.GetProfileNames())
+	runtimeProfilesSubscribers.notify(configMap)
+	return
+}
+
+// LoadRuntimeProfiles reads the ConfigMap holding the runtime profiles straight from the API server, so the controllers start with them
+func (r *RuntimeProfilesReconciler) LoadRuntimeProfiles(reader client.Reader) (*runtimeprofile.Registry, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := reader.Get(context.TODO(), r.ConfigMap, configMap); err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	registry, err := runtimeprofile.LoadRegistry(configMap.Data[runtimeprofile.ProfilesKey])
+	if err != nil {
+		return nil, fmt.Errorf("ConfigMap %s: %v", r.ConfigMap, err)
+	}
+	runtimeprofile.SetRegistry(registry)
+	return registry, nil
+}
+
+// SetupWithManager registers the controller with manager
+func (r *RuntimeProfilesReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// the cache only holds the profiles ConfigMap, which may be out of the watched namespaces
+	profilesCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme:    mgr.GetScheme(),
+		Mapper:    mgr.GetRESTMapper(),
+		Namespace: r.ConfigMap.Namespace,
+		SelectorsByObject: cache.SelectorsByObject{
+			&corev1.ConfigMap{}: {Field: fields.OneTermEqualSelector("metadata.name", r.ConfigMap.Name)},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err = mgr.Add(profilesCache); err != nil {
+		return err
+	}
+	r.reader = profilesCache
+
+	options := controllerOptions(r.Config, RuntimeProfilesControllerName)
+	options.Reconciler = r
+	c, err := controller.New("runtimeprofiles", mgr, options)
+	if err != nil {
+		return err
+	}
+	return c.Watch(source.NewKindWithCache(&corev1.ConfigMap{}, profilesCache), &handler.EnqueueRequestForObject{})
+}
@@ -16,24 +16,37 @@ package common
 
 import (
 	"context"
+	"reflect"
 
+	"github.com/kiegroup/kogito-operator/apis"
 	kogitocli "github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/imagedigest"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	externalsecrets "github.com/kiegroup/kogito-operator/core/infrastructure/externalsecrets/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/introspection"
+	"github.com/kiegroup/kogito-operator/core/kogitoservice"
 	"github.com/kiegroup/kogito-operator/core/kogitosupportingservice"
 	"github.com/kiegroup/kogito-operator/core/logger"
 	"github.com/kiegroup/kogito-operator/core/manager"
 	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/shared"
 	app2 "github.com/kiegroup/kogito-operator/version/app"
 	imgv1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // KogitoSupportingServiceReconciler reconciles a KogitoSupportingService object
@@ -47,20 +60,37 @@ type KogitoSupportingServiceReconciler struct {
 	SupportingServiceHandler func(context operator.Context) manager.KogitoSupportingServiceHandler
 	InfraHandler             func(context operator.Context) manager.KogitoInfraHandler
 	ReconcilingObject        client.Object
-	Labels                   map[string]string
-	DeploymentIdentifier     string
+	// InfraObject is the KogitoInfra kind referenced by the services, they're reconciled when the health of their infra changes
+	InfraObject          client.Object
+	Labels               map[string]string
+	DeploymentIdentifier string
+	// Introspector polls the supporting services endpoints, created from the operator environment when the controller is set up if nil
+	Introspector *introspection.Introspector
+	// DigestResolver polls the registries for the image tags of the supporting services with the Periodic image update policy, created when the controller is set up if nil
+	DigestResolver *imagedigest.Resolver
+	// Config tunes the controller, the default configuration is used if nil
+	Config *operator.Config
+	// Backoff delays the requeues of the objects failing repeatedly, shared by the reconciliations of the controller
+	Backoff *operator.RequeueBackoff
 }
 
 //+kubebuilder:rbac:groups=app.kiegroup.org,resources=kogitosupportingservices,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=app.kiegroup.org,resources=kogitosupportingservices/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=app.kiegroup.org,resources=kogitosupportingservices/finalizers,verbs=get;update;patch
-//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;create;list;delete
+//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;create;list;delete;update;patch
 //+kubebuilder:rbac:groups=apps,resources=deployments/finalizers,verbs=update
-//+kubebuilder:rbac:groups=integreatly.org,resources=grafanadashboards,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams;imagestreamtags,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=core,resources=configmaps;events;pods;secrets;services,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;create;list;watch;delete
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;create;list;watch;update;patch
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings;roles,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=kafka.strimzi.io,resources=kafkatopics,verbs=get;create;list;watch;update;patch
+//+kubebuilder:rbac:groups=infinispan.org,resources=caches,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=integreatly.org,resources=grafanadashboards,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams;imagestreamtags,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=external-secrets.io,resources=externalsecrets,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=core,resources=configmaps;events;pods;secrets;serviceaccounts;services,verbs=create;delete;get;list;patch;update;watch
 
 // Reconcile reads that state of the cluster for a KogitoSupportingService object and makes changes based on the state read
 // and what is in the KogitoSupportingService.Spec
@@ -76,7 +106,10 @@ func (r *KogitoSupportingServiceReconciler) Reconcile(ctx context.Context, req c
 		Version:              app2.Version,
 		Labels:               r.Labels,
 		DeploymentIdentifier: r.DeploymentIdentifier,
+		Backoff:              r.Backoff,
 	}
+	// backs off the requeues while the object keeps failing with the same reason
+	errorHandler := infrastructure.NewReconciliationErrorHandlerFor(kogitoContext, KogitoSupportingServiceControllerName, req.NamespacedName)
 
 	// Fetch the KogitoSupportingService instance
 	supportingServiceHandler := r.SupportingServiceHandler(kogitoContext)
@@ -86,7 +119,20 @@ func (r *KogitoSupportingServiceReconciler) Reconcile(ctx context.Context, req c
 	}
 	if instance == nil {
 		log.Debug("kogitoSupportingService Instance not found")
-		return
+		if r.Introspector != nil {
+			r.Introspector.Forget(req.NamespacedName)
+		}
+		if r.DigestResolver != nil {
+			r.DigestResolver.Forget(req.NamespacedName)
+		}
+		return errorHandler.GetReconcileResultFor(nil)
+	}
+	if !instance.GetDeletionTimestamp().IsZero() {
+		// the owned resources are garbage collected, the Kafka topics and Infinispan caches are released
+		if resultErr = kogitoservice.ReleaseKafkaTopics(kogitoContext, instance, r.InfraHandler(kogitoContext)); resultErr != nil {
+			return errorHandler.GetReconcileResultFor(resultErr)
+		}
+		return errorHandler.GetReconcileResultFor(shared.ReleaseInfinispanCaches(kogitoContext, instance, r.InfraHandler(kogitoContext)))
 	}
 
 	supportingServiceManager := manager.NewKogitoSupportingServiceManager(kogitoContext, supportingServiceHandler)
@@ -96,11 +142,16 @@ func (r *KogitoSupportingServiceReconciler) Reconcile(ctx context.Context, req c
 
 	runtimeHandler := r.RuntimeHandler(kogitoContext)
 	infraHandler := r.InfraHandler(kogitoContext)
-	reconcileHandler := kogitosupportingservice.NewReconcilerHandler(kogitoContext, infraHandler, supportingServiceHandler, runtimeHandler)
+	reconcileHandler := kogitosupportingservice.NewReconcilerHandler(kogitoContext, infraHandler, supportingServiceHandler, runtimeHandler, r.Introspector, r.DigestResolver)
 	reconciler := reconcileHandler.GetSupportingServiceReconciler(instance)
 	resultErr = reconciler.Reconcile()
 	if resultErr != nil {
-		return infrastructure.NewReconciliationErrorHandler(kogitoContext).GetReconcileResultFor(resultErr)
+		return errorHandler.GetReconcileResultFor(resultErr)
+	}
+	result, resultErr = errorHandler.GetReconcileResultFor(nil)
+	if instance.GetSupportingServiceSpec().IsExternal() {
+		// external services don't own any resource that could trigger a new reconciliation, their health is checked periodically
+		result.RequeueAfter = infrastructure.ReconciliationAfterOneMinute
 	}
 	return
 }
@@ -113,16 +164,86 @@ func (r *KogitoSupportingServiceReconciler) SetupWithManager(mgr ctrl.Manager) e
 			return false
 		},
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			return e.ObjectNew.GetDeletionTimestamp().IsZero()
+			// the deleted services declaring Kafka topics or using Infinispan caches are reconciled to release them
+			return e.ObjectNew.GetDeletionTimestamp().IsZero() || controllerutil.ContainsFinalizer(e.ObjectNew, kogitoservice.KafkaTopicsFinalizer) ||
+				controllerutil.ContainsFinalizer(e.ObjectNew, shared.InfinispanCachesFinalizer)
 		},
 	}
 
+	// leader election Leases are renewed every few seconds, only a new holder is relevant for the status
+	leaderChangedPred := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldLease, oldOK := e.ObjectOld.(*coordinationv1.Lease)
+			newLease, newOK := e.ObjectNew.(*coordinationv1.Lease)
+			if !oldOK || !newOK {
+				return false
+			}
+			return !reflect.DeepEqual(oldLease.Spec.HolderIdentity, newLease.Spec.HolderIdentity)
+		},
+	}
+
+	if r.Introspector == nil {
+		tlsConfig, err := introspection.NewTLSConfigFromEnv()
+		if err != nil {
+			return err
+		}
+		r.Introspector = introspection.NewIntrospector(tlsConfig)
+	}
+	if err := mgr.Add(r.Introspector); err != nil {
+		return err
+	}
+	if r.DigestResolver == nil {
+		r.DigestResolver = imagedigest.NewResolver()
+	}
+	if err := mgr.Add(r.DigestResolver); err != nil {
+		return err
+	}
+
 	b := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controllerOptions(r.Config, KogitoSupportingServiceControllerName)).
 		For(r.ReconcilingObject, builder.WithPredicates(pred)).
-		Owns(&corev1.Service{}).Owns(&appsv1.Deployment{}).Owns(&corev1.ConfigMap{})
+		Owns(&corev1.Service{}).Owns(&appsv1.Deployment{}).Owns(&corev1.ConfigMap{}).Owns(&batchv1.Job{}).
+		Owns(&coordinationv1.Lease{}, builder.WithPredicates(leaderChangedPred)).
+		// services deployed by a Trusty stack are owned by the stack, which reports their readiness
+		Owns(r.ReconcilingObject).
+		// reconcile again once the topics or dashboards exposed by the service change
+		Watches(&source.Channel{Source: r.Introspector.Events()}, &handler.EnqueueRequestForObject{}).
+		// reconcile again once the image tag points to a new digest
+		Watches(&source.Channel{Source: r.DigestResolver.Events()}, &handler.EnqueueRequestForObject{})
 
 	if r.IsOpenshift() {
 		b.Owns(&routev1.Route{}).Owns(&imgv1.ImageStream{})
 	}
+	// the services are deployed once the ExternalSecrets of their Vault secrets are synced, shared by the services referencing them
+	if r.HasCapability(kogitocli.ExternalSecretsCapability) {
+		b.Watches(&source.Kind{Type: &externalsecrets.ExternalSecret{}}, &handler.EnqueueRequestForOwner{OwnerType: r.ReconcilingObject, IsController: false})
+	}
+
+	gvk, err := apiutil.GVKForObject(r.ReconcilingObject, mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	watchReferencedConfig(b, r.Client, gvk.Kind)
+	if r.InfraObject != nil {
+		watchReferencedInfra(b, r.InfraObject, r.listKogitoSupportingServices)
+	}
 	return b.Complete(r)
 }
+
+// listKogitoSupportingServices lists the KogitoSupportingService instances of the given namespace
+func (r *KogitoSupportingServiceReconciler) listKogitoSupportingServices(namespace string) ([]api.KogitoService, error) {
+	kogitoContext := operator.Context{
+		Client: r.Client,
+		Log:    logger.GetLogger("kogitosupportingservice_controller"),
+		Scheme: r.Scheme,
+	}
+	supportingServices, err := r.SupportingServiceHandler(kogitoContext).FetchKogitoSupportingServiceList(namespace)
+	if err != nil {
+		return nil, err
+	}
+	var services []api.KogitoService
+	for _, supportingService := range supportingServices.GetItems() {
+		services = append(services, supportingService)
+	}
+	return services, nil
+}
@@ -18,6 +18,7 @@ import (
 	v1 "github.com/kiegroup/kogito-operator/apis/rhpam/v1"
 	"github.com/kiegroup/kogito-operator/controllers/common"
 	"github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/operator"
 	"github.com/kiegroup/kogito-operator/internal/rhpam"
 	rhpam2 "github.com/kiegroup/kogito-operator/version/rhpam"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -26,13 +27,13 @@ import (
 //+kubebuilder:rbac:groups=rhpam.kiegroup.org,resources=kogitobuilds,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rhpam.kiegroup.org,resources=kogitobuilds/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=rhpam.kiegroup.org,resources=kogitobuilds/finalizers,verbs=get;update;patch
-//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;delete;update
+//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;delete;update;patch
 //+kubebuilder:rbac:groups=apps,resources=deployments/finalizers,verbs=update
-//+kubebuilder:rbac:groups=build.openshift.io,resources=builds;buildconfigs,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams;imagestreamtags,verbs=get;create;list;watch;delete;update
+//+kubebuilder:rbac:groups=build.openshift.io,resources=builds;buildconfigs,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams;imagestreamtags,verbs=get;create;list;watch;delete;update;patch
 
 // NewKogitoBuildReconciler ...
-func NewKogitoBuildReconciler(client *client.Client, scheme *runtime.Scheme) *common.KogitoBuildReconciler {
+func NewKogitoBuildReconciler(client *client.Client, scheme *runtime.Scheme, config *operator.Config) *common.KogitoBuildReconciler {
 	return &common.KogitoBuildReconciler{
 		Client:            client,
 		Scheme:            scheme,
@@ -40,5 +41,6 @@ func NewKogitoBuildReconciler(client *client.Client, scheme *runtime.Scheme) *co
 		BuildHandler:      rhpam.NewKogitoBuildHandler,
 		ReconcilingObject: &v1.KogitoBuild{},
 		Labels:            getMeteringLabels(),
+		Config:            config,
 	}
 }
@@ -17,6 +17,7 @@ package rhpam
 import (
 	v1 "github.com/kiegroup/kogito-operator/apis/rhpam/v1"
 	"github.com/kiegroup/kogito-operator/controllers/common"
+	"github.com/kiegroup/kogito-operator/core/operator"
 	"github.com/kiegroup/kogito-operator/internal/rhpam"
 	rhpam2 "github.com/kiegroup/kogito-operator/version/rhpam"
 
@@ -27,24 +28,27 @@ import (
 //+kubebuilder:rbac:groups=rhpam.kiegroup.org,resources=kogitoinfras,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rhpam.kiegroup.org,resources=kogitoinfras/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=rhpam.kiegroup.org,resources=kogitoinfras/finalizers,verbs=get;update;patch
-//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;create;delete;update
+//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;create;delete;update;patch
 //+kubebuilder:rbac:groups=infinispan.org,resources=infinispans,verbs=get;create;list;delete;watch
-//+kubebuilder:rbac:groups=kafka.strimzi.io,resources=kafkas;kafkatopics,verbs=get;create;list;delete;watch
+//+kubebuilder:rbac:groups=kafka.strimzi.io,resources=kafkas;kafkatopics;kafkausers,verbs=get;create;list;delete;watch
 //+kubebuilder:rbac:groups=keycloak.org,resources=keycloaks,verbs=get;create;list;delete;watch
 //+kubebuilder:rbac:groups=apps,resources=deployments/finalizers,verbs=update
 //+kubebuilder:rbac:groups=eventing.knative.dev,resources=brokers,verbs=get;list;watch
-//+kubebuilder:rbac:groups=eventing.knative.dev,resources=triggers,verbs=get;list;watch;create;delete;update
-//+kubebuilder:rbac:groups=sources.knative.dev,resources=sinkbindings,verbs=get;list;watch;create;delete;update
-//+kubebuilder:rbac:groups=integreatly.org,resources=grafanadashboards,verbs=get;create;list;watch;create;delete;update
+//+kubebuilder:rbac:groups=eventing.knative.dev,resources=triggers,verbs=get;list;watch;create;delete;update;patch
+//+kubebuilder:rbac:groups=sources.knative.dev,resources=sinkbindings,verbs=get;list;watch;create;delete;update;patch
+//+kubebuilder:rbac:groups=integreatly.org,resources=grafanadashboards,verbs=get;create;list;watch;create;delete;update;patch
 //+kubebuilder:rbac:groups=mongodbcommunity.mongodb.com,resources=mongodbcommunity,verbs=get;create;list;watch;delete
+//+kubebuilder:rbac:groups=external-secrets.io,resources=externalsecrets,verbs=get;create;list;watch;delete;update;patch
 
 // NewKogitoInfraReconciler ...
-func NewKogitoInfraReconciler(client *kogitocli.Client, scheme *runtime.Scheme) *common.KogitoInfraReconciler {
+func NewKogitoInfraReconciler(client *kogitocli.Client, scheme *runtime.Scheme, config *operator.Config) *common.KogitoInfraReconciler {
 	return &common.KogitoInfraReconciler{
 		Client:            client,
 		Scheme:            scheme,
 		Version:           rhpam2.Version,
 		InfraHandler:      rhpam.NewKogitoInfraHandler,
 		ReconcilingObject: &v1.KogitoInfra{},
+		Config:            config,
+		Backoff:           operator.NewRequeueBackoff(config),
 	}
 }
@@ -27,17 +27,21 @@ import (
 //+kubebuilder:rbac:groups=rhpam.kiegroup.org,resources=kogitoruntimes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rhpam.kiegroup.org,resources=kogitoruntimes/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=rhpam.kiegroup.org,resources=kogitoruntimes/finalizers,verbs=get;update;patch
-//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;create;list;delete
+//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;create;list;delete;update;patch
 //+kubebuilder:rbac:groups=apps,resources=deployments/finalizers,verbs=update
-//+kubebuilder:rbac:groups=integreatly.org,resources=grafanadashboards,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams;imagestreamtags,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings;roles,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;create;list;watch;delete;update
+//+kubebuilder:rbac:groups=integreatly.org,resources=grafanadashboards,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams;imagestreamtags,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings;roles,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=infinispan.org,resources=caches,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=external-secrets.io,resources=externalsecrets,verbs=get;create;list;watch;delete;update;patch
 //+kubebuilder:rbac:groups=core,resources=configmaps;events;pods;secrets;serviceaccounts;services,verbs=create;delete;get;list;patch;update;watch
 
 // NewKogitoRuntimeReconciler ...
-func NewKogitoRuntimeReconciler(client *kogitocli.Client, scheme *runtime.Scheme) *common.KogitoRuntimeReconciler {
+func NewKogitoRuntimeReconciler(client *kogitocli.Client, scheme *runtime.Scheme, config *operator.Config) *common.KogitoRuntimeReconciler {
 	return &common.KogitoRuntimeReconciler{
 		Client:                client,
 		Scheme:                scheme,
@@ -46,7 +50,10 @@ func NewKogitoRuntimeReconciler(client *kogitocli.Client, scheme *runtime.Scheme
 		SupportServiceHandler: rhpam.NewKogitoSupportingServiceHandler,
 		InfraHandler:          rhpam.NewKogitoInfraHandler,
 		ReconcilingObject:     &v1.KogitoRuntime{},
+		InfraObject:           &v1.KogitoInfra{},
 		Labels:                getMeteringLabels(),
 		DeploymentIdentifier:  operator.KogitoRuntimeKey,
+		Config:                config,
+		Backoff:               operator.NewRequeueBackoff(config),
 	}
 }
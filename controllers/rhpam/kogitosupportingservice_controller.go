@@ -27,16 +27,23 @@ import (
 //+kubebuilder:rbac:groups=rhpam.kiegroup.org,resources=kogitosupportingservices,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rhpam.kiegroup.org,resources=kogitosupportingservices/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=rhpam.kiegroup.org,resources=kogitosupportingservices/finalizers,verbs=get;update;patch
-//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;create;list;delete
+//+kubebuilder:rbac:groups=apps,resources=deployments;replicasets,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;create;list;delete;update;patch
 //+kubebuilder:rbac:groups=apps,resources=deployments/finalizers,verbs=update
-//+kubebuilder:rbac:groups=integreatly.org,resources=grafanadashboards,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams;imagestreamtags,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;create;list;watch;delete;update
-//+kubebuilder:rbac:groups=core,resources=configmaps;events;pods;secrets;services,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;create;list;watch;delete
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;create;list;watch;update;patch
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings;roles,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=kafka.strimzi.io,resources=kafkatopics,verbs=get;create;list;watch;update;patch;delete
+//+kubebuilder:rbac:groups=infinispan.org,resources=caches,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=integreatly.org,resources=grafanadashboards,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams;imagestreamtags,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=external-secrets.io,resources=externalsecrets,verbs=get;create;list;watch;delete;update;patch
+//+kubebuilder:rbac:groups=core,resources=configmaps;events;pods;secrets;serviceaccounts;services,verbs=create;delete;get;list;patch;update;watch
 
 // NewKogitoSupportingServiceReconciler ...
-func NewKogitoSupportingServiceReconciler(client *kogitocli.Client, scheme *runtime.Scheme) *common.KogitoSupportingServiceReconciler {
+func NewKogitoSupportingServiceReconciler(client *kogitocli.Client, scheme *runtime.Scheme, config *operator.Config) *common.KogitoSupportingServiceReconciler {
 	return &common.KogitoSupportingServiceReconciler{
 		Client:                   client,
 		Scheme:                   scheme,
@@ -45,7 +52,10 @@ func NewKogitoSupportingServiceReconciler(client *kogitocli.Client, scheme *runt
 		SupportingServiceHandler: rhpam.NewKogitoSupportingServiceHandler,
 		InfraHandler:             rhpam.NewKogitoInfraHandler,
 		ReconcilingObject:        &v1.KogitoSupportingService{},
+		InfraObject:              &v1.KogitoInfra{},
 		Labels:                   getMeteringLabels(),
 		DeploymentIdentifier:     operator.KogitoSupportingServiceKey,
+		Config:                   config,
+		Backoff:                  operator.NewRequeueBackoff(config),
 	}
 }
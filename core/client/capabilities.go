@@ -0,0 +1,271 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+)
+
+const (
+	// DefaultCapabilitiesTTL is the time the discovery results are kept before querying the API server again
+	DefaultCapabilitiesTTL = time.Minute * 5
+)
+
+// Capability is a feature of the cluster the operator integrates with, detected through the discovery API
+type Capability string
+
+const (
+	// OpenShiftCapability the cluster is OpenShift
+	OpenShiftCapability Capability = "OpenShift"
+	// StrimziCapability the Strimzi kafka.strimzi.io/v1beta2 API is available
+	StrimziCapability Capability = "Strimzi"
+	// InfinispanCapability the Infinispan Operator API is available
+	InfinispanCapability Capability = "Infinispan"
+	// InfinispanCacheCapability the Infinispan Operator infinispan.org/v2alpha1 Cache API is available
+	InfinispanCacheCapability Capability = "InfinispanCache"
+	// MongoDBCapability the MongoDB Community Operator API is available
+	MongoDBCapability Capability = "MongoDB"
+	// KeycloakCapability the Keycloak Operator API is available
+	KeycloakCapability Capability = "Keycloak"
+	// KnativeEventingCapability the Knative Eventing API is available
+	KnativeEventingCapability Capability = "KnativeEventing"
+	// PrometheusCapability the Prometheus Operator API is available
+	PrometheusCapability Capability = "Prometheus"
+	// GrafanaCapability the Grafana Operator API is available
+	GrafanaCapability Capability = "Grafana"
+	// ExternalSecretsCapability the External Secrets Operator external-secrets.io/v1beta1 API is available
+	ExternalSecretsCapability Capability = "ExternalSecrets"
+)
+
+// capabilityDefinition is the API group, and optionally version, that must be served by the cluster for a capability to be available.
+// As for HasServerGroup, the group matches any served group containing it, eg: openshift.io matches route.openshift.io.
+type capabilityDefinition struct {
+	group   string
+	version string
+}
+
+var capabilityDefinitions = map[Capability]capabilityDefinition{
+	OpenShiftCapability:       {group: OpenShiftGroupName},
+	StrimziCapability:         {group: "kafka.strimzi.io", version: "v1beta2"},
+	InfinispanCapability:      {group: "infinispan.org"},
+	InfinispanCacheCapability: {group: "infinispan.org", version: "v2alpha1"},
+	MongoDBCapability:         {group: "mongodbcommunity.mongodb.com"},
+	KeycloakCapability:        {group: "keycloak.org"},
+	KnativeEventingCapability: {group: "eventing.knative.dev"},
+	PrometheusCapability:      {group: "monitoring.coreos.com"},
+	GrafanaCapability:         {group: "integreatly.org"},
+	ExternalSecretsCapability: {group: "external-secrets.io", version: "v1beta1"},
+}
+
+// IsCapabilityGroup tells whether the availability of the given api group affects any of the capabilities
+func IsCapabilityGroup(groupName string) bool {
+	for _, definition := range capabilityDefinitions {
+		if strings.Contains(groupName, definition.group) {
+			return true
+		}
+	}
+	return false
+}
+
+// CapabilityRegistry caches the results of the discovery API, so the cluster capabilities can be checked on every reconciliation
+// without querying the API server each time.
+// The cached results are refreshed once the TTL expires, or right after Invalidate is called, eg: when a CRD is installed.
+type CapabilityRegistry struct {
+	discovery discovery.DiscoveryInterface
+	ttl       time.Duration
+	now       func() time.Time
+
+	mutex         sync.RWMutex
+	groupVersions map[string][]string
+	serverVersion *version.Info
+	refreshedAt   time.Time
+	expiresAt     time.Time
+}
+
+// CapabilitiesStatus is the report of the detected capabilities served by the status endpoint
+type CapabilitiesStatus struct {
+	Capabilities  map[Capability]bool `json:"capabilities"`
+	ServerVersion string              `json:"serverVersion,omitempty"`
+	RefreshedAt   time.Time           `json:"refreshedAt"`
+}
+
+// NewCapabilityRegistry creates a registry caching the discovery results for the given TTL
+func NewCapabilityRegistry(discovery discovery.DiscoveryInterface, ttl time.Duration) *CapabilityRegistry {
+	return &CapabilityRegistry{
+		discovery: discovery,
+		ttl:       ttl,
+		now:       time.Now,
+	}
+}
+
+// Invalidate discards the cached results, the next query refreshes them from the API server
+func (r *CapabilityRegistry) Invalidate() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.expiresAt = time.Time{}
+}
+
+// HasServerGroup detects if the given api group is supported by the server
+func (r *CapabilityRegistry) HasServerGroup(groupName string) bool {
+	return r.hasGroupVersion(groupName, "")
+}
+
+// HasCapability detects if the given capability is available in the cluster
+func (r *CapabilityRegistry) HasCapability(capability Capability) bool {
+	definition, exists := capabilityDefinitions[capability]
+	if !exists {
+		return false
+	}
+	return r.hasGroupVersion(definition.group, definition.version)
+}
+
+// GetCapabilities returns every known capability and whether it's available in the cluster
+func (r *CapabilityRegistry) GetCapabilities() map[Capability]bool {
+	capabilities := map[Capability]bool{}
+	for capability := range capabilityDefinitions {
+		capabilities[capability] = r.HasCapability(capability)
+	}
+	return capabilities
+}
+
+// GetServerVersion returns the Kubernetes version of the API server
+func (r *CapabilityRegistry) GetServerVersion() (*version.Info, error) {
+	if err := r.refreshIfExpired(); err != nil {
+		return nil, err
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if r.serverVersion == nil {
+		return nil, fmt.Errorf("Kubernetes server version not available ")
+	}
+	return r.serverVersion, nil
+}
+
+// GetServerMinorVersion returns the Kubernetes minor version of the API server, eg: 23 for 1.23
+func (r *CapabilityRegistry) GetServerMinorVersion() (int, error) {
+	versionInfo, err := r.GetServerVersion()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSuffix(versionInfo.Minor, "+"))
+}
+
+// GetStatus returns the report of the detected capabilities
+func (r *CapabilityRegistry) GetStatus() CapabilitiesStatus {
+	status := CapabilitiesStatus{Capabilities: r.GetCapabilities()}
+	if versionInfo, err := r.GetServerVersion(); err == nil {
+		status.ServerVersion = versionInfo.GitVersion
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	status.RefreshedAt = r.refreshedAt
+	return status
+}
+
+// LogCapabilities logs the detected capabilities
+func (r *CapabilityRegistry) LogCapabilities() {
+	status := r.GetStatus()
+	var available, unavailable []string
+	for capability, isAvailable := range status.Capabilities {
+		if isAvailable {
+			available = append(available, string(capability))
+		} else {
+			unavailable = append(unavailable, string(capability))
+		}
+	}
+	sort.Strings(available)
+	sort.Strings(unavailable)
+	log.Info("Detected cluster capabilities", "serverVersion", status.ServerVersion, "available", available, "unavailable", unavailable)
+}
+
+// ServeHTTP serves the report of the detected capabilities as JSON
+func (r *CapabilityRegistry) ServeHTTP(writer http.ResponseWriter, _ *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(r.GetStatus()); err != nil {
+		log.Error(err, "Error while writing capabilities status")
+	}
+}
+
+func (r *CapabilityRegistry) hasGroupVersion(groupName string, groupVersion string) bool {
+	if err := r.refreshIfExpired(); err != nil {
+		log.Warn("Impossible to get server groups using discovery API", "error", err)
+		return false
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	for group, versions := range r.groupVersions {
+		if !strings.Contains(group, groupName) {
+			continue
+		}
+		if len(groupVersion) == 0 {
+			return true
+		}
+		for _, v := range versions {
+			if v == groupVersion {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// refreshIfExpired queries the discovery API if the cached results expired, failed queries are retried on the next call
+func (r *CapabilityRegistry) refreshIfExpired() error {
+	r.mutex.RLock()
+	expired := r.now().After(r.expiresAt)
+	r.mutex.RUnlock()
+	if !expired {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	// another caller might have refreshed in the meantime
+	if !r.now().After(r.expiresAt) {
+		return nil
+	}
+	groups, err := r.discovery.ServerGroups()
+	if err != nil {
+		return err
+	}
+	groupVersions := map[string][]string{}
+	for _, group := range groups.Groups {
+		groupVersions[group.Name] = []string{}
+		for _, groupVersion := range group.Versions {
+			groupVersions[group.Name] = append(groupVersions[group.Name], groupVersion.Version)
+		}
+	}
+	serverVersion, err := r.discovery.ServerVersion()
+	if err != nil {
+		log.Warn("Could not access Kubernetes server version", "error", err)
+		serverVersion = nil
+	}
+	r.groupVersions = groupVersions
+	r.serverVersion = serverVersion
+	r.refreshedAt = r.now()
+	r.expiresAt = r.refreshedAt.Add(r.ttl)
+	log.Debug("Refreshed cluster capabilities from discovery API", "groups", len(groupVersions))
+	return nil
+}
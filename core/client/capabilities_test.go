@@ -0,0 +1,121 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	discfake "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+// countingDiscovery counts the calls to the discovery API
+type countingDiscovery struct {
+	*discfake.FakeDiscovery
+	serverGroupsCalls int
+}
+
+func (c *countingDiscovery) ServerGroups() (*metav1.APIGroupList, error) {
+	c.serverGroupsCalls++
+	return c.FakeDiscovery.ServerGroups()
+}
+
+func newCountingDiscovery(groupVersions ...string) *countingDiscovery {
+	disco := &discfake.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	for _, groupVersion := range groupVersions {
+		disco.Resources = append(disco.Resources, &metav1.APIResourceList{GroupVersion: groupVersion})
+	}
+	disco.FakedServerVersion = &version.Info{Major: "1", Minor: "23+", GitVersion: "v1.23.5"}
+	return &countingDiscovery{FakeDiscovery: disco}
+}
+
+func TestCapabilityRegistry_HasCapability(t *testing.T) {
+	disco := newCountingDiscovery("kafka.strimzi.io/v1beta1", "route.openshift.io/v1", "infinispan.org/v1")
+	registry := NewCapabilityRegistry(disco, DefaultCapabilitiesTTL)
+
+	assert.True(t, registry.HasCapability(OpenShiftCapability))
+	assert.True(t, registry.HasCapability(InfinispanCapability))
+	// only Strimzi v1beta2 is supported
+	assert.False(t, registry.HasCapability(StrimziCapability))
+	assert.True(t, registry.HasServerGroup("kafka.strimzi.io"))
+	assert.False(t, registry.HasCapability(GrafanaCapability))
+
+	minorVersion, err := registry.GetServerMinorVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, 23, minorVersion)
+	// all the checks were answered from the cache
+	assert.Equal(t, 1, disco.serverGroupsCalls)
+}
+
+func TestCapabilityRegistry_Refresh(t *testing.T) {
+	disco := newCountingDiscovery("route.openshift.io/v1")
+	registry := NewCapabilityRegistry(disco, time.Minute)
+	now := time.Now()
+	registry.now = func() time.Time { return now }
+
+	assert.False(t, registry.HasCapability(StrimziCapability))
+
+	// Strimzi installed after the first check
+	disco.Resources = append(disco.Resources, &metav1.APIResourceList{GroupVersion: "kafka.strimzi.io/v1beta2"})
+	assert.False(t, registry.HasCapability(StrimziCapability))
+	assert.Equal(t, 1, disco.serverGroupsCalls)
+
+	registry.Invalidate()
+	assert.True(t, registry.HasCapability(StrimziCapability))
+	assert.Equal(t, 2, disco.serverGroupsCalls)
+
+	// removed, only seen once the TTL expires
+	disco.Resources = disco.Resources[:1]
+	assert.True(t, registry.HasCapability(StrimziCapability))
+	now = now.Add(2 * time.Minute)
+	assert.False(t, registry.HasCapability(StrimziCapability))
+	assert.Equal(t, 3, disco.serverGroupsCalls)
+}
+
+func TestCapabilityRegistry_ServeHTTP(t *testing.T) {
+	registry := NewCapabilityRegistry(newCountingDiscovery("monitoring.coreos.com/v1"), DefaultCapabilitiesTTL)
+	recorder := httptest.NewRecorder()
+	registry.ServeHTTP(recorder, httptest.NewRequest("GET", "/capabilities", nil))
+
+	status := CapabilitiesStatus{}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	assert.Equal(t, "v1.23.5", status.ServerVersion)
+	assert.True(t, status.Capabilities[PrometheusCapability])
+	assert.False(t, status.Capabilities[OpenShiftCapability])
+	assert.Len(t, status.Capabilities, len(capabilityDefinitions))
+}
+
+func TestIsCapabilityGroup(t *testing.T) {
+	assert.True(t, IsCapabilityGroup("kafka.strimzi.io"))
+	assert.True(t, IsCapabilityGroup("route.openshift.io"))
+	assert.False(t, IsCapabilityGroup("cert-manager.io"))
+}
+
+func TestClient_CapabilitiesCreatedOnce(t *testing.T) {
+	disco := newCountingDiscovery("route.openshift.io/v1")
+	cli := &Client{Discovery: disco}
+
+	assert.True(t, cli.IsOpenshift())
+	assert.False(t, cli.HasCapability(StrimziCapability))
+	assert.True(t, cli.HasServerGroup("route.openshift.io"))
+	assert.NotNil(t, cli.Capabilities)
+	assert.Equal(t, 1, disco.serverGroupsCalls)
+}
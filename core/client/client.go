@@ -19,6 +19,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/kiegroup/kogito-operator/core/logger"
 	rbac "k8s.io/api/rbac/v1"
@@ -59,6 +60,13 @@ type Client struct {
 	Discovery              discovery.DiscoveryInterface
 	DeploymentCli          appsv1.AppsV1Interface
 	KubernetesExtensionCli kubernetes.Interface
+	// Capabilities caches the discovery results, when nil it's created from the Discovery client on the first check
+	Capabilities *CapabilityRegistry
+	// WatchedNamespaces tells which namespaces the operator watches, all namespaces when nil
+	WatchedNamespaces NamespaceFilter
+
+	// capabilitiesOnce guards the creation of the capability registry of the clients built without it
+	capabilitiesOnce sync.Once
 }
 
 // NewForConsole will create a brand new client using the local machine
@@ -70,13 +78,15 @@ func NewForConsole(scheme *runtime.Scheme) *Client {
 	return client
 }
 
-// NewForController creates a new client based on the rest config and the controller client created by Operator SDK
+// NewForController creates a new client based on the rest config and the controller client created by Operator SDK.
+// The objects of the namespaces not watched by the operator are read from the API server instead of the manager cache.
 // Panic if something goes wrong
-func NewForController(manager controllerruntime.Manager) *Client {
+func NewForController(manager controllerruntime.Manager, watchedNamespaces NamespaceFilter) *Client {
 	newClient, err := NewClientBuilder(manager.GetScheme()).
 		WithAllClients().
 		UseConfig(manager.GetConfig()).
-		UseControllerClient(manager.GetClient()).
+		UseControllerClient(newNamespaceFilteredClient(manager.GetClient(), manager.GetAPIReader(), watchedNamespaces)).
+		UseWatchedNamespaces(watchedNamespaces).
 		Build()
 	if err != nil {
 		panic(err)
@@ -86,28 +96,50 @@ func NewForController(manager controllerruntime.Manager) *Client {
 
 // IsOpenshift detects if the application is running on OpenShift or not
 func (c *Client) IsOpenshift() bool {
-	return c.HasServerGroup(OpenShiftGroupName)
+	return c.HasCapability(OpenShiftCapability)
+}
+
+// IsNamespaceWatched returns true if the operator watches the given namespace
+func (c *Client) IsNamespaceWatched(namespace string) bool {
+	return c.WatchedNamespaces == nil || c.WatchedNamespaces.IsNamespaceWatched(namespace)
+}
+
+// HasCapability detects if the given capability is available in the cluster
+func (c *Client) HasCapability(capability Capability) bool {
+	if capabilities := c.getCapabilities(); capabilities != nil {
+		return capabilities.HasCapability(capability)
+	}
+	log.Warn("Tried to discover the platform, but no discovery API is available")
+	return false
+}
+
+// GetServerMinorVersion returns the Kubernetes minor version of the API server, eg: 23 for 1.23
+func (c *Client) GetServerMinorVersion() (int, error) {
+	if capabilities := c.getCapabilities(); capabilities != nil {
+		return capabilities.GetServerMinorVersion()
+	}
+	return 0, fmt.Errorf("No discovery API is available ")
 }
 
 // HasServerGroup detects if the given api group is supported by the server
 func (c *Client) HasServerGroup(groupName string) bool {
-	if c.Discovery != nil {
-		groups, err := c.Discovery.ServerGroups()
-		if err != nil {
-			log.Warn("Impossible to get server groups using discovery API", "error", err)
-			return false
-		}
-		for _, group := range groups.Groups {
-			if strings.Contains(group.Name, groupName) {
-				return true
-			}
-		}
-		return false
+	if capabilities := c.getCapabilities(); capabilities != nil {
+		return capabilities.HasServerGroup(groupName)
 	}
 	log.Warn("Tried to discover the platform, but no discovery API is available")
 	return false
 }
 
+// getCapabilities returns the client capability registry, it's created once for the clients built without it
+func (c *Client) getCapabilities() *CapabilityRegistry {
+	c.capabilitiesOnce.Do(func() {
+		if c.Capabilities == nil && c.Discovery != nil {
+			c.Capabilities = NewCapabilityRegistry(c.Discovery, DefaultCapabilitiesTTL)
+		}
+	})
+	return c.Capabilities
+}
+
 func newKubeClient(config *restclient.Config, scheme *runtime.Scheme, useDynamicRestMapper bool) (client.Client, error) {
 	log.Debug("Creating a new core client for kube connection")
 	var options client.Options
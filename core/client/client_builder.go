@@ -40,6 +40,8 @@ type Builder interface {
 	UseConfig(kubeconfig *restclient.Config) Builder
 	// UseControllerClient sets a specific controllerclient
 	UseControllerClient(controllerClient client.Client) Builder
+	// UseWatchedNamespaces sets the namespaces watched by the operator, all namespaces if not set
+	UseWatchedNamespaces(watchedNamespaces NamespaceFilter) Builder
 	// UseControllerDynamicMapper will set a dynamic mapper to the constructed controller client. Cannot be used with `UseControllerClient`
 	UseControllerDynamicMapper() Builder
 	// WithDiscoveryClient tells the builder to create the discovery client
@@ -63,6 +65,7 @@ type builderStruct struct {
 	config        *restclient.Config
 	scheme        *runtime.Scheme
 	controllerCli client.Client
+	watchedNs     NamespaceFilter
 
 	useControllerDynamicMapper bool
 
@@ -83,6 +86,11 @@ func (builder *builderStruct) UseControllerClient(controllerClient client.Client
 	return builder
 }
 
+func (builder *builderStruct) UseWatchedNamespaces(watchedNamespaces NamespaceFilter) Builder {
+	builder.watchedNs = watchedNamespaces
+	return builder
+}
+
 func (builder *builderStruct) UseControllerDynamicMapper() Builder {
 	builder.useControllerDynamicMapper = true
 	return builder
@@ -136,6 +144,7 @@ func (builder *builderStruct) Build() (*Client, error) {
 	}
 
 	client.ControlCli = builder.controllerCli
+	client.WatchedNamespaces = builder.watchedNs
 	if client.ControlCli == nil {
 
 		scheme := builder.scheme
@@ -153,6 +162,7 @@ func (builder *builderStruct) Build() (*Client, error) {
 		if err != nil {
 			return nil, fmt.Errorf("Impossible to create new Discovery client: %v", err)
 		}
+		client.Capabilities = NewCapabilityRegistry(client.Discovery, DefaultCapabilitiesTTL)
 	}
 	if builder.isBuildClient {
 		client.BuildCli, err = buildv1.NewForConfig(config)
@@ -18,9 +18,15 @@ import (
 	"context"
 	"github.com/RHsyseng/operator-utils/pkg/resource/write"
 	kogitocli "github.com/kiegroup/kogito-operator/core/client"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
+// FieldManager is the field manager used by the operator to server-side apply the resources it owns
+const FieldManager = "kogito-operator"
+
 // ResourceWriter interface to write kubernetes object
 type ResourceWriter interface {
 	// Create creates a new Kubernetes object in the cluster.
@@ -30,6 +36,8 @@ type ResourceWriter interface {
 	Delete(resource client.Object) error
 	// Update the given object
 	Update(resource client.Object) error
+	// Patch the given object with the given patch
+	Patch(resource client.Object, patch client.Patch) error
 	// UpdateStatus update the given object status
 	UpdateStatus(resource client.Object) error
 	// CreateResources create provided objects
@@ -38,6 +46,12 @@ type ResourceWriter interface {
 	UpdateResources(existing []client.Object, resources []client.Object) (bool, error)
 	// DeleteResources delete provided objects
 	DeleteResources(resources []client.Object) (bool, error)
+	// Apply creates or updates the given object with server-side apply, only the fields set on the object are owned by the operator.
+	// The fields owned by another manager are never taken over, a conflict error is returned instead.
+	// The status isn't sent, fields not set on the object are left to their managers.
+	Apply(resource client.Object) error
+	// ApplyResources server-side apply provided objects
+	ApplyResources(resources []client.Object) (bool, error)
 }
 
 // ResourceWriterC provide ResourceWrite reference
@@ -69,6 +83,11 @@ func (r *resourceWriter) Update(resource client.Object) error {
 	return nil
 }
 
+func (r *resourceWriter) Patch(resource client.Object, patch client.Patch) error {
+	log.Debug("About to patch resource", "name", resource.GetName(), "namespace", resource.GetNamespace())
+	return r.client.ControlCli.Patch(context.TODO(), resource, patch)
+}
+
 func (r *resourceWriter) Delete(resource client.Object) error {
 	if err := r.client.ControlCli.Delete(context.TODO(), resource); err != nil {
 		log.Error(err, "Failed to delete resource.", "name", resource.GetName())
@@ -101,3 +120,77 @@ func (r *resourceWriter) DeleteResources(resources []client.Object) (bool, error
 	writer := write.New(r.client.ControlCli)
 	return writer.RemoveResources(resources)
 }
+
+func (r *resourceWriter) Apply(resource client.Object) error {
+	gvk, err := apiutil.GVKForObject(resource, r.client.ControlCli.Scheme())
+	if err != nil {
+		return err
+	}
+	// apply requests must declare the type and can't carry the server managed metadata
+	resource.GetObjectKind().SetGroupVersionKind(gvk)
+	resource.SetResourceVersion("")
+	resource.SetManagedFields(nil)
+	applied, err := getApplyConfiguration(resource)
+	if err != nil {
+		return err
+	}
+	log.Debug("About to apply resource", "kind", gvk.Kind, "name", resource.GetName(), "namespace", resource.GetNamespace())
+	if err = r.client.ControlCli.Patch(context.TODO(), applied, client.Apply, client.FieldOwner(FieldManager)); err != nil {
+		return err
+	}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(applied.Object, resource); err != nil {
+		return err
+	}
+	log.Debug("Resource applied.", "name", resource.GetName(), "namespace", resource.GetNamespace())
+	return nil
+}
+
+// emptyApplyFields are the fields whose empty object is meaningful, they're kept in the apply configurations
+var emptyApplyFields = map[string]bool{
+	"emptyDir": true,
+}
+
+// getApplyConfiguration returns the fields of the given object owned by the operator, without the status and the server managed metadata
+func getApplyConfiguration(resource client.Object) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(resource)
+	if err != nil {
+		return nil, err
+	}
+	delete(content, "status")
+	pruneApplyConfiguration(content)
+	return &unstructured.Unstructured{Object: content}, nil
+}
+
+// pruneApplyConfiguration removes the null values and the empty objects from the given content, they come from the fields of the typed
+// objects that aren't set by the operator, eg: the creationTimestamp of the pod template or the resources of a container.
+// Otherwise, the operator would own these fields and conflict with their actual managers.
+func pruneApplyConfiguration(content map[string]interface{}) {
+	for key, value := range content {
+		switch typed := value.(type) {
+		case nil:
+			delete(content, key)
+		case map[string]interface{}:
+			pruneApplyConfiguration(typed)
+			if len(typed) == 0 && !emptyApplyFields[key] {
+				delete(content, key)
+			}
+		case []interface{}:
+			for _, item := range typed {
+				if object, ok := item.(map[string]interface{}); ok {
+					pruneApplyConfiguration(object)
+				}
+			}
+		}
+	}
+}
+
+func (r *resourceWriter) ApplyResources(resources []client.Object) (bool, error) {
+	var applied bool
+	for _, resource := range resources {
+		if err := r.Apply(resource); err != nil {
+			return applied, err
+		}
+		applied = true
+	}
+	return applied, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_getApplyConfiguration(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "image"}},
+					Volumes:    []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+				},
+			},
+		},
+	}
+	applied, err := getApplyConfiguration(deployment)
+	assert.NoError(t, err)
+
+	// the fields not set by the operator aren't declared
+	assert.NotContains(t, applied.Object, "status")
+	_, found, _ := unstructured.NestedFieldNoCopy(applied.Object, "metadata", "creationTimestamp")
+	assert.False(t, found)
+	_, found, _ = unstructured.NestedFieldNoCopy(applied.Object, "spec", "template", "metadata", "creationTimestamp")
+	assert.False(t, found)
+	_, found, _ = unstructured.NestedFieldNoCopy(applied.Object, "spec", "strategy")
+	assert.False(t, found)
+	containers, _, _ := unstructured.NestedSlice(applied.Object, "spec", "template", "spec", "containers")
+	assert.Equal(t, map[string]interface{}{"name": "test", "image": "image"}, containers[0])
+
+	// the empty objects meaningful on their own are kept
+	volumes, _, _ := unstructured.NestedSlice(applied.Object, "spec", "template", "spec", "volumes")
+	assert.Equal(t, map[string]interface{}{"name": "data", "emptyDir": map[string]interface{}{}}, volumes[0])
+}
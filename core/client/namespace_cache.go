@@ -0,0 +1,481 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// namespaceSelectorCache keeps one cache per namespace matching the label selector.
+// The namespaces are watched, so a namespace is added or removed as soon as its labels change,
+// the informers requested by the controllers and their handlers are replayed on the caches of the new namespaces.
+type namespaceSelectorCache struct {
+	selector     labels.Selector
+	scheme       *runtime.Scheme
+	mapper       apimeta.RESTMapper
+	clusterCache cache.Cache
+	newCache     func(namespace string) (cache.Cache, error)
+
+	mutex      sync.RWMutex
+	ctx        context.Context
+	namespaces map[string]*watchedNamespace
+	informers  map[string]*selectorInformer
+	indexes    []fieldIndex
+}
+
+type watchedNamespace struct {
+	cache.Cache
+	cancel context.CancelFunc
+}
+
+type fieldIndex struct {
+	obj          client.Object
+	field        string
+	extractValue client.IndexerFunc
+}
+
+var _ cache.Cache = &namespaceSelectorCache{}
+
+func newNamespaceSelectorCache(config *rest.Config, opts cache.Options, selector labels.Selector) (*namespaceSelectorCache, error) {
+	if opts.Mapper == nil {
+		mapper, err := apiutil.NewDynamicRESTMapper(config)
+		if err != nil {
+			return nil, err
+		}
+		opts.Mapper = mapper
+	}
+	clusterCache, err := cache.New(config, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cluster cache: %v", err)
+	}
+	newCache := func(namespace string) (cache.Cache, error) {
+		namespaceOpts := opts
+		namespaceOpts.Namespace = namespace
+		return cache.New(config, namespaceOpts)
+	}
+	return newNamespaceSelectorCacheWith(clusterCache, newCache, opts.Scheme, opts.Mapper, selector), nil
+}
+
+func newNamespaceSelectorCacheWith(clusterCache cache.Cache, newCache func(namespace string) (cache.Cache, error), scheme *runtime.Scheme, mapper apimeta.RESTMapper, selector labels.Selector) *namespaceSelectorCache {
+	return &namespaceSelectorCache{
+		selector:     selector,
+		scheme:       scheme,
+		mapper:       mapper,
+		clusterCache: clusterCache,
+		newCache:     newCache,
+		ctx:          context.Background(),
+		namespaces:   map[string]*watchedNamespace{},
+		informers:    map[string]*selectorInformer{},
+	}
+}
+
+func (c *namespaceSelectorCache) isNamespaced(gvk schema.GroupVersionKind) (bool, error) {
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return false, err
+	}
+	return mapping.Scope.Name() == apimeta.RESTScopeNameNamespace, nil
+}
+
+func (c *namespaceSelectorCache) isObjectNamespaced(obj runtime.Object) (bool, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return false, err
+	}
+	return c.isNamespaced(gvk)
+}
+
+func (c *namespaceSelectorCache) isNamespaceWatched(namespace string) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	_, exists := c.namespaces[namespace]
+	return exists
+}
+
+func (c *namespaceSelectorCache) GetInformer(ctx context.Context, obj client.Object) (cache.Informer, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	return c.getInformer(ctx, gvk, obj)
+}
+
+func (c *namespaceSelectorCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (cache.Informer, error) {
+	obj, err := c.scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	clientObj, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a client object", gvk)
+	}
+	return c.getInformer(ctx, gvk, clientObj)
+}
+
+func (c *namespaceSelectorCache) getInformer(ctx context.Context, gvk schema.GroupVersionKind, obj client.Object) (cache.Informer, error) {
+	namespaced, err := c.isNamespaced(gvk)
+	if err != nil {
+		return nil, err
+	}
+	if !namespaced {
+		return c.clusterCache.GetInformer(ctx, obj)
+	}
+	// typed, unstructured and metadata only informers of the same kind are different informers
+	key := fmt.Sprintf("%s/%T", gvk, obj)
+	c.mutex.Lock()
+	if informer, exists := c.informers[key]; exists {
+		c.mutex.Unlock()
+		return informer, nil
+	}
+	// the informer is registered first, so the namespaces watched from now on are added by addNamespace
+	informer := newSelectorInformer(obj)
+	c.informers[key] = informer
+	namespaces := make(map[string]*watchedNamespace, len(c.namespaces))
+	for namespace, watched := range c.namespaces {
+		namespaces[namespace] = watched
+	}
+	c.mutex.Unlock()
+	// getting the informer of a started cache blocks until it's synced, the other lookups mustn't wait for it
+	for namespace, watched := range namespaces {
+		namespaceInformer, err := watched.GetInformer(ctx, obj.DeepCopyObject().(client.Object))
+		if err == nil {
+			err = c.addNamespaceInformer(informer, namespace, watched, namespaceInformer)
+		}
+		if err != nil {
+			c.mutex.Lock()
+			if c.informers[key] == informer {
+				delete(c.informers, key)
+			}
+			c.mutex.Unlock()
+			return nil, err
+		}
+	}
+	return informer, nil
+}
+
+// addNamespaceInformer adds the informer of the namespace unless the namespace stopped being watched in the meantime
+func (c *namespaceSelectorCache) addNamespaceInformer(informer *selectorInformer, namespace string, watched *watchedNamespace, namespaceInformer cache.Informer) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.namespaces[namespace] != watched {
+		return nil
+	}
+	return informer.addNamespace(namespace, namespaceInformer)
+}
+
+// Start watches the namespaces and starts the caches of the ones matching the selector, blocks until the context is done
+func (c *namespaceSelectorCache) Start(ctx context.Context) error {
+	c.mutex.Lock()
+	c.ctx = ctx
+	c.mutex.Unlock()
+	namespaceInformer, err := c.clusterCache.GetInformer(ctx, &corev1.Namespace{})
+	if err != nil {
+		return err
+	}
+	namespaceInformer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: c.onNamespace,
+		UpdateFunc: func(_, newObj interface{}) {
+			c.onNamespace(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if key, err := toolscache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+				c.removeNamespace(key)
+			}
+		},
+	})
+	go func() {
+		if err := c.clusterCache.Start(ctx); err != nil {
+			log.Error(err, "Cluster scoped cache failed to start")
+		}
+	}()
+	<-ctx.Done()
+	return nil
+}
+
+func (c *namespaceSelectorCache) onNamespace(obj interface{}) {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+	if namespace.Status.Phase != corev1.NamespaceTerminating && c.selector.Matches(labels.Set(namespace.Labels)) {
+		c.addNamespace(namespace.Name)
+	} else {
+		c.removeNamespace(namespace.Name)
+	}
+}
+
+func (c *namespaceSelectorCache) addNamespace(namespace string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, exists := c.namespaces[namespace]; exists {
+		return
+	}
+	namespaceCache, err := c.newCache(namespace)
+	if err != nil {
+		log.Error(err, "Failed to create cache", "namespace", namespace)
+		return
+	}
+	// the indexes and informers must be registered before the cache starts
+	for _, index := range c.indexes {
+		if err = namespaceCache.IndexField(c.ctx, index.obj, index.field, index.extractValue); err != nil {
+			log.Error(err, "Failed to index field", "namespace", namespace, "field", index.field)
+			return
+		}
+	}
+	for _, informer := range c.informers {
+		namespaceInformer, err := namespaceCache.GetInformer(c.ctx, informer.newObject())
+		if err == nil {
+			err = informer.addNamespace(namespace, namespaceInformer)
+		}
+		if err != nil {
+			log.Error(err, "Failed to create informer", "namespace", namespace)
+			return
+		}
+	}
+	ctx, cancel := context.WithCancel(c.ctx)
+	c.namespaces[namespace] = &watchedNamespace{Cache: namespaceCache, cancel: cancel}
+	go func() {
+		if err := namespaceCache.Start(ctx); err != nil {
+			log.Error(err, "Namespace cache failed to start", "namespace", namespace)
+		}
+	}()
+	log.Info("Watching namespace", "namespace", namespace)
+}
+
+func (c *namespaceSelectorCache) removeNamespace(namespace string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	watched, exists := c.namespaces[namespace]
+	if !exists {
+		return
+	}
+	watched.cancel()
+	delete(c.namespaces, namespace)
+	for _, informer := range c.informers {
+		informer.removeNamespace(namespace)
+	}
+	log.Info("Stopped watching namespace", "namespace", namespace)
+}
+
+// WaitForCacheSync adds the namespaces matching the selector once the namespaces are synced, then waits for their caches
+func (c *namespaceSelectorCache) WaitForCacheSync(ctx context.Context) bool {
+	if !c.clusterCache.WaitForCacheSync(ctx) {
+		return false
+	}
+	namespaces := &corev1.NamespaceList{}
+	if err := c.clusterCache.List(ctx, namespaces); err != nil {
+		log.Error(err, "Failed to list namespaces")
+		return false
+	}
+	for i := range namespaces.Items {
+		c.onNamespace(&namespaces.Items[i])
+	}
+	c.mutex.RLock()
+	caches := make([]cache.Cache, 0, len(c.namespaces))
+	for _, namespaceCache := range c.namespaces {
+		caches = append(caches, namespaceCache)
+	}
+	c.mutex.RUnlock()
+	synced := true
+	for _, namespaceCache := range caches {
+		if !namespaceCache.WaitForCacheSync(ctx) {
+			synced = false
+		}
+	}
+	return synced
+}
+
+func (c *namespaceSelectorCache) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	namespaced, err := c.isObjectNamespaced(obj)
+	if err != nil {
+		return err
+	}
+	if !namespaced {
+		return c.clusterCache.IndexField(ctx, obj, field, extractValue)
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.indexes = append(c.indexes, fieldIndex{obj: obj, field: field, extractValue: extractValue})
+	for _, namespaceCache := range c.namespaces {
+		if err = namespaceCache.IndexField(ctx, obj, field, extractValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *namespaceSelectorCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	namespaced, err := c.isObjectNamespaced(obj)
+	if err != nil {
+		return err
+	}
+	if !namespaced {
+		return c.clusterCache.Get(ctx, key, obj)
+	}
+	c.mutex.RLock()
+	namespaceCache, exists := c.namespaces[key.Namespace]
+	c.mutex.RUnlock()
+	if !exists {
+		return fmt.Errorf("unable to get %v, namespace %s isn't watched", key, key.Namespace)
+	}
+	return namespaceCache.Get(ctx, key, obj)
+}
+
+func (c *namespaceSelectorCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	namespaced, err := c.isObjectNamespaced(list)
+	if err != nil {
+		return err
+	}
+	if !namespaced {
+		return c.clusterCache.List(ctx, list, opts...)
+	}
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if listOpts.Namespace != corev1.NamespaceAll {
+		namespaceCache, exists := c.namespaces[listOpts.Namespace]
+		if !exists {
+			return fmt.Errorf("unable to list, namespace %s isn't watched", listOpts.Namespace)
+		}
+		return namespaceCache.List(ctx, list, opts...)
+	}
+	var allItems []runtime.Object
+	for _, namespaceCache := range c.namespaces {
+		namespaceList := list.DeepCopyObject().(client.ObjectList)
+		if err = namespaceCache.List(ctx, namespaceList, opts...); err != nil {
+			return err
+		}
+		items, err := apimeta.ExtractList(namespaceList)
+		if err != nil {
+			return err
+		}
+		allItems = append(allItems, items...)
+	}
+	return apimeta.SetList(list, allItems)
+}
+
+// selectorInformer dispatches the handlers and indexers to the informers of the watched namespaces,
+// they're recorded to be added to the informers of the namespaces watched later
+type selectorInformer struct {
+	prototype client.Object
+
+	mutex     sync.RWMutex
+	informers map[string]cache.Informer
+	handlers  []handlerRegistration
+	indexers  []toolscache.Indexers
+}
+
+type handlerRegistration struct {
+	handler      toolscache.ResourceEventHandler
+	resyncPeriod *time.Duration
+}
+
+var _ cache.Informer = &selectorInformer{}
+
+func newSelectorInformer(prototype client.Object) *selectorInformer {
+	return &selectorInformer{prototype: prototype, informers: map[string]cache.Informer{}}
+}
+
+func (i *selectorInformer) newObject() client.Object {
+	return i.prototype.DeepCopyObject().(client.Object)
+}
+
+func (i *selectorInformer) addNamespace(namespace string, informer cache.Informer) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	for _, indexers := range i.indexers {
+		if err := informer.AddIndexers(indexers); err != nil {
+			return err
+		}
+	}
+	for _, registration := range i.handlers {
+		registration.addTo(informer)
+	}
+	i.informers[namespace] = informer
+	return nil
+}
+
+func (i *selectorInformer) removeNamespace(namespace string) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	delete(i.informers, namespace)
+}
+
+func (r handlerRegistration) addTo(informer cache.Informer) {
+	if r.resyncPeriod != nil {
+		informer.AddEventHandlerWithResyncPeriod(r.handler, *r.resyncPeriod)
+	} else {
+		informer.AddEventHandler(r.handler)
+	}
+}
+
+func (i *selectorInformer) addHandler(registration handlerRegistration) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.handlers = append(i.handlers, registration)
+	for _, informer := range i.informers {
+		registration.addTo(informer)
+	}
+}
+
+// AddEventHandler adds the handler to the informers of the current and future namespaces
+func (i *selectorInformer) AddEventHandler(handler toolscache.ResourceEventHandler) {
+	i.addHandler(handlerRegistration{handler: handler})
+}
+
+// AddEventHandlerWithResyncPeriod adds the handler to the informers of the current and future namespaces
+func (i *selectorInformer) AddEventHandlerWithResyncPeriod(handler toolscache.ResourceEventHandler, resyncPeriod time.Duration) {
+	i.addHandler(handlerRegistration{handler: handler, resyncPeriod: &resyncPeriod})
+}
+
+// AddIndexers adds the indexers to the informers of the current and future namespaces
+func (i *selectorInformer) AddIndexers(indexers toolscache.Indexers) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.indexers = append(i.indexers, indexers)
+	for _, informer := range i.informers {
+		if err := informer.AddIndexers(indexers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasSynced returns true if the informers of all the watched namespaces are synced
+func (i *selectorInformer) HasSynced() bool {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+	for _, informer := range i.informers {
+		if !informer.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
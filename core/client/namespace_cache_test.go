@@ -0,0 +1,113 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newTestNamespaceSelectorCache(caches map[string]*informertest.FakeInformers) *namespaceSelectorCache {
+	mapper := apimeta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), apimeta.RESTScopeRoot)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), apimeta.RESTScopeNamespace)
+	newCache := func(namespace string) (cache.Cache, error) {
+		caches[namespace] = &informertest.FakeInformers{Scheme: scheme.Scheme}
+		return caches[namespace], nil
+	}
+	selector, _ := labels.Parse("tenant=a")
+	return newNamespaceSelectorCacheWith(&informertest.FakeInformers{Scheme: scheme.Scheme}, newCache, scheme.Scheme, mapper, selector)
+}
+
+func TestNamespaceSelectorCache(t *testing.T) {
+	caches := map[string]*informertest.FakeInformers{}
+	namespaceCache := newTestNamespaceSelectorCache(caches)
+
+	informer, err := namespaceCache.GetInformer(context.TODO(), &corev1.ConfigMap{})
+	assert.NoError(t, err)
+	var added []string
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{AddFunc: func(obj interface{}) {
+		added = append(added, obj.(*corev1.ConfigMap).Namespace)
+	}})
+
+	// the informers and handlers are replayed on the namespaces matching the selector
+	teamA := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"tenant": "a"}}}
+	namespaceCache.onNamespace(teamA)
+	namespaceCache.onNamespace(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}})
+	assert.True(t, namespaceCache.isNamespaceWatched("team-a"))
+	assert.False(t, namespaceCache.isNamespaceWatched("team-b"))
+	assert.NotContains(t, caches, "team-b")
+
+	teamAInformer, err := caches["team-a"].FakeInformerFor(&corev1.ConfigMap{})
+	assert.NoError(t, err)
+	teamAInformer.Add(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "team-a"}})
+	assert.Equal(t, []string{"team-a"}, added)
+	assert.NoError(t, namespaceCache.Get(context.TODO(), types.NamespacedName{Name: "config", Namespace: "team-a"}, &corev1.ConfigMap{}))
+
+	// the namespace is no longer watched once its labels don't match
+	teamA = teamA.DeepCopy()
+	teamA.Labels = nil
+	namespaceCache.onNamespace(teamA)
+	assert.False(t, namespaceCache.isNamespaceWatched("team-a"))
+	assert.Error(t, namespaceCache.Get(context.TODO(), types.NamespacedName{Name: "config", Namespace: "team-a"}, &corev1.ConfigMap{}))
+	assert.True(t, informer.HasSynced())
+
+	// cluster scoped objects are read from the cluster cache
+	assert.NoError(t, namespaceCache.Get(context.TODO(), types.NamespacedName{Name: "team-a"}, &corev1.Namespace{}))
+}
+
+type blockingInformers struct {
+	*informertest.FakeInformers
+	unblock chan struct{}
+}
+
+func (b *blockingInformers) GetInformer(ctx context.Context, obj client.Object) (cache.Informer, error) {
+	<-b.unblock
+	return b.FakeInformers.GetInformer(ctx, obj)
+}
+
+func TestNamespaceSelectorCache_GetInformerDoesNotBlockLookups(t *testing.T) {
+	namespaceCache := newTestNamespaceSelectorCache(map[string]*informertest.FakeInformers{})
+	blocking := &blockingInformers{FakeInformers: &informertest.FakeInformers{Scheme: scheme.Scheme}, unblock: make(chan struct{})}
+	namespaceCache.newCache = func(namespace string) (cache.Cache, error) {
+		return blocking, nil
+	}
+	namespaceCache.onNamespace(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"tenant": "a"}}})
+
+	// the informer of a started namespace cache isn't returned until it's synced
+	done := make(chan error)
+	go func() {
+		_, err := namespaceCache.GetInformer(context.TODO(), &corev1.ConfigMap{})
+		done <- err
+	}()
+	assert.True(t, namespaceCache.isNamespaceWatched("team-a"))
+	namespaceCache.onNamespace(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}})
+	assert.False(t, namespaceCache.isNamespaceWatched("team-b"))
+
+	close(blocking.unblock)
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,183 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// WatchNamespaceEnvVar is the comma separated list of namespaces watched by the operator, all namespaces when empty
+	WatchNamespaceEnvVar = "WATCH_NAMESPACE"
+	// WatchNamespaceSelectorEnvVar is the label selector of the namespaces watched by the operator, evaluated while the namespaces change
+	WatchNamespaceSelectorEnvVar = "WATCH_NAMESPACE_SELECTOR"
+)
+
+// WatchMode defines how the operator selects the namespaces it watches
+type WatchMode string
+
+const (
+	// AllNamespacesWatchMode the operator watches the whole cluster
+	AllNamespacesWatchMode WatchMode = "AllNamespaces"
+	// SingleNamespaceWatchMode the operator watches one namespace
+	SingleNamespaceWatchMode WatchMode = "SingleNamespace"
+	// MultiNamespaceWatchMode the operator watches a fixed list of namespaces
+	MultiNamespaceWatchMode WatchMode = "MultiNamespace"
+	// SelectorWatchMode the operator watches the namespaces matching a label selector
+	SelectorWatchMode WatchMode = "Selector"
+)
+
+// NamespaceFilter tells whether the operator watches a namespace
+type NamespaceFilter interface {
+	IsNamespaceWatched(namespace string) bool
+}
+
+// WatchOptions defines the namespaces watched by the operator
+type WatchOptions struct {
+	// Namespaces watched by the operator, ignored when a Selector is given
+	Namespaces []string
+	// Selector of the watched namespaces
+	Selector labels.Selector
+
+	selectorCache *namespaceSelectorCache
+}
+
+// NewWatchOptions parses a comma separated list of namespaces and a namespace label selector, the selector wins if both are given
+func NewWatchOptions(namespaces, selector string) (*WatchOptions, error) {
+	options := &WatchOptions{}
+	if len(strings.TrimSpace(selector)) > 0 {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace selector %q: %v", selector, err)
+		}
+		options.Selector = parsed
+		return options, nil
+	}
+	for _, namespace := range strings.Split(namespaces, ",") {
+		if namespace = strings.TrimSpace(namespace); len(namespace) > 0 {
+			options.Namespaces = append(options.Namespaces, namespace)
+		}
+	}
+	return options, nil
+}
+
+// GetMode returns how the namespaces are selected
+func (w *WatchOptions) GetMode() WatchMode {
+	switch {
+	case w.Selector != nil:
+		return SelectorWatchMode
+	case len(w.Namespaces) == 1:
+		return SingleNamespaceWatchMode
+	case len(w.Namespaces) > 1:
+		return MultiNamespaceWatchMode
+	}
+	return AllNamespacesWatchMode
+}
+
+// IsClusterScoped returns true if the operator reads cluster scoped objects, the operator watching a single namespace
+// or a list of namespaces only gets namespaced roles, so it doesn't watch the namespaces nor the CustomResourceDefinitions
+func (w *WatchOptions) IsClusterScoped() bool {
+	mode := w.GetMode()
+	return mode == AllNamespacesWatchMode || mode == SelectorWatchMode
+}
+
+// ApplyToManager scopes the cache and the client of the manager to the watched namespaces
+func (w *WatchOptions) ApplyToManager(options *controllerruntime.Options) {
+	switch w.GetMode() {
+	case SingleNamespaceWatchMode:
+		options.Namespace = w.Namespaces[0]
+	case MultiNamespaceWatchMode:
+		options.NewCache = cache.MultiNamespacedCacheBuilder(w.Namespaces)
+	case SelectorWatchMode:
+		options.NewCache = func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+			selectorCache, err := newNamespaceSelectorCache(config, opts, w.Selector)
+			if err != nil {
+				return nil, err
+			}
+			w.selectorCache = selectorCache
+			return selectorCache, nil
+		}
+	}
+}
+
+// IsNamespaceWatched returns true if the operator watches the given namespace, the cluster scoped objects are always watched
+func (w *WatchOptions) IsNamespaceWatched(namespace string) bool {
+	switch w.GetMode() {
+	case AllNamespacesWatchMode:
+		return true
+	case SelectorWatchMode:
+		return len(namespace) == 0 || (w.selectorCache != nil && w.selectorCache.isNamespaceWatched(namespace))
+	}
+	if len(namespace) == 0 {
+		return true
+	}
+	for _, watched := range w.Namespaces {
+		if watched == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the watched namespaces
+func (w *WatchOptions) String() string {
+	switch w.GetMode() {
+	case SelectorWatchMode:
+		return fmt.Sprintf("namespaces matching %q", w.Selector.String())
+	case AllNamespacesWatchMode:
+		return "all namespaces"
+	}
+	return fmt.Sprintf("namespaces %s", strings.Join(w.Namespaces, ","))
+}
+
+// namespaceFilteredClient reads the watched namespaces from the cache and the others straight from the API server,
+// e.g. the infrastructure deployed in a shared namespace, so these reads don't fail for unknown namespaces in the cache
+type namespaceFilteredClient struct {
+	client.Client
+	apiReader client.Reader
+	filter    NamespaceFilter
+}
+
+func newNamespaceFilteredClient(cachedClient client.Client, apiReader client.Reader, filter NamespaceFilter) client.Client {
+	if filter == nil {
+		return cachedClient
+	}
+	return &namespaceFilteredClient{Client: cachedClient, apiReader: apiReader, filter: filter}
+}
+
+func (c *namespaceFilteredClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if c.filter.IsNamespaceWatched(key.Namespace) {
+		return c.Client.Get(ctx, key, obj)
+	}
+	return c.apiReader.Get(ctx, key, obj)
+}
+
+func (c *namespaceFilteredClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOptions := &client.ListOptions{}
+	listOptions.ApplyOptions(opts)
+	if listOptions.Namespace == corev1.NamespaceAll || c.filter.IsNamespaceWatched(listOptions.Namespace) {
+		return c.Client.List(ctx, list, opts...)
+	}
+	return c.apiReader.List(ctx, list, opts...)
+}
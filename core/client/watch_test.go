@@ -0,0 +1,99 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	controllerruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewWatchOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		namespaces    string
+		selector      string
+		mode          WatchMode
+		watched       []string
+		notWatched    []string
+		clusterScoped bool
+		wantErr       bool
+	}{
+		{name: "All namespaces", mode: AllNamespacesWatchMode, watched: []string{"any"}, clusterScoped: true},
+		{name: "Single namespace", namespaces: "team-a", mode: SingleNamespaceWatchMode, watched: []string{"team-a", ""}, notWatched: []string{"team-b"}},
+		{name: "Multi namespace", namespaces: "team-a, team-b,", mode: MultiNamespaceWatchMode, watched: []string{"team-a", "team-b"}, notWatched: []string{"team-c"}},
+		{name: "Selector", namespaces: "team-a", selector: "tenant=a", mode: SelectorWatchMode, watched: []string{""}, notWatched: []string{"team-a"}, clusterScoped: true},
+		{name: "Invalid selector", selector: "tenant in (", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := NewWatchOptions(tt.namespaces, tt.selector)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.mode, options.GetMode())
+			assert.Equal(t, tt.clusterScoped, options.IsClusterScoped())
+			for _, namespace := range tt.watched {
+				assert.True(t, options.IsNamespaceWatched(namespace), namespace)
+			}
+			for _, namespace := range tt.notWatched {
+				assert.False(t, options.IsNamespaceWatched(namespace), namespace)
+			}
+		})
+	}
+}
+
+func TestWatchOptions_ApplyToManager(t *testing.T) {
+	single, _ := NewWatchOptions("team-a", "")
+	managerOptions := controllerruntime.Options{}
+	single.ApplyToManager(&managerOptions)
+	assert.Equal(t, "team-a", managerOptions.Namespace)
+	assert.Nil(t, managerOptions.NewCache)
+
+	multi, _ := NewWatchOptions("team-a,team-b", "")
+	managerOptions = controllerruntime.Options{}
+	multi.ApplyToManager(&managerOptions)
+	assert.Empty(t, managerOptions.Namespace)
+	assert.NotNil(t, managerOptions.NewCache)
+
+	selector, _ := NewWatchOptions("", "tenant=a")
+	managerOptions = controllerruntime.Options{}
+	selector.ApplyToManager(&managerOptions)
+	assert.NotNil(t, managerOptions.NewCache)
+}
+
+func TestNamespaceFilteredClient(t *testing.T) {
+	cached := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "team-a"}}
+	shared := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "shared"}}
+	options, _ := NewWatchOptions("team-a", "")
+	cli := newNamespaceFilteredClient(fake.NewClientBuilder().WithObjects(cached).Build(), fake.NewClientBuilder().WithObjects(shared).Build(), options)
+
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Name: "config", Namespace: "team-a"}, &corev1.ConfigMap{}))
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Name: "config", Namespace: "shared"}, &corev1.ConfigMap{}))
+
+	list := &corev1.ConfigMapList{}
+	assert.NoError(t, cli.List(context.TODO(), list, &controllerruntimeclient.ListOptions{Namespace: "shared"}))
+	assert.Len(t, list.Items, 1)
+	assert.Equal(t, "shared", list.Items[0].Namespace)
+}
@@ -0,0 +1,121 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventtopology
+
+import (
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/manager"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Builder builds the event topology of the Kogito services of a namespace from the CloudEvents reported in their status
+type Builder interface {
+	Build(namespace string) (*Graph, error)
+}
+
+type builder struct {
+	operator.Context
+	runtimeHandler           manager.KogitoRuntimeHandler
+	supportingServiceHandler manager.KogitoSupportingServiceHandler
+	infraHandler             manager.KogitoInfraHandler
+}
+
+// NewBuilder ...
+func NewBuilder(context operator.Context, runtimeHandler manager.KogitoRuntimeHandler, supportingServiceHandler manager.KogitoSupportingServiceHandler, infraHandler manager.KogitoInfraHandler) Builder {
+	return &builder{
+		Context:                  context,
+		runtimeHandler:           runtimeHandler,
+		supportingServiceHandler: supportingServiceHandler,
+		infraHandler:             infraHandler,
+	}
+}
+
+func (b *builder) Build(namespace string) (*Graph, error) {
+	services, err := b.fetchServices(namespace)
+	if err != nil {
+		return nil, err
+	}
+	graph := NewGraph()
+	for _, service := range services {
+		cloudEvents := service.GetStatus().GetCloudEvents()
+		if cloudEvents == nil {
+			continue
+		}
+		broker, err := b.resolveKnativeBroker(service)
+		if err != nil {
+			return nil, err
+		}
+		for _, produced := range cloudEvents.GetProduces() {
+			graph.AddProducer(produced.GetType(), newEndpoint(service, produced, broker))
+		}
+		for _, consumed := range cloudEvents.GetConsumes() {
+			graph.AddConsumer(consumed.GetType(), newEndpoint(service, consumed, broker))
+		}
+	}
+	return graph, nil
+}
+
+func (b *builder) fetchServices(namespace string) ([]api.KogitoService, error) {
+	var services []api.KogitoService
+	runtimes, err := b.runtimeHandler.FetchAllKogitoRuntimeInstances(namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, runtime := range runtimes.GetItems() {
+		services = append(services, runtime)
+	}
+	supportingServices, err := b.supportingServiceHandler.FetchKogitoSupportingServiceList(namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, supportingService := range supportingServices.GetItems() {
+		services = append(services, supportingService)
+	}
+	return services, nil
+}
+
+// resolveKnativeBroker returns the name of the Knative Eventing Broker bound to the given service, empty when there's none
+func (b *builder) resolveKnativeBroker(service api.KogitoService) (string, error) {
+	for _, infraName := range service.GetSpec().GetInfra() {
+		infra, err := b.infraHandler.FetchKogitoInfraInstance(types.NamespacedName{Name: infraName, Namespace: service.GetNamespace()})
+		if err != nil {
+			return "", err
+		}
+		if infra == nil || infra.GetSpec().IsResourceEmpty() {
+			continue
+		}
+		resource := infra.GetSpec().GetResource()
+		if infrastructure.IsKnativeEventingResource(resource.GetAPIVersion(), resource.GetKind()) {
+			return resource.GetName(), nil
+		}
+	}
+	return "", nil
+}
+
+// newEndpoint creates the endpoint of the given service for the given event.
+// Events go through the Knative Eventing Broker bound to the service, else through the Kafka topic reported by the service.
+func newEndpoint(service api.KogitoService, info api.KogitoCloudEventInfoInterface, broker string) Endpoint {
+	endpoint := Endpoint{Service: service.GetName(), Source: info.GetSource()}
+	if len(broker) > 0 {
+		endpoint.ChannelKind = api.KnativeBrokerEventChannel
+		endpoint.Channel = broker
+	} else if len(info.GetTopic()) > 0 {
+		endpoint.ChannelKind = api.KafkaTopicEventChannel
+		endpoint.Channel = info.GetTopic()
+	}
+	return endpoint
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventtopology
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/internal/app"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestRuntime(namespace, name string, produces, consumes []v1beta1.KogitoCloudEventInfo, infra ...string) *v1beta1.KogitoRuntime {
+	runtime := test.CreateFakeKogitoRuntime(namespace)
+	runtime.Name = name
+	runtime.Spec.Infra = infra
+	runtime.Status.CloudEvents = v1beta1.KogitoCloudEventsStatus{Produces: produces, Consumes: consumes}
+	return runtime
+}
+
+func Test_builder_Build(t *testing.T) {
+	ns := t.Name()
+	knativeInfra := test.CreateFakeKogitoKnative(ns).(*v1beta1.KogitoInfra)
+	knativeInfra.Spec.Resource.Name = "default"
+	travels := newTestRuntime(ns, "travels",
+		[]v1beta1.KogitoCloudEventInfo{{Type: "travels", Source: "/travels", Topic: "kogito-travels"}, {Type: "audits", Topic: "kogito-audits"}},
+		nil)
+	visas := newTestRuntime(ns, "visas",
+		nil,
+		[]v1beta1.KogitoCloudEventInfo{{Type: "travels"}, {Type: "approvals"}},
+		knativeInfra.Name)
+	cli := test.NewFakeClientBuilder().AddK8sObjects(travels, visas, knativeInfra).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+
+	graph, err := NewBuilder(context, app.NewKogitoRuntimeHandler(context), app.NewKogitoSupportingServiceHandler(context), app.NewKogitoInfraHandler(context)).Build(ns)
+	assert.NoError(t, err)
+
+	events := graph.GetEvents()
+	assert.Len(t, events, 3)
+	assert.Equal(t, "travels", events[2].Type)
+	assert.Equal(t, Endpoint{Service: "travels", Source: "/travels", ChannelKind: api.KafkaTopicEventChannel, Channel: "kogito-travels"}, events[2].Producers[0])
+	assert.Equal(t, Endpoint{Service: "visas", ChannelKind: api.KnativeBrokerEventChannel, Channel: "default"}, events[2].Consumers[0])
+	assert.Equal(t, []string{"approvals"}, graph.GetOrphanConsumers())
+	assert.Equal(t, []string{"audits"}, graph.GetUnconsumedProducers())
+}
+
+func Test_builder_BuildWithoutServices(t *testing.T) {
+	cli := test.NewFakeClientBuilder().AddK8sObjects(&v1beta1.KogitoInfra{ObjectMeta: v1.ObjectMeta{Name: "kafka", Namespace: t.Name()}}).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+
+	graph, err := NewBuilder(context, app.NewKogitoRuntimeHandler(context), app.NewKogitoSupportingServiceHandler(context), app.NewKogitoInfraHandler(context)).Build(t.Name())
+	assert.NoError(t, err)
+	assert.Empty(t, graph.GetEvents())
+}
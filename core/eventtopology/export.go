@@ -0,0 +1,136 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventtopology
+
+import (
+	"fmt"
+	"strings"
+)
+
+// node is either a Kogito service or the channel through which an event is exchanged.
+// Events exchanged without a known channel get a node of their own, named after their type.
+type node struct {
+	id      string
+	name    string
+	kind    string
+	service bool
+}
+
+// edge links a producer to a channel or a channel to a consumer. Flagged edges belong to orphan consumers or unconsumed producers.
+type edge struct {
+	from    *node
+	to      *node
+	label   string
+	flagged bool
+}
+
+type layout struct {
+	nodes   []*node
+	edges   []*edge
+	nodeIDs map[string]*node
+}
+
+func (l *layout) getOrCreateNode(key, name, kind string, service bool) *node {
+	if n, exists := l.nodeIDs[key]; exists {
+		return n
+	}
+	n := &node{id: fmt.Sprintf("n%d", len(l.nodes)), name: name, kind: kind, service: service}
+	l.nodes = append(l.nodes, n)
+	l.nodeIDs[key] = n
+	return n
+}
+
+func (l *layout) serviceNode(service string) *node {
+	return l.getOrCreateNode("service/"+service, service, "", true)
+}
+
+func (l *layout) channelNode(eventType string, endpoint Endpoint) *node {
+	if len(endpoint.Channel) == 0 {
+		return l.getOrCreateNode("event/"+eventType, eventType, "", false)
+	}
+	return l.getOrCreateNode(fmt.Sprintf("%s/%s", endpoint.ChannelKind, endpoint.Channel), endpoint.Channel, string(endpoint.ChannelKind), false)
+}
+
+func (g *Graph) layout() *layout {
+	l := &layout{nodeIDs: map[string]*node{}}
+	for _, event := range g.GetEvents() {
+		for _, producer := range event.Producers {
+			from := l.serviceNode(producer.Service)
+			l.edges = append(l.edges, &edge{from: from, to: l.channelNode(event.Type, producer), label: event.Type, flagged: event.IsUnconsumed()})
+		}
+		for _, consumer := range event.Consumers {
+			to := l.serviceNode(consumer.Service)
+			l.edges = append(l.edges, &edge{from: l.channelNode(event.Type, consumer), to: to, label: event.Type, flagged: event.IsOrphanConsumed()})
+		}
+	}
+	return l
+}
+
+// DOT renders the graph in the Graphviz DOT language.
+// Services are boxes, channels are ellipses and the edges of orphan consumers and unconsumed producers are dashed in red.
+func (g *Graph) DOT() string {
+	l := g.layout()
+	b := &strings.Builder{}
+	b.WriteString("digraph kogito_events {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, n := range l.nodes {
+		if n.service {
+			fmt.Fprintf(b, "  %s [shape=box, label=%q];\n", n.id, n.name)
+		} else if len(n.kind) > 0 {
+			fmt.Fprintf(b, "  %s [shape=ellipse, label=%q];\n", n.id, fmt.Sprintf("%s\n(%s)", n.name, n.kind))
+		} else {
+			fmt.Fprintf(b, "  %s [shape=ellipse, label=%q];\n", n.id, n.name)
+		}
+	}
+	for _, e := range l.edges {
+		if e.flagged {
+			fmt.Fprintf(b, "  %s -> %s [label=%q, style=dashed, color=red];\n", e.from.id, e.to.id, e.label)
+		} else {
+			fmt.Fprintf(b, "  %s -> %s [label=%q];\n", e.from.id, e.to.id, e.label)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the graph as a Mermaid flowchart.
+// Services are rectangles, channels are stadiums and the edges of orphan consumers and unconsumed producers are dotted.
+func (g *Graph) Mermaid() string {
+	l := g.layout()
+	b := &strings.Builder{}
+	b.WriteString("flowchart LR\n")
+	for _, n := range l.nodes {
+		if n.service {
+			fmt.Fprintf(b, "  %s[\"%s\"]\n", n.id, escapeMermaid(n.name))
+		} else if len(n.kind) > 0 {
+			fmt.Fprintf(b, "  %s([\"%s<br/>(%s)\"])\n", n.id, escapeMermaid(n.name), n.kind)
+		} else {
+			fmt.Fprintf(b, "  %s([\"%s\"])\n", n.id, escapeMermaid(n.name))
+		}
+	}
+	for _, e := range l.edges {
+		if e.flagged {
+			fmt.Fprintf(b, "  %s -. \"%s\" .-> %s\n", e.from.id, escapeMermaid(e.label), e.to.id)
+		} else {
+			fmt.Fprintf(b, "  %s -- \"%s\" --> %s\n", e.from.id, escapeMermaid(e.label), e.to.id)
+		}
+	}
+	return b.String()
+}
+
+// escapeMermaid escapes the quotes that would end a Mermaid label
+func escapeMermaid(label string) string {
+	return strings.ReplaceAll(label, "\"", "#quot;")
+}
@@ -0,0 +1,170 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventtopology
+
+import (
+	"sort"
+
+	"github.com/kiegroup/kogito-operator/apis"
+)
+
+// Endpoint is a Kogito service producing or consuming a CloudEvent through a channel
+type Endpoint struct {
+	Service     string
+	Source      string
+	ChannelKind api.KogitoEventChannelKind
+	Channel     string
+}
+
+// GetService ...
+func (e Endpoint) GetService() string {
+	return e.Service
+}
+
+// GetSource ...
+func (e Endpoint) GetSource() string {
+	return e.Source
+}
+
+// GetChannelKind ...
+func (e Endpoint) GetChannelKind() api.KogitoEventChannelKind {
+	return e.ChannelKind
+}
+
+// GetChannel ...
+func (e Endpoint) GetChannel() string {
+	return e.Channel
+}
+
+// Event is a CloudEvent type with the Kogito services producing and consuming it
+type Event struct {
+	Type      string
+	Producers []Endpoint
+	Consumers []Endpoint
+}
+
+// GetType ...
+func (e *Event) GetType() string {
+	return e.Type
+}
+
+// GetProducers ...
+func (e *Event) GetProducers() []api.KogitoEventEndpointInterface {
+	return toEndpointInterfaces(e.Producers)
+}
+
+// GetConsumers ...
+func (e *Event) GetConsumers() []api.KogitoEventEndpointInterface {
+	return toEndpointInterfaces(e.Consumers)
+}
+
+// IsOrphanConsumed returns true when the event is consumed by a service but produced by none
+func (e *Event) IsOrphanConsumed() bool {
+	return len(e.Producers) == 0 && len(e.Consumers) > 0
+}
+
+// IsUnconsumed returns true when the event is produced by a service but consumed by none
+func (e *Event) IsUnconsumed() bool {
+	return len(e.Consumers) == 0 && len(e.Producers) > 0
+}
+
+func toEndpointInterfaces(endpoints []Endpoint) []api.KogitoEventEndpointInterface {
+	result := make([]api.KogitoEventEndpointInterface, len(endpoints))
+	for i, endpoint := range endpoints {
+		result[i] = endpoint
+	}
+	return result
+}
+
+// Graph is the topology of the CloudEvents exchanged by the Kogito services of a namespace
+type Graph struct {
+	events map[string]*Event
+}
+
+// NewGraph creates an empty Graph
+func NewGraph() *Graph {
+	return &Graph{events: map[string]*Event{}}
+}
+
+// AddProducer adds the given endpoint as a producer of the given CloudEvent type
+func (g *Graph) AddProducer(eventType string, producer Endpoint) {
+	event := g.getOrCreateEvent(eventType)
+	event.Producers = append(event.Producers, producer)
+}
+
+// AddConsumer adds the given endpoint as a consumer of the given CloudEvent type
+func (g *Graph) AddConsumer(eventType string, consumer Endpoint) {
+	event := g.getOrCreateEvent(eventType)
+	event.Consumers = append(event.Consumers, consumer)
+}
+
+func (g *Graph) getOrCreateEvent(eventType string) *Event {
+	event, exists := g.events[eventType]
+	if !exists {
+		event = &Event{Type: eventType}
+		g.events[eventType] = event
+	}
+	return event
+}
+
+// GetEvents returns the events sorted by type, so the graph is always rendered the same way
+func (g *Graph) GetEvents() []*Event {
+	events := make([]*Event, 0, len(g.events))
+	for _, event := range g.events {
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Type < events[j].Type
+	})
+	return events
+}
+
+// GetEventInterfaces returns the events sorted by type as API interfaces
+func (g *Graph) GetEventInterfaces() []api.KogitoEventTopologyEventInterface {
+	var events []api.KogitoEventTopologyEventInterface
+	for _, event := range g.GetEvents() {
+		events = append(events, event)
+	}
+	return events
+}
+
+// GetOrphanConsumers returns the types of the events consumed but not produced by any service
+func (g *Graph) GetOrphanConsumers() []string {
+	var types []string
+	for _, event := range g.GetEvents() {
+		if event.IsOrphanConsumed() {
+			types = append(types, event.Type)
+		}
+	}
+	return types
+}
+
+// GetUnconsumedProducers returns the types of the events produced but not consumed by any service
+func (g *Graph) GetUnconsumedProducers() []string {
+	var types []string
+	for _, event := range g.GetEvents() {
+		if event.IsUnconsumed() {
+			types = append(types, event.Type)
+		}
+	}
+	return types
+}
+
+// SetStatus sets the events of the graph, its orphan consumers and unconsumed producers in the given status
+func (g *Graph) SetStatus(status api.KogitoEventTopologyStatusInterface) {
+	status.SetEvents(g.GetEventInterfaces())
+	status.SetOrphanConsumers(g.GetOrphanConsumers())
+	status.SetUnconsumedProducers(g.GetUnconsumedProducers())
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventtopology
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGraph() *Graph {
+	graph := NewGraph()
+	graph.AddProducer("travels", Endpoint{Service: "travels", ChannelKind: api.KafkaTopicEventChannel, Channel: "kogito-travels"})
+	graph.AddConsumer("travels", Endpoint{Service: "visas", ChannelKind: api.KafkaTopicEventChannel, Channel: "kogito-travels"})
+	graph.AddConsumer("approvals", Endpoint{Service: "visas", ChannelKind: api.KafkaTopicEventChannel, Channel: "kogito-approvals"})
+	graph.AddProducer("audits", Endpoint{Service: "travels"})
+	return graph
+}
+
+func TestGraph_GetEvents(t *testing.T) {
+	events := newTestGraph().GetEvents()
+	assert.Len(t, events, 3)
+	assert.Equal(t, "approvals", events[0].Type)
+	assert.Equal(t, "audits", events[1].Type)
+	assert.Equal(t, "travels", events[2].Type)
+	assert.Len(t, events[2].Producers, 1)
+	assert.Len(t, events[2].Consumers, 1)
+	assert.Equal(t, "visas", events[2].Consumers[0].Service)
+}
+
+func TestGraph_FlaggedEvents(t *testing.T) {
+	graph := newTestGraph()
+	assert.Equal(t, []string{"approvals"}, graph.GetOrphanConsumers())
+	assert.Equal(t, []string{"audits"}, graph.GetUnconsumedProducers())
+}
+
+func TestGraph_SetStatus(t *testing.T) {
+	status := &v1beta1.KogitoEventTopologyStatus{}
+	newTestGraph().SetStatus(status)
+	assert.Len(t, status.Events, 3)
+	assert.Equal(t, "travels", status.Events[2].Type)
+	assert.Equal(t, api.KafkaTopicEventChannel, status.Events[2].Producers[0].ChannelKind)
+	assert.Equal(t, "kogito-travels", status.Events[2].Producers[0].Channel)
+	assert.Equal(t, []string{"approvals"}, status.OrphanConsumers)
+	assert.Equal(t, []string{"audits"}, status.UnconsumedProducers)
+}
+
+func TestGraph_DOT(t *testing.T) {
+	dot := newTestGraph().DOT()
+	assert.Contains(t, dot, "digraph kogito_events {")
+	assert.Contains(t, dot, "[shape=box, label=\"travels\"]")
+	assert.Contains(t, dot, "[shape=ellipse, label=\"kogito-travels\\n(KafkaTopic)\"]")
+	assert.Contains(t, dot, "style=dashed, color=red")
+}
+
+func TestGraph_Mermaid(t *testing.T) {
+	mermaid := newTestGraph().Mermaid()
+	assert.Contains(t, mermaid, "flowchart LR")
+	assert.Contains(t, mermaid, "-- \"travels\" -->")
+	assert.Contains(t, mermaid, "-. \"approvals\" .->")
+}
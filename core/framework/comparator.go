@@ -28,6 +28,8 @@ import (
 	apps "k8s.io/api/apps/v1"
 
 	v1 "k8s.io/api/core/v1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 
 	"reflect"
 )
@@ -104,6 +106,17 @@ func (c *comparatorBuilder) Build() (reflect.Type, func(deployed client.Object,
 	return c.comparator.ResourceType, c.comparator.CompFunc
 }
 
+// containAllAnnotations checks that the deployed object has the requested annotations, eg: added by the patches of the CR
+func containAllAnnotations(deployed client.Object, requested client.Object) bool {
+	deployedAnnotations := deployed.GetAnnotations()
+	for key, value := range requested.GetAnnotations() {
+		if deployedAnnotations[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
 func containAllLabels(deployed client.Object, requested client.Object) bool {
 	deployedLabels := deployed.GetLabels()
 	requestedLabels := requested.GetLabels()
@@ -148,18 +161,56 @@ func CreateDeploymentConfigComparator() func(deployed client.Object, requested c
 	}
 }
 
-// CreateDeploymentComparator creates a new comparator for Deployment sorting volumes
+// CreateDeploymentComparator creates a new comparator for Deployment sorting volumes,
+// the replicas are ignored when they're not requested, eg: when they're managed by an autoscaler
 func CreateDeploymentComparator() func(deployed client.Object, requested client.Object) bool {
 	return func(deployed client.Object, requested client.Object) bool {
+		if requested.(*apps.Deployment).Spec.Replicas == nil {
+			deployed.(*apps.Deployment).Spec.Replicas = nil
+		}
 		sortVolumes(&deployed.(*apps.Deployment).Spec.Template.Spec)
 		sortVolumes(&requested.(*apps.Deployment).Spec.Template.Spec)
 		ignoreInjectedVariables(
 			&deployed.(*apps.Deployment).Spec.Template,
 			&requested.(*apps.Deployment).Spec.Template)
+		ignoreContainerDefaults(
+			&deployed.(*apps.Deployment).Spec.Template.Spec,
+			&requested.(*apps.Deployment).Spec.Template.Spec)
 		return true
 	}
 }
 
+// ignoreContainerDefaults clears the values defaulted by the API server on containers that are not set in the requested ones,
+// like the containers added through the pod template of the service spec
+func ignoreContainerDefaults(deployed *v1.PodSpec, requested *v1.PodSpec) {
+	ignoreContainersDefaults(deployed.Containers, requested.Containers)
+	ignoreContainersDefaults(deployed.InitContainers, requested.InitContainers)
+}
+
+func ignoreContainersDefaults(deployed []v1.Container, requested []v1.Container) {
+	for i := range requested {
+		for j := range deployed {
+			if deployed[j].Name != requested[i].Name {
+				continue
+			}
+			if len(requested[i].TerminationMessagePath) == 0 {
+				deployed[j].TerminationMessagePath = ""
+			}
+			if len(requested[i].TerminationMessagePolicy) == 0 {
+				deployed[j].TerminationMessagePolicy = ""
+			}
+			if len(requested[i].ImagePullPolicy) == 0 {
+				deployed[j].ImagePullPolicy = ""
+			}
+			for k := range requested[i].Ports {
+				if len(requested[i].Ports[k].Protocol) == 0 && k < len(deployed[j].Ports) {
+					deployed[j].Ports[k].Protocol = ""
+				}
+			}
+		}
+	}
+}
+
 // sortVolumes sorts the volumes of a given PodSpec (can be used either by a Deployment or DeploymentConfig objects)
 // TODO: open a PR to operatorutils fixing this once we verify KOGITO-2797
 func sortVolumes(pod *v1.PodSpec) {
@@ -204,7 +255,14 @@ func CreateRouteComparator() func(deployed client.Object, requested client.Objec
 		rtDeployed := deployed.(*routev1.Route)
 		rtRequested := requested.(*routev1.Route).DeepCopy()
 
-		return containAllLabels(rtDeployed, rtRequested)
+		if !containAllLabels(rtDeployed, rtRequested) {
+			return false
+		}
+		// weights are only set while the traffic is split between several backends
+		if rtRequested.Spec.To.Weight != nil && !reflect.DeepEqual(rtDeployed.Spec.To.Weight, rtRequested.Spec.To.Weight) {
+			return false
+		}
+		return reflect.DeepEqual(rtDeployed.Spec.AlternateBackends, rtRequested.Spec.AlternateBackends)
 	}
 }
 
@@ -278,12 +336,45 @@ func sortContainersByName(pod *v1.PodTemplateSpec) {
 	})
 }
 
-// CreateServiceMonitorComparator creates a new comparator for ServiceMonitor using Label
+// CreateServiceMonitorComparator creates a new comparator for ServiceMonitor using Label, Annotations and Spec
 func CreateServiceMonitorComparator() func(deployed client.Object, requested client.Object) bool {
 	return func(deployed client.Object, requested client.Object) bool {
 		smDeployed := deployed.(*monv1.ServiceMonitor)
 		smRequested := requested.(*monv1.ServiceMonitor).DeepCopy()
 
-		return containAllLabels(smDeployed, smRequested)
+		return containAllLabels(smDeployed, smRequested) &&
+			containAllAnnotations(smDeployed, smRequested) &&
+			reflect.DeepEqual(smDeployed.Spec, smRequested.Spec)
+	}
+}
+
+// CreateSinkBindingComparator creates a new comparator for Knative SinkBinding comparing the sink, the subject and the CloudEvents overrides
+func CreateSinkBindingComparator() func(deployed client.Object, requested client.Object) bool {
+	return func(deployed client.Object, requested client.Object) bool {
+		sinkBindingDeployed := deployed.(*sourcesv1.SinkBinding)
+		sinkBindingRequested := requested.(*sourcesv1.SinkBinding).DeepCopy()
+
+		return containAllLabels(sinkBindingDeployed, sinkBindingRequested) &&
+			containAllAnnotations(sinkBindingDeployed, sinkBindingRequested) &&
+			reflect.DeepEqual(sinkBindingDeployed.Spec.Sink, sinkBindingRequested.Spec.Sink) &&
+			reflect.DeepEqual(sinkBindingDeployed.Spec.Subject, sinkBindingRequested.Spec.Subject) &&
+			reflect.DeepEqual(sinkBindingDeployed.Spec.CloudEventOverrides, sinkBindingRequested.Spec.CloudEventOverrides)
+	}
+}
+
+// CreateTriggerComparator creates a new comparator for Knative Trigger comparing the filter, the subscriber and the delivery settings.
+// The broker is immutable, triggers of another broker must be recreated.
+func CreateTriggerComparator() func(deployed client.Object, requested client.Object) bool {
+	return func(deployed client.Object, requested client.Object) bool {
+		triggerDeployed := deployed.(*eventingv1.Trigger)
+		triggerRequested := requested.(*eventingv1.Trigger).DeepCopy()
+
+		if !containAllLabels(triggerDeployed, triggerRequested) || !containAllAnnotations(triggerDeployed, triggerRequested) {
+			return false
+		}
+		return triggerDeployed.Spec.Broker == triggerRequested.Spec.Broker &&
+			reflect.DeepEqual(triggerDeployed.Spec.Filter, triggerRequested.Spec.Filter) &&
+			reflect.DeepEqual(triggerDeployed.Spec.Subscriber, triggerRequested.Spec.Subscriber) &&
+			reflect.DeepEqual(triggerDeployed.Spec.Delivery, triggerRequested.Spec.Delivery)
 	}
 }
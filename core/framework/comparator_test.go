@@ -709,6 +709,59 @@ func Test_CreateDeploymentComparator(t *testing.T) {
 			reflect.TypeOf(apps.Deployment{}),
 			false,
 		},
+		{
+			"Ignore server defaults on pod template containers",
+			args{
+				deployed: &apps.Deployment{
+					Spec: apps.DeploymentSpec{
+						Template: v1.PodTemplateSpec{
+							Spec: v1.PodSpec{
+								Containers: []v1.Container{
+									{Name: "service"},
+									{
+										Name:                     "sidecar",
+										Image:                    "sidecar:1.0",
+										ImagePullPolicy:          v1.PullIfNotPresent,
+										TerminationMessagePath:   v1.TerminationMessagePathDefault,
+										TerminationMessagePolicy: v1.TerminationMessageReadFile,
+										Ports:                    []v1.ContainerPort{{ContainerPort: 9090, Protocol: v1.ProtocolTCP}},
+									},
+								},
+								InitContainers: []v1.Container{
+									{
+										Name:                     "init",
+										Image:                    "init:1.0",
+										TerminationMessagePath:   v1.TerminationMessagePathDefault,
+										TerminationMessagePolicy: v1.TerminationMessageReadFile,
+									},
+								},
+							},
+						},
+					},
+				},
+				requested: &apps.Deployment{
+					Spec: apps.DeploymentSpec{
+						Template: v1.PodTemplateSpec{
+							Spec: v1.PodSpec{
+								Containers: []v1.Container{
+									{Name: "service"},
+									{
+										Name:  "sidecar",
+										Image: "sidecar:1.0",
+										Ports: []v1.ContainerPort{{ContainerPort: 9090}},
+									},
+								},
+								InitContainers: []v1.Container{
+									{Name: "init", Image: "init:1.0"},
+								},
+							},
+						},
+					},
+				},
+			},
+			reflect.TypeOf(apps.Deployment{}),
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
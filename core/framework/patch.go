@@ -0,0 +1,90 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/kiegroup/kogito-operator/apis"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+// ApplyPatches applies to the given object, in order, the patches targeting its kind and name.
+// The object is updated in place, so the patches must be applied after the operator renders it and before it's compared with the deployed one.
+func ApplyPatches(scheme *runtime.Scheme, object client.Object, patches []api.KogitoPatchInterface) error {
+	if len(patches) == 0 {
+		return nil
+	}
+	gvk, err := apiutil.GVKForObject(object, scheme)
+	if err != nil {
+		return err
+	}
+	for _, patch := range patches {
+		if patch.GetKind() != gvk.Kind || (len(patch.GetName()) > 0 && patch.GetName() != object.GetName()) {
+			continue
+		}
+		if err := applyPatch(object, patch); err != nil {
+			return fmt.Errorf("failed to apply %s patch to %s %s: %w", patch.GetType(), gvk.Kind, object.GetName(), err)
+		}
+		object.GetObjectKind().SetGroupVersionKind(gvk)
+	}
+	return nil
+}
+
+func applyPatch(object client.Object, patch api.KogitoPatchInterface) error {
+	original, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	patchJSON, err := yaml.YAMLToJSON([]byte(patch.GetPatch()))
+	if err != nil {
+		return err
+	}
+	var patched []byte
+	switch patch.GetType() {
+	case api.JSONPatchType:
+		jsonPatch, err := jsonpatch.DecodePatch(patchJSON)
+		if err != nil {
+			return err
+		}
+		patched, err = jsonPatch.Apply(original)
+		if err != nil {
+			return err
+		}
+	case api.MergePatchType:
+		patched, err = jsonpatch.MergePatch(original, patchJSON)
+		if err != nil {
+			return err
+		}
+	case api.StrategicMergePatchType:
+		patched, err = strategicpatch.StrategicMergePatch(original, patchJSON, object)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported patch type %s", patch.GetType())
+	}
+	// reset the object, so the fields removed by the patch are not kept by the unmarshalling
+	value := reflect.ValueOf(object).Elem()
+	value.Set(reflect.Zero(value.Type()))
+	return json.Unmarshal(patched, object)
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/infrastructure/kafka/v1beta2"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplyPatches_StrategicMerge(t *testing.T) {
+	deployment := &apps.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "service"},
+		Spec: apps.DeploymentSpec{
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{Name: "service", Image: "service:1.0", Env: []v1.EnvVar{{Name: "ENV1", Value: "value1"}}},
+					},
+				},
+			},
+		},
+	}
+	patches := []api.KogitoPatchInterface{
+		v1beta1.KogitoPatch{
+			Kind:  "Deployment",
+			Patch: "spec:\n  template:\n    spec:\n      containers:\n      - name: service\n        env:\n        - name: ENV2\n          value: value2",
+		},
+	}
+	assert.NoError(t, ApplyPatches(meta.GetRegisteredSchema(), deployment, patches))
+	assert.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+	assert.Equal(t, "service:1.0", deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, v1.EnvVar{Name: "ENV1", Value: "value1"})
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, v1.EnvVar{Name: "ENV2", Value: "value2"})
+}
+
+func TestApplyPatches_JSONPatch(t *testing.T) {
+	topic := &v1beta2.KafkaTopic{
+		ObjectMeta: metav1.ObjectMeta{Name: "topic"},
+		Spec:       v1beta2.KafkaTopicSpec{Partitions: 1, Replicas: 1, TopicName: "topic"},
+	}
+	patches := []api.KogitoPatchInterface{
+		v1beta1.KogitoPatch{Kind: "KafkaTopic", Name: "topic", Type: api.JSONPatchType, Patch: `[{"op":"replace","path":"/spec/partitions","value":6}]`},
+		v1beta1.KogitoPatch{Kind: "KafkaTopic", Name: "another-topic", Type: api.MergePatchType, Patch: `{"spec":{"replicas":3}}`},
+	}
+	assert.NoError(t, ApplyPatches(meta.GetRegisteredSchema(), topic, patches))
+	assert.Equal(t, int32(6), topic.Spec.Partitions)
+	assert.Equal(t, int32(1), topic.Spec.Replicas)
+	assert.Equal(t, "topic", topic.Spec.TopicName)
+}
+
+func TestApplyPatches_Invalid(t *testing.T) {
+	service := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "service"}}
+	patches := []api.KogitoPatchInterface{
+		v1beta1.KogitoPatch{Kind: "Service", Type: api.MergePatchType, Patch: "spec: ["},
+	}
+	assert.Error(t, ApplyPatches(meta.GetRegisteredSchema(), service, patches))
+}
@@ -19,17 +19,23 @@ import (
 	"crypto/md5"
 	"fmt"
 	"math/rand"
+	"sort"
 	"strconv"
 )
 
-// GenerateMD5Hash will generate a MD5 hash from the given map
+// GenerateMD5Hash will generate a MD5 hash from the given map, the same map content always generates the same hash
 func GenerateMD5Hash(source map[string]string) string {
 	if len(source) == 0 {
 		return ""
 	}
+	keys := make([]string, 0, len(source))
+	for k := range source {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
 	b := new(bytes.Buffer)
-	for k, v := range source {
-		fmt.Fprintf(b, "%s=\"%s\"\n", k, v)
+	for _, k := range keys {
+		fmt.Fprintf(b, "%s=\"%s\"\n", k, source[k])
 	}
 	return fmt.Sprintf("%x", md5.Sum(b.Bytes()))
 }
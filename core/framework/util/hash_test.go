@@ -39,6 +39,7 @@ func TestGenerateMD5Hash(t *testing.T) {
 		{"Should have no hash", args{map[string]string{}}, ""},
 		{"Should have no hash because nil", args{nil}, ""},
 		{"Should have different hash", args{map3}, differentHash},
+		{"Should have the same hash regardless of the keys order", args{map[string]string{"c": "3", "a": "1", "b": "2"}}, GenerateMD5Hash(map[string]string{"a": "1", "b": "2", "c": "3"})},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,155 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagedigest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+const (
+	// DefaultPollInterval is the time between two resolutions of the image tag of the same service
+	DefaultPollInterval = 5 * time.Minute
+	eventsBufferSize    = 100
+)
+
+// Resolver polls the registries in background to resolve the image tags of the services with the Periodic image update policy,
+// so the reconcilers never wait for a registry to reply.
+// When the digest a tag points to changes, the service is sent to the Events channel to trigger a new reconciliation.
+type Resolver struct {
+	pollInterval time.Duration
+	events       chan event.GenericEvent
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	mutex  sync.Mutex
+	images map[types.NamespacedName]*imageState
+}
+
+type imageState struct {
+	image          string
+	registryClient infrastructure.RegistryClient
+	cancel         context.CancelFunc
+	digest         string
+	resolved       bool
+}
+
+// NewResolver creates a Resolver polling the image tags every DefaultPollInterval
+func NewResolver() *Resolver {
+	return newResolver(DefaultPollInterval)
+}
+
+func newResolver(pollInterval time.Duration) *Resolver {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Resolver{
+		pollInterval: pollInterval,
+		events:       make(chan event.GenericEvent, eventsBufferSize),
+		ctx:          ctx,
+		cancel:       cancel,
+		images:       map[types.NamespacedName]*imageState{},
+	}
+}
+
+// Events is the channel receiving the services whose image tag points to a new digest, to be used as a controller source
+func (r *Resolver) Events() <-chan event.GenericEvent {
+	return r.events
+}
+
+// Start implements manager.Runnable, the polls are stopped once the manager stops
+func (r *Resolver) Start(ctx context.Context) error {
+	<-ctx.Done()
+	r.cancel()
+	return nil
+}
+
+// Get returns the last digest resolved for the image tag of the given service.
+// The tag is polled in background from the first call, or again from scratch if the image changed,
+// resolved is false until the first resolution succeeds. The registry client is refreshed at every call, e.g. new pull secrets.
+func (r *Resolver) Get(key types.NamespacedName, image string, registryClient infrastructure.RegistryClient) (digest string, resolved bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	state, exists := r.images[key]
+	if exists && state.image == image {
+		state.registryClient = registryClient
+		return state.digest, state.resolved
+	}
+	if exists {
+		state.cancel()
+	}
+	ctx, cancel := context.WithCancel(r.ctx)
+	state = &imageState{image: image, registryClient: registryClient, cancel: cancel}
+	r.images[key] = state
+	go r.poll(ctx, key, state)
+	return "", false
+}
+
+// Forget stops polling the image tag of the given service, eg: when the service is deleted
+func (r *Resolver) Forget(key types.NamespacedName) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if state, exists := r.images[key]; exists {
+		state.cancel()
+		delete(r.images, key)
+	}
+}
+
+func (r *Resolver) poll(ctx context.Context, key types.NamespacedName, state *imageState) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+	for {
+		r.resolve(ctx, key, state)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Resolver) resolve(ctx context.Context, key types.NamespacedName, state *imageState) {
+	r.mutex.Lock()
+	registryClient := state.registryClient
+	r.mutex.Unlock()
+	digest, err := registryClient.ResolveDigest(ctx, state.image)
+	if err != nil {
+		// the last resolved digest is kept until the registry replies again
+		log.Warn("Failed to resolve image digest", "name", key.Name, "namespace", key.Namespace, "image", state.image, "error", err)
+		return
+	}
+	r.mutex.Lock()
+	if ctx.Err() != nil {
+		// the image changed or the service was forgotten meanwhile
+		r.mutex.Unlock()
+		return
+	}
+	changed := !state.resolved || state.digest != digest
+	state.digest = digest
+	state.resolved = true
+	r.mutex.Unlock()
+
+	if changed {
+		log.Debug("Image digest changed", "name", key.Name, "namespace", key.Namespace, "image", state.image, "digest", digest)
+		select {
+		case r.events <- event.GenericEvent{Object: &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}}:
+		case <-ctx.Done():
+		}
+	}
+}
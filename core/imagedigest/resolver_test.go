@@ -0,0 +1,70 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagedigest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type fakeRegistryClient struct {
+	mutex   sync.Mutex
+	digests map[string]string
+}
+
+func (f *fakeRegistryClient) setDigest(image, digest string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.digests[image] = digest
+}
+
+func (f *fakeRegistryClient) ResolveDigest(_ context.Context, image string) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.digests[image], nil
+}
+
+func TestResolver_Get(t *testing.T) {
+	registryClient := &fakeRegistryClient{digests: map[string]string{"quay.io/kiegroup/app:1.0": "sha256:1111"}}
+	resolver := newResolver(time.Millisecond * 10)
+	defer resolver.cancel()
+	key := types.NamespacedName{Name: "app", Namespace: "test"}
+
+	_, resolved := resolver.Get(key, "quay.io/kiegroup/app:1.0", registryClient)
+	assert.False(t, resolved)
+	event := <-resolver.Events()
+	assert.Equal(t, "app", event.Object.GetName())
+	digest, resolved := resolver.Get(key, "quay.io/kiegroup/app:1.0", registryClient)
+	assert.True(t, resolved)
+	assert.Equal(t, "sha256:1111", digest)
+
+	// the tag moved in the registry
+	registryClient.setDigest("quay.io/kiegroup/app:1.0", "sha256:2222")
+	<-resolver.Events()
+	digest, _ = resolver.Get(key, "quay.io/kiegroup/app:1.0", registryClient)
+	assert.Equal(t, "sha256:2222", digest)
+
+	// a new tag is resolved from scratch
+	_, resolved = resolver.Get(key, "quay.io/kiegroup/app:2.0", registryClient)
+	assert.False(t, resolved)
+
+	resolver.Forget(key)
+	assert.Empty(t, resolver.images)
+}
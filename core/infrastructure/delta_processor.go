@@ -18,11 +18,15 @@ import (
 	"github.com/RHsyseng/operator-utils/pkg/resource/compare"
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
 	"github.com/kiegroup/kogito-operator/core/operator"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// DeltaProcessor ...
+// DeltaProcessor creates and updates the requested resources with server-side apply, so the fields set by other controllers or users
+// on the deployed resources are kept, eg: replicas managed by an HPA or annotations added by a service mesh.
+// The fields declared by the operator but owned by another manager are never taken over, the conflicts are reported as
+// ReconciliationError so the service condition tells which resource to fix.
 type DeltaProcessor interface {
 	ProcessDelta(comparator compare.MapComparator, requestedResources map[reflect.Type][]client.Object, deployedResources map[reflect.Type][]client.Object) (isDeltaProcessed bool, err error)
 }
@@ -47,11 +51,7 @@ func (d *deltaProcessor) ProcessDelta(comparator compare.MapComparator, requeste
 		}
 		d.Log.Info("Will", "create", len(delta.Added), "update", len(delta.Updated), "delete", len(delta.Removed), "resourceType", resourceType)
 
-		if _, err = kubernetes.ResourceC(d.Client).CreateResources(delta.Added); err != nil {
-			return
-		}
-
-		if _, err = kubernetes.ResourceC(d.Client).UpdateResources(deployedResources[resourceType], delta.Updated); err != nil {
+		if err = d.applyResources(append(delta.Added, delta.Updated...)); err != nil {
 			return
 		}
 
@@ -63,3 +63,16 @@ func (d *deltaProcessor) ProcessDelta(comparator compare.MapComparator, requeste
 	}
 	return
 }
+
+func (d *deltaProcessor) applyResources(resources []client.Object) error {
+	for _, resource := range resources {
+		if err := kubernetes.ResourceC(d.Client).Apply(resource); err != nil {
+			if errors.IsConflict(err) {
+				d.Log.Warn("Conflict while applying resource", "kind", resource.GetObjectKind().GroupVersionKind().Kind, "name", resource.GetName(), "error", err)
+				return ErrorForFieldManagerConflict(resource.GetObjectKind().GroupVersionKind().Kind, resource.GetName(), err)
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// conflictingClient fails every apply with a field manager conflict, as the API server does when a field is owned by another manager
+type conflictingClient struct {
+	client.Client
+}
+
+func (c *conflictingClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	return errors.NewConflict(schema.GroupResource{Resource: "configmaps"}, obj.GetName(), nil)
+}
+
+func Test_deltaProcessor_ProcessDelta_KeepsFieldsOfOtherManagers(t *testing.T) {
+	ns := t.Name()
+	deployed := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "config",
+			Namespace:   ns,
+			Annotations: map[string]string{"sidecar.istio.io/status": "injected"},
+		},
+		Data: map[string]string{"application.properties": "old"},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(deployed).Build()
+	context := operator.Context{Client: cli, Log: test.TestLogger, Scheme: meta.GetRegisteredSchema()}
+
+	requested := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: ns},
+		Data:       map[string]string{"application.properties": "new"},
+	}
+	added := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "added", Namespace: ns},
+		Data:       map[string]string{"application.properties": "added"},
+	}
+	configMapType := reflect.TypeOf(v1.ConfigMap{})
+	isDeltaProcessed, err := NewDeltaProcessor(context).ProcessDelta(NewConfigMapHandler(context).GetComparator(),
+		map[reflect.Type][]client.Object{configMapType: {requested, added}},
+		map[reflect.Type][]client.Object{configMapType: {deployed.DeepCopy()}})
+	assert.NoError(t, err)
+	assert.True(t, isDeltaProcessed)
+
+	updated := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: ns}}
+	exists, err := kubernetes.ResourceC(cli).Fetch(updated)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, "new", updated.Data["application.properties"])
+	assert.Equal(t, "injected", updated.Annotations["sidecar.istio.io/status"])
+
+	created := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "added", Namespace: ns}}
+	exists, err = kubernetes.ResourceC(cli).Fetch(created)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func Test_deltaProcessor_ProcessDelta_Conflict(t *testing.T) {
+	ns := t.Name()
+	fakeCli := test.NewFakeClientBuilder().Build()
+	cli := &kogitocli.Client{ControlCli: &conflictingClient{Client: fakeCli.ControlCli}, Discovery: fakeCli.Discovery}
+	context := operator.Context{Client: cli, Log: test.TestLogger, Scheme: meta.GetRegisteredSchema()}
+
+	requested := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: ns}}
+	configMapType := reflect.TypeOf(v1.ConfigMap{})
+	isDeltaProcessed, err := NewDeltaProcessor(context).ProcessDelta(NewConfigMapHandler(context).GetComparator(),
+		map[reflect.Type][]client.Object{configMapType: {requested}},
+		map[reflect.Type][]client.Object{})
+	assert.Error(t, err)
+	assert.False(t, isDeltaProcessed)
+	assert.Equal(t, FieldManagerConflictReason, NewReconciliationErrorHandler(context).GetReasonForError(err))
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/kiegroup/kogito-operator/core/framework"
 	"github.com/kiegroup/kogito-operator/core/operator"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"reflect"
 )
@@ -32,6 +33,7 @@ type DeploymentHandler interface {
 	MustFetchDeployment(key types.NamespacedName) (*appsv1.Deployment, error)
 	IsDeploymentAvailable(key types.NamespacedName) (bool, error)
 	FetchReadyReplicas(key types.NamespacedName) (int32, error)
+	IsAutoscaled(key types.NamespacedName) (bool, error)
 	GetComparator() compare.MapComparator
 }
 
@@ -98,6 +100,21 @@ func (d *deploymentHandler) FetchReadyReplicas(key types.NamespacedName) (int32,
 	return deployment.Status.AvailableReplicas, nil
 }
 
+// IsAutoscaled verifies if a HorizontalPodAutoscaler scales the given Deployment, its replicas are then left to the autoscaler
+func (d *deploymentHandler) IsAutoscaled(key types.NamespacedName) (bool, error) {
+	autoscalers := &autoscalingv1.HorizontalPodAutoscalerList{}
+	if err := kubernetes.ResourceC(d.Client).ListWithNamespace(key.Namespace, autoscalers); err != nil {
+		return false, err
+	}
+	for _, autoscaler := range autoscalers.Items {
+		target := autoscaler.Spec.ScaleTargetRef
+		if target.Kind == "Deployment" && target.Name == key.Name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // GetComparator gets the comparator for the owned resources
 func (d *deploymentHandler) GetComparator() compare.MapComparator {
 	resourceComparator := compare.DefaultComparator()
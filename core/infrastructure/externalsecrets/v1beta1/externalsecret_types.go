@@ -0,0 +1,112 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SecretStoreKind is the kind of the store of a namespace
+	SecretStoreKind = "SecretStore"
+	// ClusterSecretStoreKind is the kind of the store shared by all the namespaces
+	ClusterSecretStoreKind = "ClusterSecretStore"
+	// CreatePolicyOwner the ExternalSecret owns the Secret it creates
+	CreatePolicyOwner ExternalSecretCreationPolicy = "Owner"
+	// ExternalSecretReady is the condition set once the Secret is synced with the provider
+	ExternalSecretReady ExternalSecretConditionType = "Ready"
+)
+
+// ExternalSecretCreationPolicy defines how the target Secret is created
+type ExternalSecretCreationPolicy string
+
+// ExternalSecretConditionType ...
+type ExternalSecretConditionType string
+
+// SecretStoreRef defines which SecretStore to fetch the secret from
+type SecretStoreRef struct {
+	Name string `json:"name"`
+	Kind string `json:"kind,omitempty"`
+}
+
+// ExternalSecretTarget defines the Secret created by the ExternalSecret
+type ExternalSecretTarget struct {
+	Name           string                       `json:"name,omitempty"`
+	CreationPolicy ExternalSecretCreationPolicy `json:"creationPolicy,omitempty"`
+}
+
+// ExternalSecretDataRemoteRef defines the secret of the provider and its property to fetch
+type ExternalSecretDataRemoteRef struct {
+	Key      string `json:"key"`
+	Property string `json:"property,omitempty"`
+}
+
+// ExternalSecretData defines a key of the target Secret
+type ExternalSecretData struct {
+	SecretKey string                      `json:"secretKey"`
+	RemoteRef ExternalSecretDataRemoteRef `json:"remoteRef"`
+}
+
+// ExternalSecretDataFromRemoteRef defines a secret of the provider whose keys are all fetched
+type ExternalSecretDataFromRemoteRef struct {
+	Extract *ExternalSecretDataRemoteRef `json:"extract,omitempty"`
+}
+
+// ExternalSecretSpec defines the desired state of ExternalSecret
+type ExternalSecretSpec struct {
+	SecretStoreRef  SecretStoreRef                    `json:"secretStoreRef"`
+	Target          ExternalSecretTarget              `json:"target,omitempty"`
+	RefreshInterval *metav1.Duration                  `json:"refreshInterval,omitempty"`
+	Data            []ExternalSecretData              `json:"data,omitempty"`
+	DataFrom        []ExternalSecretDataFromRemoteRef `json:"dataFrom,omitempty"`
+}
+
+// ExternalSecretStatusCondition ...
+type ExternalSecretStatusCondition struct {
+	Type               ExternalSecretConditionType `json:"type"`
+	Status             corev1.ConditionStatus      `json:"status"`
+	Reason             string                      `json:"reason,omitempty"`
+	Message            string                      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time                 `json:"lastTransitionTime,omitempty"`
+}
+
+// ExternalSecretStatus defines the observed state of ExternalSecret
+type ExternalSecretStatus struct {
+	RefreshTime metav1.Time                     `json:"refreshTime,omitempty"`
+	Conditions  []ExternalSecretStatusCondition `json:"conditions,omitempty"`
+}
+
+// ExternalSecret is the Schema for the externalsecrets API
+// +kubebuilder:object:root=true
+type ExternalSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ExternalSecretSpec   `json:"spec,omitempty"`
+	Status ExternalSecretStatus `json:"status,omitempty"`
+}
+
+// ExternalSecretList contains a list of ExternalSecret
+// +kubebuilder:object:root=true
+type ExternalSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExternalSecret `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ExternalSecret{}, &ExternalSecretList{})
+}
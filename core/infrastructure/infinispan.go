@@ -16,13 +16,17 @@ package infrastructure
 
 import (
 	"fmt"
+	"github.com/kiegroup/kogito-operator/apis"
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/framework"
 	ispn "github.com/kiegroup/kogito-operator/core/infrastructure/infinispan/v1"
 	"github.com/kiegroup/kogito-operator/core/operator"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -47,6 +51,7 @@ const (
 // InfinispanHandler ...
 type InfinispanHandler interface {
 	FetchInfinispanInstance(key types.NamespacedName) (*ispn.Infinispan, error)
+	CreateInfinispanInstance(key types.NamespacedName, provision api.InfraProvisionInterface, owner client.Object) (*ispn.Infinispan, error)
 	IsInfinispanAvailable() bool
 	FetchInfinispanInstanceURI(key types.NamespacedName) (string, error)
 	GetInfinispanCredential(infinispanInstance *ispn.Infinispan) (*InfinispanCredential, error)
@@ -94,9 +99,32 @@ func (i *infinispanHandler) FetchInfinispanInstance(key types.NamespacedName) (*
 	}
 }
 
+// CreateInfinispanInstance creates a minimal Infinispan cluster from the given provision template, owned by the given owner
+func (i *infinispanHandler) CreateInfinispanInstance(key types.NamespacedName, provision api.InfraProvisionInterface, owner client.Object) (*ispn.Infinispan, error) {
+	i.Log.Debug("Going to provision infinispan instance", "name", key.Name)
+	infinispanInstance := &ispn.Infinispan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: ispn.InfinispanSpec{
+			Replicas: getProvisionReplicas(provision),
+		},
+	}
+	if err := framework.SetOwner(owner, i.Scheme, infinispanInstance); err != nil {
+		return nil, err
+	}
+	if err := kubernetes.ResourceC(i.Client).Create(infinispanInstance); err != nil {
+		i.Log.Error(err, "Error occurs while provisioning infinispan instance")
+		return nil, err
+	}
+	i.Log.Info("Infinispan instance provisioned", "name", key.Name)
+	return infinispanInstance, nil
+}
+
 // IsInfinispanAvailable checks whether Infinispan CRD is available or not
 func (i *infinispanHandler) IsInfinispanAvailable() bool {
-	return i.Client.HasServerGroup(ispn.SchemeGroupVersion.Group)
+	return i.Client.HasCapability(kogitocli.InfinispanCapability)
 }
 
 // FetchInfinispanInstanceURI provide infinispan URI for given instance name
@@ -0,0 +1,74 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CacheConditionType ...
+type CacheConditionType string
+
+const (
+	// CacheConditionReady the cache is created in the Infinispan cluster
+	CacheConditionReady CacheConditionType = "Ready"
+)
+
+// CacheSpec defines the desired state of Cache
+type CacheSpec struct {
+	// Name of the Infinispan cluster where the cache is created
+	ClusterName string `json:"clusterName"`
+	// Name of the cache, the name of the Cache CR when not set
+	Name string `json:"name,omitempty"`
+	// Configuration of the cache in XML, JSON or YAML
+	Template string `json:"template,omitempty"`
+	// Name of a template defined in the Infinispan cluster
+	TemplateName string `json:"templateName,omitempty"`
+}
+
+// CacheCondition ...
+type CacheCondition struct {
+	Type    CacheConditionType     `json:"type"`
+	Status  metav1.ConditionStatus `json:"status"`
+	Message string                 `json:"message,omitempty"`
+}
+
+// CacheStatus defines the observed state of Cache
+type CacheStatus struct {
+	Conditions  []CacheCondition `json:"conditions,omitempty"`
+	ServiceName string           `json:"serviceName,omitempty"`
+}
+
+// Cache is the Schema for the caches API
+// +kubebuilder:object:root=true
+type Cache struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CacheSpec   `json:"spec,omitempty"`
+	Status CacheStatus `json:"status,omitempty"`
+}
+
+// CacheList contains a list of Cache
+// +kubebuilder:object:root=true
+type CacheList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Cache `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Cache{}, &CacheList{})
+}
@@ -0,0 +1,265 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/framework"
+	ispnv2alpha1 "github.com/kiegroup/kogito-operator/core/infrastructure/infinispan/v2alpha1"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// InfinispanJobsCacheName is the cache storing the jobs of the Jobs Service
+	InfinispanJobsCacheName = "jobs"
+	// processInstancesCacheSuffix is appended to the process id to name the cache storing its process instances
+	processInstancesCacheSuffix = "_store"
+	// dataIndexDomainCacheSuffix is appended to the process id to name the cache storing its domain data indexed by Data Index
+	dataIndexDomainCacheSuffix = "_domain"
+
+	// defaultInfinispanCacheTemplate is the configuration of the caches without a template, unlike the Infinispan default configuration
+	// the entries are persisted and the ones exceeding the memory bounds are evicted to the store
+	defaultInfinispanCacheTemplate = `{"distributed-cache":{"mode":"SYNC","statistics":true,` +
+		`"encoding":{"media-type":"application/x-protostream"},` +
+		`"memory":{"max-count":100000,"when-full":"REMOVE"},` +
+		`"persistence":{"passivation":false,"file-store":{}}}}`
+
+	// InfinispanCacheOwnerLabel holds the UID of the KogitoInfra that created the cache, the caches out of the namespace
+	// of the KogitoInfra can't be owned by it
+	InfinispanCacheOwnerLabel = "kogito.kie.org/owner-uid"
+	// InfinispanCacheServicesAnnotation lists the Kogito services, as namespace/name, using the cache
+	InfinispanCacheServicesAnnotation = "kogito.kie.org/services"
+)
+
+// InfinispanCacheHandler ...
+type InfinispanCacheHandler interface {
+	IsInfinispanCacheAvailable() bool
+	// ReconcileInfinispanCaches creates or updates the Caches with the given names and templates used by the given service in the given
+	// Infinispan cluster, they're labeled after the given owner and owned by it when it's in the namespace of the cluster.
+	// The caches of the owner no longer used by the service are released.
+	ReconcileInfinispanCaches(cluster types.NamespacedName, caches map[string]api.InfinispanCacheTemplateInterface, owner client.Object, service string) error
+	// ReleaseInfinispanCaches removes the given service from the caches of the given owner in the given Infinispan cluster,
+	// the caches no longer used by any service are deleted
+	ReleaseInfinispanCaches(cluster types.NamespacedName, owner client.Object, service string) error
+}
+
+type infinispanCacheHandler struct {
+	operator.Context
+}
+
+// NewInfinispanCacheHandler ...
+func NewInfinispanCacheHandler(context operator.Context) InfinispanCacheHandler {
+	return &infinispanCacheHandler{
+		context,
+	}
+}
+
+// GetProcessInstancesCacheName returns the cache storing the process instances of the given process
+func GetProcessInstancesCacheName(processID string) string {
+	return processID + processInstancesCacheSuffix
+}
+
+// GetDataIndexDomainCacheName returns the cache storing the domain data of the given process indexed by Data Index
+func GetDataIndexDomainCacheName(processID string) string {
+	return processID + dataIndexDomainCacheSuffix
+}
+
+// IsInfinispanCacheAvailable checks whether the Infinispan Cache CRD is available or not
+func (i *infinispanCacheHandler) IsInfinispanCacheAvailable() bool {
+	return i.Client.HasCapability(kogitocli.InfinispanCacheCapability)
+}
+
+func (i *infinispanCacheHandler) ReconcileInfinispanCaches(cluster types.NamespacedName, caches map[string]api.InfinispanCacheTemplateInterface, owner client.Object, service string) error {
+	cacheNames := make([]string, 0, len(caches))
+	for cacheName := range caches {
+		cacheNames = append(cacheNames, cacheName)
+	}
+	sort.Strings(cacheNames)
+	for _, cacheName := range cacheNames {
+		if err := i.reconcileInfinispanCache(newInfinispanCache(cluster, cacheName, caches[cacheName]), owner, service); err != nil {
+			return err
+		}
+	}
+	return i.releaseInfinispanCaches(cluster, owner, service, caches)
+}
+
+func (i *infinispanCacheHandler) ReleaseInfinispanCaches(cluster types.NamespacedName, owner client.Object, service string) error {
+	return i.releaseInfinispanCaches(cluster, owner, service, nil)
+}
+
+func (i *infinispanCacheHandler) reconcileInfinispanCache(requested *ispnv2alpha1.Cache, owner client.Object, service string) error {
+	deployed := &ispnv2alpha1.Cache{}
+	exists, err := kubernetes.ResourceC(i.Client).FetchWithKey(types.NamespacedName{Name: requested.Name, Namespace: requested.Namespace}, deployed)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if owner.GetNamespace() == requested.Namespace {
+			if err := framework.SetOwner(owner, i.Scheme, requested); err != nil {
+				return err
+			}
+		}
+		requested.Labels = map[string]string{InfinispanCacheOwnerLabel: string(owner.GetUID())}
+		addInfinispanCacheService(requested, service)
+		i.Log.Info("Creating Infinispan Cache", "cache", requested.Spec.Name, "cluster", requested.Spec.ClusterName)
+		return kubernetes.ResourceC(i.Client).Create(requested)
+	}
+	// Caches created by another KogitoInfra referencing the same cluster, or by the users, are left as they are
+	if !isInfinispanCacheOwnedBy(deployed, owner) {
+		i.Log.Debug("Infinispan Cache not managed by the KogitoInfra, skipping it", "cache", requested.Spec.Name)
+		return nil
+	}
+	updated := addInfinispanCacheService(deployed, service)
+	if _, labeled := deployed.GetLabels()[InfinispanCacheOwnerLabel]; !labeled {
+		// caches created before being labeled are released along with the others
+		if deployed.Labels == nil {
+			deployed.Labels = map[string]string{}
+		}
+		deployed.Labels[InfinispanCacheOwnerLabel] = string(owner.GetUID())
+		updated = true
+	}
+	if !reflect.DeepEqual(requested.Spec, deployed.Spec) {
+		deployed.Spec = requested.Spec
+		updated = true
+	}
+	if updated {
+		i.Log.Info("Updating Infinispan Cache", "cache", requested.Spec.Name, "cluster", requested.Spec.ClusterName)
+		return kubernetes.ResourceC(i.Client).Update(deployed)
+	}
+	return nil
+}
+
+// releaseInfinispanCaches removes the given service from the caches of the owner in the cluster that are not in the given caches,
+// the caches no longer used by any service are deleted
+func (i *infinispanCacheHandler) releaseInfinispanCaches(cluster types.NamespacedName, owner client.Object, service string, caches map[string]api.InfinispanCacheTemplateInterface) error {
+	deployedCaches := &ispnv2alpha1.CacheList{}
+	if err := kubernetes.ResourceC(i.Client).ListWithNamespaceAndLabel(cluster.Namespace, deployedCaches, map[string]string{InfinispanCacheOwnerLabel: string(owner.GetUID())}); err != nil {
+		return err
+	}
+	for j := range deployedCaches.Items {
+		deployed := &deployedCaches.Items[j]
+		if deployed.Spec.ClusterName != cluster.Name {
+			continue
+		}
+		if _, used := caches[deployed.Spec.Name]; used || !removeInfinispanCacheService(deployed, service) {
+			continue
+		}
+		if len(GetInfinispanCacheServices(deployed)) == 0 {
+			i.Log.Info("Deleting Infinispan Cache no longer used", "cache", deployed.Spec.Name, "cluster", deployed.Spec.ClusterName)
+			if err := kubernetes.ResourceC(i.Client).Delete(deployed); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := kubernetes.ResourceC(i.Client).Update(deployed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isInfinispanCacheOwnedBy checks if the given cache was created for the given KogitoInfra, through its label or its owner reference
+func isInfinispanCacheOwnedBy(cache *ispnv2alpha1.Cache, owner client.Object) bool {
+	if uid, exists := cache.GetLabels()[InfinispanCacheOwnerLabel]; exists {
+		return uid == string(owner.GetUID())
+	}
+	controller := metav1.GetControllerOf(cache)
+	return controller != nil && controller.UID == owner.GetUID()
+}
+
+// GetInfinispanCacheServices returns the Kogito services using the given cache
+func GetInfinispanCacheServices(cache *ispnv2alpha1.Cache) []string {
+	services := cache.GetAnnotations()[InfinispanCacheServicesAnnotation]
+	if len(services) == 0 {
+		return nil
+	}
+	return strings.Split(services, ",")
+}
+
+func setInfinispanCacheServices(cache *ispnv2alpha1.Cache, services []string) {
+	annotations := cache.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if len(services) == 0 {
+		delete(annotations, InfinispanCacheServicesAnnotation)
+	} else {
+		sort.Strings(services)
+		annotations[InfinispanCacheServicesAnnotation] = strings.Join(services, ",")
+	}
+	cache.SetAnnotations(annotations)
+}
+
+// addInfinispanCacheService records that the given service uses the cache, returns false when it was already recorded
+func addInfinispanCacheService(cache *ispnv2alpha1.Cache, service string) bool {
+	services := GetInfinispanCacheServices(cache)
+	for _, usingService := range services {
+		if usingService == service {
+			return false
+		}
+	}
+	setInfinispanCacheServices(cache, append(services, service))
+	return true
+}
+
+// removeInfinispanCacheService records that the given service no longer uses the cache, returns false when it wasn't recorded
+func removeInfinispanCacheService(cache *ispnv2alpha1.Cache, service string) bool {
+	services := GetInfinispanCacheServices(cache)
+	for i, usingService := range services {
+		if usingService == service {
+			setInfinispanCacheServices(cache, append(services[:i], services[i+1:]...))
+			return true
+		}
+	}
+	return false
+}
+
+// newInfinispanCache creates the Cache CR of the given cache, named after the cluster and the cache
+func newInfinispanCache(cluster types.NamespacedName, cacheName string, template api.InfinispanCacheTemplateInterface) *ispnv2alpha1.Cache {
+	cache := &ispnv2alpha1.Cache{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getInfinispanCacheResourceName(cluster.Name, cacheName),
+			Namespace: cluster.Namespace,
+		},
+		Spec: ispnv2alpha1.CacheSpec{
+			ClusterName: cluster.Name,
+			Name:        cacheName,
+		},
+	}
+	if len(template.GetTemplate()) > 0 {
+		cache.Spec.Template = template.GetTemplate()
+	} else if len(template.GetTemplateName()) > 0 {
+		cache.Spec.TemplateName = template.GetTemplateName()
+	} else {
+		cache.Spec.Template = defaultInfinispanCacheTemplate
+	}
+	return cache
+}
+
+// getInfinispanCacheResourceName converts the cache name, like travels_store, into a valid resource name
+func getInfinispanCacheResourceName(clusterName, cacheName string) string {
+	name := strings.ToLower(fmt.Sprintf("%s-%s", clusterName, cacheName))
+	return strings.NewReplacer("_", "-", ".", "-").Replace(name)
+}
@@ -16,17 +16,23 @@ package infrastructure
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/kiegroup/kogito-operator/apis"
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/framework"
 	"github.com/kiegroup/kogito-operator/core/infrastructure/kafka/v1beta2"
 	"github.com/kiegroup/kogito-operator/core/operator"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	strimziServerGroup = "kafka.strimzi.io"
-
 	strimziBrokerLabel         = "strimzi.io/cluster"
 	defaultKafkaTopicPartition = 1
 	defaultKafkaTopicReplicas  = 1
@@ -36,6 +42,41 @@ const (
 
 	// KafkaInstanceName is the default name for the Kafka cluster managed by KogitoInfra
 	KafkaInstanceName = "kogito-kafka"
+
+	// KafkaClusterCACertSecretName is the secret generated by Strimzi with the CA certificate of the Kafka cluster
+	KafkaClusterCACertSecretName = "%s-cluster-ca-cert"
+	// KafkaClusterCACertP12Key is the PKCS12 truststore of the Kafka cluster CA certificate
+	KafkaClusterCACertP12Key = "ca.p12"
+	// KafkaClusterCACertPasswordKey is the password of the Kafka cluster CA truststore
+	KafkaClusterCACertPasswordKey = "ca.password"
+	// KafkaUserP12Key is the PKCS12 keystore generated by Strimzi for the KafkaUser with tls authentication
+	KafkaUserP12Key = "user.p12"
+	// KafkaUserP12PasswordKey is the password of the KafkaUser keystore
+	KafkaUserP12PasswordKey = "user.password"
+	// KafkaUserPasswordKey is the password generated by Strimzi for the KafkaUser with scram-sha-512 authentication
+	KafkaUserPasswordKey = "password"
+	// KafkaUserJaasConfigKey is the SASL JAAS configuration generated by Strimzi for the KafkaUser with scram-sha-512 authentication
+	KafkaUserJaasConfigKey = "sasl.jaas.config"
+
+	defaultKafkaListenerName    = "plain"
+	defaultKafkaTLSListenerName = "tls"
+	defaultKafkaListenerPort    = 9092
+	kafkaInternalListenerType   = "internal"
+
+	// KafkaTopicServicesAnnotation lists the Kogito services, as namespace/name, declaring the topic
+	KafkaTopicServicesAnnotation = "kogito.kie.org/services"
+	// KafkaTopicCreatedByLabel marks the topics created by the operator, only these topics are deleted once no service declares them
+	KafkaTopicCreatedByLabel = "kogito.kie.org/created-by"
+	kafkaTopicCreatedByValue = "kogito-operator"
+
+	kafkaTopicRetentionConfig         = "retention.ms"
+	kafkaTopicCleanupPolicyConfig     = "cleanup.policy"
+	kafkaTopicMinInSyncReplicasConfig = "min.insync.replicas"
+)
+
+var (
+	// kafkaTopicManagedConfigs are the configurations of the KafkaTopics set from the Kogito topic settings
+	kafkaTopicManagedConfigs = []string{kafkaTopicRetentionConfig, kafkaTopicCleanupPolicyConfig, kafkaTopicMinInSyncReplicasConfig}
 )
 
 var (
@@ -47,9 +88,15 @@ var (
 type KafkaHandler interface {
 	IsStrimziAvailable() bool
 	FetchKafkaInstance(key types.NamespacedName) (*v1beta2.Kafka, error)
+	CreateKafkaInstance(key types.NamespacedName, provision api.InfraProvisionInterface, owner client.Object) (*v1beta2.Kafka, error)
 	FetchKafkaTopic(key types.NamespacedName) (*v1beta2.KafkaTopic, error)
 	CreateKafkaTopic(topicName, kafkaName, kafkaNamespace string) (*v1beta2.KafkaTopic, error)
+	ApplyKafkaTopic(topicName, kafkaName, kafkaNamespace, service string, settings []api.KafkaTopicSettingsInterface, patches ...api.KogitoPatchInterface) (*v1beta2.KafkaTopic, error)
+	ReleaseKafkaTopics(kafkaName, kafkaNamespace, service string, declaredTopics []string, deletionPolicy api.KafkaTopicDeletionPolicy) error
 	ResolveKafkaServerURI(kafka *v1beta2.Kafka) (string, error)
+	ResolveKafkaListener(kafka *v1beta2.Kafka, name, listenerType string) (*v1beta2.ListenerStatus, error)
+	FetchKafkaUser(key types.NamespacedName) (*v1beta2.KafkaUser, error)
+	CreateKafkaUser(userName, kafkaName, kafkaNamespace string, authentication v1beta2.KafkaAuthenticationType, owner client.Object) (*v1beta2.KafkaUser, error)
 }
 
 type kafkaHandler struct {
@@ -65,7 +112,7 @@ func NewKafkaHandler(context operator.Context) KafkaHandler {
 
 // IsStrimziAvailable checks if Strimzi CRD is available in the cluster
 func (k *kafkaHandler) IsStrimziAvailable() bool {
-	return k.Client.HasServerGroup(strimziServerGroup)
+	return k.Client.HasCapability(kogitocli.StrimziCapability)
 }
 
 func (k *kafkaHandler) FetchKafkaInstance(key types.NamespacedName) (*v1beta2.Kafka, error) {
@@ -83,6 +130,56 @@ func (k *kafkaHandler) FetchKafkaInstance(key types.NamespacedName) (*v1beta2.Ka
 	}
 }
 
+// CreateKafkaInstance creates a minimal Kafka cluster with ephemeral storage and a plain internal listener from the given provision template,
+// owned by the given owner. Internal topics are replicated over all the brokers, up to three of them, and ZooKeeper runs three nodes
+// from three brokers on to keep its quorum odd.
+func (k *kafkaHandler) CreateKafkaInstance(key types.NamespacedName, provision api.InfraProvisionInterface, owner client.Object) (*v1beta2.Kafka, error) {
+	k.Log.Debug("Going to provision kafka instance", "name", key.Name)
+	replicas := getProvisionReplicas(provision)
+	internalReplicas := replicas
+	zookeeperReplicas := int32(1)
+	if replicas >= 3 {
+		internalReplicas = 3
+		zookeeperReplicas = 3
+	}
+	kafkaInstance := &v1beta2.Kafka{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: v1beta2.KafkaSpec{
+			Kafka: v1beta2.KafkaClusterSpec{
+				Replicas: replicas,
+				Listeners: []v1beta2.GenericKafkaListener{
+					{Name: defaultKafkaListenerName, Port: defaultKafkaListenerPort, ListenerType: kafkaInternalListenerType},
+				},
+				Storage: v1beta2.KafkaStorage{StorageType: v1beta2.KafkaEphemeralStorage},
+				Config: v1beta2.KafkaMap{
+					"offsets.topic.replication.factor":         internalReplicas,
+					"transaction.state.log.replication.factor": internalReplicas,
+					"transaction.state.log.min.isr":            1,
+				},
+			},
+			Zookeeper: v1beta2.ZookeeperClusterSpec{
+				Replicas: zookeeperReplicas,
+				Storage:  v1beta2.KafkaStorage{StorageType: v1beta2.KafkaEphemeralStorage},
+			},
+		},
+	}
+	if provision != nil {
+		kafkaInstance.Spec.Kafka.Version = provision.GetVersion()
+	}
+	if err := framework.SetOwner(owner, k.Scheme, kafkaInstance); err != nil {
+		return nil, err
+	}
+	if err := kubernetes.ResourceC(k.Client).Create(kafkaInstance); err != nil {
+		k.Log.Error(err, "Error occurs while provisioning kafka instance")
+		return nil, err
+	}
+	k.Log.Info("Kafka instance provisioned", "name", key.Name)
+	return kafkaInstance, nil
+}
+
 func (k *kafkaHandler) FetchKafkaTopic(key types.NamespacedName) (*v1beta2.KafkaTopic, error) {
 	k.Log.Debug("Going to load deployed kafka topic", "topicName", key.Name)
 	kafkaTopic := &v1beta2.KafkaTopic{}
@@ -100,6 +197,7 @@ func (k *kafkaHandler) FetchKafkaTopic(key types.NamespacedName) (*v1beta2.Kafka
 func (k *kafkaHandler) CreateKafkaTopic(topicName, kafkaName, kafkaNamespace string) (*v1beta2.KafkaTopic, error) {
 	k.Log.Debug("Going to create kafka topic", "topicName", topicName)
 	kafkaTopic := getKafkaTopic(topicName, kafkaNamespace, kafkaName)
+	kafkaTopic.Labels[KafkaTopicCreatedByLabel] = kafkaTopicCreatedByValue
 	if err := kubernetes.ResourceC(k.Client).Create(kafkaTopic); err != nil {
 		k.Log.Error(err, "Error occurs while creating kogito Kafka topic")
 		return nil, err
@@ -108,24 +206,313 @@ func (k *kafkaHandler) CreateKafkaTopic(topicName, kafkaName, kafkaNamespace str
 	return kafkaTopic, nil
 }
 
-// getKafkaTopic returns a Kafka topic resource with default configuration
-func getKafkaTopic(name, namespace, kafkaBroker string) *v1beta2.KafkaTopic {
+// ApplyKafkaTopic creates the Kafka topic declared by the given service or reverts the drift of the settings explicitly given,
+// the settings are given by precedence. The partitions of an existing topic are never decreased and its replicas never changed,
+// the configurations not given are left as they are, eg: set by the Kafka administrators.
+func (k *kafkaHandler) ApplyKafkaTopic(topicName, kafkaName, kafkaNamespace, service string, settings []api.KafkaTopicSettingsInterface, patches ...api.KogitoPatchInterface) (*v1beta2.KafkaTopic, error) {
+	requestedTopic := getKafkaTopic(topicName, kafkaNamespace, kafkaName, settings...)
+	if err := framework.ApplyPatches(k.Scheme, requestedTopic, patches); err != nil {
+		return nil, ErrorForInvalidPatch(err)
+	}
+	deployedTopic, err := k.FetchKafkaTopic(types.NamespacedName{Name: topicName, Namespace: kafkaNamespace})
+	if err != nil {
+		return nil, err
+	}
+	if deployedTopic == nil {
+		addKafkaTopicService(requestedTopic, service)
+		requestedTopic.Labels[KafkaTopicCreatedByLabel] = kafkaTopicCreatedByValue
+		k.Log.Debug("Going to create kafka topic", "topicName", topicName)
+		if err := kubernetes.ResourceC(k.Client).Create(requestedTopic); err != nil {
+			k.Log.Error(err, "Error occurs while creating kogito Kafka topic")
+			return nil, err
+		}
+		return requestedTopic, nil
+	}
+
+	updated := addKafkaTopicService(deployedTopic, service)
+	if partitions := getRequestedKafkaTopicPartitions(settings); deployedTopic.Spec.Partitions < partitions {
+		deployedTopic.Spec.Partitions = partitions
+		updated = true
+	} else if deployedTopic.Spec.Partitions > partitions && partitions > 0 {
+		k.Log.Debug("Partitions of a kafka topic can't be decreased", "topicName", topicName, "partitions", deployedTopic.Spec.Partitions)
+	}
+	for _, config := range kafkaTopicManagedConfigs {
+		requestedValue, requested := requestedTopic.Spec.Config[config]
+		deployedValue, deployed := deployedTopic.Spec.Config[config]
+		if requested && (!deployed || getKafkaConfigValue(deployedValue) != getKafkaConfigValue(requestedValue)) {
+			if deployedTopic.Spec.Config == nil {
+				deployedTopic.Spec.Config = v1beta2.KafkaMap{}
+			}
+			deployedTopic.Spec.Config[config] = requestedValue
+			updated = true
+		}
+	}
+	// the patches are applied on top of the deployed topic as well, so their changes are reconciled after the creation
+	patchedTopic := deployedTopic.DeepCopy()
+	if err := framework.ApplyPatches(k.Scheme, patchedTopic, patches); err != nil {
+		return nil, ErrorForInvalidPatch(err)
+	}
+	if !reflect.DeepEqual(patchedTopic, deployedTopic) {
+		deployedTopic = patchedTopic
+		updated = true
+	}
+	if updated {
+		k.Log.Info("Updating kafka topic", "topicName", topicName)
+		if err := kubernetes.ResourceC(k.Client).Update(deployedTopic); err != nil {
+			return nil, err
+		}
+	}
+	return deployedTopic, nil
+}
+
+// ReleaseKafkaTopics removes the given service from the Kafka topics it no longer declares.
+// With the Delete policy, the topics created by the operator and no longer declared by any service are deleted.
+func (k *kafkaHandler) ReleaseKafkaTopics(kafkaName, kafkaNamespace, service string, declaredTopics []string, deletionPolicy api.KafkaTopicDeletionPolicy) error {
+	kafkaTopics := &v1beta2.KafkaTopicList{}
+	if err := kubernetes.ResourceC(k.Client).ListWithNamespaceAndLabel(kafkaNamespace, kafkaTopics, map[string]string{strimziBrokerLabel: kafkaName}); err != nil {
+		return err
+	}
+	declared := make(map[string]bool, len(declaredTopics))
+	for _, topic := range declaredTopics {
+		declared[topic] = true
+	}
+	for i := range kafkaTopics.Items {
+		kafkaTopic := &kafkaTopics.Items[i]
+		if declared[kafkaTopic.Name] || !removeKafkaTopicService(kafkaTopic, service) {
+			continue
+		}
+		if deletionPolicy == api.DeleteKafkaTopicDeletionPolicy && len(GetKafkaTopicServices(kafkaTopic)) == 0 && isKafkaTopicCreatedByOperator(kafkaTopic) {
+			k.Log.Info("Deleting kafka topic no longer declared", "topicName", kafkaTopic.Name)
+			if err := kubernetes.ResourceC(k.Client).Delete(kafkaTopic); err != nil {
+				return err
+			}
+			continue
+		}
+		k.Log.Debug("Kafka topic no longer declared by the service", "topicName", kafkaTopic.Name, "service", service)
+		if err := kubernetes.ResourceC(k.Client).Update(kafkaTopic); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isKafkaTopicCreatedByOperator checks if the given topic was created by the operator, the other topics are never deleted
+func isKafkaTopicCreatedByOperator(kafkaTopic *v1beta2.KafkaTopic) bool {
+	return kafkaTopic.GetLabels()[KafkaTopicCreatedByLabel] == kafkaTopicCreatedByValue
+}
+
+// getRequestedKafkaTopicPartitions returns the partitions explicitly given in the settings, 0 if none
+func getRequestedKafkaTopicPartitions(settings []api.KafkaTopicSettingsInterface) int32 {
+	for _, setting := range settings {
+		if setting.GetPartitions() > 0 {
+			return setting.GetPartitions()
+		}
+	}
+	return 0
+}
+
+// GetKafkaTopicServices returns the Kogito services declaring the given topic
+func GetKafkaTopicServices(kafkaTopic *v1beta2.KafkaTopic) []string {
+	services := kafkaTopic.GetAnnotations()[KafkaTopicServicesAnnotation]
+	if len(services) == 0 {
+		return nil
+	}
+	return strings.Split(services, ",")
+}
+
+func setKafkaTopicServices(kafkaTopic *v1beta2.KafkaTopic, services []string) {
+	annotations := kafkaTopic.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if len(services) == 0 {
+		delete(annotations, KafkaTopicServicesAnnotation)
+	} else {
+		sort.Strings(services)
+		annotations[KafkaTopicServicesAnnotation] = strings.Join(services, ",")
+	}
+	kafkaTopic.SetAnnotations(annotations)
+}
+
+// addKafkaTopicService records that the given service declares the topic, returns false when it was already recorded
+func addKafkaTopicService(kafkaTopic *v1beta2.KafkaTopic, service string) bool {
+	services := GetKafkaTopicServices(kafkaTopic)
+	for _, declaringService := range services {
+		if declaringService == service {
+			return false
+		}
+	}
+	setKafkaTopicServices(kafkaTopic, append(services, service))
+	return true
+}
+
+// removeKafkaTopicService records that the given service no longer declares the topic, returns false when it wasn't recorded
+func removeKafkaTopicService(kafkaTopic *v1beta2.KafkaTopic, service string) bool {
+	services := GetKafkaTopicServices(kafkaTopic)
+	for i, declaringService := range services {
+		if declaringService == service {
+			setKafkaTopicServices(kafkaTopic, append(services[:i], services[i+1:]...))
+			return true
+		}
+	}
+	return false
+}
+
+// kafkaTopicSettings are the topic settings required by the operator itself, eg: one partition per replica of a consuming service
+type kafkaTopicSettings struct {
+	partitions        int32
+	replicas          int32
+	retention         *metav1.Duration
+	cleanupPolicy     api.KafkaTopicCleanupPolicy
+	minInSyncReplicas int32
+}
+
+// NewKafkaTopicPartitionsSettings returns topic settings requiring the given partitions
+func NewKafkaTopicPartitionsSettings(partitions int32) api.KafkaTopicSettingsInterface {
+	return &kafkaTopicSettings{partitions: partitions}
+}
+
+func (k *kafkaTopicSettings) GetPartitions() int32 {
+	return k.partitions
+}
+
+func (k *kafkaTopicSettings) SetPartitions(partitions int32) {
+	k.partitions = partitions
+}
+
+func (k *kafkaTopicSettings) GetReplicas() int32 {
+	return k.replicas
+}
+
+func (k *kafkaTopicSettings) SetReplicas(replicas int32) {
+	k.replicas = replicas
+}
+
+func (k *kafkaTopicSettings) GetRetention() *metav1.Duration {
+	return k.retention
+}
+
+func (k *kafkaTopicSettings) SetRetention(retention *metav1.Duration) {
+	k.retention = retention
+}
+
+func (k *kafkaTopicSettings) GetCleanupPolicy() api.KafkaTopicCleanupPolicy {
+	return k.cleanupPolicy
+}
+
+func (k *kafkaTopicSettings) SetCleanupPolicy(cleanupPolicy api.KafkaTopicCleanupPolicy) {
+	k.cleanupPolicy = cleanupPolicy
+}
+
+func (k *kafkaTopicSettings) GetMinInSyncReplicas() int32 {
+	return k.minInSyncReplicas
+}
+
+func (k *kafkaTopicSettings) SetMinInSyncReplicas(minInSyncReplicas int32) {
+	k.minInSyncReplicas = minInSyncReplicas
+}
+
+// getKafkaConfigValue returns the given configuration value as the string Kafka reads, the numbers are decoded as floats from the JSON
+func getKafkaConfigValue(value interface{}) string {
+	if number, ok := value.(float64); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// getKafkaTopic returns a Kafka topic resource with the first value set in the given settings or the default configuration
+func getKafkaTopic(name, namespace, kafkaBroker string, settings ...api.KafkaTopicSettingsInterface) *v1beta2.KafkaTopic {
 
 	labels := make(map[string]string)
 	labels[strimziBrokerLabel] = kafkaBroker
 
-	return &v1beta2.KafkaTopic{
+	kafkaTopic := &v1beta2.KafkaTopic{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
 			Labels:    labels,
 		},
 		Spec: v1beta2.KafkaTopicSpec{
-			Partitions: defaultKafkaTopicPartition,
-			Replicas:   defaultKafkaTopicReplicas,
-			TopicName:  name,
+			TopicName: name,
 		},
 	}
+	config := v1beta2.KafkaMap{}
+	for _, setting := range settings {
+		if kafkaTopic.Spec.Partitions == 0 {
+			kafkaTopic.Spec.Partitions = setting.GetPartitions()
+		}
+		if kafkaTopic.Spec.Replicas == 0 {
+			kafkaTopic.Spec.Replicas = setting.GetReplicas()
+		}
+		if _, ok := config[kafkaTopicRetentionConfig]; !ok && setting.GetRetention() != nil {
+			config[kafkaTopicRetentionConfig] = strconv.FormatInt(setting.GetRetention().Milliseconds(), 10)
+		}
+		if _, ok := config[kafkaTopicCleanupPolicyConfig]; !ok && len(setting.GetCleanupPolicy()) > 0 {
+			config[kafkaTopicCleanupPolicyConfig] = string(setting.GetCleanupPolicy())
+		}
+		if _, ok := config[kafkaTopicMinInSyncReplicasConfig]; !ok && setting.GetMinInSyncReplicas() > 0 {
+			config[kafkaTopicMinInSyncReplicasConfig] = strconv.Itoa(int(setting.GetMinInSyncReplicas()))
+		}
+	}
+	if kafkaTopic.Spec.Partitions == 0 {
+		kafkaTopic.Spec.Partitions = defaultKafkaTopicPartition
+	}
+	if kafkaTopic.Spec.Replicas == 0 {
+		kafkaTopic.Spec.Replicas = defaultKafkaTopicReplicas
+	}
+	if len(config) > 0 {
+		kafkaTopic.Spec.Config = config
+	}
+	return kafkaTopic
+}
+
+func (k *kafkaHandler) FetchKafkaUser(key types.NamespacedName) (*v1beta2.KafkaUser, error) {
+	k.Log.Debug("Going to load kafka user", "userName", key.Name)
+	kafkaUser := &v1beta2.KafkaUser{}
+	if exists, err := kubernetes.ResourceC(k.Client).FetchWithKey(key, kafkaUser); err != nil {
+		k.Log.Error(err, "Error occurs while fetching kafka user", "userName", key.Name)
+		return nil, err
+	} else if exists {
+		k.Log.Debug("kafka user found", "userName", key.Name)
+		return kafkaUser, nil
+	}
+	k.Log.Debug("kafka user not exists", "userName", key.Name)
+	return nil, nil
+}
+
+// CreateKafkaUser creates the given KafkaUser, it's owned by the given owner when they are in the same namespace
+func (k *kafkaHandler) CreateKafkaUser(userName, kafkaName, kafkaNamespace string, authentication v1beta2.KafkaAuthenticationType, owner client.Object) (*v1beta2.KafkaUser, error) {
+	k.Log.Debug("Going to create kafka user", "userName", userName, "authentication", authentication)
+	kafkaUser := &v1beta2.KafkaUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      userName,
+			Namespace: kafkaNamespace,
+			Labels:    map[string]string{strimziBrokerLabel: kafkaName},
+		},
+		Spec: v1beta2.KafkaUserSpec{
+			Authentication: v1beta2.KafkaUserAuthentication{AuthenticationType: authentication},
+		},
+	}
+	if owner.GetNamespace() == kafkaNamespace {
+		if err := framework.SetOwner(owner, k.Scheme, kafkaUser); err != nil {
+			return nil, err
+		}
+	}
+	if err := kubernetes.ResourceC(k.Client).Create(kafkaUser); err != nil {
+		k.Log.Error(err, "Error occurs while creating kafka user")
+		return nil, err
+	}
+	k.Log.Debug("Kafka user created successfully", "userName", userName)
+	return kafkaUser, nil
+}
+
+// ResolveKafkaListener returns the listener of the kafka instance with the given name or type
+func (k *kafkaHandler) ResolveKafkaListener(kafka *v1beta2.Kafka, name, listenerType string) (*v1beta2.ListenerStatus, error) {
+	k.Log.Debug("Resolving kafka listener", "kafka instance", kafka.Name, "name", name, "type", listenerType)
+	if listener := ResolveKafkaListener(kafka, name, listenerType); listener != nil {
+		return listener, nil
+	}
+	return nil, fmt.Errorf("not able resolve listener for given kafka instance %s", kafka.Name)
 }
 
 // ResolveKafkaServerURI returns the uri of the kafka instance
@@ -142,21 +529,79 @@ func (k *kafkaHandler) ResolveKafkaServerURI(kafka *v1beta2.Kafka) (string, erro
 
 // ResolveKafkaServerURI returns the uri of the kafka instance
 func ResolveKafkaServerURI(kafka *v1beta2.Kafka) string {
-	if len(kafka.Status.Listeners) > 0 {
-		for _, listenerStatus := range kafka.Status.Listeners {
-			if listenerStatus.Type == "plain" && len(listenerStatus.Addresses) > 0 {
-				for _, listenerAddress := range listenerStatus.Addresses {
-					if len(listenerAddress.Host) > 0 && listenerAddress.Port > 0 {
-						kafkaURI := fmt.Sprintf("%s:%d", listenerAddress.Host, listenerAddress.Port)
-						return kafkaURI
-					}
+	if listener := ResolveKafkaListener(kafka, "", ""); listener != nil {
+		return GetKafkaListenerURI(listener)
+	}
+	return ""
+}
+
+// ResolveKafkaListener returns the listener of the kafka instance matching the given name or type with an address.
+// The type is the type of listener in the Kafka spec, e.g. internal or route.
+// Without name nor type, the plain listener is preferred to the tls one.
+func ResolveKafkaListener(kafka *v1beta2.Kafka, name, listenerType string) *v1beta2.ListenerStatus {
+	if len(name) > 0 || len(listenerType) > 0 {
+		for i := range kafka.Status.Listeners {
+			listenerStatus := &kafka.Status.Listeners[i]
+			if len(GetKafkaListenerURI(listenerStatus)) == 0 {
+				continue
+			}
+			if len(name) > 0 && name != getKafkaListenerName(listenerStatus) {
+				continue
+			}
+			if len(listenerType) > 0 {
+				listenerSpec := GetKafkaListenerSpec(kafka, listenerStatus)
+				if listenerSpec == nil || listenerSpec.ListenerType != listenerType {
+					continue
 				}
 			}
+			return listenerStatus
+		}
+		return nil
+	}
+	for _, defaultName := range []string{defaultKafkaListenerName, defaultKafkaTLSListenerName} {
+		if listener := ResolveKafkaListener(kafka, defaultName, ""); listener != nil {
+			return listener
+		}
+	}
+	return nil
+}
+
+// GetKafkaListenerSpec returns the listener declared in the Kafka spec for the given listener status
+func GetKafkaListenerSpec(kafka *v1beta2.Kafka, listener *v1beta2.ListenerStatus) *v1beta2.GenericKafkaListener {
+	name := getKafkaListenerName(listener)
+	for i, listenerSpec := range kafka.Spec.Kafka.Listeners {
+		if listenerSpec.Name == name {
+			return &kafka.Spec.Kafka.Listeners[i]
+		}
+	}
+	return nil
+}
+
+// GetKafkaListenerURI returns the bootstrap servers of the given listener
+func GetKafkaListenerURI(listener *v1beta2.ListenerStatus) string {
+	if len(listener.BootstrapServers) > 0 {
+		return listener.BootstrapServers
+	}
+	for _, listenerAddress := range listener.Addresses {
+		if len(listenerAddress.Host) > 0 && listenerAddress.Port > 0 {
+			return fmt.Sprintf("%s:%d", listenerAddress.Host, listenerAddress.Port)
 		}
 	}
 	return ""
 }
 
+func getKafkaListenerName(listener *v1beta2.ListenerStatus) string {
+	if len(listener.Name) > 0 {
+		return listener.Name
+	}
+	return listener.Type
+}
+
+// GetKafkaClusterCACertSecretName returns the name of the secret holding the CA certificate of the given Kafka cluster
+func GetKafkaClusterCACertSecretName(kafka *v1beta2.Kafka) string {
+	return fmt.Sprintf(KafkaClusterCACertSecretName, kafka.Name)
+}
+
 // IsKafkaResource checks if provided KogitoInfra instance is for kafka resource
 func IsKafkaResource(apiVersion, kind string) bool {
 	return apiVersion == KafkaAPIVersion && kind == KafkaKind
@@ -53,6 +53,7 @@ func (kafkaMap *KafkaMap) DeepCopy() *KafkaMap {
 
 // KafkaClusterSpec defines the desired state of Kafka Cluster
 type KafkaClusterSpec struct {
+	Version    string                 `json:"version,omitempty"`
 	Replicas   int32                  `json:"replicas,omitempty"`
 	Listeners  []GenericKafkaListener `json:"listeners,omitempty"`
 	Storage    KafkaStorage           `json:"storage,omitempty"`
@@ -66,8 +67,25 @@ type GenericKafkaListener struct {
 	Port         int    `json:"port,omitempty"`
 	ListenerType string `json:"type,omitempty"`
 	TLS          bool   `json:"tls"`
+	// Authentication of the clients connecting to the listener
+	Authentication *KafkaListenerAuthentication `json:"authentication,omitempty"`
 }
 
+// KafkaListenerAuthentication ...
+type KafkaListenerAuthentication struct {
+	AuthenticationType KafkaAuthenticationType `json:"type,omitempty"`
+}
+
+// KafkaAuthenticationType defines the enum for the authentication of Kafka listeners and users
+type KafkaAuthenticationType string
+
+const (
+	// KafkaScramSha512Authentication authenticates the clients with SASL SCRAM-SHA-512
+	KafkaScramSha512Authentication KafkaAuthenticationType = "scram-sha-512"
+	// KafkaTLSAuthentication authenticates the clients with their TLS certificate (mTLS)
+	KafkaTLSAuthentication KafkaAuthenticationType = "tls"
+)
+
 // ZookeeperClusterSpec Representation of a Strimzi-managed ZooKeeper "cluster".
 type ZookeeperClusterSpec struct {
 	Replicas int32        `json:"replicas,omitempty"`
@@ -113,8 +131,12 @@ const (
 
 // ListenerStatus defines a single listener
 type ListenerStatus struct {
-	Type      string            `json:"type,omitempty"`
-	Addresses []ListenerAddress `json:"addresses,omitempty"`
+	// Type is deprecated by Strimzi in favour of the name, it holds the name of the listener
+	Type             string            `json:"type,omitempty"`
+	Name             string            `json:"name,omitempty"`
+	Addresses        []ListenerAddress `json:"addresses,omitempty"`
+	BootstrapServers string            `json:"bootstrapServers,omitempty"`
+	Certificates     []string          `json:"certificates,omitempty"`
 }
 
 // ListenerAddress defines a single address of particular listener
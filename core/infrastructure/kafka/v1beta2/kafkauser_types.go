@@ -0,0 +1,58 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KafkaUserSpec defines the desired state of KafkaUser
+type KafkaUserSpec struct {
+	Authentication KafkaUserAuthentication `json:"authentication,omitempty"`
+}
+
+// KafkaUserAuthentication ...
+type KafkaUserAuthentication struct {
+	AuthenticationType KafkaAuthenticationType `json:"type,omitempty"`
+}
+
+// KafkaUserStatus defines the observed state of KafkaUser
+type KafkaUserStatus struct {
+	Username   string           `json:"username,omitempty"`
+	Secret     string           `json:"secret,omitempty"`
+	Conditions []KafkaCondition `json:"conditions,omitempty"`
+}
+
+// KafkaUser is the Schema for the kafkausers API
+// +kubebuilder:object:root=true
+type KafkaUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KafkaUserSpec   `json:"spec,omitempty"`
+	Status KafkaUserStatus `json:"status,omitempty"`
+}
+
+// KafkaUserList contains a list of KafkaUser
+// +kubebuilder:object:root=true
+type KafkaUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KafkaUser{}, &KafkaUserList{})
+}
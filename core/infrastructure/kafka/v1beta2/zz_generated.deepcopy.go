@@ -73,6 +73,11 @@ func (in *EntityUserOperatorSpec) DeepCopy() *EntityUserOperatorSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GenericKafkaListener) DeepCopyInto(out *GenericKafkaListener) {
 	*out = *in
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(KafkaListenerAuthentication)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericKafkaListener.
@@ -118,7 +123,9 @@ func (in *KafkaClusterSpec) DeepCopyInto(out *KafkaClusterSpec) {
 	if in.Listeners != nil {
 		in, out := &in.Listeners, &out.Listeners
 		*out = make([]GenericKafkaListener, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	out.Storage = in.Storage
 	in.Config.DeepCopyInto(&out.Config)
@@ -182,6 +189,21 @@ func (in *KafkaList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaListenerAuthentication) DeepCopyInto(out *KafkaListenerAuthentication) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaListenerAuthentication.
+func (in *KafkaListenerAuthentication) DeepCopy() *KafkaListenerAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaListenerAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in KafkaMap) DeepCopyInto(out *KafkaMap) {
 	{
@@ -256,7 +278,7 @@ func (in *KafkaTopic) DeepCopyInto(out *KafkaTopic) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
@@ -313,6 +335,7 @@ func (in *KafkaTopicList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaTopicSpec) DeepCopyInto(out *KafkaTopicSpec) {
 	*out = *in
+	in.Config.DeepCopyInto(&out.Config)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopicSpec.
@@ -340,6 +363,116 @@ func (in *KafkaTopicStatus) DeepCopy() *KafkaTopicStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaUser) DeepCopyInto(out *KafkaUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUser.
+func (in *KafkaUser) DeepCopy() *KafkaUser {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaUserAuthentication) DeepCopyInto(out *KafkaUserAuthentication) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUserAuthentication.
+func (in *KafkaUserAuthentication) DeepCopy() *KafkaUserAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaUserAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaUserList) DeepCopyInto(out *KafkaUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KafkaUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUserList.
+func (in *KafkaUserList) DeepCopy() *KafkaUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaUserSpec) DeepCopyInto(out *KafkaUserSpec) {
+	*out = *in
+	out.Authentication = in.Authentication
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUserSpec.
+func (in *KafkaUserSpec) DeepCopy() *KafkaUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaUserStatus) DeepCopyInto(out *KafkaUserStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]KafkaCondition, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUserStatus.
+func (in *KafkaUserStatus) DeepCopy() *KafkaUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ListenerAddress) DeepCopyInto(out *ListenerAddress) {
 	*out = *in
@@ -363,6 +496,11 @@ func (in *ListenerStatus) DeepCopyInto(out *ListenerStatus) {
 		*out = make([]ListenerAddress, len(*in))
 		copy(*out, *in)
 	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerStatus.
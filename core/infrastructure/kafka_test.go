@@ -15,15 +15,20 @@
 package infrastructure
 
 import (
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
 	"github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
 	"github.com/kiegroup/kogito-operator/core/infrastructure/kafka/v1beta2"
 	"github.com/kiegroup/kogito-operator/core/operator"
 	"github.com/kiegroup/kogito-operator/core/test"
 	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func Test_getKafkaInstanceWithName(t *testing.T) {
@@ -165,3 +170,171 @@ func Test_resolveKafkaServerURI(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveKafkaListener(t *testing.T) {
+	kafka := &v1beta2.Kafka{
+		Spec: v1beta2.KafkaSpec{
+			Kafka: v1beta2.KafkaClusterSpec{
+				Listeners: []v1beta2.GenericKafkaListener{
+					{Name: "tls", ListenerType: "internal", TLS: true},
+					{Name: "external", ListenerType: "route", TLS: true},
+				},
+			},
+		},
+		Status: v1beta2.KafkaStatus{
+			Listeners: []v1beta2.ListenerStatus{
+				{Name: "tls", Type: "tls", BootstrapServers: "kafka-bootstrap:9093"},
+				{Name: "external", Type: "external", Addresses: []v1beta2.ListenerAddress{{Host: "kafka.apps", Port: 443}}},
+			},
+		},
+	}
+
+	// the tls listener is used when there is no plain listener
+	assert.Equal(t, "kafka-bootstrap:9093", ResolveKafkaServerURI(kafka))
+	assert.Equal(t, "kafka.apps:443", GetKafkaListenerURI(ResolveKafkaListener(kafka, "external", "")))
+	assert.Equal(t, "kafka.apps:443", GetKafkaListenerURI(ResolveKafkaListener(kafka, "", "route")))
+	assert.Nil(t, ResolveKafkaListener(kafka, "tls", "route"))
+	assert.Nil(t, ResolveKafkaListener(kafka, "missing", ""))
+	assert.Equal(t, "external", GetKafkaListenerSpec(kafka, &kafka.Status.Listeners[1]).Name)
+}
+
+func TestKafkaHandler_ApplyKafkaTopic(t *testing.T) {
+	ns := t.Name()
+	cli := test.NewFakeClientBuilder().Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	kafkaHandler := NewKafkaHandler(context)
+	topicSettings := &v1beta1.KafkaTopicConfig{
+		Name:               "travellers",
+		KafkaTopicSettings: v1beta1.KafkaTopicSettings{Partitions: 3, Retention: &v1.Duration{Duration: time.Hour}},
+	}
+	defaults := &v1beta1.KafkaTopicSettings{Partitions: 2, Replicas: 2, CleanupPolicy: api.CompactKafkaTopicCleanupPolicy}
+
+	kafkaTopic, err := kafkaHandler.ApplyKafkaTopic("travellers", "kogito-kafka", ns, ns+"/travels", []api.KafkaTopicSettingsInterface{topicSettings, defaults})
+	assert.NoError(t, err)
+	kafkaTopic, err = kafkaHandler.FetchKafkaTopic(types.NamespacedName{Name: "travellers", Namespace: ns})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), kafkaTopic.Spec.Partitions)
+	assert.Equal(t, int32(2), kafkaTopic.Spec.Replicas)
+	assert.Equal(t, "3600000", kafkaTopic.Spec.Config["retention.ms"])
+	assert.Equal(t, "compact", kafkaTopic.Spec.Config["cleanup.policy"])
+	assert.Equal(t, "kogito-kafka", kafkaTopic.Labels["strimzi.io/cluster"])
+	assert.Equal(t, "kogito-operator", kafkaTopic.Labels[KafkaTopicCreatedByLabel])
+	assert.Equal(t, []string{ns + "/travels"}, GetKafkaTopicServices(kafkaTopic))
+
+	// drift of the given settings is reverted, but the partitions are never decreased, the replicas never changed
+	// and the configurations not given are kept
+	kafkaTopic.Spec.Partitions = 6
+	kafkaTopic.Spec.Replicas = 1
+	kafkaTopic.Spec.Config["retention.ms"] = float64(1000)
+	kafkaTopic.Spec.Config["min.insync.replicas"] = "2"
+	assert.NoError(t, kubernetes.ResourceC(cli).Update(kafkaTopic))
+	_, err = kafkaHandler.ApplyKafkaTopic("travellers", "kogito-kafka", ns, ns+"/visas", []api.KafkaTopicSettingsInterface{topicSettings, defaults})
+	assert.NoError(t, err)
+	kafkaTopic, err = kafkaHandler.FetchKafkaTopic(types.NamespacedName{Name: "travellers", Namespace: ns})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(6), kafkaTopic.Spec.Partitions)
+	assert.Equal(t, int32(1), kafkaTopic.Spec.Replicas)
+	assert.Equal(t, "3600000", kafkaTopic.Spec.Config["retention.ms"])
+	assert.Equal(t, "2", kafkaTopic.Spec.Config["min.insync.replicas"])
+	assert.Equal(t, []string{ns + "/travels", ns + "/visas"}, GetKafkaTopicServices(kafkaTopic))
+}
+
+func TestKafkaHandler_ApplyKafkaTopicDefaultsOnlyOnCreation(t *testing.T) {
+	ns := t.Name()
+	kafkaTopic := getKafkaTopic("travellers", ns, "kogito-kafka")
+	kafkaTopic.Spec.Partitions = 3
+	kafkaTopic.Spec.Replicas = 3
+	kafkaTopic.Spec.Config = v1beta2.KafkaMap{"retention.ms": "1000", "cleanup.policy": "compact"}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kafkaTopic).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	kafkaHandler := NewKafkaHandler(context)
+
+	// the topic created outside the operator keeps its settings
+	_, err := kafkaHandler.ApplyKafkaTopic("travellers", "kogito-kafka", ns, ns+"/travels", nil)
+	assert.NoError(t, err)
+	kafkaTopic, err = kafkaHandler.FetchKafkaTopic(types.NamespacedName{Name: "travellers", Namespace: ns})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), kafkaTopic.Spec.Partitions)
+	assert.Equal(t, int32(3), kafkaTopic.Spec.Replicas)
+	assert.Equal(t, v1beta2.KafkaMap{"retention.ms": "1000", "cleanup.policy": "compact"}, kafkaTopic.Spec.Config)
+	assert.NotContains(t, kafkaTopic.Labels, KafkaTopicCreatedByLabel)
+	assert.Equal(t, []string{ns + "/travels"}, GetKafkaTopicServices(kafkaTopic))
+}
+
+func TestKafkaHandler_ApplyKafkaTopicPatchesAfterCreation(t *testing.T) {
+	ns := t.Name()
+	cli := test.NewFakeClientBuilder().Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	kafkaHandler := NewKafkaHandler(context)
+
+	_, err := kafkaHandler.ApplyKafkaTopic("travellers", "kogito-kafka", ns, ns+"/travels", nil)
+	assert.NoError(t, err)
+	patch := v1beta1.KogitoPatch{Kind: "KafkaTopic", Name: "travellers", Type: api.MergePatchType, Patch: `{"metadata":{"labels":{"team":"travels"}}}`}
+	_, err = kafkaHandler.ApplyKafkaTopic("travellers", "kogito-kafka", ns, ns+"/travels", nil, patch)
+	assert.NoError(t, err)
+	kafkaTopic, err := kafkaHandler.FetchKafkaTopic(types.NamespacedName{Name: "travellers", Namespace: ns})
+	assert.NoError(t, err)
+	assert.Equal(t, "travels", kafkaTopic.Labels["team"])
+}
+
+func TestKafkaHandler_ReleaseKafkaTopics(t *testing.T) {
+	ns := t.Name()
+	newKafkaTopic := func(name string, services string) *v1beta2.KafkaTopic {
+		kafkaTopic := getKafkaTopic(name, ns, "kogito-kafka")
+		kafkaTopic.Labels[KafkaTopicCreatedByLabel] = "kogito-operator"
+		kafkaTopic.Annotations = map[string]string{KafkaTopicServicesAnnotation: services}
+		return kafkaTopic
+	}
+	external := newKafkaTopic("external", ns+"/travels")
+	delete(external.Labels, KafkaTopicCreatedByLabel)
+	cli := test.NewFakeClientBuilder().AddK8sObjects(
+		newKafkaTopic("declared", ns+"/travels"),
+		newKafkaTopic("shared", ns+"/travels,"+ns+"/visas"),
+		newKafkaTopic("removed", ns+"/travels"),
+		newKafkaTopic("other", ns+"/visas"),
+		external).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	kafkaHandler := NewKafkaHandler(context)
+
+	assert.NoError(t, kafkaHandler.ReleaseKafkaTopics("kogito-kafka", ns, ns+"/travels", []string{"declared"}, api.DeleteKafkaTopicDeletionPolicy))
+
+	declared, err := kafkaHandler.FetchKafkaTopic(types.NamespacedName{Name: "declared", Namespace: ns})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ns + "/travels"}, GetKafkaTopicServices(declared))
+	shared, err := kafkaHandler.FetchKafkaTopic(types.NamespacedName{Name: "shared", Namespace: ns})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ns + "/visas"}, GetKafkaTopicServices(shared))
+	removed, err := kafkaHandler.FetchKafkaTopic(types.NamespacedName{Name: "removed", Namespace: ns})
+	assert.NoError(t, err)
+	assert.Nil(t, removed)
+	other, err := kafkaHandler.FetchKafkaTopic(types.NamespacedName{Name: "other", Namespace: ns})
+	assert.NoError(t, err)
+	assert.NotNil(t, other)
+	// the topics not created by the operator are only forgotten
+	external, err = kafkaHandler.FetchKafkaTopic(types.NamespacedName{Name: "external", Namespace: ns})
+	assert.NoError(t, err)
+	assert.NotNil(t, external)
+	assert.Empty(t, GetKafkaTopicServices(external))
+
+	// the Retain policy only forgets the service
+	assert.NoError(t, kafkaHandler.ReleaseKafkaTopics("kogito-kafka", ns, ns+"/visas", nil, api.RetainKafkaTopicDeletionPolicy))
+	shared, err = kafkaHandler.FetchKafkaTopic(types.NamespacedName{Name: "shared", Namespace: ns})
+	assert.NoError(t, err)
+	assert.Empty(t, GetKafkaTopicServices(shared))
+}
@@ -15,8 +15,15 @@
 package infrastructure
 
 import (
+	"github.com/kiegroup/kogito-operator/apis"
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/framework"
 	"github.com/kiegroup/kogito-operator/core/infrastructure/keycloak/v1alpha1"
 	"github.com/kiegroup/kogito-operator/core/operator"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -27,13 +34,12 @@ const (
 var (
 	// KeycloakAPIVersion refers to kafka APIVersion
 	KeycloakAPIVersion = v1alpha1.SchemeGroupVersion.String()
-
-	keycloakServerGroup = v1alpha1.SchemeGroupVersion.Group
 )
 
 // KeycloakHandler ...
 type KeycloakHandler interface {
 	IsKeycloakAvailable() bool
+	CreateKeycloakInstance(key types.NamespacedName, provision api.InfraProvisionInterface, owner client.Object) (*v1alpha1.Keycloak, error)
 }
 
 type keycloakHandler struct {
@@ -49,5 +55,29 @@ func NewKeycloakHandler(context operator.Context) KeycloakHandler {
 
 // IsKeycloakAvailable checks if Strimzi CRD is available in the cluster
 func (k *keycloakHandler) IsKeycloakAvailable() bool {
-	return k.Client.HasServerGroup(keycloakServerGroup)
+	return k.Client.HasCapability(kogitocli.KeycloakCapability)
+}
+
+// CreateKeycloakInstance creates a minimal Keycloak with external access from the given provision template, owned by the given owner
+func (k *keycloakHandler) CreateKeycloakInstance(key types.NamespacedName, provision api.InfraProvisionInterface, owner client.Object) (*v1alpha1.Keycloak, error) {
+	k.Log.Debug("Going to provision keycloak instance", "name", key.Name)
+	keycloakInstance := &v1alpha1.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: v1alpha1.KeycloakSpec{
+			Instances:      int(getProvisionReplicas(provision)),
+			ExternalAccess: v1alpha1.KeycloakExternalAccess{Enabled: true},
+		},
+	}
+	if err := framework.SetOwner(owner, k.Scheme, keycloakInstance); err != nil {
+		return nil, err
+	}
+	if err := kubernetes.ResourceC(k.Client).Create(keycloakInstance); err != nil {
+		k.Log.Error(err, "Error occurs while provisioning keycloak instance")
+		return nil, err
+	}
+	k.Log.Info("Keycloak instance provisioned", "name", key.Name)
+	return keycloakInstance, nil
 }
@@ -15,11 +15,17 @@
 package infrastructure
 
 import (
+	"reflect"
+
+	"github.com/RHsyseng/operator-utils/pkg/resource/compare"
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/framework"
 	"github.com/kiegroup/kogito-operator/core/operator"
 	"k8s.io/apimachinery/pkg/types"
-	"knative.dev/eventing/pkg/apis/eventing"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -36,6 +42,10 @@ var (
 type KnativeHandler interface {
 	IsKnativeEventingAvailable() bool
 	FetchBroker(key types.NamespacedName) (*eventingv1.Broker, error)
+	FetchTriggersForOwner(owner client.Object, labels map[string]string) ([]client.Object, error)
+	GetTriggerComparator() compare.MapComparator
+	FetchSinkBindingsForOwner(owner client.Object, labels map[string]string) ([]client.Object, error)
+	GetSinkBindingComparator() compare.MapComparator
 }
 
 type knativeHandler struct {
@@ -51,7 +61,7 @@ func NewKnativeHandler(context operator.Context) KnativeHandler {
 
 // IsKnativeEventingAvailable checks if Knative Eventing CRDs are available in the cluster
 func (k *knativeHandler) IsKnativeEventingAvailable() bool {
-	return k.Client.HasServerGroup(eventing.GroupName)
+	return k.Client.HasCapability(kogitocli.KnativeEventingCapability)
 }
 
 func (k *knativeHandler) FetchBroker(key types.NamespacedName) (*eventingv1.Broker, error) {
@@ -64,6 +74,56 @@ func (k *knativeHandler) FetchBroker(key types.NamespacedName) (*eventingv1.Brok
 	return broker, nil
 }
 
+// FetchTriggersForOwner fetches the Triggers with the given labels owned by the given object
+func (k *knativeHandler) FetchTriggersForOwner(owner client.Object, labels map[string]string) ([]client.Object, error) {
+	triggers := &eventingv1.TriggerList{}
+	if err := kubernetes.ResourceC(k.Client).ListWithNamespaceAndLabel(owner.GetNamespace(), triggers, labels); err != nil {
+		return nil, err
+	}
+	var owned []client.Object
+	for i := range triggers.Items {
+		if framework.IsOwner(&triggers.Items[i], owner) {
+			owned = append(owned, &triggers.Items[i])
+		}
+	}
+	return owned, nil
+}
+
+func (k *knativeHandler) GetTriggerComparator() compare.MapComparator {
+	resourceComparator := compare.DefaultComparator()
+	resourceComparator.SetComparator(
+		framework.NewComparatorBuilder().
+			WithType(reflect.TypeOf(eventingv1.Trigger{})).
+			WithCustomComparator(framework.CreateTriggerComparator()).
+			Build())
+	return compare.MapComparator{Comparator: resourceComparator}
+}
+
+// FetchSinkBindingsForOwner fetches the SinkBindings with the given labels owned by the given object
+func (k *knativeHandler) FetchSinkBindingsForOwner(owner client.Object, labels map[string]string) ([]client.Object, error) {
+	sinkBindings := &sourcesv1.SinkBindingList{}
+	if err := kubernetes.ResourceC(k.Client).ListWithNamespaceAndLabel(owner.GetNamespace(), sinkBindings, labels); err != nil {
+		return nil, err
+	}
+	var owned []client.Object
+	for i := range sinkBindings.Items {
+		if framework.IsOwner(&sinkBindings.Items[i], owner) {
+			owned = append(owned, &sinkBindings.Items[i])
+		}
+	}
+	return owned, nil
+}
+
+func (k *knativeHandler) GetSinkBindingComparator() compare.MapComparator {
+	resourceComparator := compare.DefaultComparator()
+	resourceComparator.SetComparator(
+		framework.NewComparatorBuilder().
+			WithType(reflect.TypeOf(sourcesv1.SinkBinding{})).
+			WithCustomComparator(framework.CreateSinkBindingComparator()).
+			Build())
+	return compare.MapComparator{Comparator: resourceComparator}
+}
+
 // IsKnativeEventingResource checks if provided KogitoInfra instance is for Knative eventing resource
 func IsKnativeEventingResource(apiVersion, kind string) bool {
 	return apiVersion == KnativeEventingAPIVersion && kind == KnativeEventingBrokerKind
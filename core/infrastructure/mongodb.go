@@ -15,12 +15,16 @@
 package infrastructure
 
 import (
+	"github.com/kiegroup/kogito-operator/apis"
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/framework"
 	mongodb "github.com/kiegroup/kogito-operator/core/infrastructure/mongodb/v1"
 	"github.com/kiegroup/kogito-operator/core/operator"
 	v1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -31,6 +35,10 @@ const (
 	DefaultMongoDBAuthDatabase = "admin"
 	// DefaultMongoDBPasswordSecretRef is the default key for the secret reference in MongoDB
 	DefaultMongoDBPasswordSecretRef = "password"
+	// DefaultMongoDBVersion is the version of the MongoDB provisioned when the provision template doesn't set it
+	DefaultMongoDBVersion = "4.4.6"
+	// mongoDBScramAuthMode is the SCRAM authentication mode of the provisioned MongoDB
+	mongoDBScramAuthMode mongodb.AuthMode = "SCRAM"
 
 	// MongoDBKind refers to MongoDB Kind
 	MongoDBKind = "MongoDB"
@@ -48,8 +56,6 @@ const (
 var (
 	// MongoDBAPIVersion refers to MongoDB APIVersion
 	MongoDBAPIVersion = mongodb.SchemeBuilder.GroupVersion.String()
-
-	mongoDBServerGroup = mongodb.GroupVersion.Group
 )
 
 // MongoDBHandler ...
@@ -57,6 +63,7 @@ type MongoDBHandler interface {
 	IsMongoDBAvailable() bool
 	IsMongoDBOperatorAvailable(namespace string) (bool, error)
 	FetchMongoDBInstance(key types.NamespacedName) (*mongodb.MongoDBCommunity, error)
+	CreateMongoDBInstance(key types.NamespacedName, provision api.InfraProvisionInterface, users []mongodb.MongoDBUser, owner client.Object) (*mongodb.MongoDBCommunity, error)
 }
 
 type mongoDBHandler struct {
@@ -72,7 +79,7 @@ func NewMongoDBHandler(context operator.Context) MongoDBHandler {
 
 // IsMongoDBAvailable checks if MongoDB CRD is available in the cluster
 func (m *mongoDBHandler) IsMongoDBAvailable() bool {
-	return m.Client.HasServerGroup(mongoDBServerGroup)
+	return m.Client.HasCapability(kogitocli.MongoDBCapability)
 }
 
 // IsMongoDBOperatorAvailable verify if MongoDB Operator is running in the given namespace and the CRD is available
@@ -113,3 +120,37 @@ func (m *mongoDBHandler) FetchMongoDBInstance(key types.NamespacedName) (*mongod
 		return mongoDBInstance, nil
 	}
 }
+
+// CreateMongoDBInstance creates a minimal MongoDB replica set with SCRAM authentication for the given users from the given provision template,
+// owned by the given owner
+func (m *mongoDBHandler) CreateMongoDBInstance(key types.NamespacedName, provision api.InfraProvisionInterface, users []mongodb.MongoDBUser, owner client.Object) (*mongodb.MongoDBCommunity, error) {
+	m.Log.Debug("Going to provision mongoDB instance", "name", key.Name)
+	version := DefaultMongoDBVersion
+	if provision != nil && len(provision.GetVersion()) > 0 {
+		version = provision.GetVersion()
+	}
+	mongoDBInstance := &mongodb.MongoDBCommunity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: mongodb.MongoDBCommunitySpec{
+			Members: int(getProvisionReplicas(provision)),
+			Type:    mongodb.ReplicaSet,
+			Version: version,
+			Security: mongodb.Security{
+				Authentication: mongodb.Authentication{Modes: []mongodb.AuthMode{mongoDBScramAuthMode}},
+			},
+			Users: users,
+		},
+	}
+	if err := framework.SetOwner(owner, m.Scheme, mongoDBInstance); err != nil {
+		return nil, err
+	}
+	if err := kubernetes.ResourceC(m.Client).Create(mongoDBInstance); err != nil {
+		m.Log.Error(err, "Error occurs while provisioning mongoDB instance")
+		return nil, err
+	}
+	m.Log.Info("MongoDB instance provisioned", "name", key.Name)
+	return mongoDBInstance, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"github.com/kiegroup/kogito-operator/apis"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const defaultProvisionReplicas int32 = 1
+
+// getProvisionReplicas returns the replicas of the given provision template, defaulting to one replica
+func getProvisionReplicas(provision api.InfraProvisionInterface) int32 {
+	if provision == nil || provision.GetReplicas() == nil || *provision.GetReplicas() < 1 {
+		return defaultProvisionReplicas
+	}
+	return *provision.GetReplicas()
+}
+
+// IsInfraResourceProvisioned checks if the resource of the given KogitoInfra is provisioned by the operator,
+// which is the case when no resource name is given and a provision template is set
+func IsInfraResourceProvisioned(instance api.KogitoInfraInterface) bool {
+	return !instance.GetSpec().IsResourceEmpty() && len(instance.GetSpec().GetResource().GetName()) == 0 && instance.GetSpec().GetProvision() != nil
+}
+
+// GetInfraResourceKey returns the key of the resource referenced by the given KogitoInfra, defaulting to its namespace.
+// The resource provisioned by the operator is named after the KogitoInfra, in its namespace.
+// Nil when the resource is neither referenced nor provisioned.
+func GetInfraResourceKey(instance api.KogitoInfraInterface) *types.NamespacedName {
+	if IsInfraResourceProvisioned(instance) {
+		return &types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+	}
+	if instance.GetSpec().IsResourceEmpty() || len(instance.GetSpec().GetResource().GetName()) == 0 {
+		return nil
+	}
+	namespace := instance.GetSpec().GetResource().GetNamespace()
+	if len(namespace) == 0 {
+		namespace = instance.GetNamespace()
+	}
+	return &types.NamespacedName{Name: instance.GetSpec().GetResource().GetName(), Namespace: namespace}
+}
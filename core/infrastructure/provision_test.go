@@ -0,0 +1,56 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestGetInfraResourceKey(t *testing.T) {
+	newInfra := func(resource *v1beta1.InfraResource, provision *v1beta1.InfraProvision) *v1beta1.KogitoInfra {
+		return &v1beta1.KogitoInfra{
+			ObjectMeta: v1.ObjectMeta{Name: "kogito-kafka-infra", Namespace: "kogito"},
+			Spec:       v1beta1.KogitoInfraSpec{Resource: resource, Provision: provision},
+		}
+	}
+	tests := []struct {
+		name  string
+		infra *v1beta1.KogitoInfra
+		want  *types.NamespacedName
+	}{
+		{"Referenced", newInfra(&v1beta1.InfraResource{Name: "kafka", Namespace: "kafka"}, nil), &types.NamespacedName{Name: "kafka", Namespace: "kafka"}},
+		{"Referenced in the same namespace", newInfra(&v1beta1.InfraResource{Name: "kafka"}, &v1beta1.InfraProvision{}), &types.NamespacedName{Name: "kafka", Namespace: "kogito"}},
+		{"Provisioned", newInfra(&v1beta1.InfraResource{}, &v1beta1.InfraProvision{}), &types.NamespacedName{Name: "kogito-kafka-infra", Namespace: "kogito"}},
+		{"Neither referenced nor provisioned", newInfra(&v1beta1.InfraResource{}, nil), nil},
+		{"No resource", newInfra(nil, &v1beta1.InfraProvision{}), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetInfraResourceKey(tt.infra))
+		})
+	}
+}
+
+func Test_getProvisionReplicas(t *testing.T) {
+	replicas := int32(3)
+	assert.Equal(t, int32(1), getProvisionReplicas(nil))
+	assert.Equal(t, int32(1), getProvisionReplicas(&v1beta1.InfraProvision{}))
+	assert.Equal(t, replicas, getProvisionReplicas(&v1beta1.InfraProvision{Replicas: &replicas}))
+}
@@ -15,6 +15,8 @@
 package infrastructure
 
 import (
+	"fmt"
+
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
 	"github.com/kiegroup/kogito-operator/core/operator"
 	v1 "k8s.io/api/core/v1"
@@ -52,6 +54,11 @@ func NewRBACHandler(context operator.Context) RBACHandler {
 }
 
 func (r *rbacHandler) SetupRBAC(namespace string) (err error) {
+	// the operator is granted the permissions to create the service viewer role only in the namespaces it watches
+	if !r.Client.IsNamespaceWatched(namespace) {
+		return fmt.Errorf("Namespace %s isn't watched by the operator, can't create the service viewer role ", namespace)
+	}
+
 	// create service viewer role
 	if err = kubernetes.ResourceC(r.Client).CreateIfNotExists(getServiceViewerRole(namespace)); err != nil {
 		r.Log.Error(err, "Fail to create role for service viewer")
@@ -17,9 +17,11 @@ package infrastructure
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/kiegroup/kogito-operator/core/operator"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -65,6 +67,52 @@ const (
 	RouteProcessed ConditionReason = "RouteProcessed"
 	// RouteCreationFailureReason - Unable to properly create Route
 	RouteCreationFailureReason ConditionReason = "RouteCreationFailure"
+	// ExternalServiceReachableReason - The external service answered to the health check
+	ExternalServiceReachableReason ConditionReason = "ExternalServiceReachable"
+	// ExternalServiceNotReachableReason - The external service didn't answer to the health check
+	ExternalServiceNotReachableReason ConditionReason = "ExternalServiceNotReachable"
+	// ProtoBufCompatibleReason - The protobuf files are backward compatible with the previous ones
+	ProtoBufCompatibleReason ConditionReason = "ProtoBufCompatible"
+	// ProtoBufIncompatibleReason - The protobuf files break backward compatibility with the previous ones
+	ProtoBufIncompatibleReason ConditionReason = "ProtoBufIncompatible"
+	// InvalidStorageReason - The requested storage can't be used with the referenced infra
+	InvalidStorageReason ConditionReason = "InvalidStorage"
+	// StorageScalingDownReason - The service is being scaled down to change its storage
+	StorageScalingDownReason ConditionReason = "ScalingDown"
+	// StorageMigratingSchemaReason - The schema migration Job for the new storage is running
+	StorageMigratingSchemaReason ConditionReason = "MigratingSchema"
+	// StorageMigrationFailedReason - The schema migration Job for the new storage failed
+	StorageMigrationFailedReason ConditionReason = "SchemaMigrationFailed"
+	// StorageScalingUpReason - The service is being scaled up with the new storage
+	StorageScalingUpReason ConditionReason = "ScalingUp"
+	// StorageReadyReason - The service runs with the requested storage
+	StorageReadyReason ConditionReason = "StorageReady"
+	// TrustyStackInvalidInfraReason - The infra referenced by the Trusty stack can't be used by its services
+	TrustyStackInvalidInfraReason ConditionReason = "InvalidInfra"
+	// TrustyStackMissingKafkaTopicsReason - The Kafka topics consumed by the Trusty stack services don't exist
+	TrustyStackMissingKafkaTopicsReason ConditionReason = "MissingKafkaTopics"
+	// TrustyStackServicesNotReadyReason - Some of the Trusty stack services are not deployed yet
+	TrustyStackServicesNotReadyReason ConditionReason = "ServicesNotReady"
+	// TrustyStackReadyReason - All the Trusty stack services are deployed
+	TrustyStackReadyReason ConditionReason = "TrustyStackReady"
+	// LeaderElectionReason - The replicas of the service share a persistent storage and elect a leader
+	LeaderElectionReason ConditionReason = "LeaderElection"
+	// ReplicasRequirePersistenceReason - More than one replica was requested without a shared persistent storage
+	ReplicasRequirePersistenceReason ConditionReason = "ReplicasRequirePersistence"
+	// FieldManagerConflictReason - A field declared by the operator is owned by another field manager
+	FieldManagerConflictReason ConditionReason = "FieldManagerConflict"
+	// InvalidPatchReason - A patch declared in the CR can't be applied to the objects generated by the operator
+	InvalidPatchReason ConditionReason = "InvalidPatch"
+	// ImageDigestResolutionFailedReason - The image tag couldn't be resolved to a digest against the registry
+	ImageDigestResolutionFailedReason ConditionReason = "ImageDigestResolutionFailed"
+	// InfraDegradedReason - A resource referenced by a KogitoInfra used by the service is not healthy
+	InfraDegradedReason ConditionReason = "InfraDegraded"
+	// InfraHealthyReason - The resources referenced by the KogitoInfras used by the service are healthy
+	InfraHealthyReason ConditionReason = "InfraHealthy"
+	// VaultSecretNotMaterializedReason - A secret stored in Vault used by the service is not materialized in the namespace yet
+	VaultSecretNotMaterializedReason ConditionReason = "VaultSecretNotMaterialized"
+	// UnregisteredRuntimeReason - The runtime of the service has no runtime profile in the operator
+	UnregisteredRuntimeReason ConditionReason = "UnregisteredRuntime"
 )
 
 const (
@@ -179,6 +227,33 @@ func ErrorForProcessingProtoBufConfigMapDelta() ReconciliationError {
 	}
 }
 
+// ErrorForIncompatibleProtoBuf ...
+func ErrorForIncompatibleProtoBuf(serviceName string, issues []string) ReconciliationError {
+	return ReconciliationError{
+		reason:                 ProtoBufIncompatibleReason,
+		reconciliationInterval: ReconciliationAfterOneMinute,
+		innerError:             fmt.Errorf("Protobuf files of %s are not backward compatible, keeping the previous ones on Data Index: %s ", serviceName, strings.Join(issues, "; ")),
+	}
+}
+
+// ErrorForInvalidStorage ...
+func ErrorForInvalidStorage(serviceName string, message string) ReconciliationError {
+	return ReconciliationError{
+		reason:                 InvalidStorageReason,
+		reconciliationInterval: ReconciliationAfterOneMinute,
+		innerError:             fmt.Errorf("Invalid storage configuration for %s: %s ", serviceName, message),
+	}
+}
+
+// ErrorForStorageMigration ...
+func ErrorForStorageMigration(reason ConditionReason, message string) ReconciliationError {
+	return ReconciliationError{
+		reason:                 reason,
+		reconciliationInterval: ReconciliationAfterTen,
+		innerError:             fmt.Errorf("Storage migration in progress: %s ", message),
+	}
+}
+
 // ErrorForImageNotFound ...
 func ErrorForImageNotFound() ReconciliationError {
 	return ReconciliationError{
@@ -196,6 +271,87 @@ func ErrorForRouteCreation(err error) ReconciliationError {
 	}
 }
 
+// ErrorForExternalServiceNotReachable ...
+func ErrorForExternalServiceNotReachable(serviceURL string, err error) ReconciliationError {
+	return ReconciliationError{
+		reason:                 ExternalServiceNotReachableReason,
+		reconciliationInterval: ReconciliationAfterThirty,
+		innerError:             fmt.Errorf("External service %s is not reachable: %w ", serviceURL, err),
+	}
+}
+
+// ErrorForTrustyStackInvalidInfra ...
+func ErrorForTrustyStackInvalidInfra(serviceName string, message string) ReconciliationError {
+	return ReconciliationError{
+		reason:                 TrustyStackInvalidInfraReason,
+		reconciliationInterval: ReconciliationAfterOneMinute,
+		innerError:             fmt.Errorf("Trusty stack %s can't be deployed: %s ", serviceName, message),
+	}
+}
+
+// ErrorForTrustyStackMissingKafkaTopics ...
+func ErrorForTrustyStackMissingKafkaTopics(serviceName string, topics []string) ReconciliationError {
+	return ReconciliationError{
+		reason:                 TrustyStackMissingKafkaTopicsReason,
+		reconciliationInterval: ReconciliationAfterThirty,
+		innerError:             fmt.Errorf("Trusty stack %s is waiting for the Kafka topics %s ", serviceName, strings.Join(topics, ", ")),
+	}
+}
+
+// ErrorForTrustyStackServicesNotReady ...
+func ErrorForTrustyStackServicesNotReady(serviceName string, services []string) ReconciliationError {
+	return ReconciliationError{
+		reason:                 TrustyStackServicesNotReadyReason,
+		reconciliationInterval: ReconciliationAfterThirty,
+		innerError:             fmt.Errorf("Trusty stack %s is waiting for the services %s to be deployed ", serviceName, strings.Join(services, ", ")),
+	}
+}
+
+// ErrorForFieldManagerConflict ...
+func ErrorForFieldManagerConflict(kind string, name string, err error) ReconciliationError {
+	return ReconciliationError{
+		reason:                 FieldManagerConflictReason,
+		reconciliationInterval: ReconciliationAfterOneMinute,
+		innerError:             fmt.Errorf("Fields of %s %s are managed by another field manager, remove them from the other manager or from the operator requested resources: %w ", kind, name, err),
+	}
+}
+
+// ErrorForInvalidPatch ...
+func ErrorForInvalidPatch(err error) ReconciliationError {
+	return ReconciliationError{
+		reason:                 InvalidPatchReason,
+		reconciliationInterval: ReconciliationAfterOneMinute,
+		innerError:             fmt.Errorf("Invalid patch, fix it in the patches of the CR: %w ", err),
+	}
+}
+
+// ErrorForImageDigestResolution ...
+func ErrorForImageDigestResolution(image string, err error) ReconciliationError {
+	return ReconciliationError{
+		reason:                 ImageDigestResolutionFailedReason,
+		reconciliationInterval: ReconciliationAfterOneMinute,
+		innerError:             fmt.Errorf("Failed to resolve the digest of image %s, verify the image and its pull secrets: %w ", image, err),
+	}
+}
+
+// ErrorForVaultSecretNotMaterialized ...
+func ErrorForVaultSecretNotMaterialized(serviceName string, issues []string) ReconciliationError {
+	return ReconciliationError{
+		reason:                 VaultSecretNotMaterializedReason,
+		reconciliationInterval: ReconciliationAfterThirty,
+		innerError:             fmt.Errorf("KogitoService '%s' is waiting for its Vault secrets; skipping deployment; %s ", serviceName, strings.Join(issues, "; ")),
+	}
+}
+
+// ErrorForUnregisteredRuntime ...
+func ErrorForUnregisteredRuntime(serviceName string, runtime string) ReconciliationError {
+	return ReconciliationError{
+		reason:                 UnregisteredRuntimeReason,
+		reconciliationInterval: ReconciliationAfterThreeMinutes,
+		innerError:             fmt.Errorf("KogitoService '%s' uses the runtime %s, which has no runtime profile in the operator; skipping deployment ", serviceName, runtime),
+	}
+}
+
 // ReconciliationErrorHandler ...
 type ReconciliationErrorHandler interface {
 	IsReconciliationError(err error) bool
@@ -205,12 +361,23 @@ type ReconciliationErrorHandler interface {
 
 type reconciliationErrorHandler struct {
 	operator.Context
+	// backoffKey identifies the reconciled object to back off its requeues, no backoff when empty
+	backoffKey string
 }
 
 // NewReconciliationErrorHandler ...
 func NewReconciliationErrorHandler(context operator.Context) ReconciliationErrorHandler {
 	return &reconciliationErrorHandler{
-		context,
+		Context: context,
+	}
+}
+
+// NewReconciliationErrorHandlerFor creates a handler backing off the requeues of the given object while it keeps failing with the same reason,
+// see operator.Config. The failures are forgotten when the result for a nil error is requested, once the object is reconciled or deleted.
+func NewReconciliationErrorHandlerFor(context operator.Context, controller string, request types.NamespacedName) ReconciliationErrorHandler {
+	return &reconciliationErrorHandler{
+		Context:    context,
+		backoffKey: fmt.Sprintf("%s/%s", controller, request),
 	}
 }
 
@@ -224,12 +391,21 @@ func (r *reconciliationErrorHandler) IsReconciliationError(err error) bool {
 
 func (r *reconciliationErrorHandler) GetReconcileResultFor(err error) (ctrl.Result, error) {
 	reconcileResult := ctrl.Result{}
+	if err == nil {
+		if len(r.backoffKey) > 0 {
+			r.Backoff.Forget(r.backoffKey)
+		}
+		return reconcileResult, nil
+	}
 
 	// reconciliation always happens if we return an error
 	if r.IsReconciliationError(err) {
 		reconcileError := err.(ReconciliationError)
-		r.Log.Info("Waiting for all resources to be created, re-scheduling.", "reason", reconcileError.reason, "requeueAfter", reconcileError.reconciliationInterval)
 		reconcileResult.RequeueAfter = reconcileError.reconciliationInterval
+		if len(r.backoffKey) > 0 {
+			reconcileResult.RequeueAfter = r.Backoff.Next(r.backoffKey, string(reconcileError.reason), reconcileError.reconciliationInterval)
+		}
+		r.Log.Info("Waiting for all resources to be created, re-scheduling.", "reason", reconcileError.reason, "requeueAfter", reconcileResult.RequeueAfter)
 		return reconcileResult, nil
 	}
 	return reconcileResult, err
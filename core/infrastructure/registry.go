@@ -0,0 +1,347 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	dockerHubDomain         = "docker.io"
+	dockerHubRegistryDomain = "registry-1.docker.io"
+	dockerHubLibraryPrefix  = "library/"
+	digestSeparator         = "@"
+	digestHeader            = "Docker-Content-Digest"
+	registryTimeout         = 30 * time.Second
+)
+
+// manifestMediaTypes are the manifests accepted when resolving a tag, the image index comes first so the digest is the same
+// one pulled by the nodes regardless of their architecture
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// RegistryCredentials are the credentials to authenticate against an image registry
+type RegistryCredentials struct {
+	Username string
+	Password string
+}
+
+// RegistryClient resolves image tags to digests against the images registries
+type RegistryClient interface {
+	// ResolveDigest returns the digest of the manifest that the given image tag points to, e.g. "sha256:..."
+	ResolveDigest(ctx context.Context, image string) (string, error)
+}
+
+type registryClient struct {
+	client      *http.Client
+	credentials map[string]RegistryCredentials
+	scheme      string
+}
+
+// NewRegistryClient creates a RegistryClient using the given credentials per registry domain.
+// When insecure is true the registry certificates aren't verified.
+func NewRegistryClient(insecure bool, credentials map[string]RegistryCredentials) RegistryClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if insecure {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // #nosec G402 requested by the user for insecure registries
+	}
+	return newRegistryClient(&http.Client{Transport: transport, Timeout: registryTimeout}, credentials)
+}
+
+func newRegistryClient(client *http.Client, credentials map[string]RegistryCredentials) *registryClient {
+	if credentials == nil {
+		credentials = map[string]RegistryCredentials{}
+	}
+	return &registryClient{client: client, credentials: credentials, scheme: "https"}
+}
+
+// ParseImageReference splits an image like "quay.io/kiegroup/kogito-runtime-jvm:latest" into registry domain, repository and tag.
+// Images without domain are resolved from Docker Hub, images without tag use "latest".
+// The tag is empty if the image is already referenced by digest.
+func ParseImageReference(image string) (domain, repository, tag string) {
+	name := image
+	if i := strings.Index(name, digestSeparator); i >= 0 {
+		name = name[:i]
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		tag = name[i+1:]
+		name = name[:i]
+	} else {
+		tag = LatestTag
+	}
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		domain, repository = parts[0], parts[1]
+	} else {
+		domain, repository = dockerHubDomain, name
+	}
+	if domain == dockerHubDomain && !strings.Contains(repository, "/") {
+		repository = dockerHubLibraryPrefix + repository
+	}
+	return
+}
+
+// GetImageDigestReference returns the given image referenced by digest, the tag is kept to know which one it was resolved from
+// and is ignored when pulling, e.g. "quay.io/kiegroup/kogito-runtime-jvm:latest@sha256:..."
+func GetImageDigestReference(image, digest string) string {
+	name, _ := SplitImageDigestReference(image)
+	return name + digestSeparator + digest
+}
+
+// SplitImageDigestReference returns the image and the digest of the given image reference, the digest is empty if the image isn't referenced by digest
+func SplitImageDigestReference(reference string) (image, digest string) {
+	if i := strings.Index(reference, digestSeparator); i >= 0 {
+		return reference[:i], reference[i+len(digestSeparator):]
+	}
+	return reference, ""
+}
+
+func (r *registryClient) ResolveDigest(ctx context.Context, image string) (string, error) {
+	domain, repository, tag := ParseImageReference(image)
+	if len(tag) == 0 {
+		return image[strings.Index(image, digestSeparator)+1:], nil
+	}
+	host := domain
+	if host == dockerHubDomain {
+		host = dockerHubRegistryDomain
+	}
+	manifestURL := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", r.scheme, host, repository, tag)
+	credentials, hasCredentials := r.credentials[domain]
+
+	// HEAD avoids downloading the manifest and doesn't count as a pull on rate limited registries
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		response, err := r.doWithAuth(ctx, method, manifestURL, repository, credentials, hasCredentials)
+		if err != nil {
+			return "", err
+		}
+		body, err := ioutil.ReadAll(response.Body)
+		_ = response.Body.Close()
+		if err != nil {
+			return "", err
+		}
+		if response.StatusCode != http.StatusOK {
+			if method == http.MethodHead && response.StatusCode != http.StatusUnauthorized && response.StatusCode != http.StatusNotFound {
+				continue
+			}
+			return "", fmt.Errorf("registry %s replied %s for image %s", host, response.Status, image)
+		}
+		if digest := response.Header.Get(digestHeader); len(digest) > 0 {
+			return digest, nil
+		}
+		if method == http.MethodGet {
+			return fmt.Sprintf("sha256:%x", sha256.Sum256(body)), nil
+		}
+	}
+	return "", fmt.Errorf("registry %s didn't return the digest of image %s", host, image)
+}
+
+func (r *registryClient) doWithAuth(ctx context.Context, method, manifestURL, repository string, credentials RegistryCredentials, hasCredentials bool) (*http.Response, error) {
+	response, err := r.do(ctx, method, manifestURL, "")
+	if err != nil || response.StatusCode != http.StatusUnauthorized {
+		return response, err
+	}
+	challenge := response.Header.Get("WWW-Authenticate")
+	_ = response.Body.Close()
+	scheme, params := parseAuthChallenge(challenge)
+	var authorization string
+	switch strings.ToLower(scheme) {
+	case "bearer":
+		token, err := r.fetchToken(ctx, params, repository, credentials, hasCredentials)
+		if err != nil {
+			return nil, err
+		}
+		authorization = "Bearer " + token
+	case "basic":
+		if !hasCredentials {
+			return nil, fmt.Errorf("registry requires credentials to access %s", repository)
+		}
+		authorization = "Basic " + basicAuth(credentials)
+	default:
+		return nil, fmt.Errorf("unsupported registry authentication challenge %q", challenge)
+	}
+	return r.do(ctx, method, manifestURL, authorization)
+}
+
+func (r *registryClient) do(ctx context.Context, method, manifestURL, authorization string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if len(authorization) > 0 {
+		request.Header.Set("Authorization", authorization)
+	}
+	return r.client.Do(request)
+}
+
+// fetchToken gets a token from the authorization server, see https://docs.docker.com/registry/spec/auth/token/
+func (r *registryClient) fetchToken(ctx context.Context, params map[string]string, repository string, credentials RegistryCredentials, hasCredentials bool) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || len(realm.Host) == 0 {
+		return "", fmt.Errorf("invalid registry authentication realm %q", params["realm"])
+	}
+	query := realm.Query()
+	if service, ok := params["service"]; ok {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if len(scope) == 0 {
+		scope = fmt.Sprintf("repository:%s:pull", repository)
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if hasCredentials {
+		request.SetBasicAuth(credentials.Username, credentials.Password)
+	}
+	response, err := r.client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry authentication server %s replied %s", realm.Host, response.Status)
+	}
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err = json.NewDecoder(response.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	if len(token.Token) > 0 {
+		return token.Token, nil
+	}
+	return token.AccessToken, nil
+}
+
+// parseAuthChallenge parses headers like `Bearer realm="https://auth.docker.io/token",service="registry.docker.io"`
+func parseAuthChallenge(challenge string) (scheme string, params map[string]string) {
+	params = map[string]string{}
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	scheme = parts[0]
+	if len(parts) < 2 {
+		return
+	}
+	rest := parts[1]
+	for len(rest) > 0 {
+		eq := strings.Index(rest, "=")
+		if eq < 0 {
+			return
+		}
+		key := strings.ToLower(strings.TrimSpace(rest[:eq]))
+		rest = rest[eq+1:]
+		var value string
+		if strings.HasPrefix(rest, "\"") {
+			end := strings.Index(rest[1:], "\"")
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else if comma := strings.Index(rest, ","); comma >= 0 {
+			value, rest = rest[:comma], rest[comma:]
+		} else {
+			value, rest = rest, ""
+		}
+		params[key] = value
+		rest = strings.TrimLeft(rest, ", ")
+	}
+	return
+}
+
+func basicAuth(credentials RegistryCredentials) string {
+	return base64.StdEncoding.EncodeToString([]byte(credentials.Username + ":" + credentials.Password))
+}
+
+// dockerConfig is the content of the .dockerconfigjson and .dockercfg pull secrets
+type dockerConfig map[string]struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Auth     string `json:"auth"`
+}
+
+// FetchRegistryCredentials reads the registry credentials from the given image pull secrets, the missing secrets are ignored
+func FetchRegistryCredentials(secretHandler SecretHandler, namespace string, pullSecrets []corev1.LocalObjectReference) (map[string]RegistryCredentials, error) {
+	credentials := map[string]RegistryCredentials{}
+	for _, pullSecret := range pullSecrets {
+		secret, err := secretHandler.FetchSecret(types.NamespacedName{Name: pullSecret.Name, Namespace: namespace})
+		if err != nil {
+			return nil, err
+		} else if secret == nil {
+			continue
+		}
+		var config dockerConfig
+		if data, ok := secret.Data[corev1.DockerConfigJsonKey]; ok {
+			configJSON := struct {
+				Auths dockerConfig `json:"auths"`
+			}{}
+			if err = json.Unmarshal(data, &configJSON); err != nil {
+				return nil, fmt.Errorf("invalid pull secret %s: %v", secret.Name, err)
+			}
+			config = configJSON.Auths
+		} else if data, ok := secret.Data[corev1.DockerConfigKey]; ok {
+			if err = json.Unmarshal(data, &config); err != nil {
+				return nil, fmt.Errorf("invalid pull secret %s: %v", secret.Name, err)
+			}
+		}
+		for server, auth := range config {
+			entry := RegistryCredentials{Username: auth.Username, Password: auth.Password}
+			if len(auth.Auth) > 0 {
+				if decoded, err := base64.StdEncoding.DecodeString(auth.Auth); err == nil {
+					if userPassword := strings.SplitN(string(decoded), ":", 2); len(userPassword) == 2 {
+						entry = RegistryCredentials{Username: userPassword[0], Password: userPassword[1]}
+					}
+				}
+			}
+			domain := registryDomain(server)
+			if _, exists := credentials[domain]; !exists {
+				credentials[domain] = entry
+			}
+		}
+	}
+	return credentials, nil
+}
+
+// registryDomain normalizes the servers of the pull secrets, like "https://index.docker.io/v1/", to image domains
+func registryDomain(server string) string {
+	domain := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	domain = strings.SplitN(domain, "/", 2)[0]
+	switch domain {
+	case "index.docker.io", dockerHubRegistryDomain, "registry.hub.docker.com":
+		return dockerHubDomain
+	}
+	return domain
+}
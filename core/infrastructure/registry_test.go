@@ -0,0 +1,140 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testDigest = "sha256:4a5ba0e2a0d3b3c2f9e1c6cbbf2e7b0a5d7f0c4a3c9f7e0b1d2c3e4f5a6b7c8d"
+
+func Test_ParseImageReference(t *testing.T) {
+	tests := []struct {
+		image      string
+		domain     string
+		repository string
+		tag        string
+	}{
+		{"quay.io/kiegroup/kogito-runtime-jvm:1.0", "quay.io", "kiegroup/kogito-runtime-jvm", "1.0"},
+		{"quay.io/kiegroup/kogito-runtime-jvm", "quay.io", "kiegroup/kogito-runtime-jvm", LatestTag},
+		{"localhost:5000/app:snapshot", "localhost:5000", "app", "snapshot"},
+		{"nginx", dockerHubDomain, "library/nginx", LatestTag},
+		{"kiegroup/app:1.0", dockerHubDomain, "kiegroup/app", "1.0"},
+		{"quay.io/kiegroup/app@" + testDigest, "quay.io", "kiegroup/app", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			domain, repository, tag := ParseImageReference(tt.image)
+			assert.Equal(t, tt.domain, domain)
+			assert.Equal(t, tt.repository, repository)
+			assert.Equal(t, tt.tag, tag)
+		})
+	}
+	assert.Equal(t, "localhost:5000/app:snapshot@"+testDigest, GetImageDigestReference("localhost:5000/app:snapshot", testDigest))
+	image, digest := SplitImageDigestReference(GetImageDigestReference("localhost:5000/app:snapshot@sha256:0", testDigest))
+	assert.Equal(t, "localhost:5000/app:snapshot", image)
+	assert.Equal(t, testDigest, digest)
+}
+
+func TestRegistryClient_ResolveDigest_BearerToken(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			username, password, ok := r.BasicAuth()
+			if !ok || username != "user" || password != "secret" || r.URL.Query().Get("scope") != "repository:kiegroup/app:pull" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"token":"abc"}`)
+		case r.Header.Get("Authorization") != "Bearer abc":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:kiegroup/app:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/kiegroup/app/manifests/1.0":
+			assert.Contains(t, r.Header.Get("Accept"), "application/vnd.docker.distribution.manifest.v2+json")
+			w.Header().Set(digestHeader, testDigest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	domain := strings.TrimPrefix(server.URL, "https://")
+	client := newRegistryClient(server.Client(), map[string]RegistryCredentials{domain: {Username: "user", Password: "secret"}})
+
+	digest, err := client.ResolveDigest(context.TODO(), domain+"/kiegroup/app:1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, testDigest, digest)
+
+	_, err = client.ResolveDigest(context.TODO(), domain+"/kiegroup/missing:1.0")
+	assert.Error(t, err)
+}
+
+func TestRegistryClient_ResolveDigest_WithoutDigestHeader(t *testing.T) {
+	manifest := `{"schemaVersion":2}`
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		_, _ = fmt.Fprint(w, manifest)
+	}))
+	defer server.Close()
+	domain := strings.TrimPrefix(server.URL, "https://")
+
+	digest, err := newRegistryClient(server.Client(), nil).ResolveDigest(context.TODO(), domain+"/app")
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(manifest))), digest)
+}
+
+func Test_FetchRegistryCredentials(t *testing.T) {
+	ns := t.Name()
+	dockerConfigJSON := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "config-json", Namespace: ns},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths":{"https://index.docker.io/v1/":{"auth":"dXNlcjpzZWNyZXQ="}}}`),
+		},
+	}
+	dockerConfig := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: ns},
+		Type:       corev1.SecretTypeDockercfg,
+		Data: map[string][]byte{
+			corev1.DockerConfigKey: []byte(`{"quay.io":{"username":"robot","password":"token"}}`),
+		},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(dockerConfigJSON, dockerConfig).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	credentials, err := FetchRegistryCredentials(NewSecretHandler(context), ns, []corev1.LocalObjectReference{{Name: "config-json"}, {Name: "config"}, {Name: "missing"}})
+	assert.NoError(t, err)
+	assert.Equal(t, RegistryCredentials{Username: "user", Password: "secret"}, credentials[dockerHubDomain])
+	assert.Equal(t, RegistryCredentials{Username: "robot", Password: "token"}, credentials["quay.io"])
+}
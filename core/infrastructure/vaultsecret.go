@@ -0,0 +1,229 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/framework/util"
+	externalsecrets "github.com/kiegroup/kogito-operator/core/infrastructure/externalsecrets/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// VaultSecretStoreEnvVar is the name of the ClusterSecretStore reading the Vault secrets referenced without a role
+	VaultSecretStoreEnvVar = "VAULT_SECRET_STORE"
+	// DefaultVaultSecretStore is the ClusterSecretStore used when VaultSecretStoreEnvVar is not set
+	DefaultVaultSecretStore = "vault"
+
+	// vaultSecretRefreshInterval is the interval the External Secrets Operator syncs the materialized Secrets with Vault,
+	// the services are rolled out when their content changes
+	vaultSecretRefreshInterval = time.Hour
+)
+
+// VaultSecretHandler materializes the secrets stored in HashiCorp Vault in Secrets of the namespace with ExternalSecrets
+type VaultSecretHandler interface {
+	IsExternalSecretsAvailable() bool
+	// ReconcileVaultSecrets creates or updates the ExternalSecrets of the given references owned by the given owner and releases the ones
+	// of the references removed from the owner, it returns the issues of the references whose Secret is not materialized yet
+	ReconcileVaultSecrets(references []api.VaultSecretReferenceInterface, owner client.Object) ([]string, error)
+}
+
+type vaultSecretHandler struct {
+	operator.Context
+	secretHandler SecretHandler
+}
+
+// NewVaultSecretHandler ...
+func NewVaultSecretHandler(context operator.Context) VaultSecretHandler {
+	return &vaultSecretHandler{
+		Context:       context,
+		secretHandler: NewSecretHandler(context),
+	}
+}
+
+// IsExternalSecretsAvailable checks if the External Secrets Operator CRDs are available in the cluster
+func (v *vaultSecretHandler) IsExternalSecretsAvailable() bool {
+	return v.Client.HasCapability(kogitocli.ExternalSecretsCapability)
+}
+
+func (v *vaultSecretHandler) ReconcileVaultSecrets(references []api.VaultSecretReferenceInterface, owner client.Object) ([]string, error) {
+	var issues []string
+	referenced := map[string]bool{}
+	for _, reference := range references {
+		referenced[reference.GetName()] = true
+		externalSecret, issue, err := v.reconcileExternalSecret(reference, owner)
+		if err != nil {
+			return nil, err
+		}
+		if len(issue) > 0 {
+			issues = append(issues, fmt.Sprintf("Secret %s: %s", reference.GetName(), issue))
+			continue
+		}
+		secret, err := v.secretHandler.FetchSecret(types.NamespacedName{Name: reference.GetName(), Namespace: owner.GetNamespace()})
+		if err != nil {
+			return nil, err
+		}
+		if secret == nil {
+			issues = append(issues, fmt.Sprintf("Secret %s: %s", reference.GetName(), getExternalSecretSyncMessage(externalSecret)))
+		}
+	}
+	if err := v.releaseExternalSecrets(referenced, owner); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// reconcileExternalSecret creates the ExternalSecret of the given reference or adds the owner to it, the services and infras referencing
+// the same Vault secret share it. It returns an issue if the ExternalSecret reads another Vault secret for its other owners.
+func (v *vaultSecretHandler) reconcileExternalSecret(reference api.VaultSecretReferenceInterface, owner client.Object) (*externalsecrets.ExternalSecret, string, error) {
+	requested := newExternalSecret(reference, owner.GetNamespace())
+	deployed := &externalsecrets.ExternalSecret{}
+	exists, err := kubernetes.ResourceC(v.Client).FetchWithKey(types.NamespacedName{Name: requested.Name, Namespace: requested.Namespace}, deployed)
+	if err != nil {
+		return nil, "", err
+	}
+	if !exists {
+		if err := framework.AddOwnerReference(owner, v.Scheme, requested); err != nil {
+			return nil, "", err
+		}
+		v.Log.Info("Creating ExternalSecret for Vault secret", "name", requested.Name, "path", reference.GetPath())
+		if err := kubernetes.ResourceC(v.Client).Create(requested); err != nil {
+			return nil, "", err
+		}
+		return requested, "", nil
+	}
+	if !reflect.DeepEqual(requested.Spec, deployed.Spec) {
+		if isExternalSecretShared(deployed, owner) {
+			return nil, fmt.Sprintf("ExternalSecret %s already materializes another Vault secret for %s", deployed.Name, strings.Join(getOtherOwnerNames(deployed, owner), ", ")), nil
+		}
+		v.Log.Info("Updating ExternalSecret for Vault secret", "name", requested.Name, "path", reference.GetPath())
+		deployed.Spec = requested.Spec
+	} else if framework.IsOwner(deployed, owner) {
+		return deployed, "", nil
+	}
+	if err := framework.AddOwnerReference(owner, v.Scheme, deployed); err != nil {
+		return nil, "", err
+	}
+	if err := kubernetes.ResourceC(v.Client).Update(deployed); err != nil {
+		return nil, "", err
+	}
+	return deployed, "", nil
+}
+
+// releaseExternalSecrets removes the owner from the ExternalSecrets of the references it doesn't list anymore,
+// the ExternalSecrets left without owner are deleted with the Secrets they materialized
+func (v *vaultSecretHandler) releaseExternalSecrets(referenced map[string]bool, owner client.Object) error {
+	externalSecrets := &externalsecrets.ExternalSecretList{}
+	if err := kubernetes.ResourceC(v.Client).ListWithNamespace(owner.GetNamespace(), externalSecrets); err != nil {
+		return err
+	}
+	for i := range externalSecrets.Items {
+		externalSecret := &externalSecrets.Items[i]
+		if referenced[externalSecret.Name] || !framework.IsOwner(externalSecret, owner) {
+			continue
+		}
+		if framework.RemoveSharedOwnerReference(owner, externalSecret) {
+			v.Log.Info("Releasing ExternalSecret of Vault secret no longer referenced", "name", externalSecret.Name)
+			if err := kubernetes.ResourceC(v.Client).Update(externalSecret); err != nil {
+				return err
+			}
+			continue
+		}
+		v.Log.Info("Deleting ExternalSecret of Vault secret no longer referenced", "name", externalSecret.Name)
+		if err := kubernetes.ResourceC(v.Client).Delete(externalSecret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isExternalSecretShared checks if the ExternalSecret has owners other than the given one
+func isExternalSecretShared(externalSecret *externalsecrets.ExternalSecret, owner client.Object) bool {
+	return len(getOtherOwnerNames(externalSecret, owner)) > 0
+}
+
+func getOtherOwnerNames(externalSecret *externalsecrets.ExternalSecret, owner client.Object) []string {
+	var names []string
+	for _, ownerReference := range externalSecret.GetOwnerReferences() {
+		if ownerReference.UID != owner.GetUID() {
+			names = append(names, ownerReference.Kind+" "+ownerReference.Name)
+		}
+	}
+	return names
+}
+
+// newExternalSecret creates the ExternalSecret reading the referenced Vault secret in a Secret with the name of the reference
+func newExternalSecret(reference api.VaultSecretReferenceInterface, namespace string) *externalsecrets.ExternalSecret {
+	externalSecret := &externalsecrets.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      reference.GetName(),
+			Namespace: namespace,
+		},
+		Spec: externalsecrets.ExternalSecretSpec{
+			SecretStoreRef: getVaultSecretStoreRef(reference),
+			Target: externalsecrets.ExternalSecretTarget{
+				Name:           reference.GetName(),
+				CreationPolicy: externalsecrets.CreatePolicyOwner,
+			},
+			RefreshInterval: &metav1.Duration{Duration: vaultSecretRefreshInterval},
+		},
+	}
+	if len(reference.GetKey()) > 0 {
+		externalSecret.Spec.Data = []externalsecrets.ExternalSecretData{
+			{
+				SecretKey: reference.GetKey(),
+				RemoteRef: externalsecrets.ExternalSecretDataRemoteRef{Key: reference.GetPath(), Property: reference.GetKey()},
+			},
+		}
+	} else {
+		externalSecret.Spec.DataFrom = []externalsecrets.ExternalSecretDataFromRemoteRef{
+			{Extract: &externalsecrets.ExternalSecretDataRemoteRef{Key: reference.GetPath()}},
+		}
+	}
+	return externalSecret
+}
+
+// getVaultSecretStoreRef returns the SecretStore of the namespace set in the reference,
+// or the ClusterSecretStore configured in the operator when the reference has none
+func getVaultSecretStoreRef(reference api.VaultSecretReferenceInterface) externalsecrets.SecretStoreRef {
+	if len(reference.GetSecretStore()) > 0 {
+		return externalsecrets.SecretStoreRef{Name: reference.GetSecretStore(), Kind: externalsecrets.SecretStoreKind}
+	}
+	return externalsecrets.SecretStoreRef{
+		Name: util.GetOSEnv(VaultSecretStoreEnvVar, DefaultVaultSecretStore),
+		Kind: externalsecrets.ClusterSecretStoreKind,
+	}
+}
+
+func getExternalSecretSyncMessage(externalSecret *externalsecrets.ExternalSecret) string {
+	for _, condition := range externalSecret.Status.Conditions {
+		if condition.Type == externalsecrets.ExternalSecretReady && condition.Status == corev1.ConditionFalse && len(condition.Message) > 0 {
+			return condition.Message
+		}
+	}
+	return "waiting for the External Secrets Operator to read the Vault secret"
+}
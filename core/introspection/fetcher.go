@@ -0,0 +1,147 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package introspection
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// DefaultTimeout is the maximum time a single request to a runtime endpoint can take
+	DefaultTimeout = time.Second * 5
+
+	// caFileEnvVar is the PEM CA bundle used to verify the runtimes serving their endpoints over TLS
+	caFileEnvVar = "KOGITO_INTROSPECTION_CA_FILE"
+	// certFileEnvVar is the PEM client certificate presented by the operator to the runtimes requiring mTLS
+	certFileEnvVar = "KOGITO_INTROSPECTION_CERT_FILE"
+	// keyFileEnvVar is the PEM private key of the client certificate
+	keyFileEnvVar = "KOGITO_INTROSPECTION_KEY_FILE"
+)
+
+// DefaultBackoff is the retry policy of a failed request to a runtime endpoint
+var DefaultBackoff = wait.Backoff{
+	Duration: time.Second,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    3,
+}
+
+// Fetcher gets the documents served by a runtime, each request is bounded by a timeout and retried with backoff on failures
+type Fetcher struct {
+	client  *http.Client
+	baseURL string
+	backoff wait.Backoff
+}
+
+// NewFetcher creates a Fetcher for the runtime served at the given URL, the TLS configuration is optional
+func NewFetcher(baseURL string, tlsConfig *tls.Config) *Fetcher {
+	return newFetcher(newHTTPClient(tlsConfig), baseURL, DefaultBackoff)
+}
+
+func newFetcher(client *http.Client, baseURL string, backoff wait.Backoff) *Fetcher {
+	return &Fetcher{
+		client:  client,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		backoff: backoff,
+	}
+}
+
+func newHTTPClient(tlsConfig *tls.Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	return &http.Client{Timeout: DefaultTimeout, Transport: transport}
+}
+
+// Get returns the body served at the given path of the runtime, nil if the path is not found
+func (f *Fetcher) Get(ctx context.Context, path string) (body []byte, err error) {
+	requestURL := f.baseURL + path
+	var lastErr error
+	backoffErr := wait.ExponentialBackoffWithContext(ctx, f.backoff, func() (bool, error) {
+		body, lastErr = f.get(ctx, requestURL)
+		if lastErr == nil {
+			return true, nil
+		}
+		log.Debug("Failed to fetch runtime endpoint, retrying", "url", requestURL, "error", lastErr)
+		return false, nil
+	})
+	if backoffErr != nil {
+		if lastErr != nil {
+			return nil, lastErr
+		}
+		return nil, backoffErr
+	}
+	return body, nil
+}
+
+func (f *Fetcher) get(ctx context.Context, requestURL string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := f.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, infrastructure.ErrorForServiceNotReachable(response.StatusCode, requestURL, http.MethodGet)
+	}
+	return ioutil.ReadAll(response.Body)
+}
+
+// NewTLSConfigFromEnv creates the TLS configuration used to reach the runtimes from the CA bundle and client certificate files
+// configured in the operator environment, nil if none is configured
+func NewTLSConfigFromEnv() (*tls.Config, error) {
+	caFile, certFile, keyFile := os.Getenv(caFileEnvVar), os.Getenv(certFileEnvVar), os.Getenv(keyFileEnvVar)
+	if len(caFile) == 0 && len(certFile) == 0 {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(caFile) > 0 {
+		caBundle, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("No PEM certificate found in %s ", caFile)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	if len(certFile) > 0 {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
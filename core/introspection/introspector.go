@@ -0,0 +1,183 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package introspection
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+const (
+	// DefaultPollInterval is the time between two polls of the same runtime endpoints
+	DefaultPollInterval = time.Minute
+	eventsBufferSize    = 100
+)
+
+// Probe fetches and decodes the information exposed by a runtime, the result is cached by the Introspector
+type Probe func(ctx context.Context, fetcher *Fetcher) (interface{}, error)
+
+// Target is a runtime introspected by the Introspector
+type Target struct {
+	// Object is the reconciled object deploying the runtime, its generation scopes the cached results
+	Object client.Object
+	// URL where the runtime endpoints are served
+	URL string
+}
+
+// Introspector polls the runtime endpoints in background and caches the last good result of each probe per object generation,
+// so the reconcilers never wait for a runtime to reply.
+// When a result changes, the object is sent to the Events channel to trigger a new reconciliation.
+type Introspector struct {
+	client       *http.Client
+	pollInterval time.Duration
+	backoff      wait.Backoff
+	events       chan event.GenericEvent
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	mutex  sync.Mutex
+	probes map[probeKey]*probeState
+}
+
+type probeKey struct {
+	types.NamespacedName
+	name string
+}
+
+type probeState struct {
+	generation int64
+	url        string
+	cancel     context.CancelFunc
+	result     interface{}
+	fetched    bool
+}
+
+// NewIntrospector creates an Introspector reaching the runtimes with the given optional TLS configuration
+func NewIntrospector(tlsConfig *tls.Config) *Introspector {
+	return newIntrospector(newHTTPClient(tlsConfig), DefaultPollInterval, DefaultBackoff)
+}
+
+func newIntrospector(client *http.Client, pollInterval time.Duration, backoff wait.Backoff) *Introspector {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Introspector{
+		client:       client,
+		pollInterval: pollInterval,
+		backoff:      backoff,
+		events:       make(chan event.GenericEvent, eventsBufferSize),
+		ctx:          ctx,
+		cancel:       cancel,
+		probes:       map[probeKey]*probeState{},
+	}
+}
+
+// Events is the channel receiving the objects whose probe results changed, to be used as a controller source
+func (i *Introspector) Events() <-chan event.GenericEvent {
+	return i.events
+}
+
+// Start implements manager.Runnable, the polls are stopped once the manager stops
+func (i *Introspector) Start(ctx context.Context) error {
+	<-ctx.Done()
+	i.cancel()
+	return nil
+}
+
+// Get returns the cached result of the named probe for the current generation of the target object.
+// The probe is polled in background from the first call, or again from scratch if the object generation or URL changed,
+// found is false until the first poll succeeds.
+func (i *Introspector) Get(target Target, name string, probe Probe) (result interface{}, found bool) {
+	key := probeKey{NamespacedName: client.ObjectKeyFromObject(target.Object), name: name}
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	state, exists := i.probes[key]
+	if exists && state.generation == target.Object.GetGeneration() && state.url == target.URL {
+		return state.result, state.fetched
+	}
+	if exists {
+		state.cancel()
+	}
+	ctx, cancel := context.WithCancel(i.ctx)
+	state = &probeState{generation: target.Object.GetGeneration(), url: target.URL, cancel: cancel}
+	i.probes[key] = state
+	go i.poll(ctx, key, state, newFetcher(i.client, target.URL, i.backoff), probe)
+	return nil, false
+}
+
+// Forget stops polling the probes of the given object and discards their results, eg: when the object is deleted
+func (i *Introspector) Forget(key types.NamespacedName) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	for probeKey, state := range i.probes {
+		if probeKey.NamespacedName == key {
+			state.cancel()
+			delete(i.probes, probeKey)
+		}
+	}
+}
+
+func (i *Introspector) poll(ctx context.Context, key probeKey, state *probeState, fetcher *Fetcher, probe Probe) {
+	ticker := time.NewTicker(i.pollInterval)
+	defer ticker.Stop()
+	for {
+		i.runProbe(ctx, key, state, fetcher, probe)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (i *Introspector) runProbe(ctx context.Context, key probeKey, state *probeState, fetcher *Fetcher, probe Probe) {
+	result, err := probe(ctx, fetcher)
+	if err != nil {
+		// the last good result is kept until the runtime replies again
+		log.Warn("Failed to introspect runtime", "name", key.Name, "namespace", key.Namespace, "probe", key.name, "error", err)
+		return
+	}
+	i.mutex.Lock()
+	if ctx.Err() != nil {
+		// the probe was restarted for a new generation or forgotten meanwhile
+		i.mutex.Unlock()
+		return
+	}
+	changed := !state.fetched || !reflect.DeepEqual(state.result, result)
+	state.result = result
+	state.fetched = true
+	i.mutex.Unlock()
+
+	if changed {
+		log.Debug("Runtime introspection result changed", "name", key.Name, "namespace", key.Namespace, "probe", key.name)
+		select {
+		case i.events <- event.GenericEvent{Object: &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}}:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// Fetch runs the probe once against the runtime served at the given URL, for callers without an Introspector
+func Fetch(ctx context.Context, url string, probe Probe) (interface{}, error) {
+	return probe(ctx, NewFetcher(url, nil))
+}
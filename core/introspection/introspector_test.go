@@ -0,0 +1,151 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package introspection
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var testBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+
+func bodyProbe(path string) Probe {
+	return func(ctx context.Context, fetcher *Fetcher) (interface{}, error) {
+		body, err := fetcher.Get(ctx, path)
+		return string(body), err
+	}
+}
+
+func TestFetcher_Get_RetriesWithBackoff(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = writer.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	body, err := newFetcher(server.Client(), server.URL, testBackoff).Get(context.TODO(), "/messaging/topics")
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(body))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestFetcher_Get_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	body, err := newFetcher(server.Client(), server.URL, testBackoff).Get(context.TODO(), "/messaging/topics")
+	assert.NoError(t, err)
+	assert.Nil(t, body)
+}
+
+func TestFetcher_Get_Timeout(t *testing.T) {
+	hung := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		<-hung
+	}))
+	defer server.Close()
+	defer close(hung)
+	client := server.Client()
+	client.Timeout = time.Millisecond * 50
+
+	start := time.Now()
+	_, err := newFetcher(client, server.URL, testBackoff).Get(context.TODO(), "/messaging/topics")
+	assert.Error(t, err)
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func TestIntrospector_Get(t *testing.T) {
+	var response atomic.Value
+	response.Store("v1")
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		_, _ = writer.Write([]byte(response.Load().(string)))
+	}))
+	defer server.Close()
+	introspector := newIntrospector(server.Client(), time.Millisecond*20, testBackoff)
+	defer introspector.cancel()
+
+	runtime := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "runtime", Namespace: t.Name(), Generation: 1}}
+	target := Target{Object: runtime, URL: server.URL}
+	probe := bodyProbe("/messaging/topics")
+
+	_, found := introspector.Get(target, "topics", probe)
+	assert.False(t, found)
+	assertEvent(t, introspector, runtime.Name)
+	result, found := introspector.Get(target, "topics", probe)
+	assert.True(t, found)
+	assert.Equal(t, "v1", result)
+
+	// a change in the runtime reply triggers a new reconciliation
+	response.Store("v2")
+	assertEvent(t, introspector, runtime.Name)
+	result, _ = introspector.Get(target, "topics", probe)
+	assert.Equal(t, "v2", result)
+
+	// results are scoped to the object generation
+	runtime.Generation = 2
+	_, found = introspector.Get(target, "topics", probe)
+	assert.False(t, found)
+
+	introspector.Forget(types.NamespacedName{Name: runtime.Name, Namespace: runtime.Namespace})
+	assert.Empty(t, introspector.probes)
+}
+
+func TestIntrospector_KeepsLastGoodResult(t *testing.T) {
+	var failing int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if atomic.LoadInt32(&failing) == 1 {
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = writer.Write([]byte("v1"))
+	}))
+	defer server.Close()
+	introspector := newIntrospector(server.Client(), time.Millisecond*20, testBackoff)
+	defer introspector.cancel()
+
+	runtime := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "runtime", Namespace: t.Name(), Generation: 1}}
+	target := Target{Object: runtime, URL: server.URL}
+	introspector.Get(target, "topics", bodyProbe("/messaging/topics"))
+	assertEvent(t, introspector, runtime.Name)
+
+	atomic.StoreInt32(&failing, 1)
+	time.Sleep(time.Millisecond * 100)
+	result, found := introspector.Get(target, "topics", bodyProbe("/messaging/topics"))
+	assert.True(t, found)
+	assert.Equal(t, "v1", result)
+}
+
+func assertEvent(t *testing.T, introspector *Introspector, name string) {
+	select {
+	case e := <-introspector.Events():
+		assert.Equal(t, name, e.Object.GetName())
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "No event received from introspector")
+	}
+}
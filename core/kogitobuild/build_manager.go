@@ -97,6 +97,9 @@ func (d *deltaProcessor) ProcessDelta() (resultErr error) {
 	if resultErr != nil {
 		return
 	}
+	if resultErr = d.applyPatches(requested); resultErr != nil {
+		return
+	}
 	// get the deployed resources
 	deployed, resultErr := m.GetDeployedResources()
 	if resultErr != nil {
@@ -132,6 +135,18 @@ func (d *deltaProcessor) ProcessDelta() (resultErr error) {
 	return
 }
 
+// applyPatches applies the patches declared in the build spec to the requested resources
+func (d *deltaProcessor) applyPatches(requested map[reflect.Type][]client.Object) error {
+	for _, resources := range requested {
+		for _, resource := range resources {
+			if err := framework.ApplyPatches(d.Scheme, resource, d.build.GetSpec().GetPatches()); err != nil {
+				return infrastructure.ErrorForInvalidPatch(err)
+			}
+		}
+	}
+	return nil
+}
+
 func (d *deltaProcessor) getBuildManager() BuildManager {
 	buildManager := buildManager{
 		Context: d.Context,
@@ -22,6 +22,7 @@ import (
 	"github.com/kiegroup/kogito-operator/apis"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	buildv1 "github.com/openshift/api/build/v1"
 
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
@@ -241,10 +242,7 @@ func (k *imageStreamHandler) ResolveKogitoImageNameTag(build api.KogitoBuildInte
 
 // resolveKogitoImageTag resolves the ImageTag to be used in the given build, e.g. 0.11
 func (k *imageStreamHandler) resolveKogitoImageTag(build api.KogitoBuildInterface, isBuilder bool) string {
-	image := framework.ConvertImageTagToImage(build.GetSpec().GetRuntimeImage())
-	if isBuilder {
-		image = framework.ConvertImageTagToImage(build.GetSpec().GetBuildImage())
-	}
+	image := getKogitoImage(build, isBuilder)
 	if len(image.Tag) > 0 {
 		return image.Tag
 	}
@@ -253,23 +251,33 @@ func (k *imageStreamHandler) resolveKogitoImageTag(build api.KogitoBuildInterfac
 
 // resolveKogitoImageName resolves the ImageName to be used in the given build, e.g. kogito-quarkus-ubi8-s2i
 func resolveKogitoImageName(build api.KogitoBuildInterface, isBuilder bool) string {
-	if isBuilder {
-		image := framework.ConvertImageTagToImage(build.GetSpec().GetBuildImage())
-		if len(image.Name) > 0 {
-			return image.Name
-		}
-		return GetDefaultBuilderImage()
-	}
-	image := framework.ConvertImageTagToImage(build.GetSpec().GetRuntimeImage())
+	image := getKogitoImage(build, isBuilder)
 	if len(image.Name) > 0 {
 		return image.Name
 	}
+	if isBuilder {
+		return GetDefaultBuilderImage()
+	}
 	if build.GetSpec().IsNative() {
 		return GetDefaultRuntimeNativeImage()
 	}
 	return GetDefaultRuntimeJVMImage()
 }
 
+// getKogitoImage returns the builder or runtime image set in the given build, else the one of the runtime profile of the build.
+// Empty when none of them is set, the default image is then used.
+func getKogitoImage(build api.KogitoBuildInterface, isBuilder bool) api.Image {
+	profile := runtimeprofile.GetRegistry().GetProfile(build.GetSpec().GetRuntime())
+	image, profileImage := build.GetSpec().GetRuntimeImage(), profile.GetRuntimeImage()
+	if isBuilder {
+		image, profileImage = build.GetSpec().GetBuildImage(), profile.GetBuilderImage()
+	}
+	if len(image) == 0 {
+		image = profileImage
+	}
+	return framework.ConvertImageTagToImage(image)
+}
+
 // GetDefaultBuilderImage ...
 func GetDefaultBuilderImage() string {
 	builderImage := os.Getenv(kogitoBuilderImageEnvVar)
@@ -300,10 +308,7 @@ func GetDefaultRuntimeNativeImage() string {
 // resolveKogitoImageName resolves the ImageName to be used in the given build, e.g. kogito-quarkus-ubi8-s2i
 func resolveKogitoImageStreamName(build api.KogitoBuildInterface, isBuilder bool) string {
 	imageName := resolveKogitoImageName(build, isBuilder)
-	image := framework.ConvertImageTagToImage(build.GetSpec().GetRuntimeImage())
-	if isBuilder {
-		image = framework.ConvertImageTagToImage(build.GetSpec().GetBuildImage())
-	}
+	image := getKogitoImage(build, isBuilder)
 	if len(image.Name) > 0 { // custom image
 		return strings.Join([]string{customKogitoImagePrefix, imageName}, "")
 	}
@@ -320,10 +325,7 @@ func (k *imageStreamHandler) ResolveKogitoImageStreamTagName(build api.KogitoBui
 // resolveImageRegistry resolves the registry/namespace name to be used in the given build, e.g. quay.io/kiegroup
 func resolveKogitoImageRegistryNamespace(build api.KogitoBuildInterface, isBuilder bool) string {
 	registry := infrastructure.GetDefaultImageRegistry()
-	image := framework.ConvertImageTagToImage(build.GetSpec().GetRuntimeImage())
-	if isBuilder {
-		image = framework.ConvertImageTagToImage(build.GetSpec().GetBuildImage())
-	}
+	image := getKogitoImage(build, isBuilder)
 	if len(image.Domain) > 0 {
 		registry = image.Domain
 	}
@@ -19,9 +19,11 @@ import (
 	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	"github.com/kiegroup/kogito-operator/version/app"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -126,3 +128,27 @@ func Test_resolveKogitoImageStreamName(t *testing.T) {
 		})
 	}
 }
+
+func Test_resolveKogitoImageNameTag_RuntimeProfile(t *testing.T) {
+	registry := runtimeprofile.DefaultRegistry()
+	assert.NoError(t, registry.Register(&runtimeprofile.Profile{
+		Name:    "serverless-workflow",
+		Extends: api.QuarkusRuntimeType,
+		Images:  runtimeprofile.Images{Builder: "quay.io/kiegroup/kogito-swf-builder:1.0"},
+	}))
+	runtimeprofile.SetRegistry(registry)
+	defer runtimeprofile.SetRegistry(runtimeprofile.DefaultRegistry())
+
+	build := &v1beta1.KogitoBuild{
+		ObjectMeta: metav1.ObjectMeta{Name: "buildServerlessWorkflow", Namespace: t.Name()},
+		Spec:       v1beta1.KogitoBuildSpec{Runtime: "serverless-workflow"},
+	}
+	imageStreamHandler := NewImageSteamHandler(operator.Context{Version: app.Version})
+	assert.Equal(t, "kogito-swf-builder:1.0", imageStreamHandler.ResolveKogitoImageNameTag(build, true))
+	assert.Equal(t, "custom-kogito-swf-builder", resolveKogitoImageStreamName(build, true))
+	assert.Equal(t, "quay.io/kiegroup", resolveKogitoImageRegistryNamespace(build, true))
+	assert.Equal(t, GetDefaultRuntimeJVMImage()+":"+infrastructure.GetKogitoImageVersion(app.Version), imageStreamHandler.ResolveKogitoImageNameTag(build, false))
+
+	build.Spec.BuildImage = "my-builder:2.0"
+	assert.Equal(t, "my-builder:2.0", imageStreamHandler.ResolveKogitoImageNameTag(build, true))
+}
@@ -0,0 +1,141 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/framework/util"
+	"github.com/kiegroup/kogito-operator/core/manager"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// credentialRotationGracePeriodKey infra property holding how long the services keep the previous credentials
+	// once the source Secret is rotated, e.g. "10m"
+	credentialRotationGracePeriodKey = "credential-rotation-grace-period"
+)
+
+// checkCredentialRotation records the credentials copied from the given source Secret in the instance status,
+// their revision is incremented when they change so the Secrets provided to the services are synced again.
+// The services are rolled out with the new credentials once their Secrets change.
+// It returns true while the services must keep the previous credentials, i.e. during the grace period of a rotation.
+func checkCredentialRotation(context infraContext, source types.NamespacedName, credentials map[string]string) (bool, error) {
+	status := context.instance.GetStatus()
+	hash := util.GenerateMD5Hash(credentials)
+	current := status.GetCredential()
+	if current == nil || current.GetSecretName() != source.Name || current.GetSecretNamespace() != source.Namespace {
+		var revision int64 = 1
+		if current != nil {
+			revision = current.GetRevision() + 1
+		}
+		status.SetCredential(source.Name, source.Namespace, revision, hash, nil)
+		return false, nil
+	}
+	if current.GetHash() == hash {
+		if current.GetRotationPendingSince() != nil {
+			context.Log.Info("Credential Secret restored during the grace period, the rotation is discarded", "secret", source)
+			status.SetCredential(source.Name, source.Namespace, current.GetRevision(), hash, nil)
+		}
+		return false, nil
+	}
+
+	gracePeriod, err := getCredentialRotationGracePeriod(context.instance)
+	if err != nil {
+		return false, err
+	}
+	pendingSince := current.GetRotationPendingSince()
+	if pendingSince == nil {
+		now := metav1.Now()
+		pendingSince = &now
+	}
+	if time.Since(pendingSince.Time) < gracePeriod {
+		context.Log.Info("Credential Secret rotated, the services keep the previous credentials during the grace period", "secret", source, "gracePeriod", gracePeriod.String())
+		status.SetCredential(source.Name, source.Namespace, current.GetRevision(), current.GetHash(), pendingSince)
+		return true, nil
+	}
+	context.Log.Info("Credential Secret rotated, syncing the credentials provided to the services", "secret", source, "revision", current.GetRevision()+1)
+	status.SetCredential(source.Name, source.Namespace, current.GetRevision()+1, hash, nil)
+	return false, nil
+}
+
+func getCredentialRotationGracePeriod(instance api.KogitoInfraInterface) (time.Duration, error) {
+	value := instance.GetSpec().GetInfraProperties()[credentialRotationGracePeriodKey]
+	if len(value) == 0 {
+		return 0, nil
+	}
+	gracePeriod, err := time.ParseDuration(value)
+	if err != nil || gracePeriod < 0 {
+		return 0, errorForResourceConfigError(instance, fmt.Sprintf("Invalid %s '%s', a positive duration like 10m is expected", credentialRotationGracePeriodKey, value))
+	}
+	return gracePeriod, nil
+}
+
+// IsCredentialRotationPending checks if the services of the instance keep the previous credentials after a rotation,
+// the instance must be reconciled again once the grace period elapsed
+func IsCredentialRotationPending(instance api.KogitoInfraInterface) bool {
+	credential := instance.GetStatus().GetCredential()
+	return credential != nil && credential.GetRotationPendingSince() != nil
+}
+
+// AppendCredentialSecretWatchedObjects reconciles the KogitoInfra instances copying their credentials from a Secret when its content changes,
+// the Secret is usually owned by the Infinispan or MongoDB resource instead of the KogitoInfra
+func AppendCredentialSecretWatchedObjects(b *builder.Builder, context operator.Context, infraHandler manager.KogitoInfraHandler) *builder.Builder {
+	contentChangedPred := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(getSecretData(e.ObjectOld), getSecretData(e.ObjectNew))
+		},
+	}
+	return b.Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(newCredentialSecretMapper(context, infraHandler)), builder.WithPredicates(contentChangedPred))
+}
+
+func getSecretData(object client.Object) map[string][]byte {
+	if secret, ok := object.(*corev1.Secret); ok {
+		return secret.Data
+	}
+	return nil
+}
+
+// newCredentialSecretMapper maps a Secret to the KogitoInfra instances copying their credentials from it, in any namespace
+func newCredentialSecretMapper(context operator.Context, infraHandler manager.KogitoInfraHandler) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		infras, err := infraHandler.FetchAllKogitoInfraInstances("")
+		if err != nil {
+			context.Log.Error(err, "Failed to list KogitoInfra instances referencing credential Secret", "name", object.GetName(), "namespace", object.GetNamespace())
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, infra := range infras.GetItems() {
+			credential := infra.GetStatus().GetCredential()
+			if credential != nil && credential.GetSecretName() == object.GetName() && credential.GetSecretNamespace() == object.GetNamespace() {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: infra.GetName(), Namespace: infra.GetNamespace()}})
+			}
+		}
+		return requests
+	}
+}
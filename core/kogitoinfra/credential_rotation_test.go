@@ -0,0 +1,95 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/internal/app"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_checkCredentialRotation(t *testing.T) {
+	instance := test.CreateFakeKogitoMongoDB(t.Name())
+	context := infraContext{
+		Context:  operator.Context{Log: test.TestLogger},
+		instance: instance,
+	}
+	source := types.NamespacedName{Name: "mongodb-secret", Namespace: t.Name()}
+
+	pending, err := checkCredentialRotation(context, source, map[string]string{"password": "first"})
+	assert.NoError(t, err)
+	assert.False(t, pending)
+	assert.Equal(t, int64(1), instance.GetStatus().GetCredential().GetRevision())
+
+	pending, err = checkCredentialRotation(context, source, map[string]string{"password": "first"})
+	assert.NoError(t, err)
+	assert.False(t, pending)
+	assert.Equal(t, int64(1), instance.GetStatus().GetCredential().GetRevision())
+
+	instance.GetSpec().AddInfraProperties(map[string]string{credentialRotationGracePeriodKey: "10m"})
+	pending, err = checkCredentialRotation(context, source, map[string]string{"password": "second"})
+	assert.NoError(t, err)
+	assert.True(t, pending)
+	assert.Equal(t, int64(1), instance.GetStatus().GetCredential().GetRevision())
+	assert.NotNil(t, instance.GetStatus().GetCredential().GetRotationPendingSince())
+
+	// the Secret is restored before the grace period elapsed
+	pending, err = checkCredentialRotation(context, source, map[string]string{"password": "first"})
+	assert.NoError(t, err)
+	assert.False(t, pending)
+	assert.False(t, IsCredentialRotationPending(instance))
+
+	elapsed := metav1.NewTime(time.Now().Add(-time.Hour))
+	credential := instance.GetStatus().GetCredential()
+	instance.GetStatus().SetCredential(credential.GetSecretName(), credential.GetSecretNamespace(), credential.GetRevision(), credential.GetHash(), &elapsed)
+	pending, err = checkCredentialRotation(context, source, map[string]string{"password": "second"})
+	assert.NoError(t, err)
+	assert.False(t, pending)
+	assert.Equal(t, int64(2), instance.GetStatus().GetCredential().GetRevision())
+	assert.Nil(t, instance.GetStatus().GetCredential().GetRotationPendingSince())
+
+	instance.GetSpec().AddInfraProperties(map[string]string{credentialRotationGracePeriodKey: "soon"})
+	_, err = checkCredentialRotation(context, source, map[string]string{"password": "third"})
+	assert.Error(t, err)
+	assert.Equal(t, api.ResourceConfigError, reasonForError(err))
+}
+
+func Test_newCredentialSecretMapper(t *testing.T) {
+	ns := t.Name()
+	kogitoMongoDB := test.CreateFakeKogitoMongoDB(ns)
+	kogitoMongoDB.GetStatus().SetCredential("mongodb-secret", ns, 1, "hash", nil)
+	kogitoKafka := test.CreateFakeKogitoKafka(ns)
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kogitoMongoDB, kogitoKafka).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	mapper := newCredentialSecretMapper(context, app.NewKogitoInfraHandler(context))
+
+	requests := mapper(&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mongodb-secret", Namespace: ns}})
+	assert.Len(t, requests, 1)
+	assert.Equal(t, types.NamespacedName{Name: kogitoMongoDB.GetName(), Namespace: ns}, requests[0].NamespacedName)
+	assert.Empty(t, mapper(&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other-secret", Namespace: ns}}))
+}
@@ -17,6 +17,7 @@ package kogitoinfra
 import (
 	"fmt"
 	"github.com/kiegroup/kogito-operator/apis"
+	"strings"
 )
 
 // reconciliationError type for KogitoInfra reconciliation cycle cases.
@@ -80,6 +81,13 @@ func errorForResourceNotReadyError(err error) reconciliationError {
 	}
 }
 
+func errorForVaultSecretNotMaterialized(instance api.KogitoInfraInterface, issues []string) reconciliationError {
+	return reconciliationError{
+		Reason:     api.VaultSecretNotMaterialized,
+		innerError: fmt.Errorf("Infrastructure resource %s is waiting for its Vault secrets: %s", instance.GetName(), strings.Join(issues, "; ")),
+	}
+}
+
 func getSupportedResources(context infraContext) []string {
 	res := getSupportedInfraResources(context)
 	keys := make([]string, 0, len(res))
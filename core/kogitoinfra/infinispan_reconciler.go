@@ -21,64 +21,12 @@ import (
 	infinispan "github.com/kiegroup/kogito-operator/core/infrastructure/infinispan/v1"
 
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 )
 
-const (
-	// appPropInfinispanServerList application property for setting infinispan server
-	appPropInfinispanServerList int = iota
-	// appPropInfinispanUseAuth application property for enabling infinispan authentication
-	appPropInfinispanUseAuth
-	// appPropInfinispanSaslMechanism application property for setting infinispan SASL mechanism
-	// InfinispanSaslMechanismType is the possible SASL Mechanism used during infinispan connection.
-	// For more information, see https://en.wikipedia.org/wiki/Simple_Authentication_and_Security_Layer#SASL_mechanisms.
-	appPropInfinispanSaslMechanism
-	// appPropInfinispanAuthRealm application property for setting infinispan auth realm
-	appPropInfinispanAuthRealm
-	appPropInfinispanTrustStore
-	appPropInfinispanTrustStoreType
-	appPropInfinispanTrustStorePassword
-	// envVarInfinispanUser environment variable for setting infinispan username
-	envVarInfinispanUser
-	// envVarInfinispanPassword environment variable for setting infinispan password
-	envVarInfinispanPassword
-)
-
-var (
-	//Infinispan variables for the KogitoInfra deployed infrastructure.
-	//For Quarkus: https://quarkus.io/guides/infinispan-client#quarkus-infinispan-client_configuration
-	//For Spring: https://github.com/infinispan/infinispan-spring-boot/blob/main/infinispan-spring-boot-starter-remote/src/test/resources/test-application.properties
-
-	propertiesInfinispan = map[api.RuntimeType]map[int]string{
-		api.QuarkusRuntimeType: {
-			appPropInfinispanServerList:         "quarkus.infinispan-client.server-list",
-			appPropInfinispanUseAuth:            "quarkus.infinispan-client.use-auth",
-			appPropInfinispanSaslMechanism:      "quarkus.infinispan-client.sasl-mechanism",
-			appPropInfinispanAuthRealm:          "quarkus.infinispan-client.auth-realm",
-			appPropInfinispanTrustStore:         "quarkus.infinispan-client.trust-store",
-			appPropInfinispanTrustStoreType:     "quarkus.infinispan-client.trust-store-type",
-			appPropInfinispanTrustStorePassword: "quarkus.infinispan-client.trust-store-password",
-
-			envVarInfinispanUser:     "QUARKUS_INFINISPAN_CLIENT_AUTH_USERNAME",
-			envVarInfinispanPassword: "QUARKUS_INFINISPAN_CLIENT_AUTH_PASSWORD",
-		},
-		api.SpringBootRuntimeType: {
-			appPropInfinispanServerList:         "infinispan.remote.server-list",
-			appPropInfinispanUseAuth:            "infinispan.remote.use-auth",
-			appPropInfinispanSaslMechanism:      "infinispan.remote.sasl-mechanism",
-			appPropInfinispanAuthRealm:          "infinispan.remote.auth-realm",
-			appPropInfinispanTrustStore:         "infinispan.remote.trust-store-file-name",
-			appPropInfinispanTrustStoreType:     "infinispan.remote.trust-store-type",
-			appPropInfinispanTrustStorePassword: "infinispan.remote.trust-store-password",
-
-			envVarInfinispanUser:     "INFINISPAN_REMOTE_AUTH_USERNAME",
-			envVarInfinispanPassword: "INFINISPAN_REMOTE_AUTH_PASSWORD",
-		},
-	}
-)
-
 type infinispanInfraReconciler struct {
 	infraContext
 }
@@ -120,6 +68,10 @@ func (i *infinispanInfraReconciler) Reconcile() (resultErr error) {
 		if infinispanInstance == nil {
 			return errorForResourceNotFound("Infinispan", i.instance.GetSpec().GetResource().GetName(), namespace)
 		}
+	} else if infrastructure.IsInfraResourceProvisioned(i.instance) {
+		if infinispanInstance, resultErr = i.provisionInfinispanInstance(infinispanHandler); resultErr != nil {
+			return resultErr
+		}
 	} else {
 		return errorForResourceConfigError(i.instance, "No Infinispan resource name given")
 	}
@@ -130,15 +82,24 @@ func (i *infinispanInfraReconciler) Reconcile() (resultErr error) {
 		return nil
 	}
 
-	if resultErr = i.updateInfinispanRuntimePropsInStatus(infinispanInstance, api.QuarkusRuntimeType); resultErr != nil {
-		return nil
-	}
-	if resultErr = i.updateInfinispanRuntimePropsInStatus(infinispanInstance, api.SpringBootRuntimeType); resultErr != nil {
-		return nil
+	for _, profile := range runtimeprofile.GetRegistry().GetProfiles() {
+		if resultErr = i.updateInfinispanRuntimePropsInStatus(infinispanInstance, profile.Name); resultErr != nil {
+			return nil
+		}
 	}
 	return resultErr
 }
 
+// provisionInfinispanInstance fetches the Infinispan provisioned for the instance, it's created from the provision template when missing
+func (i *infinispanInfraReconciler) provisionInfinispanInstance(infinispanHandler infrastructure.InfinispanHandler) (*infinispan.Infinispan, error) {
+	key := infrastructure.GetInfraResourceKey(i.instance)
+	infinispanInstance, err := infinispanHandler.FetchInfinispanInstance(*key)
+	if err != nil || infinispanInstance != nil {
+		return infinispanInstance, err
+	}
+	return infinispanHandler.CreateInfinispanInstance(*key, i.instance.GetSpec().GetProvision(), i.instance)
+}
+
 func (i *infinispanInfraReconciler) updateTrustStoreSecretReferenceInStatus(infinispanInstance *infinispan.Infinispan) error {
 	infinispanTrustStoreReconciler := newInfinispanTrustStoreReconciler(i.infraContext, infinispanInstance)
 	if err := infinispanTrustStoreReconciler.Reconcile(); err != nil {
@@ -17,6 +17,10 @@ package kogitoinfra
 import (
 	"testing"
 
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	infinispan "github.com/kiegroup/kogito-operator/core/infrastructure/infinispan/v1"
 	"github.com/kiegroup/kogito-operator/core/operator"
 	"github.com/kiegroup/kogito-operator/core/test"
 	"github.com/kiegroup/kogito-operator/meta"
@@ -84,3 +88,50 @@ func Test_Reconcile_Infinispan(t *testing.T) {
 	assert.Equal(t, 4, len(kogitoInfinispanInstance.GetStatus().GetSecretEnvFromReferences()))
 	assert.Equal(t, 1, len(kogitoInfinispanInstance.GetStatus().GetSecretVolumeReferences()))
 }
+
+func Test_InfinispanInfraReconciler_ProvisionInfinispan(t *testing.T) {
+	ns := t.Name()
+	replicas := int32(2)
+	kogitoInfinispanInstance := test.CreateFakeKogitoInfinispan(ns).(*v1beta1.KogitoInfra)
+	kogitoInfinispanInstance.Spec.Resource.Name = ""
+	kogitoInfinispanInstance.Spec.Provision = &v1beta1.InfraProvision{Replicas: &replicas}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfinispanInstance).Build()
+	infraContext := infraContext{
+		Context: operator.Context{
+			Client: cli,
+			Log:    test.TestLogger,
+			Scheme: meta.GetRegisteredSchema(),
+		},
+		instance: kogitoInfinispanInstance,
+	}
+	infinispanInfraReconciler := initInfinispanInfraReconciler(infraContext)
+	err := infinispanInfraReconciler.Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, api.ResourceNotReady, reasonForError(err))
+
+	infinispanInstance := &infinispan.Infinispan{ObjectMeta: v1.ObjectMeta{Name: kogitoInfinispanInstance.Name, Namespace: ns}}
+	exists, err := kubernetes.ResourceC(cli).Fetch(infinispanInstance)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, replicas, infinispanInstance.Spec.Replicas)
+	assert.Equal(t, kogitoInfinispanInstance.Name, infinispanInstance.OwnerReferences[0].Name)
+}
+
+func Test_InfinispanInfraReconciler_NoNameNorProvision(t *testing.T) {
+	ns := t.Name()
+	kogitoInfinispanInstance := test.CreateFakeKogitoInfinispan(ns).(*v1beta1.KogitoInfra)
+	kogitoInfinispanInstance.Spec.Resource.Name = ""
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kogitoInfinispanInstance).Build()
+	infraContext := infraContext{
+		Context: operator.Context{
+			Client: cli,
+			Log:    test.TestLogger,
+			Scheme: meta.GetRegisteredSchema(),
+		},
+		instance: kogitoInfinispanInstance,
+	}
+	infinispanInfraReconciler := initInfinispanInfraReconciler(infraContext)
+	err := infinispanInfraReconciler.Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, api.ResourceConfigError, reasonForError(err))
+}
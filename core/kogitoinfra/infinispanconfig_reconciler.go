@@ -20,6 +20,7 @@ import (
 	"github.com/kiegroup/kogito-operator/core/framework"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	infinispan "github.com/kiegroup/kogito-operator/core/infrastructure/infinispan/v1"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	v12 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -115,9 +116,12 @@ func (i *infinispanConfigReconciler) getInfinispanAppProps() (map[string]string,
 	}
 
 	appProps[infinispanEnablePersistenceEnvKey] = "true"
-	appProps[propertiesInfinispan[i.runtime][appPropInfinispanUseAuth]] = "true"
+	values := map[string]string{runtimeprofile.InfinispanUseAuth: "true"}
 	if len(infinispanURI) > 0 {
-		appProps[propertiesInfinispan[i.runtime][appPropInfinispanServerList]] = infinispanURI
+		values[runtimeprofile.InfinispanServerList] = infinispanURI
+	}
+	for name, value := range runtimeprofile.GetRegistry().GetProfile(i.runtime).MapProperties(runtimeprofile.InfinispanInfra, values) {
+		appProps[name] = value
 	}
 	return appProps, nil
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/kiegroup/kogito-operator/core/framework"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	infinispan "github.com/kiegroup/kogito-operator/core/infrastructure/infinispan/v1"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	v12 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -53,8 +54,14 @@ func (i *infinispanCredentialReconciler) Reconcile() (err error) {
 		return nil
 	}
 
+	infinispanHandler := infrastructure.NewInfinispanHandler(i.Context)
+	credentials, err := infinispanHandler.GetInfinispanCredential(i.infinispanInstance)
+	if err != nil {
+		return
+	}
+
 	// Create Required resource
-	requestedResources, err := i.createRequiredResources()
+	requestedResources, err := i.createRequiredResources(credentials)
 	if err != nil {
 		return
 	}
@@ -65,22 +72,25 @@ func (i *infinispanCredentialReconciler) Reconcile() (err error) {
 		return
 	}
 
+	// the services keep the previous credentials during the grace period of a rotation
+	rotationPending, err := checkCredentialRotation(i.infraContext, i.getSourceSecretKey(), getInfinispanCredentialContent(credentials))
+	if err != nil {
+		return
+	}
+
 	// Process Delta
-	if err = i.processDelta(requestedResources, deployedResources); err != nil {
-		return err
+	if !rotationPending || len(deployedResources) == 0 {
+		if err = i.processDelta(requestedResources, deployedResources); err != nil {
+			return err
+		}
 	}
 
 	i.instance.GetStatus().AddSecretEnvFromReferences(i.getCredentialSecretName())
 	return nil
 }
 
-func (i *infinispanCredentialReconciler) createRequiredResources() (map[reflect.Type][]client.Object, error) {
+func (i *infinispanCredentialReconciler) createRequiredResources(credentials *infrastructure.InfinispanCredential) (map[reflect.Type][]client.Object, error) {
 	resources := make(map[reflect.Type][]client.Object)
-	infinispanHandler := infrastructure.NewInfinispanHandler(i.Context)
-	credentials, err := infinispanHandler.GetInfinispanCredential(i.infinispanInstance)
-	if err != nil {
-		return nil, err
-	}
 	secret := i.createInfinispanSecret(credentials)
 	if err := framework.SetOwner(i.instance, i.Scheme, secret); err != nil {
 		return resources, err
@@ -118,14 +128,26 @@ func (i *infinispanCredentialReconciler) createInfinispanSecret(credentials *inf
 		Type: v12.SecretTypeOpaque,
 	}
 	if credentials != nil {
-		secret.StringData = map[string]string{
-			propertiesInfinispan[i.runtime][envVarInfinispanUser]:     credentials.Username,
-			propertiesInfinispan[i.runtime][envVarInfinispanPassword]: credentials.Password,
-		}
+		secret.StringData = runtimeprofile.GetRegistry().GetProfile(i.runtime).MapProperties(runtimeprofile.InfinispanInfra, map[string]string{
+			runtimeprofile.InfinispanUsername: credentials.Username,
+			runtimeprofile.InfinispanPassword: credentials.Password,
+		})
 	}
 	return secret
 }
 
+// getSourceSecretKey returns the Secret of the Infinispan instance the credentials are copied from
+func (i *infinispanCredentialReconciler) getSourceSecretKey() types.NamespacedName {
+	return types.NamespacedName{Name: i.infinispanInstance.Spec.Security.EndpointSecretName, Namespace: i.infinispanInstance.Namespace}
+}
+
+func getInfinispanCredentialContent(credentials *infrastructure.InfinispanCredential) map[string]string {
+	if credentials == nil {
+		return map[string]string{}
+	}
+	return map[string]string{"username": credentials.Username, "password": credentials.Password}
+}
+
 func (i *infinispanCredentialReconciler) getCredentialSecretName() string {
 	return fmt.Sprintf(credentialSecretName, i.runtime)
 }
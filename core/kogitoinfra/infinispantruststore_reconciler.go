@@ -15,12 +15,12 @@
 package kogitoinfra
 
 import (
-	"github.com/kiegroup/kogito-operator/apis"
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
 	"github.com/kiegroup/kogito-operator/core/framework"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	infinispan "github.com/kiegroup/kogito-operator/core/infrastructure/infinispan/v1"
 	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	v12 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -128,11 +128,10 @@ func (i *infinispanTrustStoreReconciler) createTrustStoreSecret() error {
 }
 
 func (i *infinispanTrustStoreReconciler) addTrustStoreSecret() error {
-	if err := newInfinispanTrustStoreSecretReconciler(i.infraContext, api.QuarkusRuntimeType).Reconcile(); err != nil {
-		return err
-	}
-	if err := newInfinispanTrustStoreSecretReconciler(i.infraContext, api.SpringBootRuntimeType).Reconcile(); err != nil {
-		return err
+	for _, profile := range runtimeprofile.GetRegistry().GetProfiles() {
+		if err := newInfinispanTrustStoreSecretReconciler(i.infraContext, profile.Name).Reconcile(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/kiegroup/kogito-operator/apis"
 	"github.com/kiegroup/kogito-operator/core/framework"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	v12 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -101,9 +102,13 @@ func (i *infinispanTrustStoreSecretReconciler) processDelta(requestedResources m
 
 func (i *infinispanTrustStoreSecretReconciler) getInfinispanTrustStoreSecretProps() map[string][]byte {
 	appProps := map[string][]byte{}
-	appProps[propertiesInfinispan[i.runtime][appPropInfinispanTrustStoreType]] = []byte(pkcs12CertType)
-	appProps[propertiesInfinispan[i.runtime][appPropInfinispanTrustStore]] = []byte(truststoreMountPath)
-	appProps[propertiesInfinispan[i.runtime][appPropInfinispanTrustStorePassword]] = []byte(pkcs12.DefaultPassword)
+	for name, value := range runtimeprofile.GetRegistry().GetProfile(i.runtime).MapProperties(runtimeprofile.InfinispanInfra, map[string]string{
+		runtimeprofile.InfinispanTrustStoreType:     pkcs12CertType,
+		runtimeprofile.InfinispanTrustStore:         truststoreMountPath,
+		runtimeprofile.InfinispanTrustStorePassword: pkcs12.DefaultPassword,
+	}) {
+		appProps[name] = []byte(value)
+	}
 	return appProps
 }
 
@@ -19,6 +19,7 @@ import (
 	"github.com/kiegroup/kogito-operator/apis"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	"github.com/kiegroup/kogito-operator/core/infrastructure/kafka/v1beta2"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sort"
 	"strings"
@@ -27,6 +28,17 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 )
 
+const (
+	// kafkaListenerKey infra property holding the name of the Kafka listener used by the services
+	kafkaListenerKey = "listener"
+	// kafkaListenerTypeKey infra property holding the type of the Kafka listener used by the services, e.g. internal or route
+	kafkaListenerTypeKey = "listener-type"
+	// kafkaUserKey infra property holding the name of the KafkaUser the services authenticate with
+	kafkaUserKey = "kafka-user"
+	// kafkaCreateUserKey infra property asking to create the KafkaUser when it doesn't exist
+	kafkaCreateUserKey = "create-kafka-user"
+)
+
 // AppendKafkaWatchedObjects ...
 func AppendKafkaWatchedObjects(b *builder.Builder) *builder.Builder {
 	return b
@@ -66,6 +78,10 @@ func (k *kafkaInfraReconciler) Reconcile() (resultErr error) {
 		} else if kafkaInstance == nil {
 			return errorForResourceNotFound("Kafka", k.instance.GetSpec().GetResource().GetName(), namespace)
 		}
+	} else if infrastructure.IsInfraResourceProvisioned(k.instance) {
+		if kafkaInstance, resultErr = k.provisionKafkaInstance(kafkaHandler); resultErr != nil {
+			return resultErr
+		}
 	} else {
 		return errorForResourceConfigError(k.instance, "No Kafka resource name given")
 	}
@@ -75,15 +91,43 @@ func (k *kafkaInfraReconciler) Reconcile() (resultErr error) {
 		return errorForResourceNotReadyError(fmt.Errorf("kafka instance %s not ready yet. Waiting for Condition status Ready", kafkaInstance.Name))
 	}
 
-	if resultErr = k.updateKafkaRuntimePropsInStatus(kafkaInstance, api.QuarkusRuntimeType); resultErr != nil {
+	listener, resultErr := k.resolveKafkaListener(kafkaHandler, kafkaInstance)
+	if resultErr != nil {
 		return resultErr
 	}
-	if resultErr = k.updateKafkaRuntimePropsInStatus(kafkaInstance, api.SpringBootRuntimeType); resultErr != nil {
+	if resultErr = newKafkaSecurityReconciler(k.infraContext, kafkaInstance, listener).Reconcile(); resultErr != nil {
 		return resultErr
 	}
+
+	for _, profile := range runtimeprofile.GetRegistry().GetProfiles() {
+		if resultErr = k.updateKafkaRuntimePropsInStatus(kafkaInstance, listener, profile.Name); resultErr != nil {
+			return resultErr
+		}
+	}
 	return nil
 }
 
+// provisionKafkaInstance fetches the Kafka provisioned for the instance, it's created from the provision template when missing
+func (k *kafkaInfraReconciler) provisionKafkaInstance(kafkaHandler infrastructure.KafkaHandler) (*v1beta2.Kafka, error) {
+	key := infrastructure.GetInfraResourceKey(k.instance)
+	kafkaInstance, err := kafkaHandler.FetchKafkaInstance(*key)
+	if err != nil || kafkaInstance != nil {
+		return kafkaInstance, err
+	}
+	return kafkaHandler.CreateKafkaInstance(*key, k.instance.GetSpec().GetProvision(), k.instance)
+}
+
+// resolveKafkaListener returns the listener selected by the infra properties, the plain or tls one by default
+func (k *kafkaInfraReconciler) resolveKafkaListener(kafkaHandler infrastructure.KafkaHandler, kafkaInstance *v1beta2.Kafka) (*v1beta2.ListenerStatus, error) {
+	name := k.instance.GetSpec().GetInfraProperties()[kafkaListenerKey]
+	listenerType := k.instance.GetSpec().GetInfraProperties()[kafkaListenerTypeKey]
+	listener, err := kafkaHandler.ResolveKafkaListener(kafkaInstance, name, listenerType)
+	if err != nil && (len(name) > 0 || len(listenerType) > 0) {
+		return nil, errorForResourceConfigError(k.instance, fmt.Sprintf("No listener with name '%s' and type '%s' found in Kafka %s", name, listenerType, kafkaInstance.Name))
+	}
+	return listener, err
+}
+
 func (k *kafkaInfraReconciler) getLatestKafkaCondition(kafka *v1beta2.Kafka) *v1beta2.KafkaCondition {
 	if len(kafka.Status.Conditions) == 0 {
 		return nil
@@ -119,9 +163,9 @@ func (k *kafkaInfraReconciler) mustParseKafkaTransition(transitionTime string) (
 	return &parsedTime, true
 }
 
-func (k *kafkaInfraReconciler) updateKafkaRuntimePropsInStatus(kafkaInstance *v1beta2.Kafka, runtime api.RuntimeType) error {
+func (k *kafkaInfraReconciler) updateKafkaRuntimePropsInStatus(kafkaInstance *v1beta2.Kafka, listener *v1beta2.ListenerStatus, runtime api.RuntimeType) error {
 	k.Log.Debug("going to Update Kafka runtime properties in kogito infra instance status", "runtime", runtime)
-	kafkaConfigReconciler := newKafkaConfigReconciler(k.infraContext, kafkaInstance, runtime)
+	kafkaConfigReconciler := newKafkaConfigReconciler(k.infraContext, kafkaInstance, listener, runtime)
 	if err := kafkaConfigReconciler.Reconcile(); err != nil {
 		return err
 	}
@@ -15,8 +15,15 @@
 package kogitoinfra
 
 import (
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
 	"github.com/kiegroup/kogito-operator/core/infrastructure/kafka/v1beta2"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/meta"
 	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"reflect"
 	"testing"
 	time "time"
@@ -52,3 +59,33 @@ func Test_mustParseKafkaTransition(t *testing.T) {
 		})
 	}
 }
+
+func Test_KafkaInfraReconciler_ProvisionKafka(t *testing.T) {
+	ns := t.Name()
+	kogitoKafkaInstance := test.CreateFakeKogitoKafka(ns).(*v1beta1.KogitoInfra)
+	kogitoKafkaInstance.Spec.Resource.Name = ""
+	kogitoKafkaInstance.Spec.Provision = &v1beta1.InfraProvision{Version: "3.1.0"}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kogitoKafkaInstance).Build()
+	infraContext := infraContext{
+		Context: operator.Context{
+			Client: cli,
+			Log:    test.TestLogger,
+			Scheme: meta.GetRegisteredSchema(),
+		},
+		instance: kogitoKafkaInstance,
+	}
+	err := initKafkaInfraReconciler(infraContext).Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, api.ResourceNotReady, reasonForError(err))
+
+	kafkaInstance := &v1beta2.Kafka{ObjectMeta: v1.ObjectMeta{Name: kogitoKafkaInstance.Name, Namespace: ns}}
+	exists, err := kubernetes.ResourceC(cli).Fetch(kafkaInstance)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, "3.1.0", kafkaInstance.Spec.Kafka.Version)
+	assert.Equal(t, int32(1), kafkaInstance.Spec.Kafka.Replicas)
+	assert.Equal(t, int32(1), kafkaInstance.Spec.Zookeeper.Replicas)
+	assert.Equal(t, v1beta2.KafkaEphemeralStorage, kafkaInstance.Spec.Kafka.Storage.StorageType)
+	assert.Equal(t, "plain", kafkaInstance.Spec.Kafka.Listeners[0].Name)
+	assert.Equal(t, kogitoKafkaInstance.Name, kafkaInstance.OwnerReferences[0].Name)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/kiegroup/kogito-operator/core/framework"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	"github.com/kiegroup/kogito-operator/core/infrastructure/kafka/v1beta2"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	v12 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -29,28 +30,24 @@ import (
 
 const (
 	enableEventsEnvKey = "ENABLE_EVENTS"
-	// springKafkaBootstrapAppProp spring boot application property for setting kafka server
-	springKafkaBootstrapAppProp = "spring.kafka.bootstrap-servers"
-	// QuarkusKafkaBootstrapAppProp quarkus application property for setting kafka server
-	QuarkusKafkaBootstrapAppProp = "kafka.bootstrap.servers"
-	kafkaConfigMapName           = "kogito-kafka-%s-config"
+	kafkaConfigMapName = "kogito-kafka-%s-config"
 )
 
 type kafkaConfigReconciler struct {
 	infraContext
 	kafkaInstance    *v1beta2.Kafka
+	listener         *v1beta2.ListenerStatus
 	runtime          api.RuntimeType
 	configMapHandler infrastructure.ConfigMapHandler
-	kafkaHandler     infrastructure.KafkaHandler
 }
 
-func newKafkaConfigReconciler(ctx infraContext, kafkaInstance *v1beta2.Kafka, runtime api.RuntimeType) Reconciler {
+func newKafkaConfigReconciler(ctx infraContext, kafkaInstance *v1beta2.Kafka, listener *v1beta2.ListenerStatus, runtime api.RuntimeType) Reconciler {
 	return &kafkaConfigReconciler{
 		infraContext:     ctx,
 		kafkaInstance:    kafkaInstance,
+		listener:         listener,
 		runtime:          runtime,
 		configMapHandler: infrastructure.NewConfigMapHandler(ctx.Context),
-		kafkaHandler:     infrastructure.NewKafkaHandler(ctx.Context),
 	}
 }
 
@@ -113,16 +110,11 @@ func (k *kafkaConfigReconciler) processDelta(requestedResources map[reflect.Type
 
 func (k *kafkaConfigReconciler) getKafkaAppProps() (map[string]string, error) {
 	appProps := map[string]string{}
-	kafkaURI, err := k.kafkaHandler.ResolveKafkaServerURI(k.kafkaInstance)
-	if err != nil {
-		return nil, err
-	}
+	kafkaURI := infrastructure.GetKafkaListenerURI(k.listener)
 	if len(kafkaURI) > 0 {
 		appProps[enableEventsEnvKey] = "true"
-		if k.runtime == api.QuarkusRuntimeType {
-			appProps[QuarkusKafkaBootstrapAppProp] = kafkaURI
-		} else if k.runtime == api.SpringBootRuntimeType {
-			appProps[springKafkaBootstrapAppProp] = kafkaURI
+		if property := runtimeprofile.GetRegistry().GetProfile(k.runtime).GetProperty(runtimeprofile.KafkaInfra, runtimeprofile.KafkaBootstrapServers); len(property) > 0 {
+			appProps[property] = kafkaURI
 		}
 	} else {
 		appProps[enableEventsEnvKey] = "false"
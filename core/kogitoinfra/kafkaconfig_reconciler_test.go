@@ -18,6 +18,7 @@ import (
 	"github.com/kiegroup/kogito-operator/apis"
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
 	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	"github.com/kiegroup/kogito-operator/core/test"
 	"github.com/kiegroup/kogito-operator/meta"
 	"github.com/stretchr/testify/assert"
@@ -40,7 +41,7 @@ func TestKafkaConfigReconciler(t *testing.T) {
 		instance: kogitoKafkaInstance,
 	}
 
-	kafkaConfigReconciler := newKafkaConfigReconciler(infraContext, kafkaInstance, api.QuarkusRuntimeType)
+	kafkaConfigReconciler := newKafkaConfigReconciler(infraContext, kafkaInstance, &kafkaInstance.Status.Listeners[0], api.QuarkusRuntimeType)
 	err := kafkaConfigReconciler.Reconcile()
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(kogitoKafkaInstance.GetStatus().GetConfigMapEnvFromReferences()))
@@ -58,3 +59,39 @@ func TestKafkaConfigReconciler(t *testing.T) {
 	assert.Equal(t, "true", kafkaConfigMap.Data[enableEventsEnvKey])
 	assert.True(t, len(kafkaConfigMap.Data["kafka.bootstrap.servers"]) > 0)
 }
+
+func TestKafkaConfigReconciler_RuntimeProfile(t *testing.T) {
+	registry := runtimeprofile.DefaultRegistry()
+	assert.NoError(t, registry.Register(&runtimeprofile.Profile{
+		Name:    "micronaut",
+		Extends: api.QuarkusRuntimeType,
+		Properties: map[runtimeprofile.InfraKind]map[string]string{
+			runtimeprofile.KafkaInfra: {runtimeprofile.KafkaBootstrapServers: "kafka.bootstrap-servers"},
+		},
+	}))
+	runtimeprofile.SetRegistry(registry)
+	defer runtimeprofile.SetRegistry(runtimeprofile.DefaultRegistry())
+
+	ns := t.Name()
+	kogitoKafkaInstance := test.CreateFakeKogitoKafka(ns)
+	kafkaInstance := test.CreateFakeKafka(ns)
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kafkaInstance).Build()
+	infraContext := infraContext{
+		Context: operator.Context{
+			Client: cli,
+			Log:    test.TestLogger,
+			Scheme: meta.GetRegisteredSchema(),
+		},
+		instance: kogitoKafkaInstance,
+	}
+
+	err := newKafkaConfigReconciler(infraContext, kafkaInstance, &kafkaInstance.Status.Listeners[0], "micronaut").Reconcile()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{GetKafkaConfigMapName("micronaut")}, kogitoKafkaInstance.GetStatus().GetConfigMapEnvFromReferences())
+	kafkaConfigMap := &v12.ConfigMap{ObjectMeta: v1.ObjectMeta{Name: GetKafkaConfigMapName("micronaut"), Namespace: ns}}
+	exist, err := kubernetes.ResourceC(cli).Fetch(kafkaConfigMap)
+	assert.True(t, exist)
+	assert.NoError(t, err)
+	assert.True(t, len(kafkaConfigMap.Data["kafka.bootstrap-servers"]) > 0)
+	assert.Empty(t, kafkaConfigMap.Data["kafka.bootstrap.servers"])
+}
@@ -0,0 +1,289 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/infrastructure/kafka/v1beta2"
+	"github.com/kiegroup/kogito-operator/core/manager"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
+	v12 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// kafkaCertsSecretName is the secret holding the truststore and keystore of the KogitoInfra with the given name
+	kafkaCertsSecretName     = "%s-kafka-certs"
+	kafkaCertMountPath       = operator.KogitoHomeDir + "/certs/kafka"
+	kafkaTrustStoreKey       = "truststore.p12"
+	kafkaKeyStoreKey         = "keystore.p12"
+	kafkaTrustStoreMountPath = kafkaCertMountPath + "/" + kafkaTrustStoreKey
+	kafkaKeyStoreMountPath   = kafkaCertMountPath + "/" + kafkaKeyStoreKey
+
+	kafkaSSLProtocol           = "SSL"
+	kafkaSASLSSLProtocol       = "SASL_SSL"
+	kafkaSASLPlaintextProtocol = "SASL_PLAINTEXT"
+	kafkaScramSha512Mechanism  = "SCRAM-SHA-512"
+	kafkaScramJaasConfig       = "org.apache.kafka.common.security.scram.ScramLoginModule required username=\"%s\" password=\"%s\";"
+)
+
+// kafkaSecurity holds the settings the Kogito services need to connect to a secured Kafka listener
+type kafkaSecurity struct {
+	protocol           string
+	trustStorePassword string
+	keyStorePassword   string
+	saslMechanism      string
+	jaasConfig         string
+}
+
+// kafkaSecurityReconciler publishes the truststore, keystore and SASL settings of the Kafka listener used by the services,
+// the listener spec tells whether it's encrypted and how the clients authenticate
+type kafkaSecurityReconciler struct {
+	infraContext
+	kafkaInstance *v1beta2.Kafka
+	listener      *v1beta2.ListenerStatus
+	secretHandler infrastructure.SecretHandler
+	kafkaHandler  infrastructure.KafkaHandler
+}
+
+// AppendKafkaSecretWatchedObjects reconciles the KogitoInfra instances connecting to a Kafka cluster when the Secrets generated by Strimzi
+// for the cluster CA or their KafkaUser change, so the rotated certificates and passwords reach the services
+func AppendKafkaSecretWatchedObjects(b *builder.Builder, context operator.Context, infraHandler manager.KogitoInfraHandler) *builder.Builder {
+	contentChangedPred := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(getSecretData(e.ObjectOld), getSecretData(e.ObjectNew))
+		},
+	}
+	return b.Watches(&source.Kind{Type: &v12.Secret{}}, handler.EnqueueRequestsFromMapFunc(newKafkaSecretMapper(context, infraHandler)), builder.WithPredicates(contentChangedPred))
+}
+
+// newKafkaSecretMapper maps a Secret to the KogitoInfra instances using it as Kafka cluster CA or KafkaUser Secret, in any namespace
+func newKafkaSecretMapper(context operator.Context, infraHandler manager.KogitoInfraHandler) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		infras, err := infraHandler.FetchAllKogitoInfraInstances("")
+		if err != nil {
+			context.Log.Error(err, "Failed to list KogitoInfra instances referencing Kafka Secret", "name", object.GetName(), "namespace", object.GetNamespace())
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, infra := range infras.GetItems() {
+			if isKafkaSecret(infra, object) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: infra.GetName(), Namespace: infra.GetNamespace()}})
+			}
+		}
+		return requests
+	}
+}
+
+// isKafkaSecret checks if the given Secret is the cluster CA or the KafkaUser Secret of the Kafka cluster used by the instance
+func isKafkaSecret(instance api.KogitoInfraInterface, secret client.Object) bool {
+	if instance.GetSpec().IsResourceEmpty() || !infrastructure.IsKafkaResource(instance.GetSpec().GetResource().GetAPIVersion(), instance.GetSpec().GetResource().GetKind()) {
+		return false
+	}
+	kafkaKey := infrastructure.GetInfraResourceKey(instance)
+	if kafkaKey == nil || kafkaKey.Namespace != secret.GetNamespace() {
+		return false
+	}
+	if secret.GetName() == fmt.Sprintf(infrastructure.KafkaClusterCACertSecretName, kafkaKey.Name) {
+		return true
+	}
+	userName := getKafkaUserName(instance)
+	return len(userName) > 0 && secret.GetName() == userName
+}
+
+// getKafkaUserName returns the name of the KafkaUser the services of the instance authenticate with, empty if not given
+func getKafkaUserName(instance api.KogitoInfraInterface) string {
+	if userName := instance.GetSpec().GetInfraProperties()[kafkaUserKey]; len(userName) > 0 {
+		return userName
+	}
+	if instance.GetSpec().GetInfraProperties()[kafkaCreateUserKey] == "true" {
+		return instance.GetName()
+	}
+	return ""
+}
+
+func newKafkaSecurityReconciler(context infraContext, kafkaInstance *v1beta2.Kafka, listener *v1beta2.ListenerStatus) Reconciler {
+	return &kafkaSecurityReconciler{
+		infraContext:  context,
+		kafkaInstance: kafkaInstance,
+		listener:      listener,
+		secretHandler: infrastructure.NewSecretHandler(context.Context),
+		kafkaHandler:  infrastructure.NewKafkaHandler(context.Context),
+	}
+}
+
+func (k *kafkaSecurityReconciler) Reconcile() error {
+	listenerSpec := infrastructure.GetKafkaListenerSpec(k.kafkaInstance, k.listener)
+	if listenerSpec == nil {
+		k.Log.Debug("Kafka listener not declared in the Kafka spec, connecting without security")
+		return nil
+	}
+	var authentication v1beta2.KafkaAuthenticationType
+	if listenerSpec.Authentication != nil {
+		authentication = listenerSpec.Authentication.AuthenticationType
+	}
+	if !listenerSpec.TLS && len(authentication) == 0 {
+		return nil
+	}
+
+	security := &kafkaSecurity{}
+	certs := map[string][]byte{}
+	if listenerSpec.TLS {
+		caSecret, err := k.fetchKafkaSecret(infrastructure.GetKafkaClusterCACertSecretName(k.kafkaInstance))
+		if err != nil {
+			return err
+		}
+		certs[kafkaTrustStoreKey] = caSecret.Data[infrastructure.KafkaClusterCACertP12Key]
+		security.trustStorePassword = string(caSecret.Data[infrastructure.KafkaClusterCACertPasswordKey])
+		security.protocol = kafkaSSLProtocol
+	}
+	switch authentication {
+	case "":
+	case v1beta2.KafkaScramSha512Authentication:
+		kafkaUser, userSecret, err := k.getKafkaUserSecret(authentication)
+		if err != nil {
+			return err
+		}
+		security.saslMechanism = kafkaScramSha512Mechanism
+		security.jaasConfig = string(userSecret.Data[infrastructure.KafkaUserJaasConfigKey])
+		if len(security.jaasConfig) == 0 {
+			username := kafkaUser.Status.Username
+			if len(username) == 0 {
+				username = kafkaUser.Name
+			}
+			security.jaasConfig = fmt.Sprintf(kafkaScramJaasConfig, username, userSecret.Data[infrastructure.KafkaUserPasswordKey])
+		}
+		if listenerSpec.TLS {
+			security.protocol = kafkaSASLSSLProtocol
+		} else {
+			security.protocol = kafkaSASLPlaintextProtocol
+		}
+	case v1beta2.KafkaTLSAuthentication:
+		_, userSecret, err := k.getKafkaUserSecret(authentication)
+		if err != nil {
+			return err
+		}
+		certs[kafkaKeyStoreKey] = userSecret.Data[infrastructure.KafkaUserP12Key]
+		security.keyStorePassword = string(userSecret.Data[infrastructure.KafkaUserP12PasswordKey])
+	default:
+		return errorForResourceConfigError(k.instance, fmt.Sprintf("Authentication %s of the Kafka listener %s is not supported", authentication, listenerSpec.Name))
+	}
+
+	if len(certs) > 0 {
+		if err := k.reconcileCertsSecret(certs); err != nil {
+			return err
+		}
+		k.instance.GetStatus().AddSecretVolumeReference(k.getKafkaCertsSecretName(), kafkaCertMountPath, &framework.ModeForCertificates, nil)
+	}
+	for _, profile := range runtimeprofile.GetRegistry().GetProfiles() {
+		if err := newKafkaSecuritySecretReconciler(k.infraContext, profile.Name, security).Reconcile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getKafkaUserSecret returns the KafkaUser referenced by the infra properties and the secret Strimzi generated for it,
+// the KafkaUser is created when asked and missing
+func (k *kafkaSecurityReconciler) getKafkaUserSecret(authentication v1beta2.KafkaAuthenticationType) (*v1beta2.KafkaUser, *v12.Secret, error) {
+	userName := getKafkaUserName(k.instance)
+	if len(userName) == 0 {
+		return nil, nil, errorForMissingResourceConfig(k.instance, kafkaUserKey)
+	}
+	createUser := k.instance.GetSpec().GetInfraProperties()[kafkaCreateUserKey] == "true"
+	kafkaUser, err := k.kafkaHandler.FetchKafkaUser(types.NamespacedName{Name: userName, Namespace: k.kafkaInstance.Namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+	if kafkaUser == nil {
+		if !createUser {
+			return nil, nil, errorForResourceNotFound("KafkaUser", userName, k.kafkaInstance.Namespace)
+		}
+		if kafkaUser, err = k.kafkaHandler.CreateKafkaUser(userName, k.kafkaInstance.Name, k.kafkaInstance.Namespace, authentication, k.instance); err != nil {
+			return nil, nil, err
+		}
+	}
+	if kafkaUser.Spec.Authentication.AuthenticationType != authentication {
+		return nil, nil, errorForResourceConfigError(k.instance,
+			fmt.Sprintf("KafkaUser %s authenticates with %s while the Kafka listener expects %s", userName, kafkaUser.Spec.Authentication.AuthenticationType, authentication))
+	}
+	if len(kafkaUser.Status.Secret) == 0 {
+		return nil, nil, errorForResourceNotReadyError(fmt.Errorf("kafka user %s not ready yet. Waiting for its secret", userName))
+	}
+	userSecret, err := k.fetchKafkaSecret(kafkaUser.Status.Secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kafkaUser, userSecret, nil
+}
+
+func (k *kafkaSecurityReconciler) fetchKafkaSecret(name string) (*v12.Secret, error) {
+	secret, err := k.secretHandler.FetchSecret(types.NamespacedName{Name: name, Namespace: k.kafkaInstance.Namespace})
+	if err != nil {
+		return nil, err
+	} else if secret == nil {
+		return nil, errorForResourceNotFound("Secret", name, k.kafkaInstance.Namespace)
+	}
+	return secret, nil
+}
+
+func (k *kafkaSecurityReconciler) reconcileCertsSecret(certs map[string][]byte) error {
+	requestedSecret := &v12.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      k.getKafkaCertsSecretName(),
+			Namespace: k.instance.GetNamespace(),
+			Labels: map[string]string{
+				framework.LabelAppKey: k.instance.GetName(),
+			},
+		},
+		Type: v12.SecretTypeOpaque,
+		Data: certs,
+	}
+	if err := framework.SetOwner(k.instance, k.Scheme, requestedSecret); err != nil {
+		return err
+	}
+	requestedResources := map[reflect.Type][]client.Object{reflect.TypeOf(v12.Secret{}): {requestedSecret}}
+
+	deployedResources := make(map[reflect.Type][]client.Object)
+	deployedSecret, err := k.secretHandler.FetchSecret(types.NamespacedName{Name: k.getKafkaCertsSecretName(), Namespace: k.instance.GetNamespace()})
+	if err != nil {
+		return err
+	}
+	if deployedSecret != nil {
+		deployedResources[reflect.TypeOf(v12.Secret{})] = []client.Object{deployedSecret}
+	}
+
+	deltaProcessor := infrastructure.NewDeltaProcessor(k.Context)
+	_, err = deltaProcessor.ProcessDelta(k.secretHandler.GetComparator(), requestedResources, deployedResources)
+	return err
+}
+
+func (k *kafkaSecurityReconciler) getKafkaCertsSecretName() string {
+	return fmt.Sprintf(kafkaCertsSecretName, k.instance.GetName())
+}
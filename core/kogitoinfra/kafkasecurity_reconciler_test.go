@@ -0,0 +1,219 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/infrastructure/kafka/v1beta2"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/internal/app"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	v12 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func createFakeSecuredKafka(ns string, authentication v1beta2.KafkaAuthenticationType) *v1beta2.Kafka {
+	kafkaInstance := test.CreateFakeKafka(ns)
+	kafkaInstance.Spec.Kafka.Listeners[1].Authentication = &v1beta2.KafkaListenerAuthentication{AuthenticationType: authentication}
+	kafkaInstance.Status.Listeners = []v1beta2.ListenerStatus{
+		{
+			Name:             "tls",
+			BootstrapServers: "kogito-kafka-infra-kafka-bootstrap:9093",
+		},
+	}
+	return kafkaInstance
+}
+
+func createFakeKafkaClusterCASecret(ns string) *v12.Secret {
+	return &v12.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-kafka-cluster-ca-cert", Namespace: ns},
+		Data: map[string][]byte{
+			"ca.crt":      []byte("certificate"),
+			"ca.p12":      []byte("truststore"),
+			"ca.password": []byte("truststore-password"),
+		},
+	}
+}
+
+func fetchSecret(t *testing.T, cli *client.Client, name, ns string) *v12.Secret {
+	secret := &v12.Secret{ObjectMeta: v1.ObjectMeta{Name: name, Namespace: ns}}
+	exist, err := kubernetes.ResourceC(cli).Fetch(secret)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	return secret
+}
+
+func TestKafkaSecurityReconciler_ScramOverTLS(t *testing.T) {
+	ns := t.Name()
+	kogitoKafkaInstance := test.CreateFakeKogitoKafka(ns)
+	kogitoKafkaInstance.GetSpec().AddInfraProperties(map[string]string{kafkaUserKey: "kogito"})
+	kafkaInstance := createFakeSecuredKafka(ns, v1beta2.KafkaScramSha512Authentication)
+	kafkaUser := &v1beta2.KafkaUser{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito", Namespace: ns},
+		Spec:       v1beta2.KafkaUserSpec{Authentication: v1beta2.KafkaUserAuthentication{AuthenticationType: v1beta2.KafkaScramSha512Authentication}},
+		Status:     v1beta2.KafkaUserStatus{Username: "kogito", Secret: "kogito"},
+	}
+	userSecret := &v12.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito", Namespace: ns},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kafkaInstance, kafkaUser, userSecret, createFakeKafkaClusterCASecret(ns)).Build()
+	infraContext := infraContext{
+		Context: operator.Context{
+			Client: cli,
+			Log:    test.TestLogger,
+			Scheme: meta.GetRegisteredSchema(),
+		},
+		instance: kogitoKafkaInstance,
+	}
+
+	err := newKafkaSecurityReconciler(infraContext, kafkaInstance, &kafkaInstance.Status.Listeners[0]).Reconcile()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(kogitoKafkaInstance.GetStatus().GetSecretVolumeReferences()))
+	assert.Equal(t, "kogito-kafka-infra-kafka-certs", kogitoKafkaInstance.GetStatus().GetSecretVolumeReferences()[0].GetName())
+	assert.Equal(t, []string{"kogito-kafka-infra-kafka-quarkus-security", "kogito-kafka-infra-kafka-springboot-security"}, kogitoKafkaInstance.GetStatus().GetSecretEnvFromReferences())
+
+	certsSecret := fetchSecret(t, cli, "kogito-kafka-infra-kafka-certs", ns)
+	assert.Equal(t, []byte("truststore"), certsSecret.Data[kafkaTrustStoreKey])
+	assert.NotContains(t, certsSecret.Data, kafkaKeyStoreKey)
+
+	quarkusSecret := fetchSecret(t, cli, "kogito-kafka-infra-kafka-quarkus-security", ns)
+	assert.Equal(t, "SASL_SSL", string(quarkusSecret.Data["kafka.security.protocol"]))
+	assert.Equal(t, "SCRAM-SHA-512", string(quarkusSecret.Data["kafka.sasl.mechanism"]))
+	assert.Equal(t, "org.apache.kafka.common.security.scram.ScramLoginModule required username=\"kogito\" password=\"secret\";", string(quarkusSecret.Data["kafka.sasl.jaas.config"]))
+	assert.Equal(t, "/home/kogito/certs/kafka/truststore.p12", string(quarkusSecret.Data["kafka.ssl.truststore.location"]))
+	assert.Equal(t, "truststore-password", string(quarkusSecret.Data["kafka.ssl.truststore.password"]))
+	assert.NotContains(t, quarkusSecret.Data, "kafka.ssl.keystore.location")
+
+	springSecret := fetchSecret(t, cli, "kogito-kafka-infra-kafka-springboot-security", ns)
+	assert.Equal(t, "SASL_SSL", string(springSecret.Data["spring.kafka.security.protocol"]))
+	assert.Equal(t, "file:/home/kogito/certs/kafka/truststore.p12", string(springSecret.Data["spring.kafka.ssl.trust-store-location"]))
+	assert.Equal(t, quarkusSecret.Data["kafka.sasl.jaas.config"], springSecret.Data["spring.kafka.properties.sasl.jaas.config"])
+}
+
+func TestKafkaSecurityReconciler_CreateTLSUser(t *testing.T) {
+	ns := t.Name()
+	kogitoKafkaInstance := test.CreateFakeKogitoKafka(ns)
+	kogitoKafkaInstance.GetSpec().AddInfraProperties(map[string]string{kafkaCreateUserKey: "true"})
+	kafkaInstance := createFakeSecuredKafka(ns, v1beta2.KafkaTLSAuthentication)
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kafkaInstance, createFakeKafkaClusterCASecret(ns)).Build()
+	infraContext := infraContext{
+		Context: operator.Context{
+			Client: cli,
+			Log:    test.TestLogger,
+			Scheme: meta.GetRegisteredSchema(),
+		},
+		instance: kogitoKafkaInstance,
+	}
+
+	// the user is created, the services wait for Strimzi to generate its secret
+	err := newKafkaSecurityReconciler(infraContext, kafkaInstance, &kafkaInstance.Status.Listeners[0]).Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, api.ResourceNotReady, reasonForError(err))
+	kafkaUser := &v1beta2.KafkaUser{ObjectMeta: v1.ObjectMeta{Name: kogitoKafkaInstance.GetName(), Namespace: ns}}
+	exist, err := kubernetes.ResourceC(cli).Fetch(kafkaUser)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, v1beta2.KafkaTLSAuthentication, kafkaUser.Spec.Authentication.AuthenticationType)
+	assert.Equal(t, "kogito-kafka", kafkaUser.Labels["strimzi.io/cluster"])
+	assert.Equal(t, 1, len(kafkaUser.OwnerReferences))
+
+	kafkaUser.Status.Secret = kafkaUser.Name
+	assert.NoError(t, kubernetes.ResourceC(cli).Update(kafkaUser))
+	userSecret := &v12.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: kafkaUser.Name, Namespace: ns},
+		Data:       map[string][]byte{"user.p12": []byte("keystore"), "user.password": []byte("keystore-password")},
+	}
+	assert.NoError(t, kubernetes.ResourceC(cli).Create(userSecret))
+
+	err = newKafkaSecurityReconciler(infraContext, kafkaInstance, &kafkaInstance.Status.Listeners[0]).Reconcile()
+	assert.NoError(t, err)
+	certsSecret := fetchSecret(t, cli, "kogito-kafka-infra-kafka-certs", ns)
+	assert.Equal(t, []byte("truststore"), certsSecret.Data[kafkaTrustStoreKey])
+	assert.Equal(t, []byte("keystore"), certsSecret.Data[kafkaKeyStoreKey])
+
+	quarkusSecret := fetchSecret(t, cli, "kogito-kafka-infra-kafka-quarkus-security", ns)
+	assert.Equal(t, "SSL", string(quarkusSecret.Data["kafka.security.protocol"]))
+	assert.Equal(t, "/home/kogito/certs/kafka/keystore.p12", string(quarkusSecret.Data["kafka.ssl.keystore.location"]))
+	assert.Equal(t, "keystore-password", string(quarkusSecret.Data["kafka.ssl.keystore.password"]))
+	assert.NotContains(t, quarkusSecret.Data, "kafka.sasl.jaas.config")
+}
+
+func TestKafkaSecurityReconciler_MissingUser(t *testing.T) {
+	ns := t.Name()
+	kogitoKafkaInstance := test.CreateFakeKogitoKafka(ns)
+	kafkaInstance := createFakeSecuredKafka(ns, v1beta2.KafkaScramSha512Authentication)
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kafkaInstance, createFakeKafkaClusterCASecret(ns)).Build()
+	infraContext := infraContext{
+		Context: operator.Context{
+			Client: cli,
+			Log:    test.TestLogger,
+			Scheme: meta.GetRegisteredSchema(),
+		},
+		instance: kogitoKafkaInstance,
+	}
+
+	err := newKafkaSecurityReconciler(infraContext, kafkaInstance, &kafkaInstance.Status.Listeners[0]).Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, api.ResourceMissingResourceConfig, reasonForError(err))
+}
+
+func TestKafkaSecurityReconciler_PlainListener(t *testing.T) {
+	ns := t.Name()
+	kogitoKafkaInstance := test.CreateFakeKogitoKafka(ns)
+	kafkaInstance := test.CreateFakeKafka(ns)
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kafkaInstance).Build()
+	infraContext := infraContext{
+		Context: operator.Context{
+			Client: cli,
+			Log:    test.TestLogger,
+			Scheme: meta.GetRegisteredSchema(),
+		},
+		instance: kogitoKafkaInstance,
+	}
+
+	err := newKafkaSecurityReconciler(infraContext, kafkaInstance, &kafkaInstance.Status.Listeners[0]).Reconcile()
+	assert.NoError(t, err)
+	assert.Empty(t, kogitoKafkaInstance.GetStatus().GetSecretVolumeReferences())
+	assert.Empty(t, kogitoKafkaInstance.GetStatus().GetSecretEnvFromReferences())
+}
+
+func Test_newKafkaSecretMapper(t *testing.T) {
+	ns := t.Name()
+	kogitoKafkaInstance := test.CreateFakeKogitoKafka(ns)
+	kogitoKafkaInstance.GetSpec().AddInfraProperties(map[string]string{kafkaUserKey: "kogito"})
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kogitoKafkaInstance, test.CreateFakeKogitoInfinispan(ns)).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	mapper := newKafkaSecretMapper(context, app.NewKogitoInfraHandler(context))
+
+	// the rotated cluster CA reaches the services
+	requests := mapper(createFakeKafkaClusterCASecret(ns))
+	assert.Len(t, requests, 1)
+	assert.Equal(t, types.NamespacedName{Name: kogitoKafkaInstance.GetName(), Namespace: ns}, requests[0].NamespacedName)
+	assert.Len(t, mapper(&v12.Secret{ObjectMeta: v1.ObjectMeta{Name: "kogito", Namespace: ns}}), 1)
+	assert.Empty(t, mapper(&v12.Secret{ObjectMeta: v1.ObjectMeta{Name: "kogito-kafka-cluster-ca-cert", Namespace: "other-namespace"}}))
+	assert.Empty(t, mapper(&v12.Secret{ObjectMeta: v1.ObjectMeta{Name: "other-secret", Namespace: ns}}))
+}
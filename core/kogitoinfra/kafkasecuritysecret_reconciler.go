@@ -0,0 +1,146 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
+	v12 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// kafkaSecuritySecretName is the secret holding the Kafka security properties of the given runtime for the KogitoInfra with the given name
+	kafkaSecuritySecretName = "%s-kafka-%s-security"
+)
+
+type kafkaSecuritySecretReconciler struct {
+	infraContext
+	runtime       api.RuntimeType
+	security      *kafkaSecurity
+	secretHandler infrastructure.SecretHandler
+}
+
+func newKafkaSecuritySecretReconciler(context infraContext, runtime api.RuntimeType, security *kafkaSecurity) Reconciler {
+	return &kafkaSecuritySecretReconciler{
+		infraContext:  context,
+		runtime:       runtime,
+		security:      security,
+		secretHandler: infrastructure.NewSecretHandler(context.Context),
+	}
+}
+
+func (k *kafkaSecuritySecretReconciler) Reconcile() (err error) {
+
+	// Create Required resource
+	requestedResources, err := k.createRequiredResources()
+	if err != nil {
+		return
+	}
+
+	// Get Deployed resource
+	deployedResources, err := k.getDeployedResources()
+	if err != nil {
+		return
+	}
+
+	// Process Delta
+	if err = k.processDelta(requestedResources, deployedResources); err != nil {
+		return err
+	}
+
+	k.instance.GetStatus().AddSecretEnvFromReferences(k.getKafkaSecuritySecretName())
+	return nil
+}
+
+func (k *kafkaSecuritySecretReconciler) createRequiredResources() (map[reflect.Type][]client.Object, error) {
+	resources := make(map[reflect.Type][]client.Object)
+	secret := k.createKafkaSecuritySecret(k.getKafkaSecurityProps())
+	if err := framework.SetOwner(k.instance, k.Scheme, secret); err != nil {
+		return resources, err
+	}
+	resources[reflect.TypeOf(v12.Secret{})] = []client.Object{secret}
+	return resources, nil
+}
+
+func (k *kafkaSecuritySecretReconciler) getDeployedResources() (map[reflect.Type][]client.Object, error) {
+	resources := make(map[reflect.Type][]client.Object)
+	deployedSecret, err := k.secretHandler.FetchSecret(types.NamespacedName{Name: k.getKafkaSecuritySecretName(), Namespace: k.instance.GetNamespace()})
+	if err != nil {
+		return nil, err
+	}
+	if deployedSecret != nil {
+		resources[reflect.TypeOf(v12.Secret{})] = []client.Object{deployedSecret}
+	}
+	return resources, nil
+}
+
+func (k *kafkaSecuritySecretReconciler) processDelta(requestedResources map[reflect.Type][]client.Object, deployedResources map[reflect.Type][]client.Object) (err error) {
+	comparator := k.secretHandler.GetComparator()
+	deltaProcessor := infrastructure.NewDeltaProcessor(k.Context)
+	_, err = deltaProcessor.ProcessDelta(comparator, requestedResources, deployedResources)
+	return err
+}
+
+func (k *kafkaSecuritySecretReconciler) getKafkaSecurityProps() map[string][]byte {
+	profile := runtimeprofile.GetRegistry().GetProfile(k.runtime)
+	storeLocationPrefix := profile.GetProperty(runtimeprofile.KafkaInfra, runtimeprofile.KafkaStoreLocationPrefix)
+	values := map[string]string{runtimeprofile.KafkaSecurityProtocol: k.security.protocol}
+	if len(k.security.trustStorePassword) > 0 {
+		values[runtimeprofile.KafkaTrustStoreLocation] = storeLocationPrefix + kafkaTrustStoreMountPath
+		values[runtimeprofile.KafkaTrustStoreType] = pkcs12CertType
+		values[runtimeprofile.KafkaTrustStorePassword] = k.security.trustStorePassword
+	}
+	if len(k.security.keyStorePassword) > 0 {
+		values[runtimeprofile.KafkaKeyStoreLocation] = storeLocationPrefix + kafkaKeyStoreMountPath
+		values[runtimeprofile.KafkaKeyStoreType] = pkcs12CertType
+		values[runtimeprofile.KafkaKeyStorePassword] = k.security.keyStorePassword
+	}
+	if len(k.security.saslMechanism) > 0 {
+		values[runtimeprofile.KafkaSaslMechanism] = k.security.saslMechanism
+		values[runtimeprofile.KafkaSaslJaasConfig] = k.security.jaasConfig
+	}
+	appProps := map[string][]byte{}
+	for name, value := range profile.MapProperties(runtimeprofile.KafkaInfra, values) {
+		appProps[name] = []byte(value)
+	}
+	return appProps
+}
+
+func (k *kafkaSecuritySecretReconciler) createKafkaSecuritySecret(appProps map[string][]byte) *v12.Secret {
+	secret := &v12.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      k.getKafkaSecuritySecretName(),
+			Namespace: k.instance.GetNamespace(),
+			Labels: map[string]string{
+				framework.LabelAppKey: k.instance.GetName(),
+			},
+		},
+		Type: v12.SecretTypeOpaque,
+		Data: appProps,
+	}
+	return secret
+}
+
+func (k *kafkaSecuritySecretReconciler) getKafkaSecuritySecretName() string {
+	return fmt.Sprintf(kafkaSecuritySecretName, k.instance.GetName(), k.runtime)
+}
@@ -15,6 +15,8 @@
 package kogitoinfra
 
 import (
+	"fmt"
+
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	keycloakv1alpha1 "github.com/kiegroup/kogito-operator/core/infrastructure/keycloak/v1alpha1"
@@ -59,12 +61,29 @@ func (k *keycloakInfraReconciler) Reconcile() (resultErr error) {
 		} else if keycloakInstance == nil {
 			return errorForResourceNotFound("Keycloak", k.instance.GetSpec().GetResource().GetName(), namespace)
 		}
+	} else if infrastructure.IsInfraResourceProvisioned(k.instance) {
+		if keycloakInstance, resultErr = k.provisionKeycloakInstance(keycloakHandler); resultErr != nil {
+			return resultErr
+		}
+		if !keycloakInstance.Status.Ready {
+			return errorForResourceNotReadyError(fmt.Errorf("keycloak instance %s not ready. Waiting for Status.Ready", keycloakInstance.Name))
+		}
 	} else {
 		return errorForResourceConfigError(k.instance, "No Keycloak resource name given")
 	}
 	return nil
 }
 
+// provisionKeycloakInstance fetches the Keycloak provisioned for the instance, it's created from the provision template when missing
+func (k *keycloakInfraReconciler) provisionKeycloakInstance(keycloakHandler infrastructure.KeycloakHandler) (*keycloakv1alpha1.Keycloak, error) {
+	key := infrastructure.GetInfraResourceKey(k.instance)
+	keycloakInstance, err := k.loadDeployedKeycloakInstance(key.Name, key.Namespace)
+	if err != nil || keycloakInstance != nil {
+		return keycloakInstance, err
+	}
+	return keycloakHandler.CreateKeycloakInstance(*key, k.instance.GetSpec().GetProvision(), k.instance)
+}
+
 func (k *keycloakInfraReconciler) loadDeployedKeycloakInstance(name string, namespace string) (*keycloakv1alpha1.Keycloak, error) {
 	k.Log.Debug("fetching deployed kogito Keycloak instance")
 	keycloakInstance := &keycloakv1alpha1.Keycloak{}
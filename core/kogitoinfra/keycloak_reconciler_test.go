@@ -0,0 +1,72 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	keycloakv1alpha1 "github.com/kiegroup/kogito-operator/core/infrastructure/keycloak/v1alpha1"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newKogitoKeycloak(namespace string) *v1beta1.KogitoInfra {
+	return &v1beta1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{Name: "kogito-keycloak-infra", Namespace: namespace},
+		Spec: v1beta1.KogitoInfraSpec{
+			Resource: &v1beta1.InfraResource{
+				Kind:       infrastructure.KeycloakKind,
+				APIVersion: infrastructure.KeycloakAPIVersion,
+			},
+			Provision: &v1beta1.InfraProvision{},
+		},
+	}
+}
+
+func Test_KeycloakInfraReconciler_ProvisionKeycloak(t *testing.T) {
+	ns := t.Name()
+	kogitoKeycloakInstance := newKogitoKeycloak(ns)
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kogitoKeycloakInstance).Build()
+	infraContext := infraContext{
+		Context: operator.Context{
+			Client: cli,
+			Log:    test.TestLogger,
+			Scheme: meta.GetRegisteredSchema(),
+		},
+		instance: kogitoKeycloakInstance,
+	}
+	err := initkeycloakInfraReconciler(infraContext).Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, api.ResourceNotReady, reasonForError(err))
+
+	keycloakInstance := &keycloakv1alpha1.Keycloak{ObjectMeta: v1.ObjectMeta{Name: kogitoKeycloakInstance.Name, Namespace: ns}}
+	exists, err := kubernetes.ResourceC(cli).Fetch(keycloakInstance)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, 1, keycloakInstance.Spec.Instances)
+	assert.True(t, keycloakInstance.Spec.ExternalAccess.Enabled)
+	assert.Equal(t, kogitoKeycloakInstance.Name, keycloakInstance.OwnerReferences[0].Name)
+
+	keycloakInstance.Status.Ready = true
+	assert.NoError(t, kubernetes.ResourceC(cli).Update(keycloakInstance))
+	assert.NoError(t, initkeycloakInfraReconciler(infraContext).Reconcile())
+}
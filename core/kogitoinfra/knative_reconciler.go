@@ -52,6 +52,9 @@ func (k *knativeInfraReconciler) Reconcile() (resultErr error) {
 		} else if broker == nil {
 			return errorForResourceNotFound(infrastructure.KnativeEventingBrokerKind, k.instance.GetSpec().GetResource().GetName(), ns)
 		}
+		if !broker.Status.GetTopLevelCondition().IsTrue() {
+			return errorForResourceNotReadyError(fmt.Errorf("broker %s not ready yet. Waiting for Condition status Ready", broker.Name))
+		}
 	} else {
 		return fmt.Errorf("No Knative Eventing Broker resource defined in the KogitoInfra CR %s on namespace %s, impossible to continue ", k.instance.GetName(), k.instance.GetNamespace())
 	}
@@ -15,61 +15,37 @@
 package kogitoinfra
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	api "github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	mongodb "github.com/kiegroup/kogito-operator/core/infrastructure/mongodb/v1"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 )
 
 const (
-	// Using URI for Quarkus as this is what we get from MongoDB instance
-	// and host/port for Spring Boot because URI cannot be used with credentials (Spring Boot Starter restriction) ...
-	// https://github.com/spring-projects/spring-boot/blob/b7fdf8fe87da1c01ff6aca041170a02f11280a1a/spring-boot-project/spring-boot-autoconfigure/src/main/java/org/springframework/boot/autoconfigure/mongo/MongoProperties.java#L61-L64
-	appPropMongoDBURI  = iota // for Quarkus
-	appPropMongoDBHost        // for Spring boot
-	appPropMongoDBPort        // for Spring boot
-
-	envVarMongoDBAuthDatabase
-	envVarMongoDBUser
-	envVarMongoDBPassword
-	envVarMongoDBDatabase
-
 	//mongoDBEnvKeyCredSecret        = "MONGODB_CREDENTIAL_SECRET"
 	mongoDBEnablePersistenceEnvKey = "ENABLE_PERSISTENCE"
 
 	infraPropertiesUserKey         = "username"
 	infraPropertiesDatabaseKey     = "database"
 	infraPropertiesAuthDatabaseKey = "auth-database"
-)
-
-var (
-	// MongoDB variables for the KogitoInfra deployed infrastructure.
-	//For Quarkus: https://quarkus.io/guides/mongoDB-client#quarkus-mongoDB-client_configuration
-	//For Spring: https://docs.spring.io/spring-boot/docs/current/reference/htmlsingle/#data.nosql.mongodb
-
-	propertiesMongoDB = map[api.RuntimeType]map[int]string{
-		api.QuarkusRuntimeType: {
-			appPropMongoDBURI: "quarkus.mongodb.connection-string",
 
-			envVarMongoDBAuthDatabase: "QUARKUS_MONGODB_CREDENTIALS_AUTH_SOURCE",
-			envVarMongoDBUser:         "QUARKUS_MONGODB_CREDENTIALS_USERNAME",
-			envVarMongoDBPassword:     "QUARKUS_MONGODB_CREDENTIALS_PASSWORD",
-			envVarMongoDBDatabase:     "QUARKUS_MONGODB_DATABASE",
-		},
-		api.SpringBootRuntimeType: {
-			appPropMongoDBHost: "spring.data.mongodb.host",
-			appPropMongoDBPort: "spring.data.mongodb.port",
-
-			envVarMongoDBAuthDatabase: "SPRING_DATA_MONGODB_AUTHENTICATION_DATABASE",
-			envVarMongoDBUser:         "SPRING_DATA_MONGODB_USERNAME",
-			envVarMongoDBPassword:     "SPRING_DATA_MONGODB_PASSWORD",
-			envVarMongoDBDatabase:     "SPRING_DATA_MONGODB_DATABASE",
-		},
-	}
+	// mongoDBProvisionedPasswordSecretName is the secret holding the password of the user of the provisioned MongoDB, in: mongodb name, username
+	mongoDBProvisionedPasswordSecretName = "%s-%s-password"
+	// mongoDBProvisionedScramSecretName is the prefix of the SCRAM credentials secret of the user of the provisioned MongoDB, in: mongodb name, username
+	mongoDBProvisionedScramSecretName = "%s-%s"
+	mongoDBProvisionedPasswordLength  = 16
+	mongoDBReadWriteRole              = "readWrite"
+	mongoDBDBAdminRole                = "dbAdmin"
 )
 
 type mongoDBInfraReconciler struct {
@@ -103,11 +79,13 @@ func (i *mongoDBInfraReconciler) Reconcile() (resultErr error) {
 		mongoDBNamespace = i.instance.GetNamespace()
 		i.Log.Debug("Namespace is not provided for infrastructure MongoDB resource", "instance", i.instance.GetName(), "namespace", mongoDBNamespace)
 	}
-	if len(mongoDBName) == 0 {
+	if infrastructure.IsInfraResourceProvisioned(i.instance) {
+		if mongoDBInstance, resultErr = i.provisionMongoDBInstance(mongoDBHandler); resultErr != nil {
+			return resultErr
+		}
+	} else if len(mongoDBName) == 0 {
 		return errorForResourceConfigError(i.instance, "No resource name given")
-	}
-
-	if mongoDBInstance, resultErr = mongoDBHandler.FetchMongoDBInstance(types.NamespacedName{Name: mongoDBName, Namespace: mongoDBNamespace}); resultErr != nil {
+	} else if mongoDBInstance, resultErr = mongoDBHandler.FetchMongoDBInstance(types.NamespacedName{Name: mongoDBName, Namespace: mongoDBNamespace}); resultErr != nil {
 		return resultErr
 	} else if mongoDBInstance == nil {
 		return errorForResourceNotFound("MongoDB", i.instance.GetSpec().GetResource().GetName(), mongoDBNamespace)
@@ -118,15 +96,68 @@ func (i *mongoDBInfraReconciler) Reconcile() (resultErr error) {
 		return errorForResourceNotReadyError(fmt.Errorf("mongoDB instance %s not ready. Waiting for Status.Phase == Running", mongoDBInstance.Name))
 	}
 	i.Log.Info("MongoDB instance is running")
-	if resultErr = i.updateMongoDBRuntimePropsInStatus(mongoDBInstance, api.QuarkusRuntimeType); resultErr != nil {
-		return resultErr
-	}
-	if resultErr = i.updateMongoDBRuntimePropsInStatus(mongoDBInstance, api.SpringBootRuntimeType); resultErr != nil {
-		return resultErr
+	for _, profile := range runtimeprofile.GetRegistry().GetProfiles() {
+		if resultErr = i.updateMongoDBRuntimePropsInStatus(mongoDBInstance, profile.Name); resultErr != nil {
+			return resultErr
+		}
 	}
 	return resultErr
 }
 
+// provisionMongoDBInstance fetches the MongoDB provisioned for the instance, it's created from the provision template when missing
+// with the user given in the infra properties, whose generated password is kept in a secret owned by the instance
+func (i *mongoDBInfraReconciler) provisionMongoDBInstance(mongoDBHandler infrastructure.MongoDBHandler) (*mongodb.MongoDBCommunity, error) {
+	key := infrastructure.GetInfraResourceKey(i.instance)
+	mongoDBInstance, err := mongoDBHandler.FetchMongoDBInstance(*key)
+	if err != nil || mongoDBInstance != nil {
+		return mongoDBInstance, err
+	}
+	username := i.instance.GetSpec().GetInfraProperties()[infraPropertiesUserKey]
+	if len(username) == 0 {
+		return nil, errorForMissingResourceConfig(i.instance, infraPropertiesUserKey)
+	}
+	database := i.instance.GetSpec().GetInfraProperties()[infraPropertiesDatabaseKey]
+	if len(database) == 0 {
+		return nil, errorForMissingResourceConfig(i.instance, infraPropertiesDatabaseKey)
+	}
+	authDatabase := i.instance.GetSpec().GetInfraProperties()[infraPropertiesAuthDatabaseKey]
+	if len(authDatabase) == 0 {
+		authDatabase = infrastructure.DefaultMongoDBAuthDatabase
+	}
+	passwordSecret, err := i.createProvisionedMongoDBPasswordSecret(strings.ToLower(fmt.Sprintf(mongoDBProvisionedPasswordSecretName, key.Name, username)))
+	if err != nil {
+		return nil, err
+	}
+	user := mongodb.MongoDBUser{
+		Name:              username,
+		DB:                authDatabase,
+		PasswordSecretRef: mongodb.SecretKeyReference{Name: passwordSecret.Name, Key: infrastructure.DefaultMongoDBPasswordSecretRef},
+		Roles: []mongodb.Role{
+			{DB: database, Name: mongoDBReadWriteRole},
+			{DB: database, Name: mongoDBDBAdminRole},
+		},
+		ScramCredentialsSecretName: strings.ToLower(fmt.Sprintf(mongoDBProvisionedScramSecretName, key.Name, username)),
+	}
+	return mongoDBHandler.CreateMongoDBInstance(*key, i.instance.GetSpec().GetProvision(), []mongodb.MongoDBUser{user}, i.instance)
+}
+
+// createProvisionedMongoDBPasswordSecret creates the secret holding the generated password of the provisioned MongoDB user, unless it exists
+func (i *mongoDBInfraReconciler) createProvisionedMongoDBPasswordSecret(name string) (*corev1.Secret, error) {
+	password := make([]byte, mongoDBProvisionedPasswordLength)
+	if _, err := rand.Read(password); err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: i.instance.GetNamespace()},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: map[string]string{infrastructure.DefaultMongoDBPasswordSecretRef: hex.EncodeToString(password)},
+	}
+	if err := kubernetes.ResourceC(i.Client).CreateIfNotExistsForOwner(secret, i.instance, i.Scheme); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
 func (i *mongoDBInfraReconciler) updateMongoDBRuntimePropsInStatus(mongoDBInstance *mongodb.MongoDBCommunity, runtime api.RuntimeType) error {
 	i.Log.Debug("going to Update MongoDB runtime properties in kogito infra instance status", "runtime", runtime)
 	mongoDBConfigReconciler := newMongoDBConfigReconciler(i.infraContext, mongoDBInstance, runtime)
@@ -15,10 +15,16 @@
 package kogitoinfra
 
 import (
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	mongodb "github.com/kiegroup/kogito-operator/core/infrastructure/mongodb/v1"
 	"github.com/kiegroup/kogito-operator/core/operator"
 	"github.com/kiegroup/kogito-operator/core/test"
 	"github.com/kiegroup/kogito-operator/meta"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -80,3 +86,59 @@ func TestMongoDBInfraReconciler_MongoDBInstanceNotReady(t *testing.T) {
 	err := mongoDBInfraReconciler.Reconcile()
 	assert.Errorf(t, err, "mongoDB instance kogito-mongodb not ready. Waiting for Status.Phase == Running")
 }
+
+func TestMongoDBInfraReconciler_ProvisionMongoDB(t *testing.T) {
+	ns := t.Name()
+	kogitoMongoDBInstance := test.CreateFakeKogitoMongoDB(ns).(*v1beta1.KogitoInfra)
+	kogitoMongoDBInstance.Spec.Resource.Name = ""
+	kogitoMongoDBInstance.Spec.Provision = &v1beta1.InfraProvision{}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kogitoMongoDBInstance).Build()
+	infraContext := infraContext{
+		Context: operator.Context{
+			Client: cli,
+			Log:    test.TestLogger,
+			Scheme: meta.GetRegisteredSchema(),
+		},
+		instance: kogitoMongoDBInstance,
+	}
+	err := initMongoDBInfraReconciler(infraContext).Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, api.ResourceNotReady, reasonForError(err))
+
+	mongoDBInstance := &mongodb.MongoDBCommunity{ObjectMeta: v1.ObjectMeta{Name: kogitoMongoDBInstance.Name, Namespace: ns}}
+	exists, err := kubernetes.ResourceC(cli).Fetch(mongoDBInstance)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, infrastructure.DefaultMongoDBVersion, mongoDBInstance.Spec.Version)
+	assert.Equal(t, 1, mongoDBInstance.Spec.Members)
+	assert.Len(t, mongoDBInstance.Spec.Users, 1)
+	user := mongoDBInstance.Spec.Users[0]
+	assert.Equal(t, kogitoMongoDBInstance.Spec.InfraProperties[infraPropertiesUserKey], user.Name)
+	assert.Equal(t, infrastructure.DefaultMongoDBAuthDatabase, user.DB)
+
+	passwordSecret := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Name: user.PasswordSecretRef.Name, Namespace: ns}}
+	exists, err = kubernetes.ResourceC(cli).Fetch(passwordSecret)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, kogitoMongoDBInstance.Name, passwordSecret.OwnerReferences[0].Name)
+}
+
+func TestMongoDBInfraReconciler_ProvisionMongoDBWithoutUser(t *testing.T) {
+	ns := t.Name()
+	kogitoMongoDBInstance := test.CreateFakeKogitoMongoDB(ns).(*v1beta1.KogitoInfra)
+	kogitoMongoDBInstance.Spec.Resource.Name = ""
+	kogitoMongoDBInstance.Spec.Provision = &v1beta1.InfraProvision{}
+	delete(kogitoMongoDBInstance.Spec.InfraProperties, infraPropertiesUserKey)
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kogitoMongoDBInstance).Build()
+	infraContext := infraContext{
+		Context: operator.Context{
+			Client: cli,
+			Log:    test.TestLogger,
+			Scheme: meta.GetRegisteredSchema(),
+		},
+		instance: kogitoMongoDBInstance,
+	}
+	err := initMongoDBInfraReconciler(infraContext).Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, api.ResourceMissingResourceConfig, reasonForError(err))
+}
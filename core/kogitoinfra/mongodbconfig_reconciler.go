@@ -23,6 +23,7 @@ import (
 	"github.com/kiegroup/kogito-operator/core/framework"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	mongodb "github.com/kiegroup/kogito-operator/core/infrastructure/mongodb/v1"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	v12 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -116,11 +117,13 @@ func (i *mongoDBConfigReconciler) getMongoDBAppProps() (map[string]string, error
 		if err != nil {
 			return nil, err
 		}
-		if i.runtime == api.QuarkusRuntimeType {
-			appProps[propertiesMongoDB[i.runtime][appPropMongoDBURI]] = mongoDBURI
-		} else if i.runtime == api.SpringBootRuntimeType {
-			appProps[propertiesMongoDB[i.runtime][appPropMongoDBHost]] = mongoDBParsedURL.Hostname()
-			appProps[propertiesMongoDB[i.runtime][appPropMongoDBPort]] = mongoDBParsedURL.Port()
+		// the runtimes not accepting a connection string with separate credentials map the host and port instead
+		for name, value := range runtimeprofile.GetRegistry().GetProfile(i.runtime).MapProperties(runtimeprofile.MongoDBInfra, map[string]string{
+			runtimeprofile.MongoDBURI:  mongoDBURI,
+			runtimeprofile.MongoDBHost: mongoDBParsedURL.Hostname(),
+			runtimeprofile.MongoDBPort: mongoDBParsedURL.Port(),
+		}) {
+			appProps[name] = value
 		}
 	}
 	return appProps, nil
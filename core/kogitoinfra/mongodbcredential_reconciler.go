@@ -23,6 +23,7 @@ import (
 	"github.com/kiegroup/kogito-operator/core/framework"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	mongodb "github.com/kiegroup/kogito-operator/core/infrastructure/mongodb/v1"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	v12 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -50,8 +51,13 @@ func newMongoDBCredentialReconciler(infraContext infraContext, mongoDBInstance *
 }
 
 func (i *mongoDBCredentialReconciler) Reconcile() (err error) {
+	credentials, sourceSecret, err := i.retrieveMongoDBCredentialsFromInstance()
+	if err != nil {
+		return
+	}
+
 	// Create Required resource
-	requestedResources, err := i.createRequiredResources()
+	requestedResources, err := i.createRequiredResources(credentials)
 	if err != nil {
 		return
 	}
@@ -62,21 +68,30 @@ func (i *mongoDBCredentialReconciler) Reconcile() (err error) {
 		return
 	}
 
+	// the services keep the previous credentials during the grace period of a rotation
+	rotationPending, err := checkCredentialRotation(i.infraContext, sourceSecret, map[string]string{
+		"auth-database": credentials.AuthDatabase,
+		"username":      credentials.Username,
+		"password":      credentials.Password,
+		"database":      credentials.Database,
+	})
+	if err != nil {
+		return
+	}
+
 	// Process Delta
-	if err = i.processDelta(requestedResources, deployedResources); err != nil {
-		return err
+	if !rotationPending || len(deployedResources) == 0 {
+		if err = i.processDelta(requestedResources, deployedResources); err != nil {
+			return err
+		}
 	}
 
 	i.instance.GetStatus().AddSecretEnvFromReferences(i.getCredentialSecretName())
 	return nil
 }
 
-func (i *mongoDBCredentialReconciler) createRequiredResources() (map[reflect.Type][]client.Object, error) {
+func (i *mongoDBCredentialReconciler) createRequiredResources(credentials *MongoDBCredential) (map[reflect.Type][]client.Object, error) {
 	resources := make(map[reflect.Type][]client.Object)
-	credentials, err := i.retrieveMongoDBCredentialsFromInstance()
-	if err != nil {
-		return nil, err
-	}
 	secret := i.createCustomKogitoMongoDBSecret(credentials)
 	if err := framework.SetOwner(i.infraContext.instance, i.infraContext.Scheme, secret); err != nil {
 		return resources, err
@@ -114,13 +129,13 @@ type MongoDBCredential struct {
 }
 
 // retrieveMongoDBCredentialsFromInstance retrieves the credentials of the MongoDB server deployed with the Kogito Operator
-// based on the kogitoinfra given properties
-func (i *mongoDBCredentialReconciler) retrieveMongoDBCredentialsFromInstance() (*MongoDBCredential, error) {
+// based on the kogitoinfra given properties, along with the Secret holding the password of the user
+func (i *mongoDBCredentialReconciler) retrieveMongoDBCredentialsFromInstance() (*MongoDBCredential, types.NamespacedName, error) {
 	creds := &MongoDBCredential{}
 	if len(i.instance.GetSpec().GetInfraProperties()[infraPropertiesUserKey]) == 0 {
-		return nil, errorForMissingResourceConfig(i.instance, infraPropertiesUserKey)
+		return nil, types.NamespacedName{}, errorForMissingResourceConfig(i.instance, infraPropertiesUserKey)
 	} else if len(i.instance.GetSpec().GetInfraProperties()[infraPropertiesDatabaseKey]) == 0 {
-		return nil, errorForMissingResourceConfig(i.instance, infraPropertiesDatabaseKey)
+		return nil, types.NamespacedName{}, errorForMissingResourceConfig(i.instance, infraPropertiesDatabaseKey)
 	}
 	creds.Username = i.instance.GetSpec().GetInfraProperties()[infraPropertiesUserKey]
 	creds.Database = i.instance.GetSpec().GetInfraProperties()[infraPropertiesDatabaseKey]
@@ -131,15 +146,16 @@ func (i *mongoDBCredentialReconciler) retrieveMongoDBCredentialsFromInstance() (
 
 	user := i.findMongoDBUserByUsernameAndAuthDatabase(i.mongoDBInstance, creds.Username, creds.AuthDatabase)
 	if user == nil {
-		return nil, errorForResourceConfigError(i.instance, fmt.Sprintf("No user found in MongoDB configuration for username %s and authentication database %s", creds.Username, creds.AuthDatabase))
+		return nil, types.NamespacedName{}, errorForResourceConfigError(i.instance, fmt.Sprintf("No user found in MongoDB configuration for username %s and authentication database %s", creds.Username, creds.AuthDatabase))
 	}
 	i.Log.Debug("Found", "user", user.Name, "authDB", user.DB, "password ref", user.PasswordSecretRef)
 
-	secret := &v12.Secret{ObjectMeta: metav1.ObjectMeta{Name: user.PasswordSecretRef.Name, Namespace: i.mongoDBInstance.Namespace}}
+	secretKey := types.NamespacedName{Name: user.PasswordSecretRef.Name, Namespace: i.mongoDBInstance.Namespace}
+	secret := &v12.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretKey.Name, Namespace: secretKey.Namespace}}
 	if exists, err := kubernetes.ResourceC(i.Client).Fetch(secret); err != nil {
-		return nil, err
+		return nil, types.NamespacedName{}, err
 	} else if !exists {
-		return nil, errorForResourceNotFound("Secret", user.PasswordSecretRef.Name, i.instance.GetNamespace())
+		return nil, types.NamespacedName{}, errorForResourceNotFound("Secret", user.PasswordSecretRef.Name, i.instance.GetNamespace())
 	} else {
 		i.Log.Debug("Found MongoDB secret", "password ref", user.PasswordSecretRef.Name)
 		passwordKey := infrastructure.DefaultMongoDBPasswordSecretRef
@@ -149,7 +165,7 @@ func (i *mongoDBCredentialReconciler) retrieveMongoDBCredentialsFromInstance() (
 		creds.Password = string(secret.Data[passwordKey])
 	}
 
-	return creds, nil
+	return creds, secretKey, nil
 }
 
 // Setup authentication to MongoDB
@@ -161,12 +177,12 @@ func (i *mongoDBCredentialReconciler) createCustomKogitoMongoDBSecret(credential
 			Namespace: i.instance.GetNamespace(),
 		},
 		Type: v12.SecretTypeOpaque,
-		StringData: map[string]string{
-			propertiesMongoDB[i.runtime][envVarMongoDBAuthDatabase]: credentials.AuthDatabase,
-			propertiesMongoDB[i.runtime][envVarMongoDBUser]:         credentials.Username,
-			propertiesMongoDB[i.runtime][envVarMongoDBPassword]:     credentials.Password,
-			propertiesMongoDB[i.runtime][envVarMongoDBDatabase]:     credentials.Database,
-		},
+		StringData: runtimeprofile.GetRegistry().GetProfile(i.runtime).MapProperties(runtimeprofile.MongoDBInfra, map[string]string{
+			runtimeprofile.MongoDBAuthDatabase: credentials.AuthDatabase,
+			runtimeprofile.MongoDBUsername:     credentials.Username,
+			runtimeprofile.MongoDBPassword:     credentials.Password,
+			runtimeprofile.MongoDBDatabase:     credentials.Database,
+		}),
 	}
 	return secret
 }
@@ -59,3 +59,43 @@ func TestMongoDBCredentialReconciler(t *testing.T) {
 	assert.NotNil(t, credentialSecret.StringData["QUARKUS_MONGODB_CREDENTIALS_PASSWORD"])
 	assert.NotNil(t, credentialSecret.StringData["QUARKUS_MONGODB_DATABASE"])
 }
+
+func TestMongoDBCredentialReconciler_SecretRotation(t *testing.T) {
+	ns := t.Name()
+	kogitoMongoDBInstance := test.CreateFakeKogitoMongoDB(ns)
+	kogitoMongoDBInstance.GetSpec().AddInfraProperties(map[string]string{credentialRotationGracePeriodKey: "1h"})
+	mongoDBInstance := test.CreateFakeMongoDB(ns)
+	mongoDBSecret := test.CreateFakeMongoDBSecret(ns)
+	cli := test.NewFakeClientBuilder().AddK8sObjects(mongoDBSecret).Build()
+	infraContext := infraContext{
+		Context: operator.Context{
+			Client: cli,
+			Log:    test.TestLogger,
+			Scheme: meta.GetRegisteredSchema(),
+		},
+		instance: kogitoMongoDBInstance,
+	}
+	credentialSecret := &v1.Secret{ObjectMeta: v12.ObjectMeta{Name: "kogito-mongodb-quarkus-credential", Namespace: ns}}
+
+	assert.NoError(t, newMongoDBCredentialReconciler(infraContext, mongoDBInstance, api.QuarkusRuntimeType).Reconcile())
+	credential := kogitoMongoDBInstance.GetStatus().GetCredential()
+	assert.Equal(t, mongoDBSecret.Name, credential.GetSecretName())
+	assert.Equal(t, ns, credential.GetSecretNamespace())
+	assert.Equal(t, int64(1), credential.GetRevision())
+
+	// the services keep the previous password during the grace period
+	mongoDBSecret.Data["password"] = []byte("rotatedPassword")
+	assert.NoError(t, kubernetes.ResourceC(cli).Update(mongoDBSecret))
+	assert.NoError(t, newMongoDBCredentialReconciler(infraContext, mongoDBInstance, api.QuarkusRuntimeType).Reconcile())
+	assert.True(t, IsCredentialRotationPending(kogitoMongoDBInstance))
+	assert.Equal(t, int64(1), kogitoMongoDBInstance.GetStatus().GetCredential().GetRevision())
+	test.AssertFetchMustExist(t, cli, credentialSecret)
+	assert.Equal(t, "passwordToFind", credentialSecret.StringData["QUARKUS_MONGODB_CREDENTIALS_PASSWORD"])
+
+	kogitoMongoDBInstance.GetSpec().AddInfraProperties(map[string]string{credentialRotationGracePeriodKey: "0s"})
+	assert.NoError(t, newMongoDBCredentialReconciler(infraContext, mongoDBInstance, api.QuarkusRuntimeType).Reconcile())
+	assert.False(t, IsCredentialRotationPending(kogitoMongoDBInstance))
+	assert.Equal(t, int64(2), kogitoMongoDBInstance.GetStatus().GetCredential().GetRevision())
+	test.AssertFetchMustExist(t, cli, credentialSecret)
+	assert.Equal(t, "rotatedPassword", credentialSecret.StringData["QUARKUS_MONGODB_CREDENTIALS_PASSWORD"])
+}
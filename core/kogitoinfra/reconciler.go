@@ -19,6 +19,7 @@ import (
 	"github.com/kiegroup/kogito-operator/apis"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	"github.com/kiegroup/kogito-operator/core/operator"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"strings"
 	"time"
@@ -41,18 +42,22 @@ type ReconcilerHandler interface {
 	GetInfraReconciler(instance api.KogitoInfraInterface) (Reconciler, error)
 	GetConfigMapReferenceReconciler(instance api.KogitoInfraInterface) Reconciler
 	GetSecretReferenceReconciler(instance api.KogitoInfraInterface) Reconciler
+	GetVaultSecretReconciler(instance api.KogitoInfraInterface) Reconciler
 	GetInfraPropertiesReconciler(instance api.KogitoInfraInterface) Reconciler
 	GetReconcileResultFor(err error, requeue bool) (reconcile.Result, error)
 }
 
 type reconcilerHandler struct {
 	operator.Context
+	// backoffKey identifies the reconciled KogitoInfra to back off its requeues while it keeps failing
+	backoffKey string
 }
 
 // NewReconcilerHandler ...
-func NewReconcilerHandler(context operator.Context) ReconcilerHandler {
+func NewReconcilerHandler(context operator.Context, request types.NamespacedName) ReconcilerHandler {
 	return &reconcilerHandler{
-		context,
+		Context:    context,
+		backoffKey: fmt.Sprintf("KogitoInfra/%s", request),
 	}
 }
 
@@ -89,6 +94,15 @@ func (k *reconcilerHandler) GetSecretReferenceReconciler(instance api.KogitoInfr
 	return initSecretReferenceReconciler(context)
 }
 
+// GetVaultSecretReconciler returns the reconciler materializing the Vault secrets of the given kogitoInfra
+func (k *reconcilerHandler) GetVaultSecretReconciler(instance api.KogitoInfraInterface) Reconciler {
+	context := infraContext{
+		Context:  k.Context,
+		instance: instance,
+	}
+	return initVaultSecretReconciler(context)
+}
+
 // GetAppConfigMapReconciler identify and return request kogito infra reconciliation logic on bases of information provided in kogitoInfra value
 func (k *reconcilerHandler) GetInfraPropertiesReconciler(instance api.KogitoInfraInterface) Reconciler {
 	k.Log.Debug("going to fetch related kogito infra resource")
@@ -130,13 +144,16 @@ func (k *reconcilerHandler) GetReconcileResultFor(err error, requeue bool) (reco
 	// no requeue, no errors, stop reconciliation
 	if !requeue && err == nil {
 		k.Log.Debug("No need reconciliation for KogitoInfra")
+		k.Backoff.Forget(k.backoffKey)
 		return reconcile.Result{RequeueAfter: 0, Requeue: false}, nil
 	}
 	// caller is asking for a reconciliation
 	if err == nil {
 		k.Log.Info("Waiting for all resources to be created, scheduling reconciliation.", "reconciliation interval", reconciliationStandardInterval.String())
-	} else { // reconciliation duo to a problem in the env (CRDs missing), infra deployments not ready, operators not installed.. etc. See reconciliation_error.go
-		k.Log.Info("Err", err.Error(), "Scheduling reconciliation", "reconciliation interval", reconciliationStandardInterval.String())
+		return reconcile.Result{RequeueAfter: reconciliationStandardInterval}, nil
 	}
-	return reconcile.Result{RequeueAfter: reconciliationStandardInterval}, nil
+	// reconciliation duo to a problem in the env (CRDs missing), infra deployments not ready, operators not installed.. etc. See reconciliation_error.go
+	interval := k.Backoff.Next(k.backoffKey, string(reasonForError(err)), reconciliationStandardInterval)
+	k.Log.Info("Err", err.Error(), "Scheduling reconciliation", "reconciliation interval", interval.String())
+	return reconcile.Result{RequeueAfter: interval}, nil
 }
@@ -0,0 +1,113 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"reflect"
+
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	ispn "github.com/kiegroup/kogito-operator/core/infrastructure/infinispan/v1"
+	"github.com/kiegroup/kogito-operator/core/infrastructure/kafka/v1beta2"
+	"github.com/kiegroup/kogito-operator/core/infrastructure/keycloak/v1alpha1"
+	mongodb "github.com/kiegroup/kogito-operator/core/infrastructure/mongodb/v1"
+	"github.com/kiegroup/kogito-operator/core/manager"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"k8s.io/apimachinery/pkg/types"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// watchedInfraResource is a kind of resource referenced by the KogitoInfra instances whose health is watched
+type watchedInfraResource struct {
+	capability kogitocli.Capability
+	kind       string
+	apiVersion string
+	object     client.Object
+}
+
+func getWatchedInfraResources() []watchedInfraResource {
+	return []watchedInfraResource{
+		{capability: kogitocli.StrimziCapability, kind: infrastructure.KafkaKind, apiVersion: infrastructure.KafkaAPIVersion, object: &v1beta2.Kafka{}},
+		{capability: kogitocli.InfinispanCapability, kind: infrastructure.InfinispanKind, apiVersion: infrastructure.InfinispanAPIVersion, object: &ispn.Infinispan{}},
+		{capability: kogitocli.MongoDBCapability, kind: infrastructure.MongoDBKind, apiVersion: infrastructure.MongoDBAPIVersion, object: &mongodb.MongoDBCommunity{}},
+		{capability: kogitocli.KeycloakCapability, kind: infrastructure.KeycloakKind, apiVersion: infrastructure.KeycloakAPIVersion, object: &v1alpha1.Keycloak{}},
+		{capability: kogitocli.KnativeEventingCapability, kind: infrastructure.KnativeEventingBrokerKind, apiVersion: infrastructure.KnativeEventingAPIVersion, object: &eventingv1.Broker{}},
+	}
+}
+
+// AppendInfraResourceWatchedObjects reconciles the KogitoInfra instances referencing a Kafka, Infinispan, MongoDB, Keycloak or Broker
+// when its status changes or it's deleted, so a resource broken after the infra is configured shows in the infra conditions.
+// Resources whose API is not available in the cluster are not watched.
+func AppendInfraResourceWatchedObjects(b *builder.Builder, context operator.Context, infraHandler manager.KogitoInfraHandler) *builder.Builder {
+	statusChangedPred := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(getInfraResourceStatus(e.ObjectOld), getInfraResourceStatus(e.ObjectNew))
+		},
+	}
+	for _, resource := range getWatchedInfraResources() {
+		if !context.Client.HasCapability(resource.capability) {
+			continue
+		}
+		mapper := newInfraResourceMapper(context, infraHandler, resource.kind, resource.apiVersion)
+		b = b.Watches(&source.Kind{Type: resource.object}, handler.EnqueueRequestsFromMapFunc(mapper), builder.WithPredicates(statusChangedPred))
+	}
+	return b
+}
+
+func getInfraResourceStatus(object client.Object) interface{} {
+	switch resource := object.(type) {
+	case *v1beta2.Kafka:
+		return resource.Status
+	case *ispn.Infinispan:
+		return resource.Status
+	case *mongodb.MongoDBCommunity:
+		return resource.Status
+	case *v1alpha1.Keycloak:
+		return resource.Status
+	case *eventingv1.Broker:
+		return resource.Status
+	}
+	return nil
+}
+
+// newInfraResourceMapper maps a resource of the given kind to the KogitoInfra instances referencing it, in any namespace
+func newInfraResourceMapper(context operator.Context, infraHandler manager.KogitoInfraHandler, kind, apiVersion string) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		infras, err := infraHandler.FetchAllKogitoInfraInstances("")
+		if err != nil {
+			context.Log.Error(err, "Failed to list KogitoInfra instances referencing resource", "kind", kind, "name", object.GetName(), "namespace", object.GetNamespace())
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, infra := range infras.GetItems() {
+			if infra.GetSpec().IsResourceEmpty() ||
+				infra.GetSpec().GetResource().GetKind() != kind ||
+				infra.GetSpec().GetResource().GetAPIVersion() != apiVersion {
+				continue
+			}
+			if key := infrastructure.GetInfraResourceKey(infra); key != nil && key.Name == object.GetName() && key.Namespace == object.GetNamespace() {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: infra.GetName(), Namespace: infra.GetNamespace()}})
+			}
+		}
+		return requests
+	}
+}
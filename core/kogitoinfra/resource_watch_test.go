@@ -0,0 +1,56 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/infrastructure/kafka/v1beta2"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/internal/app"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_newInfraResourceMapper(t *testing.T) {
+	ns := t.Name()
+	kogitoKafka := test.CreateFakeKogitoKafka(ns)
+	kogitoInfinispan := test.CreateFakeKogitoInfinispan(ns)
+	kafka := &v1beta2.Kafka{ObjectMeta: v1.ObjectMeta{Name: "kogito-kafka", Namespace: ns}}
+	otherKafka := &v1beta2.Kafka{ObjectMeta: v1.ObjectMeta{Name: "kogito-kafka", Namespace: "other-namespace"}}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(kogitoKafka, kogitoInfinispan).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	mapper := newInfraResourceMapper(context, app.NewKogitoInfraHandler(context), infrastructure.KafkaKind, infrastructure.KafkaAPIVersion)
+
+	requests := mapper(kafka)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, types.NamespacedName{Name: kogitoKafka.GetName(), Namespace: ns}, requests[0].NamespacedName)
+	assert.Empty(t, mapper(otherKafka))
+}
+
+func Test_getInfraResourceStatus(t *testing.T) {
+	kafka := &v1beta2.Kafka{}
+	readyKafka := &v1beta2.Kafka{Status: v1beta2.KafkaStatus{Conditions: []v1beta2.KafkaCondition{{Type: v1beta2.KafkaConditionTypeReady}}}}
+	assert.NotEqual(t, getInfraResourceStatus(kafka), getInfraResourceStatus(readyKafka))
+	assert.Nil(t, getInfraResourceStatus(test.CreateFakeKogitoKafka(t.Name())))
+}
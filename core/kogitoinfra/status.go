@@ -50,15 +50,28 @@ func (s *statusHandler) UpdateBaseStatus(instance api.KogitoInfraInterface, err
 	if instance.GetStatus().GetConditions() == nil {
 		instance.GetStatus().SetConditions(&[]metav1.Condition{})
 	}
+	deployedInstance, resultErr := s.infraHandler.FetchKogitoInfraInstance(types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()})
+	if resultErr != nil {
+		s.Log.Error(resultErr, "Error occurs while fetching deployed kogito infra instance")
+	}
 	if *err != nil {
-		s.Log.Info("Seems that an error occurred, setting failure state", "Error", *err)
-		s.setResourceFailed(instance.GetStatus().GetConditions(), *err)
+		if isResourceHealthError(*err) && s.isConfigured(instance, deployedInstance) {
+			// the services keep running with the configuration resolved while the resource was healthy
+			s.Log.Info("Infrastructure resource is not healthy anymore, keeping the last configuration", "Error", *err)
+			s.restoreConfiguration(instance, deployedInstance)
+		} else {
+			s.Log.Info("Seems that an error occurred, setting failure state", "Error", *err)
+			s.setResourceFailed(instance, *err)
+		}
 	} else {
-		s.setResourceSuccess(instance.GetStatus().GetConditions())
+		s.setResourceSuccess(instance)
 		s.Log.Info("Kogito Infra successfully reconciled")
 	}
+	if !instance.GetSpec().IsResourceEmpty() {
+		s.setResourceHealth(instance.GetStatus().GetConditions(), *err)
+	}
 
-	if s.isStatusChanged(instance) {
+	if deployedInstance != nil && !reflect.DeepEqual(instance.GetStatus(), deployedInstance.GetStatus()) {
 		s.Log.Info("Updating kogitoInfra value with new properties.")
 		if resultErr := kubernetes.ResourceC(s.Client).UpdateStatus(instance); resultErr != nil {
 			s.Log.Error(resultErr, "reconciliationError occurs while update kogitoInfra values")
@@ -67,34 +80,72 @@ func (s *statusHandler) UpdateBaseStatus(instance api.KogitoInfraInterface, err
 	}
 }
 
-func (s *statusHandler) isStatusChanged(instance api.KogitoInfraInterface) bool {
-	deployedInstance, resultErr := s.infraHandler.FetchKogitoInfraInstance(types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()})
-	if resultErr != nil {
-		s.Log.Error(resultErr, "Error occurs while checking status change state")
+// isConfigured checks if the deployed instance was configured for the current generation of the instance
+func (s *statusHandler) isConfigured(instance, deployedInstance api.KogitoInfraInterface) bool {
+	if deployedInstance == nil || deployedInstance.GetStatus().GetConditions() == nil {
+		return false
+	}
+	condition := meta.FindStatusCondition(*deployedInstance.GetStatus().GetConditions(), string(api.KogitoInfraConfigured))
+	return condition != nil && condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == instance.GetGeneration()
+}
+
+// restoreConfiguration sets back the envs and references of the deployed instance, cleaned up at the beginning of the reconciliation
+func (s *statusHandler) restoreConfiguration(instance, deployedInstance api.KogitoInfraInterface) {
+	instance.GetStatus().SetEnvs(deployedInstance.GetStatus().GetEnvs())
+	instance.GetStatus().SetConfigMapEnvFromReferences(deployedInstance.GetStatus().GetConfigMapEnvFromReferences())
+	instance.GetStatus().SetConfigMapVolumeReferences(deployedInstance.GetStatus().GetConfigMapVolumeReferences())
+	instance.GetStatus().SetSecretEnvFromReferences(deployedInstance.GetStatus().GetSecretEnvFromReferences())
+	instance.GetStatus().SetSecretVolumeReferences(deployedInstance.GetStatus().GetSecretVolumeReferences())
+}
+
+// setResourceHealth sets the ResourceHealthy condition from the result of the reconciliation,
+// errors not related to the referenced resource leave it unchanged
+func (s *statusHandler) setResourceHealth(conditions *[]metav1.Condition, err error) {
+	if err == nil {
+		meta.SetStatusCondition(conditions, newResourceHealthyCondition(metav1.ConditionTrue, api.ResourceReady, ""))
+	} else if isResourceHealthError(err) {
+		meta.SetStatusCondition(conditions, newResourceHealthyCondition(metav1.ConditionFalse, reasonForError(err), err.Error()))
 	}
+}
+
+func newResourceHealthyCondition(status metav1.ConditionStatus, reason api.KogitoInfraConditionReason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    string(api.KogitoInfraResourceHealthy),
+		Status:  status,
+		Reason:  string(reason),
+		Message: message,
+	}
+}
 
-	return !reflect.DeepEqual(instance.GetStatus(), deployedInstance.GetStatus())
+// isResourceHealthError checks if the error is raised because the referenced resource is missing or not ready
+func isResourceHealthError(err error) bool {
+	switch reasonForError(err) {
+	case api.ResourceNotFound, api.ResourceAPINotFound, api.ResourceNotReady:
+		return true
+	}
+	return false
 }
 
 // setResourceFailed sets the instance as failed
-func (s *statusHandler) setResourceFailed(conditions *[]metav1.Condition, err error) {
+func (s *statusHandler) setResourceFailed(instance api.KogitoInfraInterface, err error) {
 	reason := reasonForError(err)
-	failedCondition := s.newConfiguredCondition(metav1.ConditionFalse, reason, err.Error())
-	meta.SetStatusCondition(conditions, failedCondition)
+	failedCondition := s.newConfiguredCondition(instance, metav1.ConditionFalse, reason, err.Error())
+	meta.SetStatusCondition(instance.GetStatus().GetConditions(), failedCondition)
 }
 
 // setResourceSuccess sets the instance as success
-func (s *statusHandler) setResourceSuccess(conditions *[]metav1.Condition) {
-	successCondition := s.newConfiguredCondition(metav1.ConditionTrue, api.ResourceSuccessfullyConfigured, "")
-	meta.SetStatusCondition(conditions, successCondition)
+func (s *statusHandler) setResourceSuccess(instance api.KogitoInfraInterface) {
+	successCondition := s.newConfiguredCondition(instance, metav1.ConditionTrue, api.ResourceSuccessfullyConfigured, "")
+	meta.SetStatusCondition(instance.GetStatus().GetConditions(), successCondition)
 }
 
 // NewFailedCondition ...
-func (s *statusHandler) newConfiguredCondition(status metav1.ConditionStatus, reason api.KogitoInfraConditionReason, message string) metav1.Condition {
+func (s *statusHandler) newConfiguredCondition(instance api.KogitoInfraInterface, status metav1.ConditionStatus, reason api.KogitoInfraConditionReason, message string) metav1.Condition {
 	return metav1.Condition{
-		Type:    string(api.KogitoInfraConfigured),
-		Status:  status,
-		Reason:  string(reason),
-		Message: message,
+		Type:               string(api.KogitoInfraConfigured),
+		Status:             status,
+		Reason:             string(reason),
+		Message:            message,
+		ObservedGeneration: instance.GetGeneration(),
 	}
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/kiegroup/kogito-operator/internal/app"
 	"github.com/kiegroup/kogito-operator/meta"
 	"github.com/stretchr/testify/assert"
+	meta2 "k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"testing"
 )
@@ -71,7 +72,66 @@ func TestUpdateBaseStatus(t *testing.T) {
 	statusHandler.UpdateBaseStatus(instance, &err3)
 	test.AssertFetchMustExist(t, cli, instance)
 	conditions = *instance.Status.Conditions
-	assert.Equal(t, 1, len(conditions))
+	assert.Equal(t, 2, len(conditions))
 	assert.Equal(t, string(api.KogitoInfraConfigured), conditions[0].Type)
 	assert.Equal(t, v1.ConditionTrue, conditions[0].Status)
+	assert.True(t, meta2.IsStatusConditionTrue(conditions, string(api.KogitoInfraResourceHealthy)))
+}
+
+func TestUpdateBaseStatus_ResourceNotHealthy(t *testing.T) {
+	instance := &v1beta1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{
+			Name:       "kogito-kafka",
+			Namespace:  t.Name(),
+			Generation: 2,
+		},
+		Spec: v1beta1.KogitoInfraSpec{
+			Resource: &v1beta1.InfraResource{
+				Kind:       "Kafka",
+				APIVersion: "kafka.strimzi.io/v1beta2",
+				Name:       "kogito-kafka",
+			},
+		},
+		Status: v1beta1.KogitoInfraStatus{
+			Conditions: &[]v1.Condition{
+				{
+					Type:               string(api.KogitoInfraConfigured),
+					Status:             v1.ConditionTrue,
+					Reason:             string(api.ResourceSuccessfullyConfigured),
+					ObservedGeneration: 2,
+				},
+			},
+			ConfigMapEnvFromReferences: []string{"kogito-kafka-quarkus-config"},
+		},
+	}
+
+	cli := test.NewFakeClientBuilder().AddK8sObjects(instance).Build()
+	context := operator.Context{
+		Log:    test.TestLogger,
+		Client: cli,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	statusHandler := NewStatusHandler(context, app.NewKogitoInfraHandler(context))
+
+	// the reconciliation cleans up the status before checking the resource
+	instance.GetStatus().SetConfigMapEnvFromReferences(nil)
+	var err error = errorForResourceNotReadyError(errors.New("kafka instance kogito-kafka not ready yet"))
+	statusHandler.UpdateBaseStatus(instance, &err)
+	test.AssertFetchMustExist(t, cli, instance)
+	conditions := *instance.Status.Conditions
+	assert.True(t, meta2.IsStatusConditionTrue(conditions, string(api.KogitoInfraConfigured)))
+	healthCondition := meta2.FindStatusCondition(conditions, string(api.KogitoInfraResourceHealthy))
+	assert.NotNil(t, healthCondition)
+	assert.Equal(t, v1.ConditionFalse, healthCondition.Status)
+	assert.Equal(t, string(api.ResourceNotReady), healthCondition.Reason)
+	assert.Equal(t, []string{"kogito-kafka-quarkus-config"}, instance.Status.ConfigMapEnvFromReferences)
+
+	// a resource not found for a new generation of the spec isn't a configured infra anymore
+	instance.Generation = 3
+	err = errorForResourceNotFound("Kafka", "kogito-kafka", t.Name())
+	statusHandler.UpdateBaseStatus(instance, &err)
+	test.AssertFetchMustExist(t, cli, instance)
+	conditions = *instance.Status.Conditions
+	assert.True(t, meta2.IsStatusConditionFalse(conditions, string(api.KogitoInfraConfigured)))
+	assert.Equal(t, string(api.ResourceNotFound), meta2.FindStatusCondition(conditions, string(api.KogitoInfraResourceHealthy)).Reason)
 }
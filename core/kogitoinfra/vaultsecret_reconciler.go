@@ -0,0 +1,77 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	externalsecrets "github.com/kiegroup/kogito-operator/core/infrastructure/externalsecrets/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+type vaultSecretReconciler struct {
+	infraContext
+	vaultSecretHandler infrastructure.VaultSecretHandler
+}
+
+func initVaultSecretReconciler(context infraContext) Reconciler {
+	context.Log = context.Log.WithValues("resource", "VaultSecrets")
+	return &vaultSecretReconciler{
+		infraContext:       context,
+		vaultSecretHandler: infrastructure.NewVaultSecretHandler(context.Context),
+	}
+}
+
+// AppendExternalSecretWatchedObjects reconciles the KogitoInfra instances when the ExternalSecrets of their Vault secrets are synced,
+// they're not watched if the External Secrets Operator API is not available in the cluster
+func AppendExternalSecretWatchedObjects(b *builder.Builder, context operator.Context, infraObject client.Object) *builder.Builder {
+	if !context.Client.HasCapability(kogitocli.ExternalSecretsCapability) {
+		return b
+	}
+	// the ExternalSecrets are shared by the infras and services referencing the same Vault secret
+	return b.Watches(&source.Kind{Type: &externalsecrets.ExternalSecret{}}, &handler.EnqueueRequestForOwner{OwnerType: infraObject, IsController: false})
+}
+
+// Reconcile renders the ExternalSecrets of the Vault secrets, the materialized Secrets are provided to the services as envs or volumes
+func (v *vaultSecretReconciler) Reconcile() error {
+	references := v.instance.GetSpec().GetVaultSecrets()
+	if !v.vaultSecretHandler.IsExternalSecretsAvailable() {
+		if len(references) == 0 {
+			return nil
+		}
+		return errorForVaultSecretNotMaterialized(v.instance,
+			[]string{externalsecrets.SchemeGroupVersion.String() + " API is not available in the cluster, please install the External Secrets Operator first"})
+	}
+
+	issues, err := v.vaultSecretHandler.ReconcileVaultSecrets(references, v.instance)
+	if err != nil {
+		return err
+	}
+	if len(issues) > 0 {
+		return errorForVaultSecretNotMaterialized(v.instance, issues)
+	}
+	for _, reference := range references {
+		if len(reference.GetMountPath()) > 0 {
+			v.instance.GetStatus().AddSecretVolumeReference(reference.GetName(), reference.GetMountPath(), nil, nil)
+		} else {
+			v.instance.GetStatus().AddSecretEnvFromReferences(reference.GetName())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoinfra
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	externalsecrets "github.com/kiegroup/kogito-operator/core/infrastructure/externalsecrets/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVaultSecretReconciler_Reconcile(t *testing.T) {
+	ns := t.Name()
+	infraInstance := &v1beta1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "kogito-vault",
+			Namespace: ns,
+		},
+		Spec: v1beta1.KogitoInfraSpec{
+			VaultSecrets: []v1beta1.VaultSecretReference{
+				{Name: "kafka-credentials", Path: "kogito/kafka"},
+				{Name: "kafka-keystore", Path: "kogito/kafka-keystore", MountPath: "/home/kogito/kafka"},
+			},
+		},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(infraInstance).SupportExternalSecrets().Build()
+	context := infraContext{
+		Context: operator.Context{
+			Client: cli,
+			Log:    test.TestLogger,
+			Scheme: meta.GetRegisteredSchema(),
+		},
+		instance: infraInstance,
+	}
+
+	err := initVaultSecretReconciler(context).Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, api.VaultSecretNotMaterialized, reasonForError(err))
+	assert.Empty(t, infraInstance.GetStatus().GetSecretEnvFromReferences())
+
+	externalSecret := &externalsecrets.ExternalSecret{ObjectMeta: v1.ObjectMeta{Name: "kafka-credentials", Namespace: ns}}
+	test.AssertFetchMustExist(t, cli, externalSecret)
+	assert.Equal(t, infraInstance.GetName(), externalSecret.OwnerReferences[0].Name)
+
+	vault := test.NewDevVault()
+	vault.Put("kogito/kafka", map[string]string{"KAFKA_USER": "kogito", "KAFKA_PASSWORD": "s3cr3t"})
+	vault.Put("kogito/kafka-keystore", map[string]string{"keystore.p12": "keystore"})
+	assert.NoError(t, vault.Sync(cli, ns))
+
+	err = initVaultSecretReconciler(context).Reconcile()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"kafka-credentials"}, infraInstance.GetStatus().GetSecretEnvFromReferences())
+	assert.Len(t, infraInstance.GetStatus().GetSecretVolumeReferences(), 1)
+	assert.Equal(t, "/home/kogito/kafka", infraInstance.GetStatus().GetSecretVolumeReferences()[0].GetMountPath())
+}
+
+func TestVaultSecretReconciler_ExternalSecretsNotAvailable(t *testing.T) {
+	ns := t.Name()
+	infraInstance := &v1beta1.KogitoInfra{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "kogito-vault",
+			Namespace: ns,
+		},
+		Spec: v1beta1.KogitoInfraSpec{
+			VaultSecrets: []v1beta1.VaultSecretReference{
+				{Name: "kafka-credentials", Path: "kogito/kafka"},
+			},
+		},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(infraInstance).Build()
+	context := infraContext{
+		Context: operator.Context{
+			Client: cli,
+			Log:    test.TestLogger,
+			Scheme: meta.GetRegisteredSchema(),
+		},
+		instance: infraInstance,
+	}
+
+	err := initVaultSecretReconciler(context).Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, api.VaultSecretNotMaterialized, reasonForError(err))
+}
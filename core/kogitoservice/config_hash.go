@@ -0,0 +1,170 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoservice
+
+import (
+	"encoding/base64"
+	"sort"
+
+	"github.com/kiegroup/kogito-operator/core/framework/util"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ConfigHashAnnotation is the pod template annotation holding the hash of the ConfigMaps and Secrets referenced by the pods,
+	// a change in their content updates the annotation and triggers a rolling restart of the service
+	ConfigHashAnnotation = "kogito.kie.org/config-hash"
+)
+
+// addConfigHash annotates the pod template with the content hash of every ConfigMap and Secret referenced by the pods,
+// except the ConfigMaps reloaded by the service itself
+func (d *deploymentReconciler) addConfigHash(deployment *appsv1.Deployment) error {
+	if d.instance.GetSpec().IsConfigRolloutDisabled() {
+		return nil
+	}
+	hashes := map[string]string{}
+	configMapHandler := infrastructure.NewConfigMapHandler(d.Context)
+	for _, name := range GetReferencedConfigMaps(&deployment.Spec.Template.Spec) {
+		configMap, err := configMapHandler.FetchConfigMap(types.NamespacedName{Name: name, Namespace: deployment.Namespace})
+		if err != nil {
+			return err
+		}
+		// optional references might not exist yet, the pods are restarted once they're created
+		if configMap != nil && !d.isHotReloaded(configMap) {
+			hashes["configmap/"+name] = hashConfigMap(configMap)
+		}
+	}
+	secretHandler := infrastructure.NewSecretHandler(d.Context)
+	for _, name := range GetReferencedSecrets(&deployment.Spec.Template.Spec) {
+		secret, err := secretHandler.FetchSecret(types.NamespacedName{Name: name, Namespace: deployment.Namespace})
+		if err != nil {
+			return err
+		}
+		if secret != nil {
+			hashes["secret/"+name] = hashBinaryData(secret.Data)
+		}
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+	// the pod template might share the annotations map with the deployment
+	annotations := map[string]string{}
+	util.AppendToStringMap(deployment.Spec.Template.Annotations, annotations)
+	annotations[ConfigHashAnnotation] = util.GenerateMD5Hash(hashes)
+	deployment.Spec.Template.Annotations = annotations
+	return nil
+}
+
+// isHotReloaded returns true if the given ConfigMap is reloaded by the running service, eg: the protobuf files watched by Data Index
+func (d *deploymentReconciler) isHotReloaded(configMap *corev1.ConfigMap) bool {
+	if len(d.definition.HotReloadedConfigMapLabels) == 0 {
+		return false
+	}
+	return labels.SelectorFromSet(d.definition.HotReloadedConfigMapLabels).Matches(labels.Set(configMap.Labels))
+}
+
+func hashConfigMap(configMap *corev1.ConfigMap) string {
+	content := map[string]string{}
+	for key, value := range configMap.Data {
+		content["data/"+key] = value
+	}
+	content["binaryData"] = hashBinaryData(configMap.BinaryData)
+	return util.GenerateMD5Hash(content)
+}
+
+func hashBinaryData(data map[string][]byte) string {
+	content := map[string]string{}
+	for key, value := range data {
+		content[key] = base64.StdEncoding.EncodeToString(value)
+	}
+	return util.GenerateMD5Hash(content)
+}
+
+// GetReferencedConfigMaps returns the names of the ConfigMaps referenced by the pod as environment variables or volumes
+func GetReferencedConfigMaps(podSpec *corev1.PodSpec) []string {
+	names := map[string]bool{}
+	for _, container := range getAllContainers(podSpec) {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				names[envFrom.ConfigMapRef.Name] = true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil {
+				names[env.ValueFrom.ConfigMapKeyRef.Name] = true
+			}
+		}
+	}
+	for _, volume := range podSpec.Volumes {
+		if volume.ConfigMap != nil {
+			names[volume.ConfigMap.Name] = true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					names[source.ConfigMap.Name] = true
+				}
+			}
+		}
+	}
+	return sortedNames(names)
+}
+
+// GetReferencedSecrets returns the names of the Secrets referenced by the pod as environment variables or volumes
+func GetReferencedSecrets(podSpec *corev1.PodSpec) []string {
+	names := map[string]bool{}
+	for _, container := range getAllContainers(podSpec) {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				names[envFrom.SecretRef.Name] = true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				names[env.ValueFrom.SecretKeyRef.Name] = true
+			}
+		}
+	}
+	for _, volume := range podSpec.Volumes {
+		if volume.Secret != nil {
+			names[volume.Secret.SecretName] = true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					names[source.Secret.Name] = true
+				}
+			}
+		}
+	}
+	return sortedNames(names)
+}
+
+func getAllContainers(podSpec *corev1.PodSpec) []corev1.Container {
+	return append(append([]corev1.Container{}, podSpec.InitContainers...), podSpec.Containers...)
+}
+
+func sortedNames(names map[string]bool) []string {
+	var sorted []string
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
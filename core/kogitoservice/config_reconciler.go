@@ -83,6 +83,9 @@ func (i *configReconciler) createRequiredResources() (map[reflect.Type][]client.
 	if err := framework.SetOwner(i.instance, i.Scheme, configMap); err != nil {
 		return nil, err
 	}
+	if err := applyPatches(i.Context, i.instance, configMap); err != nil {
+		return nil, err
+	}
 	resources[reflect.TypeOf(v1.ConfigMap{})] = []client.Object{configMap}
 	return resources, nil
 }
@@ -15,15 +15,16 @@
 package kogitoservice
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"k8s.io/apimachinery/pkg/types"
-	"net/http"
 	"regexp"
 	"strings"
 
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/introspection"
 
 	"github.com/kiegroup/kogito-operator/apis"
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
@@ -36,6 +37,8 @@ import (
 const (
 	// dashboardPath which the dashboards are fetched
 	dashboardsPath = "/monitoring/dashboards/"
+	// dashboardsProbeName identifies the dashboards in the introspection cache
+	dashboardsProbeName = "dashboards"
 )
 
 // GrafanaDashboardManager ...
@@ -45,6 +48,7 @@ type GrafanaDashboardManager interface {
 
 type grafanaDashboardManager struct {
 	operator.Context
+	introspector *introspection.Introspector
 }
 
 // GrafanaDashboard is a structure that contains the fetched dashboards
@@ -54,10 +58,11 @@ type GrafanaDashboard struct {
 }
 
 // NewGrafanaDashboardManager ...
-func NewGrafanaDashboardManager(context operator.Context) GrafanaDashboardManager {
+func NewGrafanaDashboardManager(context operator.Context, introspector *introspection.Introspector) GrafanaDashboardManager {
 	context.Log = context.Log.WithValues("monitoring", "grafana")
 	return &grafanaDashboardManager{
-		context,
+		Context:      context,
+		introspector: introspector,
 	}
 }
 
@@ -83,7 +88,7 @@ func (d *grafanaDashboardManager) ConfigureGrafanaDashboards(kogitoService api.K
 
 // isPrometheusAvailable checks if Prometheus CRD is available in the cluster
 func (d *grafanaDashboardManager) isGrafanaAvailable() bool {
-	return d.Client.HasServerGroup(grafanav1.GroupVersion.Group)
+	return d.Client.HasCapability(kogitocli.GrafanaCapability)
 }
 
 func (d *grafanaDashboardManager) fetchGrafanaDashboards(instance api.KogitoService) ([]GrafanaDashboard, error) {
@@ -99,71 +104,60 @@ func (d *grafanaDashboardManager) fetchGrafanaDashboards(instance api.KogitoServ
 
 	kogitoServiceHandler := NewKogitoServiceHandler(d.Context)
 	svcURL := kogitoServiceHandler.GetKogitoServiceURL(instance)
-	dashboardNames, err := d.fetchGrafanaDashboardNamesForURL(svcURL)
+	result, found, err := introspect(d.introspector, instance, svcURL, dashboardsProbeName, d.fetchGrafanaDashboardsForService)
 	if err != nil {
 		return nil, err
 	}
+	if !found {
+		d.Log.Debug("Dashboards not fetched yet")
+		return nil, nil
+	}
+	dashboards, _ := result.([]GrafanaDashboard)
+	return dashboards, nil
+}
 
-	return d.fetchDashboards(svcURL, dashboardNames)
+// fetchGrafanaDashboardsForService is the introspection probe fetching the dashboards exposed by the service
+func (d *grafanaDashboardManager) fetchGrafanaDashboardsForService(ctx context.Context, fetcher *introspection.Fetcher) (interface{}, error) {
+	dashboardNames, err := d.fetchGrafanaDashboardNames(ctx, fetcher)
+	if err != nil {
+		return nil, err
+	}
+	return d.fetchDashboards(ctx, fetcher, dashboardNames)
 }
 
-func (d *grafanaDashboardManager) fetchGrafanaDashboardNamesForURL(serverURL string) ([]string, error) {
-	dashboardsURL := fmt.Sprintf("%s%s%s", serverURL, dashboardsPath, "list.json")
-	resp, err := http.Get(dashboardsURL)
+func (d *grafanaDashboardManager) fetchGrafanaDashboardNames(ctx context.Context, fetcher *introspection.Fetcher) ([]string, error) {
+	body, err := fetcher.Get(ctx, dashboardsPath+"list.json")
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode == http.StatusNotFound {
+	if body == nil {
 		d.Log.Debug("Dashboard list not found, the monitoring addon is disabled on the service. There are no dashboards to deploy.")
 		return nil, nil
 	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, infrastructure.ErrorForServiceNotReachable(resp.StatusCode, dashboardsURL, "GET")
-	}
 	var dashboardNames []string
-	if err := json.NewDecoder(resp.Body).Decode(&dashboardNames); err != nil {
-		return nil, fmt.Errorf("failed to decode response from %s into dashboard names", dashboardsURL)
+	if err := json.Unmarshal(body, &dashboardNames); err != nil {
+		return nil, fmt.Errorf("failed to decode response from %s into dashboard names", dashboardsPath)
 	}
 
 	return dashboardNames, nil
 }
 
-func (d *grafanaDashboardManager) fetchDashboards(serverURL string, dashboardNames []string) ([]GrafanaDashboard, error) {
+func (d *grafanaDashboardManager) fetchDashboards(ctx context.Context, fetcher *introspection.Fetcher, dashboardNames []string) ([]GrafanaDashboard, error) {
 	var dashboards []GrafanaDashboard
 	for _, name := range dashboardNames {
-		dashboardURL := fmt.Sprintf("%s%s%s", serverURL, dashboardsPath, name)
-		if dashboard, err := d.fetchDashboard(name, dashboardURL); err != nil {
+		body, err := fetcher.Get(ctx, dashboardsPath+name)
+		if err != nil {
 			return nil, err
-		} else if dashboard != nil {
-			dashboards = append(dashboards, *dashboard)
 		}
+		if body == nil {
+			d.Log.Debug("Dashboard not found, ignoring the resource.", "dashboard name", name)
+			continue
+		}
+		dashboards = append(dashboards, GrafanaDashboard{Name: name, RawJSONDashboard: string(body)})
 	}
 	return dashboards, nil
 }
 
-// we create a separate function to be able to `defer` the HTTP response after the function call.
-func (d *grafanaDashboardManager) fetchDashboard(name, dashboardURL string) (*GrafanaDashboard, error) {
-	resp, err := http.Get(dashboardURL)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode == http.StatusNotFound {
-		d.Log.Debug("Dashboard not found, ignoring the resource.", "dashboard name", name)
-		return nil, nil
-	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Received NOT expected status code %d while making GET request to %s ", resp.StatusCode, dashboardURL)
-	}
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		d.Log.Error(err, "Error in reading")
-		return nil, err
-	}
-	return &GrafanaDashboard{Name: name, RawJSONDashboard: string(bodyBytes)}, nil
-}
-
 func (d *grafanaDashboardManager) deployGrafanaDashboards(dashboards []GrafanaDashboard, kogitoService api.KogitoService) error {
 	for _, dashboard := range dashboards {
 		resourceName := sanitizeDashboardName(dashboard.Name)
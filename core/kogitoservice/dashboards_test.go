@@ -15,9 +15,11 @@
 package kogitoservice
 
 import (
+	"context"
 	"testing"
 
 	grafanav1 "github.com/kiegroup/kogito-operator/core/infrastructure/grafana/v1alpha1"
+	"github.com/kiegroup/kogito-operator/core/introspection"
 	"github.com/kiegroup/kogito-operator/core/operator"
 	"github.com/kiegroup/kogito-operator/core/test"
 	"github.com/kiegroup/kogito-operator/meta"
@@ -32,13 +34,13 @@ func Test_fetchDashboardNames(t *testing.T) {
 	defer server.Close()
 
 	cli := test.NewFakeClientBuilder().Build()
-	context := operator.Context{
+	kogitoContext := operator.Context{
 		Client: cli,
 		Log:    test.TestLogger,
 		Scheme: meta.GetRegisteredSchema(),
 	}
-	dashboardManager := grafanaDashboardManager{Context: context}
-	dashboards, err := dashboardManager.fetchGrafanaDashboardNamesForURL(server.URL)
+	dashboardManager := grafanaDashboardManager{Context: kogitoContext}
+	dashboards, err := dashboardManager.fetchGrafanaDashboardNames(context.TODO(), introspection.NewFetcher(server.URL, nil))
 	assert.NoError(t, err)
 	assert.NotEmpty(t, dashboards)
 	assert.Equal(t, "dashboard1.json", dashboards[0])
@@ -68,15 +70,16 @@ func Test_fetchDashboards(t *testing.T) {
 	server := test.MockKogitoSvcReplies(t, handlers...)
 	defer server.Close()
 	cli := test.NewFakeClientBuilder().Build()
-	context := operator.Context{
+	kogitoContext := operator.Context{
 		Client: cli,
 		Log:    test.TestLogger,
 		Scheme: meta.GetRegisteredSchema(),
 	}
-	dashboardManager := grafanaDashboardManager{Context: context}
-	fetchedDashboardNames, err := dashboardManager.fetchGrafanaDashboardNamesForURL(server.URL)
+	dashboardManager := grafanaDashboardManager{Context: kogitoContext}
+	fetcher := introspection.NewFetcher(server.URL, nil)
+	fetchedDashboardNames, err := dashboardManager.fetchGrafanaDashboardNames(context.TODO(), fetcher)
 	assert.NoError(t, err)
-	dashboards, err := dashboardManager.fetchDashboards(server.URL, fetchedDashboardNames)
+	dashboards, err := dashboardManager.fetchDashboards(context.TODO(), fetcher, fetchedDashboardNames)
 	assert.NoError(t, err)
 	assert.Equal(t, len(fetchedDashboardNames), len(dashboards))
 	assert.Equal(t, dashboard1, dashboards[0].RawJSONDashboard)
@@ -98,12 +101,12 @@ func Test_serviceDeployer_DeployGrafanaDashboards(t *testing.T) {
 			RawJSONDashboard: "[]",
 		},
 	}
-	context := operator.Context{
+	kogitoContext := operator.Context{
 		Client: cli,
 		Log:    test.TestLogger,
 		Scheme: meta.GetRegisteredSchema(),
 	}
-	dashboardManager := grafanaDashboardManager{Context: context}
+	dashboardManager := grafanaDashboardManager{Context: kogitoContext}
 	err := dashboardManager.deployGrafanaDashboards(dashboards, service)
 	assert.NoError(t, err)
 
@@ -17,10 +17,13 @@ package kogitoservice
 import (
 	api "github.com/kiegroup/kogito-operator/apis"
 	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/imagedigest"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/introspection"
 	"github.com/kiegroup/kogito-operator/core/manager"
 	"github.com/kiegroup/kogito-operator/core/operator"
 	"github.com/kiegroup/kogito-operator/core/record"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -51,6 +54,13 @@ type ServiceDefinition struct {
 	SecretEnvFromReferences    []string
 	SecretVolumeReferences     []api.VolumeReferenceInterface
 	Envs                       []v1.EnvVar
+	// HotReloadedConfigMapLabels selects the ConfigMaps reloaded by the running service, they don't trigger a rolling restart when changed
+	HotReloadedConfigMapLabels map[string]string
+	// Introspector polls the service endpoints exposing its messaging topics and dashboards, if nil they're fetched during the reconciliation
+	Introspector *introspection.Introspector
+	// DigestResolver polls the registry for the image tag of the service with the Periodic image update policy,
+	// if nil the digest is only resolved when the tag changes
+	DigestResolver *imagedigest.Resolver
 }
 
 const (
@@ -105,6 +115,12 @@ func (s *serviceDeployer) Deploy() error {
 	statusHandler := NewStatusHandler(s.Context)
 	defer statusHandler.HandleStatusUpdate(s.instance, &err)
 
+	// the profile of the runtime defines the probes, metrics and infra properties of the service
+	if runtime := s.instance.GetSpec().GetRuntime(); !runtimeprofile.GetRegistry().IsRegistered(runtime) {
+		err = infrastructure.ErrorForUnregisteredRuntime(s.instance.GetName(), string(runtime))
+		return err
+	}
+
 	s.definition.Envs = s.instance.GetSpec().GetEnvs()
 
 	infraPropertiesReconciler := newConfigReconciler(s.Context, s.instance, &s.definition)
@@ -117,12 +133,18 @@ func (s *serviceDeployer) Deploy() error {
 		return err
 	}
 
+	// the truststore may be a Vault secret, materialized first
+	vaultSecretReconciler := newVaultSecretReconciler(s.Context, s.instance, &s.definition)
+	if err = vaultSecretReconciler.Reconcile(); err != nil {
+		return err
+	}
+
 	trustStoreReconciler := newTrustStoreReconciler(s.Context, s.instance, &s.definition)
 	if err = trustStoreReconciler.Reconcile(); err != nil {
 		return err
 	}
 
-	kogitoInfraReconciler := newKogitoInfraReconciler(s.Context, s.instance, &s.definition, s.infraHandler)
+	kogitoInfraReconciler := newKogitoInfraReconciler(s.Context, s.instance, &s.definition, s.infraHandler, s.recorder)
 	if err = kogitoInfraReconciler.Reconcile(); err != nil {
 		return err
 	}
@@ -147,14 +169,22 @@ func (s *serviceDeployer) Deploy() error {
 		s.Log.Info("Error occurs while reconciling route", "err", err)
 	}
 
+	if !s.Client.IsOpenshift() {
+		if err = reconcileCanaryIngress(s.Context, s.instance); err != nil {
+			return err
+		}
+	}
+
 	err = s.configureMonitoring()
 	if err != nil {
 		return err
 	}
 
-	err = s.configureMessaging()
+	if err = s.configureMessaging(); err != nil {
+		return err
+	}
 
-	return err
+	return nil
 }
 
 func (s *serviceDeployer) configureMessaging() error {
@@ -179,7 +209,7 @@ func (s *serviceDeployer) configureMonitoring() error {
 		return infrastructure.ErrorForMonitoring(err)
 	}
 
-	grafanaDashboardManager := NewGrafanaDashboardManager(s.Context)
+	grafanaDashboardManager := NewGrafanaDashboardManager(s.Context, s.definition.Introspector)
 	if err := grafanaDashboardManager.ConfigureGrafanaDashboards(s.instance); err != nil {
 		s.Log.Error(err, "Could not deploy grafana dashboards")
 		return infrastructure.ErrorForDashboards(err)
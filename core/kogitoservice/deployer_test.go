@@ -22,6 +22,7 @@ import (
 	"github.com/kiegroup/kogito-operator/internal/app"
 	"github.com/kiegroup/kogito-operator/meta"
 	"github.com/stretchr/testify/assert"
+	meta2 "k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -127,3 +128,25 @@ func Test_serviceDeployer_DataIndex_InfraNotReconciled(t *testing.T) {
 	assert.NotNil(t, dataIndex.GetStatus())
 	assert.Len(t, *dataIndex.GetStatus().GetConditions(), 3)
 }
+
+func Test_serviceDeployer_UnregisteredRuntime(t *testing.T) {
+	runtime := test.CreateFakeKogitoRuntime(t.Name())
+	runtime.Spec.Runtime = "micronaut"
+	cli := test.NewFakeClientBuilder().AddK8sObjects(runtime).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	definition := ServiceDefinition{Request: reconcile.Request{NamespacedName: types.NamespacedName{Name: runtime.GetName(), Namespace: t.Name()}}}
+	err := NewServiceDeployer(context, definition, runtime, app.NewKogitoInfraHandler(context)).Deploy()
+	assert.Error(t, err)
+	errorHandler := infrastructure.NewReconciliationErrorHandler(context)
+	assert.Equal(t, infrastructure.UnregisteredRuntimeReason, errorHandler.GetReasonForError(err))
+
+	test.AssertFetchMustExist(t, cli, runtime)
+	failed := meta2.FindStatusCondition(*runtime.GetStatus().GetConditions(), string(api.FailedConditionType))
+	assert.NotNil(t, failed)
+	assert.Equal(t, v1.ConditionTrue, failed.Status)
+	assert.Equal(t, string(infrastructure.UnregisteredRuntimeReason), failed.Reason)
+}
@@ -76,6 +76,12 @@ func (d *deploymentReconciler) Reconcile() error {
 		return err
 	}
 
+	if runtime, ok := d.instance.(api.KogitoRuntimeInterface); ok {
+		if err = newRolloutReconciler(d.Context, runtime, d.definition.Introspector).reconcileDeployments(requestedResources, deployedResources); err != nil {
+			return err
+		}
+	}
+
 	// Process Delta
 	if err = d.processDelta(requestedResources, deployedResources); err != nil {
 		return err
@@ -100,6 +106,19 @@ func (d *deploymentReconciler) createRequiredResources(imageName string) (map[re
 		return resources, err
 	}
 	d.mountMeteringLabelsOnDeployment(deployment)
+	applyPodTemplate(deployment, d.instance.GetSpec().GetPodTemplate())
+	if err := newImageDigestPinner(d.Context, d.instance, d.definition.DigestResolver).pin(deployment, imageName); err != nil {
+		return resources, err
+	}
+	if err := applyPatches(d.Context, d.instance, deployment); err != nil {
+		return resources, err
+	}
+	if err := d.addConfigHash(deployment); err != nil {
+		return resources, err
+	}
+	if err := d.releaseAutoscaledReplicas(deployment); err != nil {
+		return resources, err
+	}
 	if err := framework.SetOwner(d.instance, d.Scheme, deployment); err != nil {
 		return nil, err
 	}
@@ -107,6 +126,19 @@ func (d *deploymentReconciler) createRequiredResources(imageName string) (map[re
 	return resources, nil
 }
 
+// releaseAutoscaledReplicas leaves the replicas out of the requested Deployment when a HorizontalPodAutoscaler scales it
+func (d *deploymentReconciler) releaseAutoscaledReplicas(deployment *appsv1.Deployment) error {
+	autoscaled, err := d.deploymentHandler.IsAutoscaled(types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace})
+	if err != nil {
+		return err
+	}
+	if autoscaled {
+		d.Log.Debug("Deployment scaled by a HorizontalPodAutoscaler, its replicas aren't applied", "deployment", deployment.Name)
+		deployment.Spec.Replicas = nil
+	}
+	return nil
+}
+
 func (d *deploymentReconciler) getDeployedResources() (map[reflect.Type][]client.Object, error) {
 	resources := make(map[reflect.Type][]client.Object)
 	deployment, err := d.deploymentHandler.FetchDeployment(types.NamespacedName{Name: d.instance.GetName(), Namespace: d.instance.GetNamespace()})
@@ -116,6 +148,15 @@ func (d *deploymentReconciler) getDeployedResources() (map[reflect.Type][]client
 	if deployment != nil {
 		resources[reflect.TypeOf(appsv1.Deployment{})] = []client.Object{deployment}
 	}
+	if _, ok := d.instance.(api.KogitoRuntimeInterface); ok {
+		candidate, err := d.deploymentHandler.FetchDeployment(types.NamespacedName{Name: GetCandidateName(d.instance.GetName()), Namespace: d.instance.GetNamespace()})
+		if err != nil {
+			return nil, err
+		}
+		if candidate != nil {
+			resources[reflect.TypeOf(appsv1.Deployment{})] = append(resources[reflect.TypeOf(appsv1.Deployment{})], candidate)
+		}
+	}
 	return resources, nil
 }
 
@@ -15,6 +15,7 @@
 package kogitoservice
 
 import (
+	"fmt"
 	api "github.com/kiegroup/kogito-operator/apis"
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
@@ -23,7 +24,12 @@ import (
 	"github.com/kiegroup/kogito-operator/meta"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
 	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -51,3 +57,293 @@ func TestDeploymentReconciler(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, exists)
 }
+
+func TestDeploymentReconciler_Autoscaled(t *testing.T) {
+	ns := t.Name()
+	instance := test.CreateFakeKogitoRuntime(ns)
+	autoscaler := &autoscalingv1.HorizontalPodAutoscaler{
+		ObjectMeta: v13.ObjectMeta{Name: instance.Name, Namespace: ns},
+		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: instance.Name},
+			MaxReplicas:    5,
+		},
+	}
+	replicas := int32(4)
+	deployed := &v1.Deployment{ObjectMeta: v13.ObjectMeta{Name: instance.Name, Namespace: ns}, Spec: v1.DeploymentSpec{Replicas: &replicas}}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(instance, autoscaler, deployed).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	image := &api.Image{Name: "test-image", Tag: "1.0"}
+	imageHandler := infrastructure.NewImageHandler(context, image, "default-image", "image-stream", ns, false, false)
+	err := newDeploymentReconciler(context, instance, ServiceDefinition{}, imageHandler).Reconcile()
+	assert.NoError(t, err)
+
+	deployment := &v1.Deployment{ObjectMeta: v13.ObjectMeta{Name: instance.Name, Namespace: ns}}
+	test.AssertFetchMustExist(t, cli, deployment)
+	// the replicas set by the autoscaler are kept
+	assert.Equal(t, replicas, *deployment.Spec.Replicas)
+	assert.NotEmpty(t, deployment.Spec.Template.Spec.Containers)
+}
+
+func TestDeploymentReconciler_ConfigHash(t *testing.T) {
+	ns := t.Name()
+	instance := test.CreateFakeKogitoRuntime(ns)
+	configMap := &corev1.ConfigMap{ObjectMeta: v13.ObjectMeta{Name: "app-config", Namespace: ns}, Data: map[string]string{"key": "value"}}
+	secret := &corev1.Secret{ObjectMeta: v13.ObjectMeta{Name: "app-secret", Namespace: ns}, Data: map[string][]byte{"password": []byte("secret")}}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(instance, configMap, secret).Build()
+	serviceDefinition := ServiceDefinition{
+		ConfigMapEnvFromReferences: []string{configMap.Name},
+		SecretEnvFromReferences:    []string{secret.Name},
+	}
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	image := &api.Image{Name: "test-image", Tag: "1.0"}
+	imageHandler := infrastructure.NewImageHandler(context, image, "default-image", "image-stream", ns, false, false)
+	reconcile := func() string {
+		err := newDeploymentReconciler(context, instance, serviceDefinition, imageHandler).Reconcile()
+		assert.NoError(t, err)
+		deployment := &v1.Deployment{ObjectMeta: v13.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}}
+		test.AssertFetchMustExist(t, cli, deployment)
+		assert.NotContains(t, deployment.Annotations, ConfigHashAnnotation)
+		return deployment.Spec.Template.Annotations[ConfigHashAnnotation]
+	}
+
+	hash := reconcile()
+	assert.NotEmpty(t, hash)
+	assert.Equal(t, hash, reconcile())
+
+	// changing the referenced config rolls out the pods
+	configMap.Data["key"] = "new-value"
+	assert.NoError(t, kubernetes.ResourceC(cli).Update(configMap))
+	configMapHash := reconcile()
+	assert.NotEqual(t, hash, configMapHash)
+
+	secret.Data["password"] = []byte("new-secret")
+	assert.NoError(t, kubernetes.ResourceC(cli).Update(secret))
+	assert.NotEqual(t, configMapHash, reconcile())
+}
+
+func TestDeploymentReconciler_ConfigHashIgnoresHotReloadedConfigMaps(t *testing.T) {
+	ns := t.Name()
+	instance := test.CreateFakeKogitoRuntime(ns)
+	configMap := &corev1.ConfigMap{ObjectMeta: v13.ObjectMeta{Name: "app-config", Namespace: ns}, Data: map[string]string{"key": "value"}}
+	protoBufConfigMap := &corev1.ConfigMap{
+		ObjectMeta: v13.ObjectMeta{Name: "travels-protobuf-files", Namespace: ns, Labels: map[string]string{"kogito-protobuf": "true"}},
+		Data:       map[string]string{"travels.proto": "message Travel {}"},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(instance, configMap, protoBufConfigMap).Build()
+	serviceDefinition := ServiceDefinition{
+		ConfigMapEnvFromReferences: []string{configMap.Name},
+		OnDeploymentCreate: func(deployment *v1.Deployment) error {
+			deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+				Name:         protoBufConfigMap.Name,
+				VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: protoBufConfigMap.Name}}},
+			})
+			return nil
+		},
+		HotReloadedConfigMapLabels: map[string]string{"kogito-protobuf": "true"},
+	}
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	image := &api.Image{Name: "test-image", Tag: "1.0"}
+	imageHandler := infrastructure.NewImageHandler(context, image, "default-image", "image-stream", ns, false, false)
+	reconcile := func() string {
+		err := newDeploymentReconciler(context, instance, serviceDefinition, imageHandler).Reconcile()
+		assert.NoError(t, err)
+		deployment := &v1.Deployment{ObjectMeta: v13.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}}
+		test.AssertFetchMustExist(t, cli, deployment)
+		return deployment.Spec.Template.Annotations[ConfigHashAnnotation]
+	}
+
+	hash := reconcile()
+	assert.NotEmpty(t, hash)
+
+	// the service reloads the protobuf files by itself
+	protoBufConfigMap.Data["travels.proto"] = "message Travel { optional string id = 1; }"
+	assert.NoError(t, kubernetes.ResourceC(cli).Update(protoBufConfigMap))
+	assert.Equal(t, hash, reconcile())
+}
+
+func TestDeploymentReconciler_ConfigRolloutDisabled(t *testing.T) {
+	ns := t.Name()
+	instance := test.CreateFakeKogitoRuntime(ns)
+	instance.GetSpec().SetDisableConfigRollout(true)
+	configMap := &corev1.ConfigMap{ObjectMeta: v13.ObjectMeta{Name: "app-config", Namespace: ns}, Data: map[string]string{"key": "value"}}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(instance, configMap).Build()
+	serviceDefinition := ServiceDefinition{ConfigMapEnvFromReferences: []string{configMap.Name}}
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	image := &api.Image{Name: "test-image", Tag: "1.0"}
+	imageHandler := infrastructure.NewImageHandler(context, image, "default-image", "image-stream", ns, false, false)
+	err := newDeploymentReconciler(context, instance, serviceDefinition, imageHandler).Reconcile()
+	assert.NoError(t, err)
+
+	deployment := &v1.Deployment{ObjectMeta: v13.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}}
+	test.AssertFetchMustExist(t, cli, deployment)
+	assert.NotContains(t, deployment.Spec.Template.Annotations, ConfigHashAnnotation)
+}
+
+func TestDeploymentReconciler_PodTemplate(t *testing.T) {
+	ns := t.Name()
+	instance := test.CreateFakeKogitoRuntime(ns)
+	podTemplate := instance.GetSpec().GetPodTemplate()
+	podTemplate.SetContainers([]corev1.Container{
+		{Name: instance.Name, Image: "ignored"},
+		{Name: "sidecar", Image: "sidecar:1.0"},
+	})
+	podTemplate.SetInitContainers([]corev1.Container{{Name: "init", Image: "init:1.0"}})
+	podTemplate.SetVolumes([]corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}})
+	podTemplate.SetVolumeMounts([]corev1.VolumeMount{{Name: "data", MountPath: "/data"}})
+	podTemplate.SetPorts([]corev1.ContainerPort{{Name: "metrics", ContainerPort: 9090}})
+	podTemplate.SetImagePullPolicy(corev1.PullIfNotPresent)
+	podTemplate.SetImagePullSecrets([]corev1.LocalObjectReference{{Name: "registry"}})
+	podTemplate.SetServiceAccountName("custom-sa")
+	podTemplate.SetPriorityClassName("high")
+	cli := test.NewFakeClientBuilder().AddK8sObjects(instance).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	image := &api.Image{Name: "test-image", Tag: "1.0"}
+	imageHandler := infrastructure.NewImageHandler(context, image, "default-image", "image-stream", ns, false, false)
+	err := newDeploymentReconciler(context, instance, ServiceDefinition{}, imageHandler).Reconcile()
+	assert.NoError(t, err)
+
+	deployment := &v1.Deployment{ObjectMeta: v13.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}}
+	test.AssertFetchMustExist(t, cli, deployment)
+	podSpec := deployment.Spec.Template.Spec
+	assert.Len(t, podSpec.Containers, 2)
+	assert.NotEqual(t, "ignored", podSpec.Containers[0].Image)
+	assert.Equal(t, corev1.PullIfNotPresent, podSpec.Containers[0].ImagePullPolicy)
+	assert.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "data", MountPath: "/data"})
+	assert.Contains(t, podSpec.Containers[0].Ports, corev1.ContainerPort{Name: "metrics", ContainerPort: 9090, Protocol: corev1.ProtocolTCP})
+	assert.Equal(t, "sidecar", podSpec.Containers[1].Name)
+	assert.Equal(t, "init", podSpec.InitContainers[0].Name)
+	assert.Equal(t, "data", podSpec.Volumes[len(podSpec.Volumes)-1].Name)
+	assert.Contains(t, podSpec.ImagePullSecrets, corev1.LocalObjectReference{Name: "registry"})
+	assert.Equal(t, "custom-sa", podSpec.ServiceAccountName)
+	assert.Equal(t, "high", podSpec.PriorityClassName)
+}
+
+func TestDeploymentReconciler_PinImageDigest(t *testing.T) {
+	digest := "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "secret" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Docker-Content-Digest", digest)
+	}))
+	defer server.Close()
+	domain := strings.TrimPrefix(server.URL, "https://")
+
+	ns := t.Name()
+	instance := test.CreateFakeKogitoRuntime(ns)
+	instance.Spec.PinImageDigest = true
+	instance.Spec.InsecureImageRegistry = true
+	pullSecret := &corev1.Secret{
+		ObjectMeta: v13.ObjectMeta{Name: "registry", Namespace: ns},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(fmt.Sprintf(`{"auths":{%q:{"username":"user","password":"secret"}}}`, domain)),
+		},
+	}
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta:       v13.ObjectMeta{Name: "default", Namespace: ns},
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(instance, pullSecret, serviceAccount).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	image := &api.Image{Domain: domain, Name: "test-image", Tag: "1.0"}
+	imageHandler := infrastructure.NewImageHandler(context, image, "default-image", "image-stream", ns, false, true)
+	err := newDeploymentReconciler(context, instance, ServiceDefinition{}, imageHandler).Reconcile()
+	assert.NoError(t, err)
+
+	deployment := &v1.Deployment{ObjectMeta: v13.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}}
+	test.AssertFetchMustExist(t, cli, deployment)
+	assert.Equal(t, domain+"/test-image:1.0@"+digest, deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, corev1.PullIfNotPresent, deployment.Spec.Template.Spec.Containers[0].ImagePullPolicy)
+	assert.Equal(t, domain+"/test-image:1.0@"+digest, instance.Status.Image)
+
+	// the tag moved in the registry, the pinned digest is kept until the tag of the service changes
+	pinnedDigest := digest
+	digest = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+	err = newDeploymentReconciler(context, instance, ServiceDefinition{}, imageHandler).Reconcile()
+	assert.NoError(t, err)
+	test.AssertFetchMustExist(t, cli, deployment)
+	assert.Equal(t, domain+"/test-image:1.0@"+pinnedDigest, deployment.Spec.Template.Spec.Containers[0].Image)
+
+	image.Tag = "1.1"
+	err = newDeploymentReconciler(context, instance, ServiceDefinition{}, imageHandler).Reconcile()
+	assert.NoError(t, err)
+	test.AssertFetchMustExist(t, cli, deployment)
+	assert.Equal(t, domain+"/test-image:1.1@"+digest, deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, domain+"/test-image:1.1@"+digest, instance.Status.Image)
+
+	// the registry isn't called again for the pinned tag
+	server.Close()
+	err = newDeploymentReconciler(context, instance, ServiceDefinition{}, imageHandler).Reconcile()
+	assert.NoError(t, err)
+	assert.Equal(t, domain+"/test-image:1.1@"+digest, instance.Status.Image)
+
+	// a new tag can't be resolved
+	image.Tag = "2.0"
+	err = newDeploymentReconciler(context, instance, ServiceDefinition{}, imageHandler).Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, infrastructure.ImageDigestResolutionFailedReason, infrastructure.NewReconciliationErrorHandler(context).GetReasonForError(err))
+}
+
+func TestDeploymentReconciler_PinImageDigestNeverUpdated(t *testing.T) {
+	digest := "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Docker-Content-Digest", digest)
+	}))
+	defer server.Close()
+	domain := strings.TrimPrefix(server.URL, "https://")
+
+	ns := t.Name()
+	instance := test.CreateFakeKogitoRuntime(ns)
+	instance.Spec.PinImageDigest = true
+	instance.Spec.InsecureImageRegistry = true
+	instance.Spec.ImageUpdatePolicy = api.NeverImageUpdatePolicy
+	cli := test.NewFakeClientBuilder().AddK8sObjects(instance).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	image := &api.Image{Domain: domain, Name: "test-image", Tag: "1.0"}
+	imageHandler := infrastructure.NewImageHandler(context, image, "default-image", "image-stream", ns, false, true)
+	err := newDeploymentReconciler(context, instance, ServiceDefinition{}, imageHandler).Reconcile()
+	assert.NoError(t, err)
+	pinnedImage := domain + "/test-image:1.0@" + digest
+	assert.Equal(t, pinnedImage, instance.Status.Image)
+
+	// the pinned image is kept even when the image tag changes
+	digest = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+	image.Tag = "1.1"
+	err = newDeploymentReconciler(context, instance, ServiceDefinition{}, imageHandler).Reconcile()
+	assert.NoError(t, err)
+	deployment := &v1.Deployment{ObjectMeta: v13.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}}
+	test.AssertFetchMustExist(t, cli, deployment)
+	assert.Equal(t, pinnedImage, deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, pinnedImage, instance.Status.Image)
+}
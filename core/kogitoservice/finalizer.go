@@ -0,0 +1,56 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoservice
+
+import (
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// AddFinalizer adds the given finalizer to the given object in the cluster
+func AddFinalizer(context operator.Context, object client.Object, finalizer string) error {
+	if controllerutil.ContainsFinalizer(object, finalizer) {
+		return nil
+	}
+	return patchFinalizers(context, object, func(latest client.Object) { controllerutil.AddFinalizer(latest, finalizer) })
+}
+
+// RemoveFinalizer removes the given finalizer from the given object in the cluster
+func RemoveFinalizer(context operator.Context, object client.Object, finalizer string) error {
+	if !controllerutil.ContainsFinalizer(object, finalizer) {
+		return nil
+	}
+	return patchFinalizers(context, object, func(latest client.Object) { controllerutil.RemoveFinalizer(latest, finalizer) })
+}
+
+// patchFinalizers only patches the finalizers of a fresh copy of the given object, the changes made to the object during the
+// reconciliation, eg: the default replicas, are never written to the cluster
+func patchFinalizers(context operator.Context, object client.Object, change func(latest client.Object)) error {
+	latest := object.DeepCopyObject().(client.Object)
+	if exists, err := kubernetes.ResourceC(context.Client).Fetch(latest); err != nil || !exists {
+		return err
+	}
+	base := latest.DeepCopyObject().(client.Object)
+	change(latest)
+	if err := kubernetes.ResourceC(context.Client).Patch(latest, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})); err != nil {
+		return err
+	}
+	// the status is updated later on with the object
+	object.SetFinalizers(latest.GetFinalizers())
+	object.SetResourceVersion(latest.GetResourceVersion())
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoservice
+
+import (
+	"context"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/imagedigest"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultServiceAccountName = "default"
+)
+
+// imageDigestPinner deploys the image of a service by digest, on Kubernetes the tags are otherwise pulled again by every new pod
+type imageDigestPinner struct {
+	operator.Context
+	instance       api.KogitoService
+	digestResolver *imagedigest.Resolver
+}
+
+func newImageDigestPinner(context operator.Context, instance api.KogitoService, digestResolver *imagedigest.Resolver) *imageDigestPinner {
+	return &imageDigestPinner{
+		Context:        context,
+		instance:       instance,
+		digestResolver: digestResolver,
+	}
+}
+
+// pin replaces the given image tag in the deployment containers with its digest and records both in the image of the service status.
+// The digest is resolved against the registry only when the image tag changes, with the Periodic policy it's also polled in background.
+// With the Never policy the first pinned image is kept, even when the image tag changes.
+func (p *imageDigestPinner) pin(deployment *appsv1.Deployment, image string) error {
+	if p.Client.IsOpenshift() || !p.instance.GetSpec().IsImageDigestPinned() {
+		return nil
+	}
+	pinnedImage, err := p.getPinnedImage(deployment, image)
+	if err != nil {
+		return infrastructure.ErrorForImageDigestResolution(image, err)
+	}
+	if p.instance.GetStatus().GetImage() != pinnedImage {
+		p.Log.Info("Pinning image digest", "image", image, "pinnedImage", pinnedImage)
+	}
+	p.instance.GetStatus().SetImage(pinnedImage)
+
+	for i, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Image == image {
+			deployment.Spec.Template.Spec.Containers[i].Image = pinnedImage
+			// digests are immutable, no need to check the registry at every pod start
+			if len(p.instance.GetSpec().GetPodTemplate().GetImagePullPolicy()) == 0 {
+				deployment.Spec.Template.Spec.Containers[i].ImagePullPolicy = corev1.PullIfNotPresent
+			}
+		}
+	}
+	return nil
+}
+
+// getPinnedImage returns the image tag followed by the digest it points to
+func (p *imageDigestPinner) getPinnedImage(deployment *appsv1.Deployment, image string) (string, error) {
+	statusImage := p.instance.GetStatus().GetImage()
+	pinnedTag, pinnedDigest := infrastructure.SplitImageDigestReference(statusImage)
+	pinned := len(pinnedDigest) > 0
+	if pinned && p.instance.GetSpec().GetImageUpdatePolicy() == api.NeverImageUpdatePolicy {
+		if pinnedTag != image {
+			p.Log.Debug("Image tag changed, keeping the pinned image", "image", image, "pinnedImage", statusImage)
+		}
+		return statusImage, nil
+	}
+	polled := p.isPolled()
+	if pinned && pinnedTag == image && !polled {
+		return statusImage, nil
+	}
+	registryClient, err := p.newRegistryClient(deployment)
+	if err != nil {
+		return "", err
+	}
+	if polled {
+		if digest, resolved := p.digestResolver.Get(client.ObjectKeyFromObject(p.instance), image, registryClient); resolved {
+			return infrastructure.GetImageDigestReference(image, digest), nil
+		}
+		if pinned && pinnedTag == image {
+			// the pinned digest is kept until the background resolution succeeds
+			return statusImage, nil
+		}
+	}
+	digest, err := registryClient.ResolveDigest(context.TODO(), image)
+	if err != nil {
+		return "", err
+	}
+	return infrastructure.GetImageDigestReference(image, digest), nil
+}
+
+// isPolled returns true if the image tag is resolved again in background to follow the tag moved to a new digest
+func (p *imageDigestPinner) isPolled() bool {
+	return p.instance.GetSpec().GetImageUpdatePolicy() == api.PeriodicImageUpdatePolicy && p.digestResolver != nil
+}
+
+// newRegistryClient authenticates with the image pull secrets of the pod and of its service account, like the kubelet does
+func (p *imageDigestPinner) newRegistryClient(deployment *appsv1.Deployment) (infrastructure.RegistryClient, error) {
+	podSpec := deployment.Spec.Template.Spec
+	pullSecrets := append([]corev1.LocalObjectReference{}, podSpec.ImagePullSecrets...)
+	serviceAccountName := podSpec.ServiceAccountName
+	if len(serviceAccountName) == 0 {
+		serviceAccountName = defaultServiceAccountName
+	}
+	serviceAccount := &corev1.ServiceAccount{}
+	if exists, err := kubernetes.ResourceC(p.Client).FetchWithKey(types.NamespacedName{Name: serviceAccountName, Namespace: p.instance.GetNamespace()}, serviceAccount); err != nil {
+		return nil, err
+	} else if exists {
+		pullSecrets = append(pullSecrets, serviceAccount.ImagePullSecrets...)
+	}
+	credentials, err := infrastructure.FetchRegistryCredentials(infrastructure.NewSecretHandler(p.Context), p.instance.GetNamespace(), pullSecrets)
+	if err != nil {
+		return nil, err
+	}
+	return infrastructure.NewRegistryClient(p.instance.GetSpec().IsInsecureImageRegistry(), credentials), nil
+}
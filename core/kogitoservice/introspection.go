@@ -0,0 +1,48 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoservice
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/introspection"
+)
+
+const httpsScheme = "https"
+
+// introspect returns the result of the probe on the service endpoints, read from the introspector cache when available.
+// found is false while the introspector didn't reach the service yet, it triggers a new reconciliation once it does.
+// Without introspector, eg: outside of the controllers, the endpoints are fetched right away, bounded by the introspection timeout.
+func introspect(introspector *introspection.Introspector, instance api.KogitoService, serverURL string, name string, probe introspection.Probe) (result interface{}, found bool, err error) {
+	serverURL = getIntrospectionURL(instance, serverURL)
+	if introspector == nil {
+		if result, err = introspection.Fetch(context.TODO(), serverURL, probe); err != nil {
+			return nil, false, err
+		}
+		return result, true, nil
+	}
+	result, found = introspector.Get(introspection.Target{Object: instance, URL: serverURL}, name, probe)
+	return result, found, nil
+}
+
+// getIntrospectionURL reaches the service over TLS when its monitoring endpoints are served with https
+func getIntrospectionURL(instance api.KogitoService, serverURL string) string {
+	if getMonitoringScheme(instance.GetSpec().GetMonitoring()) == httpsScheme && strings.HasPrefix(serverURL, httpScheme+"://") {
+		return httpsScheme + strings.TrimPrefix(serverURL, httpScheme)
+	}
+	return serverURL
+}
@@ -21,8 +21,6 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"strconv"
-	"strings"
 )
 
 const (
@@ -99,15 +97,10 @@ func (d *kogitoDeploymentHandler) CreateDeployment(service api.KogitoService, re
 
 // addStartupProbe adds a startup probe to deployment if the Kubernetes version is >= 1.18 when the feature is enabled by default
 func addStartupProbe(d *kogitoDeploymentHandler, deployment *appsv1.Deployment, startupProbe *corev1.Probe) {
-	versionInfo, err := d.Client.Discovery.ServerVersion()
+	minorVersion, err := d.Client.GetServerMinorVersion()
 	if err != nil {
-		d.Log.Warn("Could not access Kubernetes server version. Startup probes will not be added.")
-	}
-	d.Log.Debug("K8s version", "major version", versionInfo.Major, "minor version", versionInfo.Minor)
-	minorVersion := strings.TrimSuffix(versionInfo.Minor, "+")
-	if minorVersionInt, err := strconv.Atoi(minorVersion); err != nil {
-		d.Log.Warn("Could not parse Kubernetes server minor version. Startup probes will not be added.")
-	} else if minorVersionInt >= startupProbeMinorVersion {
+		d.Log.Warn("Could not access Kubernetes server minor version. Startup probes will not be added.", "error", err)
+	} else if minorVersion >= startupProbeMinorVersion {
 		deployment.Spec.Template.Spec.Containers[0].StartupProbe = startupProbe
 	}
 }
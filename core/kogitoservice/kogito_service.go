@@ -34,6 +34,7 @@ const (
 type ServiceHandler interface {
 	GetKogitoServiceURL(kogitoService api.KogitoService) string
 	GetKogitoServiceEndpoints(instance api.KogitoService, serviceHTTPRouteEnv string, serviceWSRouteEnv string) (endpoints *ServiceEndpoints, err error)
+	GetServiceEndpointsForURL(serviceURL string, serviceHTTPRouteEnv string, serviceWSRouteEnv string) (endpoints *ServiceEndpoints, err error)
 }
 
 type kogitoServiceHandler struct {
@@ -71,7 +72,11 @@ func (k *kogitoServiceHandler) getKogitoServiceURL(service api.KogitoService) st
 
 // GetKogitoServiceEndpoints ...
 func (k *kogitoServiceHandler) GetKogitoServiceEndpoints(instance api.KogitoService, serviceHTTPRouteEnv string, serviceWSRouteEnv string) (endpoints *ServiceEndpoints, err error) {
-	srvEndpoint := k.GetKogitoServiceURL(instance)
+	return k.GetServiceEndpointsForURL(k.GetKogitoServiceURL(instance), serviceHTTPRouteEnv, serviceWSRouteEnv)
+}
+
+// GetServiceEndpointsForURL creates the HTTP and WebSocket endpoints for the given service URL
+func (k *kogitoServiceHandler) GetServiceEndpointsForURL(srvEndpoint string, serviceHTTPRouteEnv string, serviceWSRouteEnv string) (endpoints *ServiceEndpoints, err error) {
 	endpoints = &ServiceEndpoints{
 		HTTPRouteEnv: serviceHTTPRouteEnv,
 		WSRouteEnv:   serviceWSRouteEnv,
@@ -16,11 +16,17 @@ package kogitoservice
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/kiegroup/kogito-operator/apis"
 	"github.com/kiegroup/kogito-operator/core/framework"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	"github.com/kiegroup/kogito-operator/core/manager"
 	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/record"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -35,22 +41,23 @@ type kogitoInfraReconciler struct {
 	serviceDefinition *ServiceDefinition
 	infraHandler      manager.KogitoInfraHandler
 	infraManager      manager.KogitoInfraManager
+	recorder          record.EventRecorder
 }
 
-func newKogitoInfraReconciler(context operator.Context, instance api.KogitoService, serviceDefinition *ServiceDefinition, infraHandler manager.KogitoInfraHandler) KogitoInfraReconciler {
+func newKogitoInfraReconciler(context operator.Context, instance api.KogitoService, serviceDefinition *ServiceDefinition, infraHandler manager.KogitoInfraHandler, recorder record.EventRecorder) KogitoInfraReconciler {
 	return &kogitoInfraReconciler{
 		Context:           context,
 		instance:          instance,
 		serviceDefinition: serviceDefinition,
 		infraHandler:      infraHandler,
 		infraManager:      manager.NewKogitoInfraManager(context, infraHandler),
+		recorder:          recorder,
 	}
 }
 
 func (k *kogitoInfraReconciler) Reconcile() error {
-	infraNames := k.instance.GetSpec().GetInfra()
-	for _, infraName := range infraNames {
-
+	var infras []api.KogitoInfraInterface
+	for _, infraName := range k.instance.GetSpec().GetInfra() {
 		infra, err := k.infraHandler.FetchKogitoInfraInstance(types.NamespacedName{Name: infraName, Namespace: k.instance.GetNamespace()})
 		if err != nil {
 			return err
@@ -59,7 +66,11 @@ func (k *kogitoInfraReconciler) Reconcile() error {
 			k.Log.Info("Infra not found", "Infra", infraName)
 			return fmt.Errorf("KogitoInfra with name %s not found in namespace %s", infraName, k.instance.GetNamespace())
 		}
+		infras = append(infras, infra)
+	}
+	k.setInfraDegradedCondition(infras)
 
+	for _, infra := range infras {
 		if err := k.checkInfraDependencies(infra); err != nil {
 			return err
 		}
@@ -86,3 +97,45 @@ func (k *kogitoInfraReconciler) checkInfraDependencies(infra api.KogitoInfraInte
 	}
 	return nil
 }
+
+// setInfraDegradedCondition updates the InfraDegraded condition of the service from the ResourceHealthy condition of its infras,
+// an event is recorded when one of them becomes unhealthy
+func (k *kogitoInfraReconciler) setInfraDegradedCondition(infras []api.KogitoInfraInterface) {
+	var issues []string
+	for _, infra := range infras {
+		if infra.GetStatus().GetConditions() == nil {
+			continue
+		}
+		healthCondition := meta.FindStatusCondition(*infra.GetStatus().GetConditions(), string(api.KogitoInfraResourceHealthy))
+		if healthCondition != nil && healthCondition.Status == metav1.ConditionFalse {
+			issues = append(issues, fmt.Sprintf("KogitoInfra %s: %s", infra.GetName(), healthCondition.Message))
+		}
+	}
+
+	instanceStatus := k.instance.GetStatus()
+	if instanceStatus.GetConditions() == nil {
+		instanceStatus.SetConditions(&[]metav1.Condition{})
+	}
+	if len(issues) == 0 {
+		// the condition is only reported once an infra has been degraded
+		if meta.FindStatusCondition(*instanceStatus.GetConditions(), string(api.InfraDegradedConditionType)) != nil {
+			meta.SetStatusCondition(instanceStatus.GetConditions(), metav1.Condition{
+				Type:   string(api.InfraDegradedConditionType),
+				Status: metav1.ConditionFalse,
+				Reason: string(infrastructure.InfraHealthyReason),
+			})
+		}
+		return
+	}
+	message := strings.Join(issues, "; ")
+	if !meta.IsStatusConditionTrue(*instanceStatus.GetConditions(), string(api.InfraDegradedConditionType)) {
+		k.Log.Info("Infra used by the service is degraded", "issues", message)
+		k.recorder.Event(k.Client, k.instance, v1.EventTypeWarning, string(infrastructure.InfraDegradedReason), message)
+	}
+	meta.SetStatusCondition(instanceStatus.GetConditions(), metav1.Condition{
+		Type:    string(api.InfraDegradedConditionType),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(infrastructure.InfraDegradedReason),
+		Message: message,
+	})
+}
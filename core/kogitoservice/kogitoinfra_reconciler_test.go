@@ -0,0 +1,96 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoservice
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/internal/app"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	meta2 "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_kogitoInfraReconciler_InfraDegraded(t *testing.T) {
+	ns := t.Name()
+	infraKafka := test.CreateFakeKogitoKafka(ns)
+	meta2.SetStatusCondition(infraKafka.GetStatus().GetConditions(), metav1.Condition{
+		Type:    string(api.KogitoInfraResourceHealthy),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(api.ResourceNotReady),
+		Message: "kafka instance kogito-kafka not ready yet",
+	})
+	runtime := test.CreateFakeKogitoRuntime(ns)
+	runtime.GetSpec().AddInfra(infraKafka.GetName())
+	cli := test.NewFakeClientBuilder().AddK8sObjects(runtime, infraKafka).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	definition := &ServiceDefinition{}
+	reconciler := newKogitoInfraReconciler(context, runtime, definition, app.NewKogitoInfraHandler(context), newRecorder(context.Scheme, runtime.Name))
+
+	// the infra is still configured, the service keeps its configuration
+	assert.NoError(t, reconciler.Reconcile())
+	condition := meta2.FindStatusCondition(*runtime.Status.Conditions, string(api.InfraDegradedConditionType))
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, string(infrastructure.InfraDegradedReason), condition.Reason)
+	assert.Equal(t, "KogitoInfra kogito-kafka-infra: kafka instance kogito-kafka not ready yet", condition.Message)
+
+	// the event is only recorded when the infra becomes degraded
+	assert.NoError(t, reconciler.Reconcile())
+	events := &v1.EventList{}
+	assert.NoError(t, kubernetes.ResourceC(cli).ListWithNamespace(ns, events))
+	assert.Len(t, events.Items, 1)
+	assert.Equal(t, v1.EventTypeWarning, events.Items[0].Type)
+	assert.Equal(t, string(infrastructure.InfraDegradedReason), events.Items[0].Reason)
+
+	meta2.SetStatusCondition(infraKafka.GetStatus().GetConditions(), metav1.Condition{
+		Type:   string(api.KogitoInfraResourceHealthy),
+		Status: metav1.ConditionTrue,
+		Reason: string(api.ResourceReady),
+	})
+	assert.NoError(t, kubernetes.ResourceC(cli).UpdateStatus(infraKafka))
+	assert.NoError(t, reconciler.Reconcile())
+	condition = meta2.FindStatusCondition(*runtime.Status.Conditions, string(api.InfraDegradedConditionType))
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, string(infrastructure.InfraHealthyReason), condition.Reason)
+}
+
+func Test_kogitoInfraReconciler_InfraHealthy(t *testing.T) {
+	ns := t.Name()
+	infraKafka := test.CreateFakeKogitoKafka(ns)
+	runtime := test.CreateFakeKogitoRuntime(ns)
+	runtime.GetSpec().AddInfra(infraKafka.GetName())
+	cli := test.NewFakeClientBuilder().AddK8sObjects(runtime, infraKafka).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	reconciler := newKogitoInfraReconciler(context, runtime, &ServiceDefinition{}, app.NewKogitoInfraHandler(context), newRecorder(context.Scheme, runtime.Name))
+
+	assert.NoError(t, reconciler.Reconcile())
+	assert.Nil(t, meta2.FindStatusCondition(*runtime.Status.Conditions, string(api.InfraDegradedConditionType)))
+}
@@ -15,15 +15,16 @@
 package kogitoservice
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/kiegroup/kogito-operator/apis"
 	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/introspection"
 	"github.com/kiegroup/kogito-operator/core/manager"
 	"github.com/kiegroup/kogito-operator/core/operator"
 	"k8s.io/apimachinery/pkg/types"
-	"net/http"
 )
 
 type messagingTopicType string
@@ -38,6 +39,8 @@ const (
 	produced messagingEventKind = "PRODUCED"
 	// topicInfoPath which the topics are fetched
 	topicInfoPath = "/messaging/topics"
+	// topicsProbeName identifies the topics in the introspection cache
+	topicsProbeName = "topics"
 )
 
 type messagingTopic struct {
@@ -105,9 +108,9 @@ func (m *messagingDeployer) setCloudEventsStatus(instance api.KogitoService, top
 		for _, event := range topic.EventsMeta {
 			switch event.Kind {
 			case consumed:
-				eventsConsumed = append(eventsConsumed, v1beta1.KogitoCloudEventInfo{Type: event.Type, Source: event.Source})
+				eventsConsumed = append(eventsConsumed, v1beta1.KogitoCloudEventInfo{Type: event.Type, Source: event.Source, Topic: topic.Name})
 			case produced:
-				eventsProduced = append(eventsProduced, v1beta1.KogitoCloudEventInfo{Type: event.Type, Source: event.Source})
+				eventsProduced = append(eventsProduced, v1beta1.KogitoCloudEventInfo{Type: event.Type, Source: event.Source, Topic: topic.Name})
 			}
 		}
 	}
@@ -127,21 +130,27 @@ func (m *messagingDeployer) fetchRequiredTopicsForURL(instance api.KogitoService
 		m.Log.Debug("Deployment not available yet for KogitoService", "KogitoService", instance.GetName())
 		return nil, nil
 	}
-	topicsURL := fmt.Sprintf("%s%s", serverURL, topicInfoPath)
-	resp, err := http.Get(topicsURL)
+	result, found, err := introspect(m.definition.Introspector, instance, serverURL, topicsProbeName, fetchRequiredTopics)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode == http.StatusNotFound {
+	if !found {
+		m.Log.Debug("Topics not fetched yet for KogitoService", "KogitoService", instance.GetName())
 		return nil, nil
 	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, infrastructure.ErrorForServiceNotReachable(resp.StatusCode, topicsURL, "GET")
+	topics, _ := result.([]messagingTopic)
+	return topics, nil
+}
+
+// fetchRequiredTopics is the introspection probe fetching the topics used by the service
+func fetchRequiredTopics(ctx context.Context, fetcher *introspection.Fetcher) (interface{}, error) {
+	body, err := fetcher.Get(ctx, topicInfoPath)
+	if err != nil || body == nil {
+		return []messagingTopic(nil), err
 	}
 	var topics []messagingTopic
-	if err := json.NewDecoder(resp.Body).Decode(&topics); err != nil {
-		return nil, fmt.Errorf("Failed to decode response from %s into topics ", topicsURL)
+	if err := json.Unmarshal(body, &topics); err != nil {
+		return nil, fmt.Errorf("Failed to decode response from %s into topics ", topicInfoPath)
 	}
 	return topics, nil
 }
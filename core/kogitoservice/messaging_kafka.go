@@ -19,7 +19,16 @@ import (
 	"github.com/kiegroup/kogito-operator/apis"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	infra2 "github.com/kiegroup/kogito-operator/core/kogitoinfra"
+	"github.com/kiegroup/kogito-operator/core/manager"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// KafkaTopicsFinalizer releases the Kafka topics declared by a Kogito service before the service is deleted
+	KafkaTopicsFinalizer = "kogito.kie.org/kafka-topics"
 )
 
 // kafkaMessagingDeployer implementation of messagingHandler
@@ -40,13 +49,14 @@ func (k *kafkaMessagingDeployer) CreateRequiredResources(service api.KogitoServi
 
 func (k *kafkaMessagingDeployer) createRequiredKafkaTopics(infra api.KogitoInfraInterface, service api.KogitoService) error {
 	k.Log.Debug("Going to apply kafka topic configurations required by the deployed service")
-	kafkaConfigMapName := infra2.GetKafkaConfigMapName(api.QuarkusRuntimeType)
+	profile := runtimeprofile.GetRegistry().GetProfile(service.GetSpec().GetRuntime())
+	kafkaConfigMapName := infra2.GetKafkaConfigMapName(profile.Name)
 	configMapHandler := infrastructure.NewConfigMapHandler(k.Context)
 	kafkaConfigMap, err := configMapHandler.FetchConfigMap(types.NamespacedName{Name: kafkaConfigMapName, Namespace: infra.GetNamespace()})
 	if err != nil || kafkaConfigMap == nil {
 		return err
 	}
-	kafkaURI := kafkaConfigMap.Data[infra2.QuarkusKafkaBootstrapAppProp]
+	kafkaURI := kafkaConfigMap.Data[profile.GetProperty(runtimeprofile.KafkaInfra, runtimeprofile.KafkaBootstrapServers)]
 	if len(kafkaURI) == 0 {
 		k.Log.Debug("Ignoring Kafka Topics creation, Kafka URI is empty from the given KogitoInfra", "KogitoInfra", infra.GetName())
 		return nil
@@ -54,50 +64,105 @@ func (k *kafkaMessagingDeployer) createRequiredKafkaTopics(infra api.KogitoInfra
 
 	// topics required by the deployed service
 	topics, err := k.fetchTopicsAndSetCloudEventsStatus(service)
+	if err != nil || topics == nil {
+		return err
+	}
+
+	kafkaNamespaceName, err := k.getKafkaInstanceNamespaceName(infra)
 	if err != nil {
 		return err
 	}
+	k.Log.Debug("Resolved kafka instance", "name", kafkaNamespaceName.Name, "namespace", kafkaNamespaceName.Namespace)
+
+	if err = AddKafkaTopicsFinalizer(k.Context, service); err != nil {
+		return err
+	}
+	kafkaHandler := infrastructure.NewKafkaHandler(k.Context)
+	serviceName := fmt.Sprintf("%s/%s", service.GetNamespace(), service.GetName())
+	var declaredTopics []string
 	for _, topic := range topics {
-		if err := k.createKafkaTopicIfNotExists(topic.Name, infra); err != nil {
+		k.Log.Debug("Going to apply kafka topic", "topicName", topic.Name)
+		settings := getKafkaTopicSettings(topic.Name, infra, service)
+		if _, err := kafkaHandler.ApplyKafkaTopic(topic.Name, kafkaNamespaceName.Name, kafkaNamespaceName.Namespace, serviceName, settings, service.GetSpec().GetPatches()...); err != nil {
 			return err
 		}
+		declaredTopics = append(declaredTopics, topic.Name)
 	}
-	return nil
+	return kafkaHandler.ReleaseKafkaTopics(kafkaNamespaceName.Name, kafkaNamespaceName.Namespace, serviceName, declaredTopics, getKafkaTopicDeletionPolicy(infra, service))
 }
 
-func (k *kafkaMessagingDeployer) createKafkaTopicIfNotExists(topicName string, instance api.KogitoInfraInterface) error {
-	k.Log.Debug("Going to create kafka topic it is not exists", "topicName", topicName)
+// AddKafkaTopicsFinalizer adds the finalizer releasing the Kafka topics declared by the given service once it's deleted
+func AddKafkaTopicsFinalizer(context operator.Context, service api.KogitoService) error {
+	return AddFinalizer(context, service, KafkaTopicsFinalizer)
+}
 
-	kafkaNamespaceName, err := k.getKafkaInstanceNamespaceName(instance)
-	if err != nil {
-		return err
+// ReleaseKafkaTopics releases the Kafka topics declared by the given deleted service in the Kafka clusters of its KogitoInfra instances,
+// following their deletion policy, then removes the finalizer of the service
+func ReleaseKafkaTopics(context operator.Context, service api.KogitoService, infraHandler manager.KogitoInfraHandler) error {
+	if !controllerutil.ContainsFinalizer(service, KafkaTopicsFinalizer) {
+		return nil
 	}
-	k.Log.Debug("Resolved kafka instance", "name", kafkaNamespaceName.Name, "namespace", kafkaNamespaceName.Namespace)
+	kafkaHandler := infrastructure.NewKafkaHandler(context)
+	serviceName := fmt.Sprintf("%s/%s", service.GetNamespace(), service.GetName())
+	for _, infraName := range service.GetSpec().GetInfra() {
+		infra, err := infraHandler.FetchKogitoInfraInstance(types.NamespacedName{Name: infraName, Namespace: service.GetNamespace()})
+		if err != nil {
+			return err
+		}
+		// the topics of a KogitoInfra already deleted can't be resolved anymore
+		if infra == nil || !IsKafkaResource(infra) {
+			continue
+		}
+		kafkaKey := infrastructure.GetInfraResourceKey(infra)
+		if kafkaKey == nil {
+			continue
+		}
+		context.Log.Info("Releasing Kafka topics of the deleted service", "kafka", kafkaKey)
+		if err = kafkaHandler.ReleaseKafkaTopics(kafkaKey.Name, kafkaKey.Namespace, serviceName, nil, getKafkaTopicDeletionPolicy(infra, service)); err != nil {
+			return err
+		}
+	}
+	return RemoveFinalizer(context, service, KafkaTopicsFinalizer)
+}
 
-	kafkaHandler := infrastructure.NewKafkaHandler(k.Context)
-	kafkaTopic, err := kafkaHandler.FetchKafkaTopic(types.NamespacedName{Name: topicName, Namespace: kafkaNamespaceName.Namespace})
-	if err != nil {
-		return err
+// getKafkaTopicSettings returns the settings of the given topic by precedence:
+// the settings of the KogitoRuntime take precedence over the ones of the KogitoInfra, the settings of the topic over the defaults
+func getKafkaTopicSettings(topicName string, infra api.KogitoInfraInterface, service api.KogitoService) []api.KafkaTopicSettingsInterface {
+	var topicsConfigs []api.KafkaTopicsConfigInterface
+	if runtime, ok := service.(api.KogitoRuntimeInterface); ok {
+		topicsConfigs = append(topicsConfigs, runtime.GetRuntimeSpec().GetKafkaTopics())
 	}
+	topicsConfigs = append(topicsConfigs, infra.GetSpec().GetKafkaTopics())
 
-	if kafkaTopic == nil {
-		_, err := kafkaHandler.CreateKafkaTopic(topicName, kafkaNamespaceName.Name, kafkaNamespaceName.Namespace)
-		if err != nil {
-			return err
+	var settings []api.KafkaTopicSettingsInterface
+	for _, topicsConfig := range topicsConfigs {
+		if topicConfig := topicsConfig.GetTopic(topicName); topicConfig != nil {
+			settings = append(settings, topicConfig)
 		}
 	}
-	return nil
+	for _, topicsConfig := range topicsConfigs {
+		settings = append(settings, topicsConfig.GetDefaults())
+	}
+	return settings
 }
 
-func (k *kafkaMessagingDeployer) getKafkaInstanceNamespaceName(instance api.KogitoInfraInterface) (*types.NamespacedName, error) {
-	if len(instance.GetSpec().GetResource().GetName()) > 0 {
-		k.Log.Debug("Custom kafka instance reference is provided")
-		namespace := instance.GetSpec().GetResource().GetNamespace()
-		if len(namespace) == 0 {
-			namespace = instance.GetNamespace()
-			k.Log.Debug("Namespace is not provided for custom resource, taking instance namespace as default", "instance namespace", namespace)
+// getKafkaTopicDeletionPolicy returns the deletion policy of the KogitoRuntime, else the one of the KogitoInfra
+func getKafkaTopicDeletionPolicy(infra api.KogitoInfraInterface, service api.KogitoService) api.KafkaTopicDeletionPolicy {
+	if runtime, ok := service.(api.KogitoRuntimeInterface); ok {
+		if deletionPolicy := runtime.GetRuntimeSpec().GetKafkaTopics().GetDeletionPolicy(); len(deletionPolicy) > 0 {
+			return deletionPolicy
 		}
-		return &types.NamespacedName{Namespace: namespace, Name: instance.GetSpec().GetResource().GetName()}, nil
+	}
+	if deletionPolicy := infra.GetSpec().GetKafkaTopics().GetDeletionPolicy(); len(deletionPolicy) > 0 {
+		return deletionPolicy
+	}
+	return api.RetainKafkaTopicDeletionPolicy
+}
+
+func (k *kafkaMessagingDeployer) getKafkaInstanceNamespaceName(instance api.KogitoInfraInterface) (*types.NamespacedName, error) {
+	if key := infrastructure.GetInfraResourceKey(instance); key != nil {
+		k.Log.Debug("Kafka instance reference is provided or provisioned", "instance", key)
+		return key, nil
 	}
 	k.Log.Debug("Custom kafka instance reference is not provided")
 	return nil, fmt.Errorf("no Kafka instances found on KogitoInfra reference: %s", instance.GetName())
@@ -0,0 +1,109 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoservice
+
+import (
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/infrastructure/kafka/v1beta2"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/internal/app"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"testing"
+)
+
+func Test_getKafkaTopicSettings(t *testing.T) {
+	ns := t.Name()
+	infra := test.CreateFakeKogitoKafka(ns)
+	infra.GetSpec().GetKafkaTopics().GetDefaults().SetReplicas(3)
+	infra.GetSpec().GetKafkaTopics().AddTopic(&v1beta1.KafkaTopicConfig{Name: "travellers", KafkaTopicSettings: v1beta1.KafkaTopicSettings{Partitions: 2}})
+	infra.GetSpec().GetKafkaTopics().SetDeletionPolicy(api.DeleteKafkaTopicDeletionPolicy)
+	runtime := test.CreateFakeKogitoRuntime(ns)
+	runtime.GetRuntimeSpec().GetKafkaTopics().GetDefaults().SetPartitions(4)
+	runtime.GetRuntimeSpec().GetKafkaTopics().AddTopic(&v1beta1.KafkaTopicConfig{Name: "travellers", KafkaTopicSettings: v1beta1.KafkaTopicSettings{MinInSyncReplicas: 2}})
+
+	// topic settings first, the runtime ones before the infra ones
+	settings := getKafkaTopicSettings("travellers", infra, runtime)
+	assert.Len(t, settings, 4)
+	assert.Equal(t, int32(2), settings[0].GetMinInSyncReplicas())
+	assert.Equal(t, int32(2), settings[1].GetPartitions())
+	assert.Equal(t, int32(4), settings[2].GetPartitions())
+	assert.Equal(t, int32(3), settings[3].GetReplicas())
+
+	settings = getKafkaTopicSettings("visas", infra, runtime)
+	assert.Len(t, settings, 2)
+
+	// supporting services only get the infra settings
+	settings = getKafkaTopicSettings("travellers", infra, test.CreateFakeDataIndex(ns))
+	assert.Len(t, settings, 2)
+	assert.Equal(t, int32(2), settings[0].GetPartitions())
+
+	assert.Equal(t, api.DeleteKafkaTopicDeletionPolicy, getKafkaTopicDeletionPolicy(infra, runtime))
+	runtime.GetRuntimeSpec().GetKafkaTopics().SetDeletionPolicy(api.RetainKafkaTopicDeletionPolicy)
+	assert.Equal(t, api.RetainKafkaTopicDeletionPolicy, getKafkaTopicDeletionPolicy(infra, runtime))
+	assert.Equal(t, api.RetainKafkaTopicDeletionPolicy, getKafkaTopicDeletionPolicy(test.CreateFakeKogitoInfinispan(ns), test.CreateFakeDataIndex(ns)))
+}
+
+func TestReleaseKafkaTopics(t *testing.T) {
+	ns := t.Name()
+	infra := test.CreateFakeKogitoKafka(ns)
+	infra.GetSpec().GetKafkaTopics().SetDeletionPolicy(api.DeleteKafkaTopicDeletionPolicy)
+	runtime := test.CreateFakeKogitoRuntime(ns)
+	runtime.GetSpec().AddInfra(infra.GetName())
+	cli := test.NewFakeClientBuilder().AddK8sObjects(infra, runtime).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	kafkaHandler := infrastructure.NewKafkaHandler(context)
+	serviceName := ns + "/" + runtime.GetName()
+	_, err := kafkaHandler.ApplyKafkaTopic("travellers", "kogito-kafka", ns, serviceName, nil)
+	assert.NoError(t, err)
+	_, err = kafkaHandler.ApplyKafkaTopic("visas", "kogito-kafka", ns, serviceName, nil)
+	assert.NoError(t, err)
+	_, err = kafkaHandler.ApplyKafkaTopic("visas", "kogito-kafka", ns, ns+"/visas", nil)
+	assert.NoError(t, err)
+
+	// nothing is released without the finalizer
+	assert.NoError(t, ReleaseKafkaTopics(context, runtime, app.NewKogitoInfraHandler(context)))
+	// the changes made to the spec during the reconciliation are not written along with the finalizer
+	replicas := int32(3)
+	runtime.Spec.Replicas = &replicas
+	assert.NoError(t, AddKafkaTopicsFinalizer(context, runtime))
+	assert.NoError(t, AddKafkaTopicsFinalizer(context, runtime))
+	assert.Equal(t, []string{KafkaTopicsFinalizer}, runtime.GetFinalizers())
+	deployedRuntime := test.CreateFakeKogitoRuntime(ns)
+	test.AssertFetchMustExist(t, cli, deployedRuntime)
+	assert.Equal(t, []string{KafkaTopicsFinalizer}, deployedRuntime.GetFinalizers())
+	assert.Equal(t, int32(1), *deployedRuntime.Spec.Replicas)
+
+	assert.NoError(t, ReleaseKafkaTopics(context, runtime, app.NewKogitoInfraHandler(context)))
+	assert.False(t, controllerutil.ContainsFinalizer(runtime, KafkaTopicsFinalizer))
+	travellers, err := kafkaHandler.FetchKafkaTopic(types.NamespacedName{Name: "travellers", Namespace: ns})
+	assert.NoError(t, err)
+	assert.Nil(t, travellers)
+	visas := &v1beta2.KafkaTopic{}
+	exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: "visas", Namespace: ns}, visas)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, []string{ns + "/visas"}, infrastructure.GetKafkaTopicServices(visas))
+}
@@ -15,22 +15,31 @@
 package kogitoservice
 
 import (
+	"context"
+	"crypto/sha256"
 	"fmt"
+	"reflect"
+	"strings"
+
 	"github.com/kiegroup/kogito-operator/apis"
-	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
 	"github.com/kiegroup/kogito-operator/core/framework"
-	"github.com/kiegroup/kogito-operator/core/framework/util"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/tracker"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	topicIdentifier        = "kogito.kie.org/cloudEventType"
-	triggerFilterAttribute = "type"
+	topicIdentifier              = "kogito.kie.org/cloudEventType"
+	triggerFilterTypeAttribute   = "type"
+	triggerFilterSourceAttribute = "source"
+	triggerNameHashLength        = 10
 )
 
 // knativeMessagingDeployer implementation of messagingHandler
@@ -45,55 +54,102 @@ func (k *knativeMessagingDeployer) CreateRequiredResources(service api.KogitoSer
 	}
 
 	// since we depend on Knative, let's bind a SinkBinding object to our deployment
-	sinkBinding := k.newSinkBinding(service, infra)
-	if err := kubernetes.ResourceC(k.Client).CreateIfNotExistsForOwner(sinkBinding, service, k.Scheme); err != nil {
+	if err := k.reconcileSinkBinding(service, infra); err != nil {
 		return err
 	}
 
-	// fetch for incoming topics to create our triggers
+	// fetch for incoming topics to reconcile our triggers
 	topics, err := k.fetchTopicsAndSetCloudEventsStatus(service)
-	if err != nil {
+	if err != nil || topics == nil {
 		return err
 	}
-	for _, topic := range topics {
-		if err := k.createKnativeTriggersIfNotExists(topic, service, infra); err != nil {
-			return err
-		}
+	return k.reconcileKnativeTriggers(topics, service, infra)
+}
+
+// reconcileSinkBinding creates or updates the SinkBinding owned by the given service, so the changes of its patches are applied as well
+func (k *knativeMessagingDeployer) reconcileSinkBinding(service api.KogitoService, infra api.KogitoInfraInterface) error {
+	knativeHandler := infrastructure.NewKnativeHandler(k.Context)
+	sinkBinding := k.newSinkBinding(service, infra)
+	if err := framework.SetOwner(service, k.Scheme, sinkBinding); err != nil {
+		return err
+	}
+	if err := applyPatches(k.Context, service, sinkBinding); err != nil {
+		return err
 	}
-	return nil
+	deployedSinkBindings, err := knativeHandler.FetchSinkBindingsForOwner(service, map[string]string{framework.LabelAppKey: service.GetName()})
+	if err != nil {
+		return err
+	}
+	sinkBindingType := reflect.TypeOf(sourcesv1.SinkBinding{})
+	requestedResources := map[reflect.Type][]client.Object{sinkBindingType: {sinkBinding}}
+	deployedResources := map[reflect.Type][]client.Object{sinkBindingType: deployedSinkBindings}
+	_, err = infrastructure.NewDeltaProcessor(k.Context).ProcessDelta(knativeHandler.GetSinkBindingComparator(), requestedResources, deployedResources)
+	return err
 }
 
-func (k *knativeMessagingDeployer) createKnativeTriggersIfNotExists(topic messagingTopic, service api.KogitoService, infra api.KogitoInfraInterface) error {
-	if topic.Kind == incoming {
+// reconcileKnativeTriggers creates, updates and deletes the Triggers owned by the given service, so there's exactly one Trigger
+// for each event consumed by the service through the broker of the given KogitoInfra
+func (k *knativeMessagingDeployer) reconcileKnativeTriggers(topics []messagingTopic, service api.KogitoService, infra api.KogitoInfraInterface) error {
+	knativeHandler := infrastructure.NewKnativeHandler(k.Context)
+	var requestedTriggers []client.Object
+	for _, topic := range topics {
+		if topic.Kind != incoming {
+			continue
+		}
 		for _, event := range topic.EventsMeta {
-			if exists, err := k.triggerExists(event, service); err != nil {
+			trigger, err := k.newTrigger(event, service, infra)
+			if err != nil {
+				return err
+			}
+			if err := framework.SetOwner(service, k.Scheme, trigger); err != nil {
+				return err
+			}
+			if err := applyPatches(k.Context, service, trigger); err != nil {
 				return err
-			} else if !exists {
-				trigger := k.newTrigger(event, service, infra)
-				if err := kubernetes.ResourceC(k.Client).CreateForOwner(trigger, service, k.Scheme); err != nil {
-					return err
-				}
 			}
+			requestedTriggers = append(requestedTriggers, trigger)
 		}
 	}
-	return nil
+	deployedTriggers, err := knativeHandler.FetchTriggersForOwner(service, map[string]string{framework.LabelAppKey: service.GetName()})
+	if err != nil {
+		return err
+	}
+	triggerType := reflect.TypeOf(eventingv1.Trigger{})
+	requestedResources := map[reflect.Type][]client.Object{triggerType: requestedTriggers}
+	deployedResources := map[reflect.Type][]client.Object{triggerType: deployedTriggers}
+	_, err = infrastructure.NewDeltaProcessor(k.Context).ProcessDelta(knativeHandler.GetTriggerComparator(), requestedResources, deployedResources)
+	return err
 }
 
 // newTrigger creates a new Knative Eventing Trigger reference for the given Event
 // See: https://knative.dev/docs/eventing/broker/triggers/#trigger-filtering
-func (k *knativeMessagingDeployer) newTrigger(e messagingEventMeta, service api.KogitoService, infra api.KogitoInfraInterface) *eventingv1.Trigger {
+func (k *knativeMessagingDeployer) newTrigger(e messagingEventMeta, service api.KogitoService, infra api.KogitoInfraInterface) (*eventingv1.Trigger, error) {
+	broker := infra.GetSpec().GetResource().GetName()
+	triggersConfig := infra.GetSpec().GetKnativeTriggers()
+	attributes := eventingv1.TriggerFilterAttributes{}
+	for attribute, value := range triggersConfig.GetFilters() {
+		attributes[attribute] = value
+	}
+	attributes[triggerFilterTypeAttribute] = e.Type
+	if len(e.Source) > 0 {
+		attributes[triggerFilterSourceAttribute] = e.Source
+	}
+	delivery, err := newTriggerDelivery(triggersConfig, service.GetNamespace())
+	if err != nil {
+		return nil, fmt.Errorf("Invalid Knative Triggers configuration in KogitoInfra %s: %v ", infra.GetName(), err)
+	}
 	return &eventingv1.Trigger{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-listener-%s", service.GetName(), util.RandomSuffix()),
+			Name:      getTriggerName(service.GetName(), broker, e),
 			Namespace: service.GetNamespace(),
 			Labels: map[string]string{
 				framework.LabelAppKey: service.GetName(),
-				topicIdentifier:       e.Type,
+				topicIdentifier:       getTriggerTypeLabel(e.Type),
 			},
 		},
 		Spec: eventingv1.TriggerSpec{
-			Broker: infra.GetSpec().GetResource().GetName(),
-			Filter: &eventingv1.TriggerFilter{Attributes: eventingv1.TriggerFilterAttributes{triggerFilterAttribute: e.Type}},
+			Broker: broker,
+			Filter: &eventingv1.TriggerFilter{Attributes: attributes},
 			Subscriber: duckv1.Destination{
 				Ref: &duckv1.KReference{
 					Name:       service.GetName(),
@@ -102,8 +158,64 @@ func (k *knativeMessagingDeployer) newTrigger(e messagingEventMeta, service api.
 					APIVersion: infrastructure.KindService.GroupVersion.Version,
 				},
 			},
+			Delivery: delivery,
 		},
+	}, nil
+}
+
+// newTriggerDelivery creates the delivery spec of the Triggers from the given settings, nil when none is set
+func newTriggerDelivery(triggersConfig api.KnativeTriggersConfigInterface, namespace string) (*eventingduckv1.DeliverySpec, error) {
+	delivery := &eventingduckv1.DeliverySpec{Retry: triggersConfig.GetRetry()}
+	if deadLetterSink := triggersConfig.GetDeadLetterSink(); deadLetterSink != nil {
+		delivery.DeadLetterSink = &duckv1.Destination{}
+		if ref := deadLetterSink.GetRef(); ref != nil {
+			ns := ref.Namespace
+			if len(ns) == 0 {
+				ns = namespace
+			}
+			delivery.DeadLetterSink.Ref = &duckv1.KReference{Kind: ref.Kind, Namespace: ns, Name: ref.Name, APIVersion: ref.APIVersion}
+		}
+		if len(deadLetterSink.GetURI()) > 0 {
+			uri, err := apis.ParseURL(deadLetterSink.GetURI())
+			if err != nil {
+				return nil, err
+			}
+			delivery.DeadLetterSink.URI = uri
+		}
+	}
+	if backoffPolicy := triggersConfig.GetBackoffPolicy(); len(backoffPolicy) > 0 {
+		policy := eventingduckv1.BackoffPolicyType(backoffPolicy)
+		delivery.BackoffPolicy = &policy
+	}
+	if backoffDelay := triggersConfig.GetBackoffDelay(); len(backoffDelay) > 0 {
+		delivery.BackoffDelay = &backoffDelay
+	}
+	if reflect.DeepEqual(delivery, &eventingduckv1.DeliverySpec{}) {
+		return nil, nil
+	}
+	if err := delivery.Validate(context.TODO()); err != nil {
+		return nil, err
 	}
+	return delivery, nil
+}
+
+// getTriggerName returns a name unique for the given service, broker and event, so the same Trigger is always reconciled for an event.
+// The broker of a Trigger is immutable: when the broker changes, a new Trigger is created and the previous one is deleted.
+func getTriggerName(serviceName, broker string, e messagingEventMeta) string {
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join([]string{broker, e.Type, e.Source}, "/"))))[:triggerNameHashLength]
+	prefix := fmt.Sprintf("%s-listener", serviceName)
+	if maxPrefixLength := validation.DNS1123LabelMaxLength - triggerNameHashLength - 1; len(prefix) > maxPrefixLength {
+		prefix = strings.TrimRight(prefix[:maxPrefixLength], "-")
+	}
+	return fmt.Sprintf("%s-%s", prefix, hash)
+}
+
+// getTriggerTypeLabel returns the event type as a valid label value, only used to ease the lookup of the Triggers
+func getTriggerTypeLabel(eventType string) string {
+	if len(eventType) > validation.LabelValueMaxLength {
+		eventType = eventType[:validation.LabelValueMaxLength]
+	}
+	return strings.TrimRight(eventType, "-_.")
 }
 
 // newSinkBinding creates a new SinkBinding object targeting the given KogitoInfra resource and binding the
@@ -148,23 +260,6 @@ func (k *knativeMessagingDeployer) newSinkBinding(service api.KogitoService, inf
 	}
 }
 
-func (k *knativeMessagingDeployer) triggerExists(e messagingEventMeta, service api.KogitoService) (bool, error) {
-	triggers := &eventingv1.TriggerList{}
-	labels := map[string]string{
-		framework.LabelAppKey: service.GetName(),
-		topicIdentifier:       e.Type,
-	}
-	if err := kubernetes.ResourceC(k.Client).ListWithNamespaceAndLabel(service.GetNamespace(), triggers, labels); err != nil {
-		return false, err
-	}
-	for _, trigger := range triggers.Items {
-		if framework.IsOwner(&trigger, service) {
-			return true, nil
-		}
-	}
-	return false, nil
-}
-
 // IsKnativeEventingResource checks if provided KogitoInfra instance is for Knative eventing resource
 func isKnativeEventingResource(instance api.KogitoInfraInterface) bool {
 	if !instance.GetSpec().IsResourceEmpty() {
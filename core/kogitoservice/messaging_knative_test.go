@@ -15,6 +15,11 @@
 package kogitoservice
 
 import (
+	"fmt"
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
 	"github.com/kiegroup/kogito-operator/core/framework"
 	"github.com/kiegroup/kogito-operator/core/operator"
@@ -22,8 +27,13 @@ import (
 	"github.com/kiegroup/kogito-operator/internal/app"
 	"github.com/kiegroup/kogito-operator/meta"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
-	"testing"
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
 func Test_knativeMessagingDeployer_CreateRequiredResources(t *testing.T) {
@@ -83,4 +93,130 @@ func Test_knativeMessagingDeployer_CreateRequiredResources(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, triggers.Items, 1)
 	assert.Equal(t, "travellers", triggers.Items[0].Spec.Filter.Attributes["type"])
+	assert.Equal(t, getTriggerName(kogitoSvc.GetName(), "", messagingEventMeta{Type: "travellers"}), triggers.Items[0].Name)
+	assert.Nil(t, triggers.Items[0].Spec.Delivery)
+
+	// reconciling again keeps the same trigger and applies the patches added after the creation
+	kogitoSvc.GetSpec().SetPatches([]api.KogitoPatchInterface{
+		v1beta1.KogitoPatch{Kind: "SinkBinding", Name: kogitoSvc.GetName() + "-publisher", Type: api.MergePatchType, Patch: `{"spec":{"ceOverrides":{"extensions":{"team":"travels"}}}}`},
+	})
+	err = knativeDeployer.CreateRequiredResources(kogitoSvc)
+	assert.NoError(t, err)
+	err = kubernetes.ResourceC(client).ListWithNamespaceAndLabel(kogitoSvc.GetNamespace(), triggers, labels)
+	assert.NoError(t, err)
+	assert.Len(t, triggers.Items, 1)
+	sinkBinding := &sourcesv1.SinkBinding{ObjectMeta: metav1.ObjectMeta{Name: kogitoSvc.GetName() + "-publisher", Namespace: kogitoSvc.GetNamespace()}}
+	test.AssertFetchMustExist(t, client, sinkBinding)
+	assert.Equal(t, "travels", sinkBinding.Spec.CloudEventOverrides.Extensions["team"])
+}
+
+func Test_knativeMessagingDeployer_ReconcileTriggers(t *testing.T) {
+	responseWithTopics := `[
+   {
+      "name":"kogito_incoming_stream",
+      "type":"INCOMING",
+      "eventsMeta":[
+         {
+            "type":"travellers",
+            "source":"/travels",
+            "kind":"CONSUMED"
+         }
+      ]
+   }
+]`
+	server := test.MockKogitoSvcReplies(t, test.ServerHandler{Path: topicInfoPath, JSONResponse: responseWithTopics})
+	defer server.Close()
+	deferFn := test.SetSharedEnv(EnvVarKogitoServiceURL, server.URL)
+	defer deferFn()
+
+	kogitoSvc := createServiceInstance(t)
+	kogitoSvc.SetUID("b5d1c0a4-3b8a-4a3e-9a4f-7c3a6e2f1d10")
+	request := newReconcileRequest(kogitoSvc.GetNamespace())
+	request.Name = kogitoSvc.GetName()
+	knativeInfra := test.CreateFakeKogitoKnative(t.Name())
+	knativeInfra.GetSpec().GetResource().SetName("default")
+	retry := int32(3)
+	triggersConfig := knativeInfra.GetSpec().GetKnativeTriggers()
+	triggersConfig.SetFilters(map[string]string{"tenant": "acme"})
+	triggersConfig.SetDeadLetterSink(&v1beta1.KnativeDestination{Ref: &corev1.ObjectReference{APIVersion: "serving.knative.dev/v1", Kind: "Service", Name: "dead-letters"}})
+	triggersConfig.SetRetry(&retry)
+	triggersConfig.SetBackoffPolicy(api.ExponentialKnativeBackoffPolicy)
+	triggersConfig.SetBackoffDelay("PT0.5S")
+	kogitoSvc.GetSpec().AddInfra(knativeInfra.GetName())
+
+	// trigger of an event type the service no longer consumes
+	staleTrigger := &eventingv1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-listener-1234", kogitoSvc.GetName()),
+			Namespace: kogitoSvc.GetNamespace(),
+			Labels:    map[string]string{framework.LabelAppKey: kogitoSvc.GetName(), topicIdentifier: "visas"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Name: kogitoSvc.GetName(), UID: kogitoSvc.GetUID()},
+			},
+		},
+		Spec: eventingv1.TriggerSpec{Broker: "default"},
+	}
+
+	client := test.NewFakeClientBuilder().AddK8sObjects(kogitoSvc, knativeInfra, staleTrigger, createAvailableDeployment(kogitoSvc)).Build()
+	context := operator.Context{
+		Client: client,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	infraHandler := app.NewKogitoInfraHandler(context)
+	knativeDeployer := NewKnativeMessagingDeployer(context, ServiceDefinition{Request: request}, infraHandler)
+
+	err := knativeDeployer.CreateRequiredResources(kogitoSvc)
+	assert.NoError(t, err)
+
+	triggers := &eventingv1.TriggerList{}
+	labels := map[string]string{framework.LabelAppKey: kogitoSvc.GetName()}
+	err = kubernetes.ResourceC(client).ListWithNamespaceAndLabel(kogitoSvc.GetNamespace(), triggers, labels)
+	assert.NoError(t, err)
+	assert.Len(t, triggers.Items, 1)
+	trigger := triggers.Items[0]
+	assert.Equal(t, "default", trigger.Spec.Broker)
+	assert.Equal(t, eventingv1.TriggerFilterAttributes{"type": "travellers", "source": "/travels", "tenant": "acme"}, trigger.Spec.Filter.Attributes)
+	exponential := eventingduckv1.BackoffPolicyExponential
+	delay := "PT0.5S"
+	assert.Equal(t, &eventingduckv1.DeliverySpec{
+		DeadLetterSink: &duckv1.Destination{
+			Ref: &duckv1.KReference{APIVersion: "serving.knative.dev/v1", Kind: "Service", Name: "dead-letters", Namespace: kogitoSvc.GetNamespace()},
+		},
+		Retry:         &retry,
+		BackoffPolicy: &exponential,
+		BackoffDelay:  &delay,
+	}, trigger.Spec.Delivery)
+
+	// moving to another broker replaces the trigger
+	infra := &v1beta1.KogitoInfra{}
+	_, err = kubernetes.ResourceC(client).FetchWithKey(types.NamespacedName{Name: knativeInfra.GetName(), Namespace: knativeInfra.GetNamespace()}, infra)
+	assert.NoError(t, err)
+	infra.Spec.Resource.Name = "events"
+	assert.NoError(t, kubernetes.ResourceC(client).Update(infra))
+
+	err = knativeDeployer.CreateRequiredResources(kogitoSvc)
+	assert.NoError(t, err)
+	err = kubernetes.ResourceC(client).ListWithNamespaceAndLabel(kogitoSvc.GetNamespace(), triggers, labels)
+	assert.NoError(t, err)
+	assert.Len(t, triggers.Items, 1)
+	assert.Equal(t, "events", triggers.Items[0].Spec.Broker)
+	assert.NotEqual(t, trigger.Name, triggers.Items[0].Name)
+}
+
+func Test_knativeMessagingDeployer_InvalidDelivery(t *testing.T) {
+	triggersConfig := &v1beta1.KnativeTriggersConfig{BackoffDelay: "half a second"}
+	_, err := newTriggerDelivery(triggersConfig, t.Name())
+	assert.Error(t, err)
+}
+
+func Test_getTriggerName(t *testing.T) {
+	event := messagingEventMeta{Type: "travellers", Source: "/travels"}
+	name := getTriggerName("travels", "default", event)
+	assert.Equal(t, name, getTriggerName("travels", "default", event))
+	assert.NotEqual(t, name, getTriggerName("travels", "events", event))
+	assert.NotEqual(t, name, getTriggerName("travels", "default", messagingEventMeta{Type: "travellers"}))
+
+	longName := getTriggerName("a-very-long-kogito-service-name-that-fills-the-whole-label-length", "default", event)
+	assert.LessOrEqual(t, len(longName), 63)
 }
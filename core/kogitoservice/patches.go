@@ -0,0 +1,33 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoservice
+
+import (
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// applyPatches applies the patches declared in the service spec to the objects generated for the service
+func applyPatches(context operator.Context, instance api.KogitoService, objects ...client.Object) error {
+	for _, object := range objects {
+		if err := framework.ApplyPatches(context.Scheme, object, instance.GetSpec().GetPatches()); err != nil {
+			return infrastructure.ErrorForInvalidPatch(err)
+		}
+	}
+	return nil
+}
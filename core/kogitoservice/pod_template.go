@@ -0,0 +1,158 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoservice
+
+import (
+	"github.com/kiegroup/kogito-operator/apis"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// applyPodTemplate merges the pod template of the service spec into the pod generated by the operator.
+// Lists keep the generated items first and in order, the items of the service spec with the same key replace them,
+// except the service container which can only be customized through its volume mounts, ports and image pull policy.
+func applyPodTemplate(deployment *appsv1.Deployment, podTemplate api.KogitoPodTemplateInterface) {
+	if podTemplate == nil {
+		return
+	}
+	podSpec := &deployment.Spec.Template.Spec
+	serviceContainer := &podSpec.Containers[0]
+	serviceContainer.VolumeMounts = mergeVolumeMounts(serviceContainer.VolumeMounts, podTemplate.GetVolumeMounts())
+	serviceContainer.Ports = mergePorts(serviceContainer.Ports, podTemplate.GetPorts())
+	if len(podTemplate.GetImagePullPolicy()) > 0 {
+		serviceContainer.ImagePullPolicy = podTemplate.GetImagePullPolicy()
+	}
+
+	podSpec.Containers = mergeContainers(podSpec.Containers, podTemplate.GetContainers(), serviceContainer.Name)
+	podSpec.InitContainers = mergeContainers(podSpec.InitContainers, podTemplate.GetInitContainers(), "")
+	podSpec.Volumes = mergeVolumes(podSpec.Volumes, podTemplate.GetVolumes())
+	podSpec.ImagePullSecrets = mergeImagePullSecrets(podSpec.ImagePullSecrets, podTemplate.GetImagePullSecrets())
+	if len(podTemplate.GetServiceAccountName()) > 0 {
+		podSpec.ServiceAccountName = podTemplate.GetServiceAccountName()
+	}
+	if len(podTemplate.GetPriorityClassName()) > 0 {
+		podSpec.PriorityClassName = podTemplate.GetPriorityClassName()
+	}
+}
+
+func mergeContainers(generated []corev1.Container, overlay []corev1.Container, protectedName string) []corev1.Container {
+	if len(overlay) == 0 {
+		return generated
+	}
+	merged := append([]corev1.Container{}, generated...)
+	for _, container := range overlay {
+		if container.Name == protectedName {
+			continue
+		}
+		replaced := false
+		for i := range merged {
+			if merged[i].Name == container.Name {
+				merged[i] = container
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, container)
+		}
+	}
+	return merged
+}
+
+func mergeVolumes(generated []corev1.Volume, overlay []corev1.Volume) []corev1.Volume {
+	if len(overlay) == 0 {
+		return generated
+	}
+	merged := append([]corev1.Volume{}, generated...)
+	for _, volume := range overlay {
+		replaced := false
+		for i := range merged {
+			if merged[i].Name == volume.Name {
+				merged[i] = volume
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, volume)
+		}
+	}
+	return merged
+}
+
+func mergeVolumeMounts(generated []corev1.VolumeMount, overlay []corev1.VolumeMount) []corev1.VolumeMount {
+	if len(overlay) == 0 {
+		return generated
+	}
+	merged := append([]corev1.VolumeMount{}, generated...)
+	for _, volumeMount := range overlay {
+		replaced := false
+		for i := range merged {
+			if merged[i].MountPath == volumeMount.MountPath {
+				merged[i] = volumeMount
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, volumeMount)
+		}
+	}
+	return merged
+}
+
+func mergePorts(generated []corev1.ContainerPort, overlay []corev1.ContainerPort) []corev1.ContainerPort {
+	if len(overlay) == 0 {
+		return generated
+	}
+	merged := append([]corev1.ContainerPort{}, generated...)
+	for _, port := range overlay {
+		if len(port.Protocol) == 0 {
+			port.Protocol = corev1.ProtocolTCP
+		}
+		replaced := false
+		for i := range merged {
+			if merged[i].ContainerPort == port.ContainerPort && merged[i].Protocol == port.Protocol {
+				merged[i] = port
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, port)
+		}
+	}
+	return merged
+}
+
+func mergeImagePullSecrets(generated []corev1.LocalObjectReference, overlay []corev1.LocalObjectReference) []corev1.LocalObjectReference {
+	if len(overlay) == 0 {
+		return generated
+	}
+	merged := append([]corev1.LocalObjectReference{}, generated...)
+	for _, secret := range overlay {
+		exists := false
+		for _, mergedSecret := range merged {
+			if mergedSecret.Name == secret.Name {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			merged = append(merged, secret)
+		}
+	}
+	return merged
+}
@@ -17,6 +17,7 @@ package kogitoservice
 import (
 	"github.com/kiegroup/kogito-operator/apis"
 	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -28,12 +29,6 @@ const (
 	livenessProbeType  ProbeType = "liveness"
 	readinessProbeType ProbeType = "readiness"
 	startupProbeType   ProbeType = "startup"
-
-	quarkusProbeLivenessPath  = "/q/health/live"
-	quarkusProbeReadinessPath = "/q/health/ready"
-
-	springBootProbeLivenessPath  = "/actuator/health/liveness"
-	springBootProbeReadinessPath = "/actuator/health/readiness"
 )
 
 type healthCheckProbe struct {
@@ -92,19 +87,14 @@ func setDefaultHTTPGetValues(probe *corev1.Probe, runtimeType api.RuntimeType, p
 	}
 }
 
+// getDefaultHTTPPath returns the probe path defined by the profile of the runtime
 func getDefaultHTTPPath(runtimeType api.RuntimeType, probeType ProbeType) string {
-	if runtimeType == api.SpringBootRuntimeType {
-		if probeType == livenessProbeType || probeType == startupProbeType {
-			return springBootProbeLivenessPath
-		}
-		// must be readiness probe based on available probe types
-		return springBootProbeReadinessPath
-	}
-	// default to Quarkus runtime. also must be Quarkus based on available runtimes
+	profile := runtimeprofile.GetRegistry().GetProfile(runtimeType)
 	if probeType == livenessProbeType || probeType == startupProbeType {
-		return quarkusProbeLivenessPath
+		return profile.GetLivenessPath()
 	}
-	return quarkusProbeReadinessPath
+	// must be readiness probe based on available probe types
+	return profile.GetReadinessPath()
 }
 
 func getDefaultHTTPGetAction(runtimeType api.RuntimeType, probeType ProbeType) *corev1.HTTPGetAction {
@@ -18,6 +18,7 @@ import (
 	"github.com/kiegroup/kogito-operator/apis"
 	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
 	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	"github.com/kiegroup/kogito-operator/core/test"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -39,7 +40,7 @@ func TestGetProbeForKogitoService_EmptyHandler_Quarkus(t *testing.T) {
 	startupProbe := healthCheckProbe.startup
 
 	assert.Nil(t, readinessProbe.ProbeHandler.TCPSocket)
-	assert.Equal(t, quarkusProbeReadinessPath, readinessProbe.ProbeHandler.HTTPGet.Path)
+	assert.Equal(t, "/q/health/ready", readinessProbe.ProbeHandler.HTTPGet.Path)
 	assert.Equal(t, intstr.IntOrString{IntVal: int32(framework.DefaultExposedPort)}, readinessProbe.ProbeHandler.HTTPGet.Port)
 	assert.Equal(t, corev1.URISchemeHTTP, readinessProbe.ProbeHandler.HTTPGet.Scheme)
 	assert.Equal(t, int32(1), readinessProbe.TimeoutSeconds)
@@ -48,7 +49,7 @@ func TestGetProbeForKogitoService_EmptyHandler_Quarkus(t *testing.T) {
 	assert.Equal(t, int32(3), readinessProbe.FailureThreshold)
 
 	assert.Nil(t, livenessProbe.ProbeHandler.TCPSocket)
-	assert.Equal(t, quarkusProbeLivenessPath, livenessProbe.ProbeHandler.HTTPGet.Path)
+	assert.Equal(t, "/q/health/live", livenessProbe.ProbeHandler.HTTPGet.Path)
 	assert.Equal(t, intstr.IntOrString{IntVal: int32(framework.DefaultExposedPort)}, livenessProbe.ProbeHandler.HTTPGet.Port)
 	assert.Equal(t, corev1.URISchemeHTTP, livenessProbe.ProbeHandler.HTTPGet.Scheme)
 	assert.Equal(t, int32(1), livenessProbe.TimeoutSeconds)
@@ -57,7 +58,7 @@ func TestGetProbeForKogitoService_EmptyHandler_Quarkus(t *testing.T) {
 	assert.Equal(t, int32(3), livenessProbe.FailureThreshold)
 
 	assert.Nil(t, startupProbe.ProbeHandler.TCPSocket)
-	assert.Equal(t, quarkusProbeLivenessPath, startupProbe.ProbeHandler.HTTPGet.Path)
+	assert.Equal(t, "/q/health/live", startupProbe.ProbeHandler.HTTPGet.Path)
 	assert.Equal(t, intstr.IntOrString{IntVal: int32(framework.DefaultExposedPort)}, startupProbe.ProbeHandler.HTTPGet.Port)
 	assert.Equal(t, corev1.URISchemeHTTP, startupProbe.ProbeHandler.HTTPGet.Scheme)
 	assert.Equal(t, int32(1), startupProbe.TimeoutSeconds)
@@ -86,17 +87,17 @@ func TestGetProbeForKogitoService_DefaultHTTP_SpringBoot(t *testing.T) {
 	startupProbe := healthCheckProbe.startup
 
 	assert.Nil(t, livenessProbe.ProbeHandler.TCPSocket)
-	assert.Equal(t, springBootProbeLivenessPath, livenessProbe.ProbeHandler.HTTPGet.Path)
+	assert.Equal(t, "/actuator/health/liveness", livenessProbe.ProbeHandler.HTTPGet.Path)
 	assert.Equal(t, intstr.IntOrString{IntVal: customProbePort}, livenessProbe.ProbeHandler.HTTPGet.Port)
 	assert.Equal(t, corev1.URISchemeHTTP, livenessProbe.ProbeHandler.HTTPGet.Scheme)
 
 	assert.Nil(t, readinessProbe.ProbeHandler.TCPSocket)
-	assert.Equal(t, springBootProbeReadinessPath, readinessProbe.ProbeHandler.HTTPGet.Path)
+	assert.Equal(t, "/actuator/health/readiness", readinessProbe.ProbeHandler.HTTPGet.Path)
 	assert.Equal(t, intstr.IntOrString{IntVal: customProbePort}, readinessProbe.ProbeHandler.HTTPGet.Port)
 	assert.Equal(t, corev1.URISchemeHTTP, readinessProbe.ProbeHandler.HTTPGet.Scheme)
 
 	assert.Nil(t, startupProbe.ProbeHandler.TCPSocket)
-	assert.Equal(t, springBootProbeLivenessPath, startupProbe.ProbeHandler.HTTPGet.Path)
+	assert.Equal(t, "/actuator/health/liveness", startupProbe.ProbeHandler.HTTPGet.Path)
 	assert.Equal(t, intstr.IntOrString{IntVal: customProbePort}, startupProbe.ProbeHandler.HTTPGet.Port)
 	assert.Equal(t, corev1.URISchemeHTTP, startupProbe.ProbeHandler.HTTPGet.Scheme)
 }
@@ -210,3 +211,23 @@ func TestGetProbeForKogitoService_CustomTCP_Quarkus(t *testing.T) {
 	assert.Equal(t, intstr.IntOrString{IntVal: int32(customProbePort)}, healthCheckProbe.liveness.ProbeHandler.TCPSocket.Port)
 	assert.Equal(t, intstr.IntOrString{IntVal: int32(customProbePort)}, healthCheckProbe.startup.ProbeHandler.TCPSocket.Port)
 }
+
+func TestGetProbeForKogitoService_DefaultHTTP_RuntimeProfile(t *testing.T) {
+	registry := runtimeprofile.DefaultRegistry()
+	assert.NoError(t, registry.Register(&runtimeprofile.Profile{
+		Name:        "micronaut",
+		Probes:      runtimeprofile.Probes{LivenessPath: "/health/liveness", ReadinessPath: "/health/readiness"},
+		MetricsPath: "/prometheus",
+	}))
+	runtimeprofile.SetRegistry(registry)
+	defer runtimeprofile.SetRegistry(runtimeprofile.DefaultRegistry())
+
+	service := test.CreateFakeKogitoRuntime(t.Name())
+	service.Spec.Runtime = "micronaut"
+	healthCheckProbe := getProbeForKogitoService(service)
+
+	assert.Equal(t, "/health/liveness", healthCheckProbe.liveness.ProbeHandler.HTTPGet.Path)
+	assert.Equal(t, "/health/readiness", healthCheckProbe.readiness.ProbeHandler.HTTPGet.Path)
+	assert.Equal(t, "/health/liveness", healthCheckProbe.startup.ProbeHandler.HTTPGet.Path)
+	assert.Equal(t, "/prometheus", getMonitoringPath(service.GetSpec().GetMonitoring(), service))
+}
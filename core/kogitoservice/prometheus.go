@@ -16,20 +16,23 @@ package kogitoservice
 
 import (
 	"net/http"
+	"reflect"
 
+	"github.com/RHsyseng/operator-utils/pkg/resource/compare"
 	api "github.com/kiegroup/kogito-operator/apis"
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
 	"github.com/kiegroup/kogito-operator/core/framework"
 	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const prometheusServerGroup = "monitoring.coreos.com"
-
 // PrometheusManager ...
 type PrometheusManager interface {
 	ConfigurePrometheus(kogitoService api.KogitoService) error
@@ -69,7 +72,7 @@ func (m *prometheusManager) ConfigurePrometheus(kogitoService api.KogitoService)
 		return err
 	}
 	if prometheusAddOnAvailable {
-		if err := m.createPrometheusServiceMonitorIfNotExists(kogitoService); err != nil {
+		if err := m.reconcileServiceMonitor(kogitoService); err != nil {
 			return err
 		}
 	}
@@ -78,7 +81,7 @@ func (m *prometheusManager) ConfigurePrometheus(kogitoService api.KogitoService)
 
 // isPrometheusAvailable checks if Prometheus CRD is available in the cluster
 func (m *prometheusManager) isPrometheusAvailable() bool {
-	return m.Client.HasServerGroup(prometheusServerGroup)
+	return m.Client.HasCapability(kogitocli.PrometheusCapability)
 }
 
 func (m *prometheusManager) isPrometheusAddOnAvailable(kogitoService api.KogitoService) (bool, error) {
@@ -94,18 +97,29 @@ func (m *prometheusManager) isPrometheusAddOnAvailable(kogitoService api.KogitoS
 	return false, nil
 }
 
-func (m *prometheusManager) createPrometheusServiceMonitorIfNotExists(kogitoService api.KogitoService) error {
-	serviceMonitor, err := m.loadDeployedServiceMonitor(kogitoService.GetName(), kogitoService.GetNamespace())
+// reconcileServiceMonitor creates or updates the ServiceMonitor of the given service, so the changes of its patches are applied as well
+func (m *prometheusManager) reconcileServiceMonitor(kogitoService api.KogitoService) error {
+	requested, err := m.newServiceMonitor(kogitoService)
 	if err != nil {
 		return err
 	}
-	if serviceMonitor == nil {
-		_, err := m.createServiceMonitor(kogitoService)
-		if err != nil {
-			return err
-		}
+	deployed, err := m.loadDeployedServiceMonitor(kogitoService.GetName(), kogitoService.GetNamespace())
+	if err != nil {
+		return err
 	}
-	return nil
+	serviceMonitorType := reflect.TypeOf(monv1.ServiceMonitor{})
+	requestedResources := map[reflect.Type][]client.Object{serviceMonitorType: {requested}}
+	deployedResources := map[reflect.Type][]client.Object{}
+	if deployed != nil {
+		deployedResources[serviceMonitorType] = []client.Object{deployed}
+	}
+	comparator := compare.DefaultComparator()
+	comparator.SetComparator(framework.NewComparatorBuilder().
+		WithType(serviceMonitorType).
+		WithCustomComparator(framework.CreateServiceMonitorComparator()).
+		Build())
+	_, err = infrastructure.NewDeltaProcessor(m.Context).ProcessDelta(compare.MapComparator{Comparator: comparator}, requestedResources, deployedResources)
+	return err
 }
 
 func (m *prometheusManager) loadDeployedServiceMonitor(instanceName, namespace string) (*monv1.ServiceMonitor, error) {
@@ -123,8 +137,8 @@ func (m *prometheusManager) loadDeployedServiceMonitor(instanceName, namespace s
 	}
 }
 
-// createServiceMonitor create ServiceMonitor used for scraping by prometheus for kogito service
-func (m *prometheusManager) createServiceMonitor(kogitoService api.KogitoService) (*monv1.ServiceMonitor, error) {
+// newServiceMonitor returns the ServiceMonitor used for scraping by prometheus for kogito service
+func (m *prometheusManager) newServiceMonitor(kogitoService api.KogitoService) (*monv1.ServiceMonitor, error) {
 	monitoring := kogitoService.GetSpec().GetMonitoring()
 	endPoint := monv1.Endpoint{}
 	endPoint.Path = getMonitoringPath(monitoring, kogitoService)
@@ -161,8 +175,7 @@ func (m *prometheusManager) createServiceMonitor(kogitoService api.KogitoService
 	if err := framework.SetOwner(kogitoService, m.Scheme, sm); err != nil {
 		return nil, err
 	}
-	if err := kubernetes.ResourceC(m.Client).Create(sm); err != nil {
-		m.Log.Error(err, "Error occurs while creating Service Monitor instance")
+	if err := applyPatches(m.Context, kogitoService, sm); err != nil {
 		return nil, err
 	}
 	return sm, nil
@@ -171,10 +184,7 @@ func (m *prometheusManager) createServiceMonitor(kogitoService api.KogitoService
 func getMonitoringPath(monitoring api.MonitoringInterface, kogitoService api.KogitoService) string {
 	path := monitoring.GetPath()
 	if len(path) == 0 {
-		path = api.MonitoringDefaultPathQuarkus
-		if kogitoService.GetSpec().GetRuntime() == api.SpringBootRuntimeType {
-			path = api.MonitoringDefaultPathSpringboot
-		}
+		path = runtimeprofile.GetRegistry().GetProfile(kogitoService.GetSpec().GetRuntime()).GetMetricsPath()
 	}
 	return path
 }
@@ -25,7 +25,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_createServiceMonitor_defaultConfiguration(t *testing.T) {
+func Test_newServiceMonitor_defaultConfiguration(t *testing.T) {
 	ns := t.Name()
 	cli := test.NewFakeClientBuilder().Build()
 	kogitoService := test.CreateFakeKogitoRuntime(ns)
@@ -35,13 +35,13 @@ func Test_createServiceMonitor_defaultConfiguration(t *testing.T) {
 		Scheme: meta.GetRegisteredSchema(),
 	}
 	monitoringManager := prometheusManager{Context: context}
-	serviceMonitor, err := monitoringManager.createServiceMonitor(kogitoService)
+	serviceMonitor, err := monitoringManager.newServiceMonitor(kogitoService)
 	assert.NoError(t, err)
 	assert.Equal(t, api.MonitoringDefaultPathQuarkus, serviceMonitor.Spec.Endpoints[0].Path)
 	assert.Equal(t, api.MonitoringDefaultScheme, serviceMonitor.Spec.Endpoints[0].Scheme)
 }
 
-func Test_createServiceMonitor_defaultSpringConfiguration(t *testing.T) {
+func Test_newServiceMonitor_defaultSpringConfiguration(t *testing.T) {
 	ns := t.Name()
 	cli := test.NewFakeClientBuilder().Build()
 	kogitoService := test.CreateFakeKogitoRuntime(ns)
@@ -52,12 +52,12 @@ func Test_createServiceMonitor_defaultSpringConfiguration(t *testing.T) {
 	}
 	kogitoService.Spec.Runtime = api.SpringBootRuntimeType
 	monitoringManager := prometheusManager{Context: context}
-	serviceMonitor, err := monitoringManager.createServiceMonitor(kogitoService)
+	serviceMonitor, err := monitoringManager.newServiceMonitor(kogitoService)
 	assert.NoError(t, err)
 	assert.Equal(t, api.MonitoringDefaultPathSpringboot, serviceMonitor.Spec.Endpoints[0].Path)
 }
 
-func Test_createServiceMonitor_customConfiguration(t *testing.T) {
+func Test_newServiceMonitor_customConfiguration(t *testing.T) {
 	ns := t.Name()
 	cli := test.NewFakeClientBuilder().Build()
 	kogitoService := test.CreateFakeKogitoRuntime(ns)
@@ -71,8 +71,29 @@ func Test_createServiceMonitor_customConfiguration(t *testing.T) {
 		Scheme: meta.GetRegisteredSchema(),
 	}
 	monitoringManager := prometheusManager{Context: context}
-	serviceMonitor, err := monitoringManager.createServiceMonitor(kogitoService)
+	serviceMonitor, err := monitoringManager.newServiceMonitor(kogitoService)
 	assert.NoError(t, err)
 	assert.Equal(t, "/testPath", serviceMonitor.Spec.Endpoints[0].Path)
 	assert.Equal(t, "https", serviceMonitor.Spec.Endpoints[0].Scheme)
 }
+
+func Test_reconcileServiceMonitor_patchesAppliedAfterCreation(t *testing.T) {
+	ns := t.Name()
+	cli := test.NewFakeClientBuilder().Build()
+	kogitoService := test.CreateFakeKogitoRuntime(ns)
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	monitoringManager := prometheusManager{Context: context}
+	assert.NoError(t, monitoringManager.reconcileServiceMonitor(kogitoService))
+
+	kogitoService.Spec.Patches = []v1beta1.KogitoPatch{
+		{Kind: "ServiceMonitor", Name: kogitoService.Name, Type: api.MergePatchType, Patch: `{"spec":{"endpoints":[{"path":"/q/metrics","interval":"10s"}]}}`},
+	}
+	assert.NoError(t, monitoringManager.reconcileServiceMonitor(kogitoService))
+	serviceMonitor, err := monitoringManager.loadDeployedServiceMonitor(kogitoService.Name, ns)
+	assert.NoError(t, err)
+	assert.Equal(t, "10s", serviceMonitor.Spec.Endpoints[0].Interval)
+}
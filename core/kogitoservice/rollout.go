@@ -0,0 +1,627 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoservice
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/introspection"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	routev1 "github.com/openshift/api/route/v1"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// RevisionLabel identifies the revision of the pods of a KogitoRuntime rolled out with the BlueGreen or Canary strategy
+	RevisionLabel = "kogito.kie.org/revision"
+	// PromoteRevisionAnnotation promotes the candidate revision of a KogitoRuntime waiting for a manual promotion, its value must be the candidate revision
+	PromoteRevisionAnnotation = "kogito.kie.org/promote-revision"
+
+	candidateSuffix     = "-next"
+	canaryIngressSuffix = "-canary"
+	revisionLength      = 10
+	// requestsMetric counts the HTTP requests served by Quarkus and Spring Boot services through Micrometer, labeled by status code
+	requestsMetric       = "http_server_requests_seconds"
+	requestsStatusLabel  = "status"
+	requestsProbeName    = "requests"
+	canaryAnnotation     = "nginx.ingress.kubernetes.io/canary"
+	canaryWeightAnnotate = "nginx.ingress.kubernetes.io/canary-weight"
+	maxWeight            = int32(100)
+)
+
+// GetCandidateName returns the name of the Deployment and Service running the candidate revision of the given service
+func GetCandidateName(serviceName string) string {
+	return serviceName + candidateSuffix
+}
+
+// getRolloutRuntime returns the given service as KogitoRuntime when it's rolled out with the BlueGreen or Canary strategy
+func getRolloutRuntime(instance api.KogitoService) (api.KogitoRuntimeInterface, bool) {
+	runtime, ok := instance.(api.KogitoRuntimeInterface)
+	if !ok || runtime.GetRuntimeSpec().GetRollout().GetStrategy() == api.RollingUpdateRolloutStrategy {
+		return nil, false
+	}
+	return runtime, true
+}
+
+// hasCandidate verifies if the candidate revision of the given service must be running
+func hasCandidate(instance api.KogitoService) (api.KogitoRuntimeInterface, bool) {
+	runtime, ok := getRolloutRuntime(instance)
+	if !ok {
+		return nil, false
+	}
+	status := runtime.GetRuntimeStatus().GetRollout()
+	switch status.GetPhase() {
+	case api.ProgressingRolloutPhase, api.AnalyzingRolloutPhase, api.PausedRolloutPhase, api.PromotingRolloutPhase:
+		return runtime, len(status.GetCandidateRevision()) > 0
+	}
+	return nil, false
+}
+
+// rolloutReconciler drives the rollout of a new revision of a KogitoRuntime with the BlueGreen or Canary strategy.
+// The Deployment of the service keeps running the stable revision while the candidate revision runs in its own Deployment.
+// Once the candidate is ready and its error rate is below the maximum, the Service selects it and the Deployment is updated,
+// otherwise the candidate is removed and the rollout aborted.
+type rolloutReconciler struct {
+	operator.Context
+	instance api.KogitoRuntimeInterface
+	// requests reads the requests served by the candidate revision, found is false until they're read
+	requests func(instance api.KogitoService) (sample *requestsSample, found bool, err error)
+}
+
+// requestsSample counts the HTTP requests served by a revision since its pods started
+type requestsSample struct {
+	Requests     int64
+	ServerErrors int64
+}
+
+func newRolloutReconciler(context operator.Context, instance api.KogitoRuntimeInterface, introspector *introspection.Introspector) *rolloutReconciler {
+	return &rolloutReconciler{
+		Context:  context,
+		instance: instance,
+		requests: func(instance api.KogitoService) (*requestsSample, bool, error) {
+			return fetchCandidateRequests(introspector, instance)
+		},
+	}
+}
+
+// reconcileDeployments replaces the requested Deployment with the Deployments required by the current rollout phase
+func (r *rolloutReconciler) reconcileDeployments(requestedResources map[reflect.Type][]client.Object, deployedResources map[reflect.Type][]client.Object) error {
+	deploymentType := reflect.TypeOf(appsv1.Deployment{})
+	if len(requestedResources[deploymentType]) == 0 {
+		return nil
+	}
+	primary := requestedResources[deploymentType][0].(*appsv1.Deployment)
+	var deployedPrimary, deployedCandidate *appsv1.Deployment
+	for _, deployed := range deployedResources[deploymentType] {
+		if deployed.GetName() == primary.Name {
+			deployedPrimary = deployed.(*appsv1.Deployment)
+		} else if deployed.GetName() == GetCandidateName(primary.Name) {
+			deployedCandidate = deployed.(*appsv1.Deployment)
+		}
+	}
+	if r.instance.GetRuntimeSpec().GetRollout().GetStrategy() == api.RollingUpdateRolloutStrategy {
+		r.reset()
+		if deployedPrimary == nil {
+			return nil
+		}
+		revision, err := getTemplateRevision(primary)
+		if err != nil {
+			return err
+		}
+		requested, _ := switchSelector(primary, newCandidateDeployment(primary, revision), deployedPrimary, deployedCandidate)
+		requestedResources[deploymentType] = requested
+		return r.recreateOnSelectorChange(requested[0].(*appsv1.Deployment), deployedPrimary, deployedResources)
+	}
+	revision, err := addRevision(primary)
+	if err != nil {
+		return err
+	}
+	candidate := newCandidateDeployment(primary, revision)
+	stableRevision := ""
+	if deployedPrimary != nil {
+		stableRevision = deployedPrimary.Spec.Template.Labels[RevisionLabel]
+	}
+	status := r.instance.GetRuntimeStatus().GetRollout()
+
+	requested := []client.Object{primary}
+	switch {
+	case deployedPrimary == nil:
+		// first deployment, there's no previous revision to keep serving
+		r.setStable(revision)
+	case len(stableRevision) == 0:
+		// the strategy was just enabled, the Service selects the pods of both Deployments until the Deployment selects the revision
+		var switched bool
+		if requested, switched = switchSelector(primary, candidate, deployedPrimary, deployedCandidate); switched {
+			status.SetCandidateRevision(revision)
+			r.setPhase(api.PromotingRolloutPhase, revision, revision, fmt.Sprintf("Revision %s is promoted", revision))
+		} else {
+			status.SetCandidateRevision(revision)
+			r.setPhase(api.ProgressingRolloutPhase, "", "", fmt.Sprintf("Revision %s is being deployed before the Deployment selects it", revision))
+		}
+	case stableRevision == revision:
+		if status.GetPhase() == api.PromotingRolloutPhase && !isDeploymentRolledOut(deployedPrimary) {
+			// the candidate keeps serving until the Deployment runs the promoted revision
+			requested = append(requested, candidate)
+		} else {
+			r.setStable(revision)
+		}
+	case status.GetAbortedRevision() == revision:
+		requested = []client.Object{deployedPrimary.DeepCopy()}
+		r.setPhase(api.AbortedRolloutPhase, stableRevision, stableRevision,
+			fmt.Sprintf("Revision %s was aborted, change the service to roll out a new revision", revision))
+	case status.GetPhase() == api.PromotingRolloutPhase && status.GetCandidateRevision() == revision:
+		requested = append(requested, candidate)
+	default:
+		switch r.analyze(deployedCandidate, stableRevision, revision) {
+		case api.PromotingRolloutPhase:
+			requested = append(requested, candidate)
+		case api.AbortedRolloutPhase:
+			requested = []client.Object{deployedPrimary.DeepCopy()}
+		default:
+			requested = []client.Object{deployedPrimary.DeepCopy(), candidate}
+		}
+	}
+	requestedResources[deploymentType] = requested
+	return r.recreateOnSelectorChange(requested[0].(*appsv1.Deployment), deployedPrimary, deployedResources)
+}
+
+// switchSelector keeps the service available while the selector of its Deployment changes, eg: when the strategy changed.
+// The selector of a Deployment is immutable, so the candidate Deployment runs the requested revision first, then the Deployment is
+// recreated with the requested selector while the candidate keeps serving until the Deployment is rolled out.
+// Returns the Deployments to request, and whether the Deployment can be recreated with the requested selector.
+func switchSelector(primary, candidate, deployedPrimary, deployedCandidate *appsv1.Deployment) ([]client.Object, bool) {
+	if getSelectorRevision(primary) == getSelectorRevision(deployedPrimary) {
+		if deployedCandidate != nil && !isDeploymentRolledOut(deployedPrimary) {
+			return []client.Object{primary, candidate}, true
+		}
+		return []client.Object{primary}, true
+	}
+	if !isDeploymentRolledOut(deployedCandidate) || deployedCandidate.Annotations[RevisionLabel] != candidate.Annotations[RevisionLabel] {
+		return []client.Object{deployedPrimary.DeepCopy(), candidate}, false
+	}
+	return []client.Object{primary, candidate}, true
+}
+
+// recreateOnSelectorChange deletes the deployed Deployment of the service when the revision of its selector differs from the requested one,
+// eg: once the promoted revision is rolled out or the strategy changed, since the selector of a Deployment is immutable.
+// The Deployment is created again with the requested selector, while the candidate keeps serving the requested revision.
+func (r *rolloutReconciler) recreateOnSelectorChange(requested, deployed *appsv1.Deployment, deployedResources map[reflect.Type][]client.Object) error {
+	if deployed == nil || getSelectorRevision(requested) == getSelectorRevision(deployed) {
+		return nil
+	}
+	r.Log.Info("Recreating the Deployment to change its selector", "deployment", deployed.Name)
+	if err := kubernetes.ResourceC(r.Client).Delete(deployed); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	deploymentType := reflect.TypeOf(appsv1.Deployment{})
+	var remaining []client.Object
+	for _, object := range deployedResources[deploymentType] {
+		if object.GetName() != deployed.Name {
+			remaining = append(remaining, object)
+		}
+	}
+	deployedResources[deploymentType] = remaining
+	return nil
+}
+
+// analyze moves the rollout of the candidate revision forward and returns the new phase
+func (r *rolloutReconciler) analyze(deployedCandidate *appsv1.Deployment, stableRevision, revision string) api.RolloutPhase {
+	rollout := r.instance.GetRuntimeSpec().GetRollout()
+	status := r.instance.GetRuntimeStatus().GetRollout()
+	if status.GetCandidateRevision() != revision {
+		status.SetCandidateRevision(revision)
+		resetAnalysis(status)
+	}
+	if !isDeploymentRolledOut(deployedCandidate) {
+		if isProgressDeadlineExceeded(deployedCandidate) {
+			return r.abort(stableRevision, revision, fmt.Sprintf("Revision %s didn't become ready before the progress deadline", revision))
+		}
+		return r.setPhase(api.ProgressingRolloutPhase, stableRevision, stableRevision, fmt.Sprintf("Revision %s is being deployed", revision))
+	}
+	if status.GetCandidateReadyTime() == nil {
+		now := metav1.Now()
+		status.SetCandidateReadyTime(&now)
+	}
+	// the Service keeps selecting the stable revision of a blue/green rollout, the candidate only serves the requests sent to its own
+	// Service: it's promoted once ready for the analysis duration, unless it answered these requests with too many server errors
+	blueGreen := rollout.GetStrategy() == api.BlueGreenRolloutStrategy
+	sample, found, err := r.requests(r.instance)
+	if err != nil {
+		r.Log.Warn("Failed to read the requests served by the candidate revision", "revision", revision, "error", err)
+		if !blueGreen {
+			return r.setPhase(api.AnalyzingRolloutPhase, stableRevision, stableRevision, fmt.Sprintf("Revision %s is ready, waiting for its error rate: %v", revision, err))
+		}
+	} else if !found && !blueGreen {
+		return r.setPhase(api.AnalyzingRolloutPhase, stableRevision, stableRevision, fmt.Sprintf("Revision %s is ready, waiting for its metrics", revision))
+	}
+	if !found {
+		sample = nil
+	}
+	if status.GetAnalysisStartTime() == nil || (sample != nil && sample.Requests < status.GetAnalysisStartRequests()) {
+		// the window starts with the first sample, or again once the counters were reset by a restart of the candidate
+		now := metav1.Now()
+		status.SetAnalysisStartTime(&now)
+		if sample != nil {
+			status.SetAnalysisStartRequests(sample.Requests)
+			status.SetAnalysisStartServerErrors(sample.ServerErrors)
+		}
+	}
+	analysis := time.Duration(rollout.GetAnalysisSeconds()) * time.Second
+	if time.Since(status.GetAnalysisStartTime().Time) < analysis {
+		return r.setPhase(api.AnalyzingRolloutPhase, stableRevision, stableRevision, fmt.Sprintf("Revision %s is ready, analyzing its error rate", revision))
+	}
+	var requests int64
+	if sample != nil {
+		requests = sample.Requests - status.GetAnalysisStartRequests()
+	}
+	if requests <= 0 && !blueGreen {
+		// an empty sample is inconclusive, the analysis goes on until the candidate serves requests
+		return r.setPhase(api.AnalyzingRolloutPhase, stableRevision, stableRevision, fmt.Sprintf("Revision %s didn't serve any request yet, waiting for its error rate", revision))
+	}
+	if requests > 0 {
+		errorRate := float64(sample.ServerErrors-status.GetAnalysisStartServerErrors()) / float64(requests) * 100
+		if errorRate > float64(rollout.GetMaxErrorRate()) {
+			return r.abort(stableRevision, revision,
+				fmt.Sprintf("Revision %s answered %.1f%% of the requests with a server error, above the maximum of %d%%", revision, errorRate, rollout.GetMaxErrorRate()))
+		}
+	}
+	if rollout.IsAutoPromotionDisabled() && r.instance.GetAnnotations()[PromoteRevisionAnnotation] != revision {
+		return r.setPhase(api.PausedRolloutPhase, stableRevision, stableRevision,
+			fmt.Sprintf("Revision %s passed the analysis, annotate the service with %s=%s to promote it", revision, PromoteRevisionAnnotation, revision))
+	}
+	return r.setPhase(api.PromotingRolloutPhase, stableRevision, revision, fmt.Sprintf("Revision %s is promoted", revision))
+}
+
+func (r *rolloutReconciler) abort(stableRevision, revision, message string) api.RolloutPhase {
+	r.Log.Info("Aborting rollout", "revision", revision, "reason", message)
+	status := r.instance.GetRuntimeStatus().GetRollout()
+	status.SetAbortedRevision(revision)
+	status.SetCandidateRevision("")
+	resetAnalysis(status)
+	return r.setPhase(api.AbortedRolloutPhase, stableRevision, stableRevision, message)
+}
+
+func (r *rolloutReconciler) setStable(revision string) {
+	status := r.instance.GetRuntimeStatus().GetRollout()
+	status.SetCandidateRevision("")
+	resetAnalysis(status)
+	r.setPhase(api.StableRolloutPhase, revision, revision, fmt.Sprintf("Revision %s is rolled out", revision))
+}
+
+func (r *rolloutReconciler) setPhase(phase api.RolloutPhase, stableRevision, activeRevision, message string) api.RolloutPhase {
+	status := r.instance.GetRuntimeStatus().GetRollout()
+	status.SetPhase(phase)
+	status.SetStableRevision(stableRevision)
+	status.SetActiveRevision(activeRevision)
+	status.SetCanaryWeight(0)
+	if r.instance.GetRuntimeSpec().GetRollout().GetStrategy() == api.CanaryRolloutStrategy &&
+		(phase == api.AnalyzingRolloutPhase || phase == api.PausedRolloutPhase) {
+		status.SetCanaryWeight(r.instance.GetRuntimeSpec().GetRollout().GetCanaryWeight())
+	}
+	conditionStatus := metav1.ConditionFalse
+	if phase == api.StableRolloutPhase {
+		conditionStatus = metav1.ConditionTrue
+	}
+	if r.instance.GetStatus().GetConditions() == nil {
+		r.instance.GetStatus().SetConditions(&[]metav1.Condition{})
+	}
+	meta.SetStatusCondition(r.instance.GetStatus().GetConditions(), metav1.Condition{
+		Type:    string(api.RolloutConditionType),
+		Status:  conditionStatus,
+		Reason:  string(phase),
+		Message: message,
+	})
+	return phase
+}
+
+// reset clears the rollout status once the service is back to rolling updates
+func (r *rolloutReconciler) reset() {
+	status := r.instance.GetRuntimeStatus().GetRollout()
+	status.SetPhase("")
+	status.SetStableRevision("")
+	status.SetCandidateRevision("")
+	status.SetActiveRevision("")
+	status.SetAbortedRevision("")
+	resetAnalysis(status)
+	status.SetCanaryWeight(0)
+	if r.instance.GetStatus().GetConditions() != nil {
+		meta.RemoveStatusCondition(r.instance.GetStatus().GetConditions(), string(api.RolloutConditionType))
+	}
+}
+
+func getSelectorRevision(deployment *appsv1.Deployment) string {
+	if deployment.Spec.Selector == nil {
+		return ""
+	}
+	return deployment.Spec.Selector.MatchLabels[RevisionLabel]
+}
+
+func resetAnalysis(status api.RolloutStatusInterface) {
+	status.SetCandidateReadyTime(nil)
+	status.SetAnalysisStartTime(nil)
+	status.SetAnalysisStartRequests(0)
+	status.SetAnalysisStartServerErrors(0)
+}
+
+// addRevision labels the pod template of the given Deployment with the hash of its content and adds it to its selector,
+// so the Deployment doesn't select the pods of the candidate revision
+func addRevision(deployment *appsv1.Deployment) (string, error) {
+	revision, err := getTemplateRevision(deployment)
+	if err != nil {
+		return "", err
+	}
+	// the template labels can be shared with the Deployment labels
+	labels := make(map[string]string, len(deployment.Spec.Template.Labels)+1)
+	for key, value := range deployment.Spec.Template.Labels {
+		labels[key] = value
+	}
+	labels[RevisionLabel] = revision
+	deployment.Spec.Template.Labels = labels
+	selector := &metav1.LabelSelector{}
+	if deployment.Spec.Selector != nil {
+		selector = deployment.Spec.Selector.DeepCopy()
+	}
+	selector.MatchLabels = withRevision(selector.MatchLabels, revision)
+	deployment.Spec.Selector = selector
+	return revision, nil
+}
+
+// getTemplateRevision returns the hash of the pod template of the given Deployment
+func getTemplateRevision(deployment *appsv1.Deployment) (string, error) {
+	template, err := json.Marshal(deployment.Spec.Template)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", md5.Sum(template))[:revisionLength], nil
+}
+
+// newCandidateDeployment returns the Deployment running the given revision of the given Deployment, annotated with the revision
+func newCandidateDeployment(primary *appsv1.Deployment, revision string) *appsv1.Deployment {
+	candidate := primary.DeepCopy()
+	candidate.Name = GetCandidateName(primary.Name)
+	if candidate.Annotations == nil {
+		candidate.Annotations = map[string]string{}
+	}
+	candidate.Annotations[RevisionLabel] = revision
+	return candidate
+}
+
+func isDeploymentRolledOut(deployment *appsv1.Deployment) bool {
+	if deployment == nil || deployment.Status.ObservedGeneration < deployment.Generation {
+		return false
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.UpdatedReplicas >= replicas && deployment.Status.AvailableReplicas >= replicas
+}
+
+func isProgressDeadlineExceeded(deployment *appsv1.Deployment) bool {
+	if deployment == nil {
+		return false
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchCandidateRequests reads the HTTP requests served by the candidate revision, polled in background by the given introspector
+func fetchCandidateRequests(introspector *introspection.Introspector, instance api.KogitoService) (*requestsSample, bool, error) {
+	serverURL := fmt.Sprintf("%s://%s.%s", httpScheme, GetCandidateName(instance.GetName()), instance.GetNamespace())
+	path := getMonitoringPath(instance.GetSpec().GetMonitoring(), instance)
+	result, found, err := introspect(introspector, instance, serverURL, requestsProbeName, func(ctx context.Context, fetcher *introspection.Fetcher) (interface{}, error) {
+		body, err := fetcher.Get(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		if body == nil {
+			return &requestsSample{}, nil
+		}
+		return parseRequests(body)
+	})
+	if err != nil || !found {
+		return nil, false, err
+	}
+	return result.(*requestsSample), true, nil
+}
+
+// parseRequests counts the requests and the server errors from the Micrometer HTTP requests metric in Prometheus text format.
+// The counters are cumulative since the pod started.
+func parseRequests(metrics []byte) (*requestsSample, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(metrics))
+	if err != nil {
+		return nil, err
+	}
+	sample := &requestsSample{}
+	add := func(labels []*dto.LabelPair, count int64) {
+		sample.Requests += count
+		if isServerError(labels) {
+			sample.ServerErrors += count
+		}
+	}
+	if family, ok := families[requestsMetric]; ok {
+		for _, metric := range family.GetMetric() {
+			add(metric.GetLabel(), int64(metric.GetSummary().GetSampleCount()+metric.GetHistogram().GetSampleCount()))
+		}
+	} else if family, ok := families[requestsMetric+"_count"]; ok {
+		for _, metric := range family.GetMetric() {
+			add(metric.GetLabel(), int64(metric.GetCounter().GetValue()+metric.GetUntyped().GetValue()))
+		}
+	}
+	return sample, nil
+}
+
+func isServerError(labels []*dto.LabelPair) bool {
+	for _, label := range labels {
+		if label.GetName() == requestsStatusLabel {
+			return strings.HasPrefix(label.GetValue(), "5")
+		}
+	}
+	return false
+}
+
+// applyRolloutToService makes the Service select the active revision and adds the Service of the candidate revision
+func applyRolloutToService(instance api.KogitoService, service *corev1.Service) []client.Object {
+	services := []client.Object{service}
+	runtime, ok := getRolloutRuntime(instance)
+	if !ok {
+		return services
+	}
+	status := runtime.GetRuntimeStatus().GetRollout()
+	if len(status.GetActiveRevision()) > 0 {
+		service.Spec.Selector = withRevision(service.Spec.Selector, status.GetActiveRevision())
+	}
+	if _, ok := hasCandidate(instance); ok {
+		candidate := service.DeepCopy()
+		candidate.Name = GetCandidateName(service.Name)
+		candidate.Spec.Selector = withRevision(candidate.Spec.Selector, status.GetCandidateRevision())
+		services = append(services, candidate)
+	}
+	return services
+}
+
+func withRevision(selector map[string]string, revision string) map[string]string {
+	newSelector := map[string]string{RevisionLabel: revision}
+	for key, value := range selector {
+		if key != RevisionLabel {
+			newSelector[key] = value
+		}
+	}
+	return newSelector
+}
+
+// applyRolloutToRoute splits the traffic of the Route between the Service and the candidate Service while a canary is analyzed
+func applyRolloutToRoute(instance api.KogitoService, route *routev1.Route) {
+	weight := getCanaryWeight(instance)
+	if weight == 0 {
+		return
+	}
+	stableWeight := maxWeight - weight
+	route.Spec.To.Weight = &stableWeight
+	route.Spec.AlternateBackends = []routev1.RouteTargetReference{
+		{Kind: infrastructure.KindService.Name, Name: GetCandidateName(instance.GetName()), Weight: &weight},
+	}
+}
+
+func getCanaryWeight(instance api.KogitoService) int32 {
+	runtime, ok := getRolloutRuntime(instance)
+	if !ok || runtime.GetRuntimeSpec().GetRollout().GetStrategy() != api.CanaryRolloutStrategy {
+		return 0
+	}
+	return runtime.GetRuntimeStatus().GetRollout().GetCanaryWeight()
+}
+
+// reconcileCanaryIngress sends a share of the traffic of the Ingress exposing the service to the candidate revision,
+// through an NGINX canary Ingress, while a canary is analyzed on Kubernetes
+func reconcileCanaryIngress(context operator.Context, instance api.KogitoService) error {
+	canaryKey := types.NamespacedName{Name: instance.GetName() + canaryIngressSuffix, Namespace: instance.GetNamespace()}
+	canary := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: canaryKey.Name, Namespace: canaryKey.Namespace}}
+	exists, err := kubernetes.ResourceC(context.Client).Fetch(canary)
+	if err != nil {
+		return err
+	}
+	weight := getCanaryWeight(instance)
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: instance.GetName(), Namespace: instance.GetNamespace()}}
+	if weight > 0 {
+		if found, err := kubernetes.ResourceC(context.Client).Fetch(ingress); err != nil {
+			return err
+		} else if !found {
+			context.Log.Debug("No Ingress exposes the service, skipping the canary Ingress")
+			weight = 0
+		}
+	}
+	if weight == 0 {
+		if exists {
+			return kubernetes.ResourceC(context.Client).Delete(canary)
+		}
+		return nil
+	}
+	canary = &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      canaryKey.Name,
+			Namespace: canaryKey.Namespace,
+			Labels:    map[string]string{framework.LabelAppKey: instance.GetName()},
+			Annotations: map[string]string{
+				canaryAnnotation:     "true",
+				canaryWeightAnnotate: strconv.Itoa(int(weight)),
+			},
+		},
+		Spec: *ingress.Spec.DeepCopy(),
+	}
+	candidateName := GetCandidateName(instance.GetName())
+	if backend := canary.Spec.DefaultBackend; backend != nil && backend.Service != nil && backend.Service.Name == instance.GetName() {
+		backend.Service.Name = candidateName
+	}
+	for _, rule := range canary.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			if service := rule.HTTP.Paths[i].Backend.Service; service != nil && service.Name == instance.GetName() {
+				service.Name = candidateName
+			}
+		}
+	}
+	if err := framework.SetOwner(instance, context.Scheme, canary); err != nil {
+		return err
+	}
+	if err := kubernetes.ResourceC(context.Client).Apply(canary); err != nil {
+		if errors.IsConflict(err) {
+			return infrastructure.ErrorForFieldManagerConflict("Ingress", canary.Name, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetRolloutRequeueAfter returns when the given service must be reconciled again to move the rollout of a new revision forward,
+// zero if no rollout is in progress. The phase of the rollout is reported by the Rollout condition.
+func GetRolloutRequeueAfter(instance api.KogitoService) time.Duration {
+	runtime, ok := getRolloutRuntime(instance)
+	if !ok {
+		return 0
+	}
+	switch runtime.GetRuntimeStatus().GetRollout().GetPhase() {
+	case api.ProgressingRolloutPhase, api.AnalyzingRolloutPhase, api.PromotingRolloutPhase:
+		return infrastructure.ReconciliationAfterTen
+	}
+	return 0
+}
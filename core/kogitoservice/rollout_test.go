@@ -0,0 +1,343 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoservice
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/meta"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var deploymentType = reflect.TypeOf(appsv1.Deployment{})
+
+func newRolloutTestDeployment(name string, value string) *appsv1.Deployment {
+	replicas := int32(1)
+	labels := map[string]string{framework.LabelAppKey: name}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{framework.LabelAppKey: name}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: name, Image: "image", Env: []corev1.EnvVar{{Name: "VERSION", Value: value}}}},
+				},
+			},
+		},
+	}
+}
+
+// rolledOut returns the given requested Deployment as the API server reports it once its pods are available
+func rolledOut(requested client.Object) *appsv1.Deployment {
+	deployment := requested.(*appsv1.Deployment).DeepCopy()
+	deployment.Status = appsv1.DeploymentStatus{UpdatedReplicas: 1, AvailableReplicas: 1}
+	return deployment
+}
+
+// newRolloutTestReconciler returns a reconciler reading the given requests served by the candidate, a nil sample is not read yet
+func newRolloutTestReconciler(instance *v1beta1.KogitoRuntime, sample *requestsSample) *rolloutReconciler {
+	context := operator.Context{Client: test.NewFakeClientBuilder().Build(), Log: test.TestLogger, Scheme: meta.GetRegisteredSchema()}
+	r := newRolloutReconciler(context, instance, nil)
+	r.requests = func(instance api.KogitoService) (*requestsSample, bool, error) {
+		if sample == nil {
+			return nil, false, nil
+		}
+		copied := *sample
+		return &copied, true, nil
+	}
+	return r
+}
+
+// endAnalysisWindow moves the start of the analysis window of the candidate before the analysis duration
+func endAnalysisWindow(status api.RolloutStatusInterface) {
+	startTime := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	status.SetAnalysisStartTime(&startTime)
+}
+
+func reconcileRolloutDeployments(t *testing.T, r *rolloutReconciler, value string, deployed ...client.Object) []client.Object {
+	requested := map[reflect.Type][]client.Object{deploymentType: {newRolloutTestDeployment(r.instance.GetName(), value)}}
+	assert.NoError(t, r.reconcileDeployments(requested, map[reflect.Type][]client.Object{deploymentType: deployed}))
+	return requested[deploymentType]
+}
+
+func TestRolloutReconciler_BlueGreen(t *testing.T) {
+	instance := test.CreateFakeKogitoRuntime(t.Name())
+	instance.Spec.Rollout.Strategy = api.BlueGreenRolloutStrategy
+	sample := &requestsSample{Requests: 20}
+	r := newRolloutTestReconciler(instance, sample)
+	status := instance.GetRuntimeStatus().GetRollout()
+
+	// first revision is deployed right away
+	requested := reconcileRolloutDeployments(t, r, "v1")
+	assert.Len(t, requested, 1)
+	assert.Equal(t, api.StableRolloutPhase, status.GetPhase())
+	stable := rolledOut(requested[0])
+	stableRevision := stable.Spec.Template.Labels[RevisionLabel]
+	assert.NotEmpty(t, stableRevision)
+	assert.Equal(t, stableRevision, status.GetActiveRevision())
+	assert.Equal(t, stable.Spec.Selector.MatchLabels[RevisionLabel], stableRevision)
+
+	// a new revision runs next to the stable one
+	requested = reconcileRolloutDeployments(t, r, "v2", stable)
+	assert.Len(t, requested, 2)
+	assert.Equal(t, stable.Spec.Template, requested[0].(*appsv1.Deployment).Spec.Template)
+	candidate := requested[1].(*appsv1.Deployment)
+	assert.Equal(t, GetCandidateName(instance.Name), candidate.Name)
+	assert.Equal(t, status.GetCandidateRevision(), candidate.Spec.Selector.MatchLabels[RevisionLabel])
+	assert.Equal(t, api.ProgressingRolloutPhase, status.GetPhase())
+	assert.Equal(t, stableRevision, status.GetActiveRevision())
+
+	// the candidate is analyzed once ready, over the requests served since the analysis started
+	requested = reconcileRolloutDeployments(t, r, "v2", stable, rolledOut(candidate))
+	assert.Len(t, requested, 2)
+	assert.Equal(t, api.AnalyzingRolloutPhase, status.GetPhase())
+	assert.Equal(t, int64(20), status.GetAnalysisStartRequests())
+	assert.Equal(t, infrastructure.ReconciliationAfterTen, GetRolloutRequeueAfter(instance))
+
+	// the Service doesn't send any request to the candidate, it's promoted once ready for the analysis duration
+	endAnalysisWindow(status)
+	requested = reconcileRolloutDeployments(t, r, "v2", stable, rolledOut(candidate))
+	assert.Len(t, requested, 2)
+	assert.Equal(t, api.PromotingRolloutPhase, status.GetPhase())
+	candidateRevision := status.GetCandidateRevision()
+	assert.Equal(t, candidateRevision, status.GetActiveRevision())
+	assert.Equal(t, candidateRevision, requested[0].(*appsv1.Deployment).Spec.Template.Labels[RevisionLabel])
+	assert.Equal(t, candidateRevision, requested[0].(*appsv1.Deployment).Spec.Selector.MatchLabels[RevisionLabel])
+
+	// the candidate is removed once the Deployment runs the promoted revision
+	promoted := requested[0].(*appsv1.Deployment).DeepCopy()
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v2", promoted, rolledOut(candidate)), 2)
+	assert.Equal(t, api.PromotingRolloutPhase, status.GetPhase())
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v2", rolledOut(promoted), rolledOut(candidate)), 1)
+	assert.Equal(t, api.StableRolloutPhase, status.GetPhase())
+	assert.Equal(t, candidateRevision, status.GetStableRevision())
+	assert.Zero(t, GetRolloutRequeueAfter(instance))
+}
+
+func TestRolloutReconciler_BlueGreenWithoutMetrics(t *testing.T) {
+	instance := test.CreateFakeKogitoRuntime(t.Name())
+	instance.Spec.Rollout.Strategy = api.BlueGreenRolloutStrategy
+	r := newRolloutTestReconciler(instance, nil)
+	status := instance.GetRuntimeStatus().GetRollout()
+
+	stable := rolledOut(reconcileRolloutDeployments(t, r, "v1")[0])
+	candidate := reconcileRolloutDeployments(t, r, "v2", stable)[1]
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v2", stable, rolledOut(candidate)), 2)
+	assert.Equal(t, api.AnalyzingRolloutPhase, status.GetPhase())
+	endAnalysisWindow(status)
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v2", stable, rolledOut(candidate)), 2)
+	assert.Equal(t, api.PromotingRolloutPhase, status.GetPhase())
+}
+
+func TestRolloutReconciler_StrategySwitch(t *testing.T) {
+	instance := test.CreateFakeKogitoRuntime(t.Name())
+	r := newRolloutTestReconciler(instance, nil)
+	status := instance.GetRuntimeStatus().GetRollout()
+	deployed := rolledOut(reconcileRolloutDeployments(t, r, "v1")[0])
+	assert.Empty(t, getSelectorRevision(deployed))
+
+	// the Deployment keeps serving until the candidate runs the revision it must select
+	instance.Spec.Rollout.Strategy = api.BlueGreenRolloutStrategy
+	requested := reconcileRolloutDeployments(t, r, "v1", deployed)
+	assert.Len(t, requested, 2)
+	assert.Equal(t, deployed.Spec.Selector, requested[0].(*appsv1.Deployment).Spec.Selector)
+	assert.Equal(t, api.ProgressingRolloutPhase, status.GetPhase())
+	assert.Empty(t, status.GetActiveRevision())
+	candidate := rolledOut(requested[1])
+
+	// then it's recreated with the revision selector while the candidate serves
+	requested = reconcileRolloutDeployments(t, r, "v1", deployed, candidate)
+	assert.Len(t, requested, 2)
+	revision := status.GetCandidateRevision()
+	assert.Equal(t, revision, getSelectorRevision(requested[0].(*appsv1.Deployment)))
+	assert.Equal(t, api.PromotingRolloutPhase, status.GetPhase())
+	assert.Equal(t, revision, status.GetActiveRevision())
+	recreated := requested[0].(*appsv1.Deployment).DeepCopy()
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v1", recreated, candidate), 2)
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v1", rolledOut(recreated), candidate), 1)
+	assert.Equal(t, api.StableRolloutPhase, status.GetPhase())
+
+	// back to rolling updates, the same way
+	instance.Spec.Rollout.Strategy = api.RollingUpdateRolloutStrategy
+	deployed = rolledOut(recreated)
+	requested = reconcileRolloutDeployments(t, r, "v1", deployed)
+	assert.Len(t, requested, 2)
+	assert.Equal(t, revision, getSelectorRevision(requested[0].(*appsv1.Deployment)))
+	candidate = rolledOut(requested[1])
+	requested = reconcileRolloutDeployments(t, r, "v1", deployed, candidate)
+	assert.Len(t, requested, 2)
+	assert.Empty(t, getSelectorRevision(requested[0].(*appsv1.Deployment)))
+	recreated = requested[0].(*appsv1.Deployment).DeepCopy()
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v1", recreated, candidate), 2)
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v1", rolledOut(recreated), candidate), 1)
+	assert.Empty(t, status.GetPhase())
+}
+
+func TestRolloutReconciler_Abort(t *testing.T) {
+	instance := test.CreateFakeKogitoRuntime(t.Name())
+	instance.Spec.Rollout.Strategy = api.BlueGreenRolloutStrategy
+	// the server errors served before the analysis started aren't counted
+	sample := &requestsSample{Requests: 100, ServerErrors: 100}
+	r := newRolloutTestReconciler(instance, sample)
+	status := instance.GetRuntimeStatus().GetRollout()
+
+	stable := rolledOut(reconcileRolloutDeployments(t, r, "v1")[0])
+	candidate := reconcileRolloutDeployments(t, r, "v2", stable)[1]
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v2", stable, rolledOut(candidate)), 2)
+	assert.Equal(t, api.AnalyzingRolloutPhase, status.GetPhase())
+	endAnalysisWindow(status)
+	sample.Requests, sample.ServerErrors = 200, 105
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v2", stable, rolledOut(candidate)), 2)
+	assert.Equal(t, api.PromotingRolloutPhase, status.GetPhase())
+
+	// a revision answering half of the requests with a server error is aborted
+	stable = rolledOut(reconcileRolloutDeployments(t, r, "v2", stable, rolledOut(candidate))[0])
+	candidate = reconcileRolloutDeployments(t, r, "v3", stable)[1]
+	sample.Requests, sample.ServerErrors = 0, 0
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v3", stable, rolledOut(candidate)), 2)
+	endAnalysisWindow(status)
+	sample.Requests, sample.ServerErrors = 100, 50
+	requested := reconcileRolloutDeployments(t, r, "v3", stable, rolledOut(candidate))
+	assert.Len(t, requested, 1)
+	assert.Equal(t, instance.Name, requested[0].GetName())
+	assert.Equal(t, api.AbortedRolloutPhase, status.GetPhase())
+	assert.Equal(t, stable.Spec.Template.Labels[RevisionLabel], status.GetActiveRevision())
+	assert.NotEmpty(t, status.GetAbortedRevision())
+
+	// the aborted revision isn't rolled out again
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v3", stable), 1)
+	assert.Equal(t, api.AbortedRolloutPhase, status.GetPhase())
+}
+
+func TestRolloutReconciler_ManualPromotion(t *testing.T) {
+	instance := test.CreateFakeKogitoRuntime(t.Name())
+	instance.Spec.Rollout.Strategy = api.CanaryRolloutStrategy
+	instance.Spec.Rollout.DisableAutoPromotion = true
+	sample := &requestsSample{}
+	r := newRolloutTestReconciler(instance, sample)
+	status := instance.GetRuntimeStatus().GetRollout()
+
+	stable := rolledOut(reconcileRolloutDeployments(t, r, "v1")[0])
+	candidate := reconcileRolloutDeployments(t, r, "v2", stable)[1]
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v2", stable, rolledOut(candidate)), 2)
+	// without any request served during the analysis, the canary analysis is inconclusive
+	endAnalysisWindow(status)
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v2", stable, rolledOut(candidate)), 2)
+	assert.Equal(t, api.AnalyzingRolloutPhase, status.GetPhase())
+	assert.Equal(t, infrastructure.ReconciliationAfterTen, GetRolloutRequeueAfter(instance))
+	sample.Requests = 10
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v2", stable, rolledOut(candidate)), 2)
+	assert.Equal(t, api.PausedRolloutPhase, status.GetPhase())
+	assert.Equal(t, int32(10), status.GetCanaryWeight())
+	assert.Zero(t, GetRolloutRequeueAfter(instance))
+
+	// the traffic is split between the stable and the candidate revisions
+	route := &routev1.Route{Spec: routev1.RouteSpec{To: routev1.RouteTargetReference{Kind: infrastructure.KindService.Name, Name: instance.Name}}}
+	applyRolloutToRoute(instance, route)
+	assert.Equal(t, int32(90), *route.Spec.To.Weight)
+	assert.Equal(t, GetCandidateName(instance.Name), route.Spec.AlternateBackends[0].Name)
+	assert.Equal(t, int32(10), *route.Spec.AlternateBackends[0].Weight)
+	services := applyRolloutToService(instance, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: instance.Name},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{framework.LabelAppKey: instance.Name}},
+	})
+	assert.Len(t, services, 2)
+	assert.Equal(t, status.GetStableRevision(), services[0].(*corev1.Service).Spec.Selector[RevisionLabel])
+	assert.Equal(t, status.GetCandidateRevision(), services[1].(*corev1.Service).Spec.Selector[RevisionLabel])
+
+	instance.Annotations = map[string]string{PromoteRevisionAnnotation: status.GetCandidateRevision()}
+	assert.Len(t, reconcileRolloutDeployments(t, r, "v2", stable, rolledOut(candidate)), 2)
+	assert.Equal(t, api.PromotingRolloutPhase, status.GetPhase())
+	assert.Equal(t, int32(0), status.GetCanaryWeight())
+}
+
+func TestDeploymentReconciler_Rollout(t *testing.T) {
+	ns := t.Name()
+	instance := test.CreateFakeKogitoRuntime(ns)
+	instance.Spec.Rollout.Strategy = api.BlueGreenRolloutStrategy
+	cli := test.NewFakeClientBuilder().AddK8sObjects(instance).Build()
+	context := operator.Context{Client: cli, Log: test.TestLogger, Scheme: meta.GetRegisteredSchema()}
+	image := &api.Image{Name: "test-image", Tag: "1.0"}
+	imageHandler := infrastructure.NewImageHandler(context, image, "default-image", "image-stream", ns, false, false)
+	assert.NoError(t, newDeploymentReconciler(context, instance, ServiceDefinition{}, imageHandler).Reconcile())
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: instance.Name, Namespace: ns}}
+	test.AssertFetchMustExist(t, cli, deployment)
+	assert.Equal(t, instance.Status.Rollout.ActiveRevision, deployment.Spec.Template.Labels[RevisionLabel])
+	assert.NotContains(t, deployment.Labels, RevisionLabel)
+	assert.Equal(t, api.StableRolloutPhase, instance.Status.Rollout.Phase)
+}
+
+func TestDeploymentReconciler_RolloutRecreatesDeploymentWithRevisionSelector(t *testing.T) {
+	ns := t.Name()
+	instance := test.CreateFakeKogitoRuntime(ns)
+	instance.Spec.Rollout.Strategy = api.BlueGreenRolloutStrategy
+	deployed := newRolloutTestDeployment(instance.Name, "v1")
+	deployed.Namespace = ns
+	cli := test.NewFakeClientBuilder().AddK8sObjects(instance, deployed).Build()
+	context := operator.Context{Client: cli, Log: test.TestLogger, Scheme: meta.GetRegisteredSchema()}
+	image := &api.Image{Name: "test-image", Tag: "1.0"}
+	imageHandler := infrastructure.NewImageHandler(context, image, "default-image", "image-stream", ns, false, false)
+	assert.NoError(t, newDeploymentReconciler(context, instance, ServiceDefinition{}, imageHandler).Reconcile())
+
+	// the deployed Deployment keeps serving until the candidate is ready
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: instance.Name, Namespace: ns}}
+	test.AssertFetchMustExist(t, cli, deployment)
+	assert.Empty(t, getSelectorRevision(deployment))
+	candidate := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: GetCandidateName(instance.Name), Namespace: ns}}
+	test.AssertFetchMustExist(t, cli, candidate)
+	candidate.Status = appsv1.DeploymentStatus{ObservedGeneration: candidate.Generation, UpdatedReplicas: 1, AvailableReplicas: 1}
+	assert.NoError(t, kubernetes.ResourceC(cli).UpdateStatus(candidate))
+
+	// the selector is immutable, the Deployment is created again to select its revision only
+	assert.NoError(t, newDeploymentReconciler(context, instance, ServiceDefinition{}, imageHandler).Reconcile())
+	deployment = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: instance.Name, Namespace: ns}}
+	test.AssertFetchMustExist(t, cli, deployment)
+	assert.Equal(t, instance.Status.Rollout.ActiveRevision, deployment.Spec.Selector.MatchLabels[RevisionLabel])
+	test.AssertFetchMustExist(t, cli, candidate)
+}
+
+func Test_parseRequests(t *testing.T) {
+	metrics := `# HELP http_server_requests_seconds
+# TYPE http_server_requests_seconds summary
+http_server_requests_seconds_count{method="GET",outcome="SUCCESS",status="200",uri="/orders"} 90.0
+http_server_requests_seconds_sum{method="GET",outcome="SUCCESS",status="200",uri="/orders"} 1.5
+http_server_requests_seconds_count{method="GET",outcome="SERVER_ERROR",status="500",uri="/orders"} 10.0
+http_server_requests_seconds_sum{method="GET",outcome="SERVER_ERROR",status="500",uri="/orders"} 0.5
+`
+	sample, err := parseRequests([]byte(metrics))
+	assert.NoError(t, err)
+	assert.Equal(t, &requestsSample{Requests: 100, ServerErrors: 10}, sample)
+
+	sample, err = parseRequests([]byte("# TYPE jvm_threads_live_threads gauge\njvm_threads_live_threads 10.0\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, &requestsSample{}, sample)
+}
@@ -82,9 +82,13 @@ func (i *routeReconciler) Reconcile() error {
 func (i *routeReconciler) createRequiredResources() (map[reflect.Type][]client.Object, error) {
 	resources := make(map[reflect.Type][]client.Object)
 	route := i.routeHandler.CreateRoute(i.instance)
+	applyRolloutToRoute(i.instance, route)
 	if err := framework.SetOwner(i.instance, i.Scheme, route); err != nil {
 		return nil, err
 	}
+	if err := applyPatches(i.Context, i.instance, route); err != nil {
+		return nil, err
+	}
 	resources[reflect.TypeOf(v1.Route{})] = []client.Object{route}
 	return resources, nil
 }
@@ -74,7 +74,10 @@ func (i *serviceReconciler) createRequiredResources() (map[reflect.Type][]client
 	if err := framework.SetOwner(i.instance, i.Scheme, service); err != nil {
 		return nil, err
 	}
-	resources[reflect.TypeOf(v1.Service{})] = []client.Object{service}
+	if err := applyPatches(i.Context, i.instance, service); err != nil {
+		return nil, err
+	}
+	resources[reflect.TypeOf(v1.Service{})] = applyRolloutToService(i.instance, service)
 	return resources, nil
 }
 
@@ -87,6 +90,15 @@ func (i *serviceReconciler) getDeployedResources() (map[reflect.Type][]client.Ob
 	if service != nil {
 		resources[reflect.TypeOf(v1.Service{})] = []client.Object{service}
 	}
+	if _, ok := i.instance.(api.KogitoRuntimeInterface); ok {
+		candidate, err := i.serviceHandler.FetchService(types.NamespacedName{Name: GetCandidateName(i.instance.GetName()), Namespace: i.instance.GetNamespace()})
+		if err != nil {
+			return nil, err
+		}
+		if candidate != nil {
+			resources[reflect.TypeOf(v1.Service{})] = append(resources[reflect.TypeOf(v1.Service{})], candidate)
+		}
+	}
 	return resources, nil
 }
 
@@ -15,7 +15,10 @@
 package kogitoservice
 
 import (
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	"github.com/kiegroup/kogito-operator/core/operator"
 	"github.com/kiegroup/kogito-operator/core/test"
 	"github.com/kiegroup/kogito-operator/meta"
@@ -43,3 +46,43 @@ func TestServiceReconciler(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, exists)
 }
+
+func TestServiceReconciler_Patches(t *testing.T) {
+	ns := t.Name()
+	instance := test.CreateFakeKogitoRuntime(ns)
+	instance.Spec.Patches = []v1beta1.KogitoPatch{
+		{Kind: "Service", Patch: "metadata:\n  annotations:\n    service.beta.kubernetes.io/aws-load-balancer-internal: \"true\"\nspec:\n  type: LoadBalancer"},
+		{Kind: "Service", Name: "another-service", Type: api.MergePatchType, Patch: `{"spec":{"type":"NodePort"}}`},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(instance).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	err := newServiceReconciler(context, instance).Reconcile()
+	assert.NoError(t, err)
+
+	service := &v1.Service{ObjectMeta: v13.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}}
+	test.AssertFetchMustExist(t, cli, service)
+	assert.Equal(t, v1.ServiceTypeLoadBalancer, service.Spec.Type)
+	assert.Equal(t, "true", service.Annotations["service.beta.kubernetes.io/aws-load-balancer-internal"])
+	assert.NotEmpty(t, service.Spec.Ports)
+}
+
+func TestServiceReconciler_InvalidPatch(t *testing.T) {
+	ns := t.Name()
+	instance := test.CreateFakeKogitoRuntime(ns)
+	instance.Spec.Patches = []v1beta1.KogitoPatch{
+		{Kind: "Service", Type: api.JSONPatchType, Patch: `[{"op":"remove","path":"/spec/missing"}]`},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(instance).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	err := newServiceReconciler(context, instance).Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, infrastructure.InvalidPatchReason, infrastructure.NewReconciliationErrorHandler(context).GetReasonForError(err))
+}
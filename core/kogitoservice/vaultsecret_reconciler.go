@@ -0,0 +1,78 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoservice
+
+import (
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	externalsecrets "github.com/kiegroup/kogito-operator/core/infrastructure/externalsecrets/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/operator"
+)
+
+// VaultSecretReconciler provides the secrets stored in Vault to the service as environment variables, volumes or truststore based on api.KogitoService spec
+type VaultSecretReconciler interface {
+	Reconcile() error
+}
+
+type vaultSecretReconciler struct {
+	operator.Context
+	instance           api.KogitoService
+	serviceDefinition  *ServiceDefinition
+	vaultSecretHandler infrastructure.VaultSecretHandler
+}
+
+func newVaultSecretReconciler(context operator.Context, instance api.KogitoService, serviceDefinition *ServiceDefinition) VaultSecretReconciler {
+	context.Log = context.Log.WithValues("resource", "VaultSecrets")
+	return &vaultSecretReconciler{
+		Context:            context,
+		instance:           instance,
+		serviceDefinition:  serviceDefinition,
+		vaultSecretHandler: infrastructure.NewVaultSecretHandler(context),
+	}
+}
+
+// Reconcile renders the ExternalSecrets of the Vault secrets, the service is deployed once all of them are materialized
+func (v *vaultSecretReconciler) Reconcile() error {
+	references := v.instance.GetSpec().GetVaultSecrets()
+	if !v.vaultSecretHandler.IsExternalSecretsAvailable() {
+		if len(references) == 0 {
+			return nil
+		}
+		return infrastructure.ErrorForVaultSecretNotMaterialized(v.instance.GetName(),
+			[]string{externalsecrets.SchemeGroupVersion.String() + " API is not available in the cluster, please install the External Secrets Operator first"})
+	}
+
+	issues, err := v.vaultSecretHandler.ReconcileVaultSecrets(references, v.instance)
+	if err != nil {
+		return err
+	}
+	if len(issues) > 0 {
+		return infrastructure.ErrorForVaultSecretNotMaterialized(v.instance.GetName(), issues)
+	}
+	for _, reference := range references {
+		switch {
+		case reference.GetName() == v.instance.GetSpec().GetTrustStoreSecret():
+			// mounted by the TrustStoreReconciler
+		case len(reference.GetMountPath()) > 0:
+			v.serviceDefinition.SecretVolumeReferences = append(v.serviceDefinition.SecretVolumeReferences, &VolumeReference{
+				Name:      reference.GetName(),
+				MountPath: reference.GetMountPath(),
+			})
+		default:
+			v.serviceDefinition.SecretEnvFromReferences = append(v.serviceDefinition.SecretEnvFromReferences, reference.GetName())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitoservice
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	externalsecrets "github.com/kiegroup/kogito-operator/core/infrastructure/externalsecrets/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	v12 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVaultSecretReconciler(t *testing.T) {
+	instance := test.CreateFakeKogitoRuntime(t.Name())
+	instance.Spec.VaultSecrets = []v1beta1.VaultSecretReference{
+		{Name: "travels-db", Path: "kogito/travels/db"},
+		{Name: "travels-api-key", Path: "kogito/travels/api", Key: "apiKey", SecretStore: "travels"},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(instance).SupportExternalSecrets().Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	vault := test.NewDevVault()
+	vault.Put("kogito/travels/db", map[string]string{"QUARKUS_DATASOURCE_USERNAME": "kogito", "QUARKUS_DATASOURCE_PASSWORD": "s3cr3t"})
+
+	// the api key is not in Vault yet, the service waits for its Secret
+	serviceDefinition := ServiceDefinition{}
+	err := newVaultSecretReconciler(context, instance, &serviceDefinition).Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, infrastructure.VaultSecretNotMaterializedReason, infrastructure.NewReconciliationErrorHandler(context).GetReasonForError(err))
+	assert.Empty(t, serviceDefinition.SecretEnvFromReferences)
+
+	dbExternalSecret := &externalsecrets.ExternalSecret{ObjectMeta: v1.ObjectMeta{Name: "travels-db", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, cli, dbExternalSecret)
+	assert.Equal(t, externalsecrets.SecretStoreRef{Name: infrastructure.DefaultVaultSecretStore, Kind: externalsecrets.ClusterSecretStoreKind}, dbExternalSecret.Spec.SecretStoreRef)
+	assert.Equal(t, "kogito/travels/db", dbExternalSecret.Spec.DataFrom[0].Extract.Key)
+	assert.Equal(t, instance.GetName(), dbExternalSecret.OwnerReferences[0].Name)
+
+	apiKeyExternalSecret := &externalsecrets.ExternalSecret{ObjectMeta: v1.ObjectMeta{Name: "travels-api-key", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, cli, apiKeyExternalSecret)
+	assert.Equal(t, externalsecrets.SecretStoreRef{Name: "travels", Kind: externalsecrets.SecretStoreKind}, apiKeyExternalSecret.Spec.SecretStoreRef)
+	assert.Equal(t, "apiKey", apiKeyExternalSecret.Spec.Data[0].RemoteRef.Property)
+
+	assert.NoError(t, vault.Sync(cli, t.Name()))
+	err = newVaultSecretReconciler(context, instance, &serviceDefinition).Reconcile()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "key apiKey not found")
+
+	vault.Put("kogito/travels/api", map[string]string{"apiKey": "abc"})
+	assert.NoError(t, vault.Sync(cli, t.Name()))
+	err = newVaultSecretReconciler(context, instance, &serviceDefinition).Reconcile()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"travels-db", "travels-api-key"}, serviceDefinition.SecretEnvFromReferences)
+
+	dbSecret := &v12.Secret{ObjectMeta: v1.ObjectMeta{Name: "travels-db", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, cli, dbSecret)
+	assert.Equal(t, []byte("s3cr3t"), dbSecret.Data["QUARKUS_DATASOURCE_PASSWORD"])
+}
+
+func TestVaultSecretReconciler_ExternalSecretsNotAvailable(t *testing.T) {
+	instance := test.CreateFakeKogitoRuntime(t.Name())
+	instance.Spec.VaultSecrets = []v1beta1.VaultSecretReference{{Name: "travels-db", Path: "kogito/travels/db"}}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(instance).Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	serviceDefinition := ServiceDefinition{}
+	err := newVaultSecretReconciler(context, instance, &serviceDefinition).Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, infrastructure.VaultSecretNotMaterializedReason, infrastructure.NewReconciliationErrorHandler(context).GetReasonForError(err))
+	assert.Empty(t, serviceDefinition.SecretEnvFromReferences)
+}
+
+func TestVaultSecretReconciler_SharedExternalSecret(t *testing.T) {
+	travels := test.CreateFakeKogitoRuntime(t.Name())
+	travels.Name, travels.UID = "travels", "travels-uid"
+	travels.Spec.VaultSecrets = []v1beta1.VaultSecretReference{{Name: "travels-db", Path: "kogito/travels/db"}}
+	visas := test.CreateFakeKogitoRuntime(t.Name())
+	visas.Name, visas.UID = "visas", "visas-uid"
+	visas.Spec.VaultSecrets = []v1beta1.VaultSecretReference{{Name: "travels-db", Path: "kogito/travels/db"}}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(travels, visas).SupportExternalSecrets().Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	vault := test.NewDevVault()
+	vault.Put("kogito/travels/db", map[string]string{"QUARKUS_DATASOURCE_PASSWORD": "s3cr3t"})
+
+	assert.Error(t, newVaultSecretReconciler(context, travels, &ServiceDefinition{}).Reconcile())
+	assert.NoError(t, vault.Sync(cli, t.Name()))
+	assert.NoError(t, newVaultSecretReconciler(context, travels, &ServiceDefinition{}).Reconcile())
+	assert.NoError(t, newVaultSecretReconciler(context, visas, &ServiceDefinition{}).Reconcile())
+
+	externalSecret := &externalsecrets.ExternalSecret{ObjectMeta: v1.ObjectMeta{Name: "travels-db", Namespace: t.Name()}}
+	test.AssertFetchMustExist(t, cli, externalSecret)
+	assert.Len(t, externalSecret.OwnerReferences, 2)
+	for _, ownerReference := range externalSecret.OwnerReferences {
+		assert.False(t, *ownerReference.Controller)
+	}
+
+	// another Vault secret can't be materialized in the shared Secret
+	visas.Spec.VaultSecrets[0].Path = "kogito/visas/db"
+	err := newVaultSecretReconciler(context, visas, &ServiceDefinition{}).Reconcile()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "KogitoRuntime travels")
+
+	// the ExternalSecret is released by the services not referencing it anymore, then deleted
+	visas.Spec.VaultSecrets = nil
+	assert.NoError(t, newVaultSecretReconciler(context, visas, &ServiceDefinition{}).Reconcile())
+	test.AssertFetchMustExist(t, cli, externalSecret)
+	assert.Len(t, externalSecret.OwnerReferences, 1)
+	assert.Equal(t, travels.GetName(), externalSecret.OwnerReferences[0].Name)
+
+	travels.Spec.VaultSecrets = nil
+	assert.NoError(t, newVaultSecretReconciler(context, travels, &ServiceDefinition{}).Reconcile())
+	exists, err := kubernetes.ResourceC(cli).Fetch(externalSecret)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestVaultSecretReconciler_VolumeAndTrustStore(t *testing.T) {
+	instance := test.CreateFakeKogitoRuntime(t.Name())
+	instance.Spec.TrustStoreSecret = "travels-truststore"
+	instance.Spec.VaultSecrets = []v1beta1.VaultSecretReference{
+		{Name: "travels-truststore", Path: "kogito/travels/truststore"},
+		{Name: "travels-keys", Path: "kogito/travels/keys", MountPath: "/home/kogito/keys"},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(instance).SupportExternalSecrets().Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+	vault := test.NewDevVault()
+	vault.Put("kogito/travels/truststore", map[string]string{"cacerts": "certs", "keyStorePassword": "changeit"})
+	vault.Put("kogito/travels/keys", map[string]string{"private.pem": "key"})
+
+	assert.Error(t, newVaultSecretReconciler(context, instance, &ServiceDefinition{}).Reconcile())
+	assert.NoError(t, vault.Sync(cli, t.Name()))
+	serviceDefinition := ServiceDefinition{}
+	assert.NoError(t, newVaultSecretReconciler(context, instance, &serviceDefinition).Reconcile())
+	assert.Empty(t, serviceDefinition.SecretEnvFromReferences)
+	assert.Len(t, serviceDefinition.SecretVolumeReferences, 1)
+	assert.Equal(t, "travels-keys", serviceDefinition.SecretVolumeReferences[0].GetName())
+	assert.Equal(t, "/home/kogito/keys", serviceDefinition.SecretVolumeReferences[0].GetMountPath())
+
+	// the materialized truststore is then mounted by the TrustStoreReconciler
+	assert.NoError(t, newTrustStoreReconciler(context, instance, &serviceDefinition).Reconcile())
+	assert.Len(t, serviceDefinition.SecretVolumeReferences, 2)
+	assert.Equal(t, "travels-truststore", serviceDefinition.SecretVolumeReferences[1].GetName())
+}
@@ -30,6 +30,8 @@ const (
 	DataIndexMongoDBImageName = "kogito-data-index-mongodb"
 	// DataIndexPostgresqlImageName is the image name for the Data Index Service with PostgreSQL
 	DataIndexPostgresqlImageName = "kogito-data-index-postgresql"
+	// DataIndexEphemeralImageName is the image name for the Data Index Service with in memory storage
+	DataIndexEphemeralImageName = "kogito-data-index-ephemeral"
 	// DefaultDataIndexImageName is just the image name for the Data Index Service
 	DefaultDataIndexImageName = DataIndexInfinispanImageName
 	// DefaultDataIndexName is the default name for the Data Index instance service
@@ -51,15 +53,30 @@ func initDataIndexSupportingServiceResource(context supportingServiceContext) Re
 // Reconcile reconcile Data Index
 func (d *dataIndexSupportingServiceResource) Reconcile() (err error) {
 	d.Log.Info("Reconciling for KogitoDataIndex")
+	storageHandler := newDataIndexStorageHandler(d.supportingServiceContext)
+	if err = storageHandler.validate(); err != nil {
+		return
+	}
+	storageHandler.prepare()
 	protoBufHandler := shared.NewProtoBufHandler(d.Context, d.supportingServiceHandler)
 	definition := kogitoservice.ServiceDefinition{
-		DefaultImageName:   DefaultDataIndexImageName,
+		DefaultImageName:   storageHandler.getImageName(),
 		Request:            controller1.Request{NamespacedName: types.NamespacedName{Name: d.instance.GetName(), Namespace: d.instance.GetNamespace()}},
 		OnDeploymentCreate: protoBufHandler.MountAllProtoBufConfigMapOnDataIndexDeployment,
+		// Data Index watches the mounted protobuf files, their changes don't restart it
+		HotReloadedConfigMapLabels: map[string]string{shared.ConfigMapProtoBufEnabledLabelKey: "true"},
+		Introspector:               d.introspector,
+		DigestResolver:             d.digestResolver,
 	}
 	if err = kogitoservice.NewServiceDeployer(d.Context, definition, d.instance, d.infraHandler).Deploy(); err != nil {
 		return
 	}
+	if storageHandler.isMigrating() {
+		return storageHandler.migrate()
+	}
+	if err = storageHandler.reportReady(); err != nil {
+		return
+	}
 	endpointConfigMapReconciler := newEndPointConfigMapReconciler(d.Context, d.instance, connector.DataIndexHTTPRouteEnv, connector.DataIndexWSRouteEnv)
 	if err = endpointConfigMapReconciler.Reconcile(); err != nil {
 		return
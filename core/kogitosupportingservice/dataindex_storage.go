@@ -0,0 +1,263 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitosupportingservice
+
+import (
+	"fmt"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// schemaMigrationJobBackoffLimit is the number of retries of the schema migration Job before considering it failed
+	schemaMigrationJobBackoffLimit = int32(3)
+	// Quarkus runs the Flyway migration at startup and exits right after when both are set
+	flywayMigrateAtStartEnv = "QUARKUS_FLYWAY_MIGRATE_AT_START"
+	initAndExitEnv          = "QUARKUS_INIT_AND_EXIT"
+)
+
+// dataIndexStorageImageNames maps each Data Index storage to its image
+var dataIndexStorageImageNames = map[api.DataIndexStorageType]string{
+	api.InfinispanDataIndexStorage: DataIndexInfinispanImageName,
+	api.MongoDBDataIndexStorage:    DataIndexMongoDBImageName,
+	api.PostgreSQLDataIndexStorage: DataIndexPostgresqlImageName,
+	api.EphemeralDataIndexStorage:  DataIndexEphemeralImageName,
+}
+
+// dataIndexStorageHandler selects the Data Index image for the requested storage and migrates Data Index when the storage changes.
+// The migration scales Data Index down, runs the schema migration Job for the new storage, if the storage has a schema, and scales it up again.
+type dataIndexStorageHandler struct {
+	supportingServiceContext
+	deploymentHandler infrastructure.DeploymentHandler
+}
+
+func newDataIndexStorageHandler(context supportingServiceContext) *dataIndexStorageHandler {
+	return &dataIndexStorageHandler{
+		supportingServiceContext: context,
+		deploymentHandler:        infrastructure.NewDeploymentHandler(context.Context),
+	}
+}
+
+// getStorage returns the storage requested for Data Index, empty if none
+func (d *dataIndexStorageHandler) getStorage() api.DataIndexStorageType {
+	if dataIndex := d.instance.GetSupportingServiceSpec().GetDataIndex(); dataIndex != nil {
+		return dataIndex.GetStorage()
+	}
+	return ""
+}
+
+// getImageName returns the Data Index image name matching the requested storage
+func (d *dataIndexStorageHandler) getImageName() string {
+	if imageName, ok := dataIndexStorageImageNames[d.getStorage()]; ok {
+		return imageName
+	}
+	return DefaultDataIndexImageName
+}
+
+// isMigrating tells whether the requested storage differs from the one Data Index currently runs with
+func (d *dataIndexStorageHandler) isMigrating() bool {
+	current := d.instance.GetSupportingServiceStatus().GetDataIndexStorage()
+	return len(d.getStorage()) > 0 && len(current) > 0 && current != d.getStorage()
+}
+
+// validate checks that the infra required by the requested storage is referenced by Data Index.
+// A KogitoInfra without resource doesn't tell which storage it connects to, so the storage must be set explicitly to use it.
+func (d *dataIndexStorageHandler) validate() error {
+	storage := d.getStorage()
+	infraKinds, err := d.getReferencedInfraKinds()
+	if err != nil {
+		return err
+	}
+	var message string
+	switch storage {
+	case "":
+		if infraKinds[""] && !infraKinds[infrastructure.InfinispanKind] && !infraKinds[infrastructure.MongoDBKind] {
+			message = fmt.Sprintf("the storage must be set to use a KogitoInfra without resource, e.g. %s", api.PostgreSQLDataIndexStorage)
+		}
+	case api.InfinispanDataIndexStorage:
+		if !infraKinds[infrastructure.InfinispanKind] {
+			message = fmt.Sprintf("storage %s requires a KogitoInfra referencing an %s instance", storage, infrastructure.InfinispanKind)
+		}
+	case api.MongoDBDataIndexStorage:
+		if !infraKinds[infrastructure.MongoDBKind] {
+			message = fmt.Sprintf("storage %s requires a KogitoInfra referencing a %s instance", storage, infrastructure.MongoDBKind)
+		}
+	case api.PostgreSQLDataIndexStorage:
+		if !infraKinds[""] {
+			message = fmt.Sprintf("storage %s requires a KogitoInfra without resource holding the connection properties", storage)
+		}
+	case api.EphemeralDataIndexStorage:
+		if infraKinds[infrastructure.InfinispanKind] || infraKinds[infrastructure.MongoDBKind] {
+			message = fmt.Sprintf("storage %s can't be used along with %s or %s KogitoInfra", storage, infrastructure.InfinispanKind, infrastructure.MongoDBKind)
+		}
+	}
+	if len(message) == 0 {
+		return nil
+	}
+	err = infrastructure.ErrorForInvalidStorage(d.instance.GetName(), message)
+	d.setStorageCondition(metav1.ConditionFalse, infrastructure.InvalidStorageReason, err.Error())
+	return d.updateStatus(err)
+}
+
+// prepare records the storage on the first deployment and keeps Data Index scaled down while the storage is migrated
+func (d *dataIndexStorageHandler) prepare() {
+	storage := d.getStorage()
+	if len(storage) == 0 {
+		return
+	}
+	if len(d.instance.GetSupportingServiceStatus().GetDataIndexStorage()) == 0 {
+		d.instance.GetSupportingServiceStatus().SetDataIndexStorage(storage)
+	}
+	if d.isMigrating() {
+		d.instance.GetSpec().SetReplicas(0)
+	}
+}
+
+// migrate moves Data Index through the migration steps, it returns an error to requeue the reconciliation until Data Index runs with the new storage
+func (d *dataIndexStorageHandler) migrate() error {
+	storage := d.getStorage()
+	deployment, err := d.deploymentHandler.FetchDeployment(types.NamespacedName{Name: d.instance.GetName(), Namespace: d.instance.GetNamespace()})
+	if err != nil {
+		return err
+	}
+	if deployment == nil || deployment.Status.Replicas > 0 {
+		d.setStorageCondition(metav1.ConditionFalse, infrastructure.StorageScalingDownReason,
+			fmt.Sprintf("Waiting for the pods using storage %s to terminate", d.instance.GetSupportingServiceStatus().GetDataIndexStorage()))
+		return d.updateStatus(infrastructure.ErrorForStorageMigration(infrastructure.StorageScalingDownReason, "scaling down"))
+	}
+
+	if storage == api.PostgreSQLDataIndexStorage {
+		completed, err := d.runSchemaMigration(deployment.Spec.Template)
+		if err != nil || !completed {
+			return err
+		}
+	}
+
+	d.instance.GetSupportingServiceStatus().SetDataIndexStorage(storage)
+	d.setStorageCondition(metav1.ConditionFalse, infrastructure.StorageScalingUpReason, fmt.Sprintf("Waiting for the pods using storage %s to be available", storage))
+	return d.updateStatus(infrastructure.ErrorForStorageMigration(infrastructure.StorageScalingUpReason, "scaling up"))
+}
+
+// runSchemaMigration creates the schema migration Job from the Data Index pod template and tells whether it completed successfully
+func (d *dataIndexStorageHandler) runSchemaMigration(template corev1.PodTemplateSpec) (bool, error) {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: d.getSchemaMigrationJobName(), Namespace: d.instance.GetNamespace()}}
+	exists, err := kubernetes.ResourceC(d.Client).Fetch(job)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		job = d.createSchemaMigrationJob(template)
+		if err = kubernetes.ResourceC(d.Client).CreateForOwner(job, d.instance, d.Scheme); err != nil {
+			return false, err
+		}
+	}
+
+	if job.Status.Succeeded > 0 {
+		if err = kubernetes.ResourceC(d.Client).Delete(job); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			message := fmt.Sprintf("Schema migration Job %s failed: %s. Delete the Job to run the migration again", job.Name, condition.Message)
+			d.setStorageCondition(metav1.ConditionFalse, infrastructure.StorageMigrationFailedReason, message)
+			return false, d.updateStatus(infrastructure.ErrorForStorageMigration(infrastructure.StorageMigrationFailedReason, message))
+		}
+	}
+	d.setStorageCondition(metav1.ConditionFalse, infrastructure.StorageMigratingSchemaReason, fmt.Sprintf("Waiting for the schema migration Job %s to complete", job.Name))
+	return false, d.updateStatus(infrastructure.ErrorForStorageMigration(infrastructure.StorageMigratingSchemaReason, "migrating schema"))
+}
+
+func (d *dataIndexStorageHandler) createSchemaMigrationJob(template corev1.PodTemplateSpec) *batchv1.Job {
+	backoffLimit := schemaMigrationJobBackoffLimit
+	podTemplate := template.DeepCopy()
+	podTemplate.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	container := &podTemplate.Spec.Containers[0]
+	container.LivenessProbe = nil
+	container.ReadinessProbe = nil
+	container.StartupProbe = nil
+	framework.SetEnvVar(flywayMigrateAtStartEnv, "true", container)
+	framework.SetEnvVar(initAndExitEnv, "true", container)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      d.getSchemaMigrationJobName(),
+			Namespace: d.instance.GetNamespace(),
+			Labels:    map[string]string{framework.LabelAppKey: d.instance.GetName()},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template:     *podTemplate,
+		},
+	}
+}
+
+func (d *dataIndexStorageHandler) getSchemaMigrationJobName() string {
+	return fmt.Sprintf("%s-%s-migration", d.instance.GetName(), d.getStorage())
+}
+
+// reportReady sets the StorageReady condition once Data Index is available with the requested storage
+func (d *dataIndexStorageHandler) reportReady() error {
+	if len(d.getStorage()) == 0 {
+		return nil
+	}
+	condition := d.findStorageCondition()
+	if condition != nil && condition.Status == metav1.ConditionTrue {
+		return nil
+	}
+	if condition != nil && condition.Reason == string(infrastructure.StorageScalingUpReason) {
+		available, err := d.deploymentHandler.IsDeploymentAvailable(types.NamespacedName{Name: d.instance.GetName(), Namespace: d.instance.GetNamespace()})
+		if err != nil || !available {
+			return err
+		}
+	}
+	d.setStorageCondition(metav1.ConditionTrue, infrastructure.StorageReadyReason, fmt.Sprintf("Running with storage %s", d.getStorage()))
+	return d.updateStatus(nil)
+}
+
+func (d *dataIndexStorageHandler) findStorageCondition() *metav1.Condition {
+	if d.instance.GetStatus().GetConditions() == nil {
+		return nil
+	}
+	return meta.FindStatusCondition(*d.instance.GetStatus().GetConditions(), string(api.StorageReadyConditionType))
+}
+
+func (d *dataIndexStorageHandler) setStorageCondition(status metav1.ConditionStatus, reason infrastructure.ConditionReason, message string) {
+	if d.instance.GetStatus().GetConditions() == nil {
+		d.instance.GetStatus().SetConditions(&[]metav1.Condition{})
+	}
+	meta.SetStatusCondition(d.instance.GetStatus().GetConditions(), metav1.Condition{
+		Type:    string(api.StorageReadyConditionType),
+		Status:  status,
+		Reason:  string(reason),
+		Message: message,
+	})
+}
+
+// updateStatus persists the instance status and returns the given error, unless the update fails
+func (d *dataIndexStorageHandler) updateStatus(err error) error {
+	if updateErr := kubernetes.ResourceC(d.Client).UpdateStatus(d.instance); updateErr != nil {
+		return updateErr
+	}
+	return err
+}
@@ -0,0 +1,184 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitosupportingservice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/internal/app"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	meta2 "k8s.io/apimachinery/pkg/api/meta"
+	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newDataIndexResourceForTest(objects ...runtime.Object) (*dataIndexSupportingServiceResource, operator.Context) {
+	cli := test.NewFakeClientBuilder().AddK8sObjects(objects...).Build()
+	context := operator.Context{
+		Client:  cli,
+		Log:     test.TestLogger,
+		Scheme:  meta.GetRegisteredSchema(),
+		Version: "1.0-SNAPSHOT",
+	}
+	return &dataIndexSupportingServiceResource{
+		supportingServiceContext: supportingServiceContext{
+			Context:                  context,
+			instance:                 objects[0].(api.KogitoSupportingServiceInterface),
+			supportingServiceHandler: app.NewKogitoSupportingServiceHandler(context),
+			infraHandler:             app.NewKogitoInfraHandler(context),
+			runtimeHandler:           app.NewKogitoRuntimeHandler(context),
+		},
+	}, context
+}
+
+func createFakeKogitoPostgreSQL(namespace string) *v1beta1.KogitoInfra {
+	return &v1beta1.KogitoInfra{
+		ObjectMeta: v13.ObjectMeta{Name: "kogito-postgresql-infra", Namespace: namespace},
+		Spec:       v1beta1.KogitoInfraSpec{ConfigMapEnvFromReferences: []string{"postgresql-properties"}},
+		Status: v1beta1.KogitoInfraStatus{
+			Conditions: &[]v13.Condition{{Type: string(api.KogitoInfraConfigured), Status: v13.ConditionTrue}},
+		},
+	}
+}
+
+func TestDataIndexStorage_InvalidInfra(t *testing.T) {
+	ns := t.Name()
+	kogitoInfinispan := test.CreateFakeKogitoInfinispan(ns)
+	dataIndex := test.CreateFakeDataIndex(ns)
+	dataIndex.Spec.DataIndex = &v1beta1.DataIndexSpec{Storage: api.MongoDBDataIndexStorage}
+	dataIndex.GetSpec().AddInfra(kogitoInfinispan.GetName())
+	r, context := newDataIndexResourceForTest(dataIndex, kogitoInfinispan)
+
+	err := r.Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, infrastructure.InvalidStorageReason, infrastructure.NewReconciliationErrorHandler(context).GetReasonForError(err))
+
+	_, err = kubernetes.ResourceC(context.Client).Fetch(dataIndex)
+	assert.NoError(t, err)
+	condition := meta2.FindStatusCondition(*dataIndex.Status.Conditions, string(api.StorageReadyConditionType))
+	assert.NotNil(t, condition)
+	assert.Equal(t, v13.ConditionFalse, condition.Status)
+	assert.Equal(t, string(infrastructure.InvalidStorageReason), condition.Reason)
+}
+
+func TestDataIndexStorage_MissingStorage(t *testing.T) {
+	ns := t.Name()
+	kogitoPostgreSQL := createFakeKogitoPostgreSQL(ns)
+	dataIndex := test.CreateFakeDataIndex(ns)
+	dataIndex.GetSpec().AddInfra(kogitoPostgreSQL.GetName())
+	r, context := newDataIndexResourceForTest(dataIndex, kogitoPostgreSQL)
+
+	// the KogitoInfra without resource isn't taken for PostgreSQL
+	err := r.Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, infrastructure.InvalidStorageReason, infrastructure.NewReconciliationErrorHandler(context).GetReasonForError(err))
+	deployment := &v1.Deployment{ObjectMeta: v13.ObjectMeta{Name: dataIndex.Name, Namespace: ns}}
+	exists, err := kubernetes.ResourceC(context.Client).Fetch(deployment)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestDataIndexStorage_ImageForStorage(t *testing.T) {
+	ns := t.Name()
+	kogitoPostgreSQL := createFakeKogitoPostgreSQL(ns)
+	dataIndex := test.CreateFakeDataIndex(ns)
+	dataIndex.Spec.DataIndex = &v1beta1.DataIndexSpec{Storage: api.PostgreSQLDataIndexStorage}
+	dataIndex.GetSpec().AddInfra(kogitoPostgreSQL.GetName())
+	r, context := newDataIndexResourceForTest(dataIndex, kogitoPostgreSQL)
+
+	err := r.Reconcile()
+	assert.NoError(t, err)
+
+	deployment := &v1.Deployment{ObjectMeta: v13.ObjectMeta{Name: dataIndex.Name, Namespace: ns}}
+	exists, err := kubernetes.ResourceC(context.Client).Fetch(deployment)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.True(t, strings.Contains(deployment.Spec.Template.Spec.Containers[0].Image, DataIndexPostgresqlImageName))
+
+	_, err = kubernetes.ResourceC(context.Client).Fetch(dataIndex)
+	assert.NoError(t, err)
+	assert.Equal(t, api.PostgreSQLDataIndexStorage, dataIndex.Status.DataIndexStorage)
+	assert.True(t, meta2.IsStatusConditionTrue(*dataIndex.Status.Conditions, string(api.StorageReadyConditionType)))
+}
+
+func TestDataIndexStorage_Migration(t *testing.T) {
+	ns := t.Name()
+	kogitoPostgreSQL := createFakeKogitoPostgreSQL(ns)
+	dataIndex := test.CreateFakeDataIndex(ns)
+	dataIndex.Spec.DataIndex = &v1beta1.DataIndexSpec{Storage: api.PostgreSQLDataIndexStorage}
+	dataIndex.Status.DataIndexStorage = api.InfinispanDataIndexStorage
+	dataIndex.GetSpec().AddInfra(kogitoPostgreSQL.GetName())
+	replicas := int32(1)
+	dataIndexDeployment := &v1.Deployment{
+		ObjectMeta: v13.ObjectMeta{Name: dataIndex.Name, Namespace: ns},
+		Spec:       v1.DeploymentSpec{Replicas: &replicas},
+		Status:     v1.DeploymentStatus{Replicas: 1, AvailableReplicas: 1},
+	}
+	r, context := newDataIndexResourceForTest(dataIndex, kogitoPostgreSQL, dataIndexDeployment)
+	errorHandler := infrastructure.NewReconciliationErrorHandler(context)
+	cli := context.Client
+
+	// scale down
+	_ = r.Reconcile()
+	_, err := kubernetes.ResourceC(cli).Fetch(dataIndexDeployment)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), *dataIndexDeployment.Spec.Replicas)
+	assert.True(t, strings.Contains(dataIndexDeployment.Spec.Template.Spec.Containers[0].Image, DataIndexPostgresqlImageName))
+	dataIndexDeployment.Status = v1.DeploymentStatus{Replicas: 1}
+	assert.NoError(t, kubernetes.ResourceC(cli).UpdateStatus(dataIndexDeployment))
+	err = r.Reconcile()
+	assert.Equal(t, infrastructure.StorageScalingDownReason, errorHandler.GetReasonForError(err))
+
+	// schema migration
+	dataIndexDeployment.Status = v1.DeploymentStatus{}
+	assert.NoError(t, kubernetes.ResourceC(cli).UpdateStatus(dataIndexDeployment))
+	err = r.Reconcile()
+	assert.Equal(t, infrastructure.StorageMigratingSchemaReason, errorHandler.GetReasonForError(err))
+	job := &batchv1.Job{ObjectMeta: v13.ObjectMeta{Name: dataIndex.Name + "-postgresql-migration", Namespace: ns}}
+	exists, err := kubernetes.ResourceC(cli).Fetch(job)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Contains(t, job.Spec.Template.Spec.Containers[0].Env, dataIndexDeployment.Spec.Template.Spec.Containers[0].Env[0])
+	assert.Equal(t, dataIndexDeployment.Spec.Template.Spec.Containers[0].Image, job.Spec.Template.Spec.Containers[0].Image)
+
+	// scale up
+	job.Status.Succeeded = 1
+	assert.NoError(t, kubernetes.ResourceC(cli).UpdateStatus(job))
+	err = r.Reconcile()
+	assert.Equal(t, infrastructure.StorageScalingUpReason, errorHandler.GetReasonForError(err))
+	exists, err = kubernetes.ResourceC(cli).Fetch(job)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	_, err = kubernetes.ResourceC(cli).Fetch(dataIndex)
+	assert.NoError(t, err)
+	assert.Equal(t, api.PostgreSQLDataIndexStorage, dataIndex.Status.DataIndexStorage)
+
+	dataIndex.Spec.Replicas = &replicas
+	err = r.Reconcile()
+	assert.NoError(t, err)
+	_, err = kubernetes.ResourceC(cli).Fetch(dataIndexDeployment)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), *dataIndexDeployment.Spec.Replicas)
+}
@@ -111,7 +111,7 @@ func (i *endPointConfigMapReconciler) processDelta(requestedResources map[reflec
 
 func (i *endPointConfigMapReconciler) createEndPointConfigMap() (*v1.ConfigMap, error) {
 
-	serviceEndpoints, err := i.kogitoServiceHandler.GetKogitoServiceEndpoints(i.instance, i.serviceHTTPRouteEnv, i.serviceWSRouteEnv)
+	serviceEndpoints, err := i.getServiceEndpoints()
 	if err != nil {
 		return nil, err
 	}
@@ -135,3 +135,20 @@ func (i *endPointConfigMapReconciler) createEndPointConfigMap() (*v1.ConfigMap,
 	}
 	return configMap, nil
 }
+
+// getServiceEndpoints resolves the endpoints from the external service definition, if any, or from the deployed service
+func (i *endPointConfigMapReconciler) getServiceEndpoints() (*kogitoservice.ServiceEndpoints, error) {
+	supportingService, ok := i.instance.(api.KogitoSupportingServiceInterface)
+	if !ok || !supportingService.GetSupportingServiceSpec().IsExternal() {
+		return i.kogitoServiceHandler.GetKogitoServiceEndpoints(i.instance, i.serviceHTTPRouteEnv, i.serviceWSRouteEnv)
+	}
+	external := supportingService.GetSupportingServiceSpec().GetExternal()
+	serviceEndpoints, err := i.kogitoServiceHandler.GetServiceEndpointsForURL(external.GetURL(), i.serviceHTTPRouteEnv, i.serviceWSRouteEnv)
+	if err != nil {
+		return nil, err
+	}
+	if len(external.GetWSURL()) > 0 {
+		serviceEndpoints.WSRouteURI = external.GetWSURL()
+	}
+	return serviceEndpoints, nil
+}
@@ -45,6 +45,8 @@ func (e *explainabilitySupportingServiceResource) Reconcile() (err error) {
 	definition := kogitoservice.ServiceDefinition{
 		DefaultImageName: DefaultExplainabilityImageName,
 		Request:          controller.Request{NamespacedName: types.NamespacedName{Name: e.instance.GetName(), Namespace: e.instance.GetNamespace()}},
+		Introspector:     e.introspector,
+		DigestResolver:   e.digestResolver,
 	}
 	return kogitoservice.NewServiceDeployer(e.Context, definition, e.instance, e.infraHandler).Deploy()
 }
@@ -0,0 +1,224 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitosupportingservice
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/connector"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// externalServiceHealthPath is the readiness endpoint exposed by every Kogito supporting service
+	externalServiceHealthPath = "/q/health/ready"
+	// externalServiceHealthCheckTimeout is the maximum time to wait for the external service to answer the health check
+	externalServiceHealthCheckTimeout = time.Second * 10
+	// externalServiceCABundleKey is the key holding the PEM encoded CA bundle in the secret referenced by the external service
+	externalServiceCABundleKey = "ca.crt"
+	// externalServiceUsernameKey is the key holding the username in the credentials secret referenced by the external service
+	externalServiceUsernameKey = "username"
+	// externalServicePasswordKey is the key holding the password in the credentials secret referenced by the external service
+	externalServicePasswordKey = "password"
+)
+
+// externalServiceEndpoint describes how the endpoint of a supporting service type is published to the other services
+type externalServiceEndpoint struct {
+	httpRouteEnv string
+	wsRouteEnv   string
+	inject       func(urlHandler connector.URLHandler, key types.NamespacedName) error
+}
+
+// getExternalServiceEndpoints returns the supporting service types that can be managed outside of the cluster
+func getExternalServiceEndpoints() map[api.ServiceType]externalServiceEndpoint {
+	return map[api.ServiceType]externalServiceEndpoint{
+		api.DataIndex: {
+			httpRouteEnv: connector.DataIndexHTTPRouteEnv,
+			wsRouteEnv:   connector.DataIndexWSRouteEnv,
+			inject: func(urlHandler connector.URLHandler, key types.NamespacedName) error {
+				if err := urlHandler.InjectDataIndexEndPointOnKogitoRuntimeServices(key); err != nil {
+					return err
+				}
+				return urlHandler.InjectDataIndexURLIntoSupportingService(key, api.MgmtConsole)
+			},
+		},
+		api.JobsService: {
+			httpRouteEnv: connector.JobsServicesHTTPRouteEnv,
+			inject: func(urlHandler connector.URLHandler, key types.NamespacedName) error {
+				return urlHandler.InjectJobsServicesEndPointOnKogitoRuntimeServices(key)
+			},
+		},
+		api.TrustyAI: {
+			httpRouteEnv: connector.TrustyHTTPRouteEnv,
+			wsRouteEnv:   connector.TrustyWSRouteEnv,
+			inject: func(urlHandler connector.URLHandler, key types.NamespacedName) error {
+				return urlHandler.InjectTrustyEndpointOnKogitoRuntimeServices(key)
+			},
+		},
+	}
+}
+
+// externalSupportingServiceResource handles supporting services that are deployed outside of the cluster
+type externalSupportingServiceResource struct {
+	supportingServiceContext
+	secretHandler infrastructure.SecretHandler
+	errorHandler  infrastructure.ReconciliationErrorHandler
+}
+
+func initExternalSupportingServiceResource(context supportingServiceContext) Reconciler {
+	context.Log = context.Log.WithValues("resource", "external")
+	return &externalSupportingServiceResource{
+		supportingServiceContext: context,
+		secretHandler:            infrastructure.NewSecretHandler(context.Context),
+		errorHandler:             infrastructure.NewReconciliationErrorHandler(context.Context),
+	}
+}
+
+// Reconcile publishes the external service endpoint and checks its health, no resources are deployed for it
+func (e *externalSupportingServiceResource) Reconcile() (err error) {
+	e.Log.Info("Reconciling for external supporting service", "url", e.instance.GetSupportingServiceSpec().GetExternal().GetURL())
+	defer e.updateStatus(&err)
+
+	serviceType := e.instance.GetSupportingServiceSpec().GetServiceType()
+	endpoint, supported := getExternalServiceEndpoints()[serviceType]
+	if !supported {
+		err = fmt.Errorf("Supporting service of type %s can't be external, supported types are %s, %s and %s ", serviceType, api.DataIndex, api.JobsService, api.TrustyAI)
+		return
+	}
+
+	endpointConfigMapReconciler := newEndPointConfigMapReconciler(e.Context, e.instance, endpoint.httpRouteEnv, endpoint.wsRouteEnv)
+	if err = endpointConfigMapReconciler.Reconcile(); err != nil {
+		return
+	}
+
+	urlHandler := connector.NewURLHandler(e.Context, e.runtimeHandler, e.supportingServiceHandler)
+	if err = endpoint.inject(urlHandler, types.NamespacedName{Name: e.instance.GetName(), Namespace: e.instance.GetNamespace()}); err != nil {
+		return
+	}
+
+	err = e.checkHealth()
+	return
+}
+
+func (e *externalSupportingServiceResource) checkHealth() error {
+	external := e.instance.GetSupportingServiceSpec().GetExternal()
+	healthURL := strings.TrimSuffix(external.GetURL(), "/") + externalServiceHealthPath
+	httpClient, err := e.newHTTPClient(external)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest(http.MethodGet, healthURL, nil)
+	if err != nil {
+		return err
+	}
+	if err = e.setBasicAuth(request, external); err != nil {
+		return err
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return infrastructure.ErrorForExternalServiceNotReachable(external.GetURL(), err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return infrastructure.ErrorForExternalServiceNotReachable(external.GetURL(), infrastructure.ErrorForServiceNotReachable(response.StatusCode, healthURL, http.MethodGet))
+	}
+	e.Log.Debug("External service is healthy", "url", healthURL)
+	return nil
+}
+
+func (e *externalSupportingServiceResource) newHTTPClient(external api.ExternalSupportingServiceInterface) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(external.GetCABundleSecret()) > 0 {
+		secret, err := e.secretHandler.MustFetchSecret(types.NamespacedName{Name: external.GetCABundleSecret(), Namespace: e.instance.GetNamespace()})
+		if err != nil {
+			return nil, err
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(secret.Data[externalServiceCABundleKey]) {
+			return nil, fmt.Errorf("No PEM certificate found in key %s of secret %s ", externalServiceCABundleKey, secret.Name)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	return &http.Client{
+		Timeout:   externalServiceHealthCheckTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}, nil
+}
+
+func (e *externalSupportingServiceResource) setBasicAuth(request *http.Request, external api.ExternalSupportingServiceInterface) error {
+	if len(external.GetCredentialsSecret()) == 0 {
+		return nil
+	}
+	secret, err := e.secretHandler.MustFetchSecret(types.NamespacedName{Name: external.GetCredentialsSecret(), Namespace: e.instance.GetNamespace()})
+	if err != nil {
+		return err
+	}
+	request.SetBasicAuth(string(secret.Data[externalServiceUsernameKey]), string(secret.Data[externalServicePasswordKey]))
+	return nil
+}
+
+func (e *externalSupportingServiceResource) updateStatus(err *error) {
+	status := e.instance.GetStatus()
+	if status.GetConditions() == nil {
+		status.SetConditions(&[]metav1.Condition{})
+	}
+	status.SetExternalURI(e.instance.GetSupportingServiceSpec().GetExternal().GetURL())
+
+	reason := e.errorHandler.GetReasonForError(*err)
+	if *err != nil {
+		meta.SetStatusCondition(status.GetConditions(), metav1.Condition{
+			Type:    string(api.FailedConditionType),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(reason),
+			Message: (*err).Error(),
+		})
+	} else if failedCondition := meta.FindStatusCondition(*status.GetConditions(), string(api.FailedConditionType)); failedCondition != nil {
+		meta.SetStatusCondition(status.GetConditions(), metav1.Condition{
+			Type:    string(api.FailedConditionType),
+			Status:  metav1.ConditionFalse,
+			Reason:  failedCondition.Reason,
+			Message: failedCondition.Message,
+		})
+	}
+
+	if *err == nil {
+		meta.SetStatusCondition(status.GetConditions(), metav1.Condition{
+			Type:   string(api.HealthyConditionType),
+			Status: metav1.ConditionTrue,
+			Reason: string(infrastructure.ExternalServiceReachableReason),
+		})
+	} else if reason == infrastructure.ExternalServiceNotReachableReason {
+		meta.SetStatusCondition(status.GetConditions(), metav1.Condition{
+			Type:    string(api.HealthyConditionType),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(reason),
+			Message: (*err).Error(),
+		})
+	}
+
+	if statusErr := kubernetes.ResourceC(e.Client).UpdateStatus(e.instance); statusErr != nil {
+		e.Log.Error(statusErr, "Error while updating status for external supporting service")
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitosupportingservice
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/connector"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/internal/app"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	meta2 "k8s.io/apimachinery/pkg/api/meta"
+	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcileExternalDataIndex_Reconcile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if r.URL.Path != externalServiceHealthPath || !ok || username != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ns := t.Name()
+	dataIndex := test.CreateFakeDataIndex(ns)
+	dataIndex.Spec.External = &v1beta1.ExternalSupportingService{URL: server.URL, CredentialsSecret: "data-index-credentials"}
+	credentials := &corev1.Secret{
+		ObjectMeta: v13.ObjectMeta{Name: "data-index-credentials", Namespace: ns},
+		Data:       map[string][]byte{externalServiceUsernameKey: []byte("user"), externalServicePasswordKey: []byte("pass")},
+	}
+	runtime := test.CreateFakeKogitoRuntime(ns)
+	runtimeDeployment := &v1.Deployment{
+		ObjectMeta: v13.ObjectMeta{Name: runtime.Name, Namespace: ns, Annotations: map[string]string{operator.KogitoRuntimeKey: "true"}},
+		Spec: v1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: runtime.Name}}},
+			},
+		},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(dataIndex, credentials, runtime, runtimeDeployment).Build()
+	context := operator.Context{
+		Client:  cli,
+		Log:     test.TestLogger,
+		Scheme:  meta.GetRegisteredSchema(),
+		Version: "1.0-SNAPSHOT",
+	}
+	r := NewReconcilerHandler(context, app.NewKogitoInfraHandler(context), app.NewKogitoSupportingServiceHandler(context), app.NewKogitoRuntimeHandler(context), nil, nil).
+		GetSupportingServiceReconciler(dataIndex)
+
+	err := r.Reconcile()
+	assert.NoError(t, err)
+
+	// nothing is deployed for external services
+	deployment := &v1.Deployment{ObjectMeta: v13.ObjectMeta{Name: dataIndex.Name, Namespace: ns}}
+	exists, err := kubernetes.ResourceC(cli).Fetch(deployment)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	endpointConfigMap := &corev1.ConfigMap{ObjectMeta: v13.ObjectMeta{Name: infrastructure.NewEndPointConfigMapHandler(context).GetEndPointConfigMapName(dataIndex.Name), Namespace: ns}}
+	exists, err = kubernetes.ResourceC(cli).Fetch(endpointConfigMap)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, server.URL, endpointConfigMap.Data[connector.DataIndexHTTPRouteEnv])
+	assert.Contains(t, endpointConfigMap.Data[connector.DataIndexWSRouteEnv], "ws://")
+
+	exists, err = kubernetes.ResourceC(cli).Fetch(runtimeDeployment)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, endpointConfigMap.Name, runtimeDeployment.Spec.Template.Spec.Containers[0].EnvFrom[0].ConfigMapRef.Name)
+
+	_, err = kubernetes.ResourceC(cli).Fetch(dataIndex)
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL, dataIndex.Status.ExternalURI)
+	assert.True(t, meta2.IsStatusConditionTrue(*dataIndex.Status.Conditions, string(api.HealthyConditionType)))
+}
+
+func TestReconcileExternalJobsService_NotReachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ns := t.Name()
+	jobsService := test.CreateFakeJobsService(ns)
+	jobsService.Spec.External = &v1beta1.ExternalSupportingService{URL: server.URL}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(jobsService).Build()
+	context := operator.Context{
+		Client:  cli,
+		Log:     test.TestLogger,
+		Scheme:  meta.GetRegisteredSchema(),
+		Version: "1.0-SNAPSHOT",
+	}
+	r := NewReconcilerHandler(context, app.NewKogitoInfraHandler(context), app.NewKogitoSupportingServiceHandler(context), app.NewKogitoRuntimeHandler(context), nil, nil).
+		GetSupportingServiceReconciler(jobsService)
+
+	err := r.Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, infrastructure.ExternalServiceNotReachableReason, infrastructure.NewReconciliationErrorHandler(context).GetReasonForError(err))
+
+	endpointConfigMap := &corev1.ConfigMap{ObjectMeta: v13.ObjectMeta{Name: infrastructure.NewEndPointConfigMapHandler(context).GetEndPointConfigMapName(jobsService.Name), Namespace: ns}}
+	exists, err := kubernetes.ResourceC(cli).Fetch(endpointConfigMap)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, server.URL, endpointConfigMap.Data[connector.JobsServicesHTTPRouteEnv])
+
+	_, err = kubernetes.ResourceC(cli).Fetch(jobsService)
+	assert.NoError(t, err)
+	healthy := meta2.FindStatusCondition(*jobsService.Status.Conditions, string(api.HealthyConditionType))
+	assert.NotNil(t, healthy)
+	assert.Equal(t, v13.ConditionFalse, healthy.Status)
+	assert.True(t, meta2.IsStatusConditionTrue(*jobsService.Status.Conditions, string(api.FailedConditionType)))
+}
+
+func TestReconcileExternalMgmtConsole_Unsupported(t *testing.T) {
+	ns := t.Name()
+	mgmtConsole := test.CreateFakeMgmtConsole(ns)
+	mgmtConsole.Spec.External = &v1beta1.ExternalSupportingService{URL: "http://mgmt-console.example.com"}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(mgmtConsole).Build()
+	context := operator.Context{
+		Client:  cli,
+		Log:     test.TestLogger,
+		Scheme:  meta.GetRegisteredSchema(),
+		Version: "1.0-SNAPSHOT",
+	}
+	r := NewReconcilerHandler(context, app.NewKogitoInfraHandler(context), app.NewKogitoSupportingServiceHandler(context), app.NewKogitoRuntimeHandler(context), nil, nil).
+		GetSupportingServiceReconciler(mgmtConsole)
+
+	err := r.Reconcile()
+	assert.Error(t, err)
+}
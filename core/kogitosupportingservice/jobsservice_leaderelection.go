@@ -0,0 +1,247 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitosupportingservice
+
+import (
+	"fmt"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/kogitoservice"
+	"github.com/kiegroup/kogito-operator/core/record"
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// jobsServiceLeaderElectionEnabledEnv enables the leader election among the Jobs Service replicas, only the leader schedules the jobs
+	jobsServiceLeaderElectionEnabledEnv = "KOGITO_JOBS_SERVICE_LEADER_ELECTION_ENABLED"
+	// jobsServiceLeaderElectionLeaseEnv is the name of the Lease used by the Jobs Service replicas to elect the leader
+	jobsServiceLeaderElectionLeaseEnv = "KOGITO_JOBS_SERVICE_LEADER_ELECTION_LEASE_NAME"
+	// jobsServiceLeaderElectionIdentityEnv is the identity of the replica in the Lease, the pod name
+	jobsServiceLeaderElectionIdentityEnv = "KOGITO_JOBS_SERVICE_LEADER_ELECTION_IDENTITY"
+	// jobsServiceStatusEventsTopic is the topic where Jobs Service publishes the job status events
+	jobsServiceStatusEventsTopic = "kogito-job-service-job-status-events"
+	leaderElectionLeaseSuffix    = "leader"
+	leaderElectionRoleSuffix     = "leader-election"
+)
+
+var leaderElectionRoleVerbs = []string{"get", "list", "watch", "create", "update", "patch"}
+
+// jobsServiceLeaderElectionHandler runs Jobs Service with more than one replica.
+// The replicas share a persistent storage and elect a leader through a Lease, so only one of them fires the jobs.
+type jobsServiceLeaderElectionHandler struct {
+	supportingServiceContext
+	kafkaHandler infrastructure.KafkaHandler
+	recorder     record.EventRecorder
+	// requestedReplicas is kept before the deployment limits the service to one replica
+	requestedReplicas int32
+	// storageIssue explains why the requested replicas can't share a persistent storage
+	storageIssue string
+}
+
+func newJobsServiceLeaderElectionHandler(context supportingServiceContext) *jobsServiceLeaderElectionHandler {
+	handler := &jobsServiceLeaderElectionHandler{
+		supportingServiceContext: context,
+		kafkaHandler:             infrastructure.NewKafkaHandler(context.Context),
+		recorder:                 record.NewRecorder(context.Scheme, corev1.EventSource{Component: context.instance.GetName(), Host: record.GetHostName()}),
+	}
+	if replicas := context.instance.GetSpec().GetReplicas(); replicas != nil {
+		handler.requestedReplicas = *replicas
+	}
+	return handler
+}
+
+// getPersistenceImageName returns the Jobs Service image for the storage shared by the replicas,
+// empty if more than one replica was requested without a storage or without the KogitoInfra it requires.
+// A KogitoInfra without resource doesn't tell which storage it connects to, so the storage must be set explicitly.
+func (j *jobsServiceLeaderElectionHandler) getPersistenceImageName() (string, error) {
+	replicas := j.instance.GetSpec().GetReplicas()
+	if replicas == nil || *replicas <= 1 {
+		return "", nil
+	}
+	var storage api.JobsServiceStorageType
+	if jobsService := j.instance.GetSupportingServiceSpec().GetJobsService(); jobsService != nil {
+		storage = jobsService.GetStorage()
+	}
+	infraKinds, err := j.getReferencedInfraKinds()
+	if err != nil {
+		return "", err
+	}
+	switch storage {
+	case api.MongoDBJobsServiceStorage:
+		if infraKinds[infrastructure.MongoDBKind] {
+			return JobsServiceMongoDBImageName, nil
+		}
+		j.storageIssue = fmt.Sprintf("storage %s requires a KogitoInfra referencing a %s instance", storage, infrastructure.MongoDBKind)
+	case api.PostgreSQLJobsServiceStorage:
+		if infraKinds[""] {
+			return JobsServicePostgresqlImageName, nil
+		}
+		j.storageIssue = fmt.Sprintf("storage %s requires a KogitoInfra without resource holding the connection properties", storage)
+	default:
+		j.storageIssue = fmt.Sprintf("the storage shared by the replicas must be set, either %s or %s", api.MongoDBJobsServiceStorage, api.PostgreSQLJobsServiceStorage)
+	}
+	j.Log.Warn("Jobs Service can't run more than one replica", "replicas", *replicas, "reason", j.storageIssue)
+	return "", nil
+}
+
+// updateHighAvailabilityCondition reports on the HighAvailability condition whether the requested replicas run with the leader election
+// or were reduced to one, an event is recorded when they are reduced
+func (j *jobsServiceLeaderElectionHandler) updateHighAvailabilityCondition(leaderElection bool) error {
+	instanceStatus := j.instance.GetSupportingServiceStatus()
+	if instanceStatus.GetConditions() == nil {
+		instanceStatus.SetConditions(&[]metav1.Condition{})
+	}
+	if j.requestedReplicas <= 1 {
+		if meta.FindStatusCondition(*instanceStatus.GetConditions(), string(api.HighAvailabilityConditionType)) == nil {
+			return nil
+		}
+		meta.RemoveStatusCondition(instanceStatus.GetConditions(), string(api.HighAvailabilityConditionType))
+		return kubernetes.ResourceC(j.Client).UpdateStatus(j.instance)
+	}
+	status := metav1.ConditionTrue
+	reason := infrastructure.LeaderElectionReason
+	message := fmt.Sprintf("%d replicas elect a leader through the Lease %s", j.requestedReplicas, j.getLeaseName())
+	if !leaderElection {
+		status = metav1.ConditionFalse
+		reason = infrastructure.ReplicasRequirePersistenceReason
+		message = fmt.Sprintf("%d replicas requested, but Jobs Service runs with 1 replica: %s", j.requestedReplicas, j.storageIssue)
+	}
+	condition := meta.FindStatusCondition(*instanceStatus.GetConditions(), string(api.HighAvailabilityConditionType))
+	if condition != nil && condition.Status == status && condition.Message == message {
+		return nil
+	}
+	meta.SetStatusCondition(instanceStatus.GetConditions(), metav1.Condition{
+		Type:    string(api.HighAvailabilityConditionType),
+		Status:  status,
+		Reason:  string(reason),
+		Message: message,
+	})
+	if status == metav1.ConditionFalse {
+		j.recorder.Event(j.Client, j.instance, corev1.EventTypeWarning, string(reason), message)
+	}
+	return kubernetes.ResourceC(j.Client).UpdateStatus(j.instance)
+}
+
+// reconcileRBAC creates the service account used by the Jobs Service pods, with the permissions to handle the leader election Lease
+func (j *jobsServiceLeaderElectionHandler) reconcileRBAC() error {
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: j.instance.GetName(), Namespace: j.instance.GetNamespace()}}
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: j.getRoleName(), Namespace: j.instance.GetNamespace()},
+		Rules: []rbacv1.PolicyRule{
+			{
+				Verbs:     leaderElectionRoleVerbs,
+				APIGroups: []string{coordinationv1.GroupName},
+				Resources: []string{"leases"},
+			},
+		},
+	}
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: j.getRoleName(), Namespace: j.instance.GetNamespace()},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: serviceAccount.Name}},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: role.Name},
+	}
+	// the Lease is created by the operator to be owned by the service, the replicas only update it
+	lease := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: j.getLeaseName(), Namespace: j.instance.GetNamespace()}}
+	for _, resource := range []client.Object{serviceAccount, role, roleBinding, lease} {
+		if err := kubernetes.ResourceC(j.Client).CreateIfNotExistsForOwner(resource, j.instance, j.Scheme); err != nil {
+			j.Log.Error(err, "Fail to create resource for Jobs Service leader election", "resource", resource.GetName())
+			return err
+		}
+	}
+	return nil
+}
+
+// onDeploymentCreate configures the leader election on the Jobs Service deployment
+func (j *jobsServiceLeaderElectionHandler) onDeploymentCreate(deployment *appsv1.Deployment) error {
+	deployment.Spec.Template.Spec.ServiceAccountName = j.instance.GetName()
+	container := &deployment.Spec.Template.Spec.Containers[0]
+	framework.SetEnvVar(jobsServiceLeaderElectionEnabledEnv, "true", container)
+	framework.SetEnvVar(jobsServiceLeaderElectionLeaseEnv, j.getLeaseName(), container)
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:      jobsServiceLeaderElectionIdentityEnv,
+		ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+	})
+	return nil
+}
+
+// reconcileKafkaTopics makes sure that the topics used by Jobs Service have at least one partition per replica,
+// the topics are written along with the ones declared by the services so the partitions are only increased
+func (j *jobsServiceLeaderElectionHandler) reconcileKafkaTopics() error {
+	serviceName := fmt.Sprintf("%s/%s", j.instance.GetNamespace(), j.instance.GetName())
+	settings := []api.KafkaTopicSettingsInterface{infrastructure.NewKafkaTopicPartitionsSettings(j.requestedReplicas)}
+	for _, infraName := range j.instance.GetSpec().GetInfra() {
+		infra, err := j.infraHandler.FetchKogitoInfraInstance(types.NamespacedName{Name: infraName, Namespace: j.instance.GetNamespace()})
+		if err != nil {
+			return err
+		}
+		if infra == nil || !kogitoservice.IsKafkaResource(infra) {
+			continue
+		}
+		kafkaKey := infrastructure.GetInfraResourceKey(infra)
+		if kafkaKey == nil {
+			continue
+		}
+		if err = kogitoservice.AddKafkaTopicsFinalizer(j.Context, j.instance); err != nil {
+			return err
+		}
+		if _, err = j.kafkaHandler.ApplyKafkaTopic(jobsServiceStatusEventsTopic, kafkaKey.Name, kafkaKey.Namespace, serviceName, settings, j.instance.GetSpec().GetPatches()...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateLeaderStatus exposes the replica holding the leader election Lease in the service status
+func (j *jobsServiceLeaderElectionHandler) updateLeaderStatus(leaderElection bool) error {
+	leader := ""
+	if leaderElection {
+		lease := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: j.getLeaseName(), Namespace: j.instance.GetNamespace()}}
+		exists, err := kubernetes.ResourceC(j.Client).Fetch(lease)
+		if err != nil {
+			return err
+		}
+		if exists && lease.Spec.HolderIdentity != nil {
+			leader = *lease.Spec.HolderIdentity
+		}
+	}
+	if j.instance.GetSupportingServiceStatus().GetLeader() == leader {
+		return nil
+	}
+	j.instance.GetSupportingServiceStatus().SetLeader(leader)
+	return kubernetes.ResourceC(j.Client).UpdateStatus(j.instance)
+}
+
+func (j *jobsServiceLeaderElectionHandler) getLeaseName() string {
+	return GetLeaderElectionLeaseName(j.instance.GetName())
+}
+
+func (j *jobsServiceLeaderElectionHandler) getRoleName() string {
+	return fmt.Sprintf("%s-%s", j.instance.GetName(), leaderElectionRoleSuffix)
+}
+
+// GetLeaderElectionLeaseName returns the name of the Lease used by the replicas of the given service to elect the leader
+func GetLeaderElectionLeaseName(serviceName string) string {
+	return fmt.Sprintf("%s-%s", serviceName, leaderElectionLeaseSuffix)
+}
@@ -0,0 +1,182 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitosupportingservice
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/internal/app"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	meta2 "k8s.io/apimachinery/pkg/api/meta"
+	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newJobsServiceResourceForTest(jobsService *v1beta1.KogitoSupportingService, objects ...runtime.Object) (*jobsServiceSupportingServiceResource, operator.Context) {
+	cli := test.NewFakeClientBuilder().AddK8sObjects(append(objects, jobsService)...).Build()
+	context := operator.Context{
+		Client:  cli,
+		Log:     test.TestLogger,
+		Scheme:  meta.GetRegisteredSchema(),
+		Version: "1.0-SNAPSHOT",
+	}
+	return &jobsServiceSupportingServiceResource{
+		supportingServiceContext: supportingServiceContext{
+			Context:                  context,
+			instance:                 jobsService,
+			supportingServiceHandler: app.NewKogitoSupportingServiceHandler(context),
+			infraHandler:             app.NewKogitoInfraHandler(context),
+			runtimeHandler:           app.NewKogitoRuntimeHandler(context),
+		},
+	}, context
+}
+
+func TestReconcileKogitoJobsService_LeaderElection(t *testing.T) {
+	ns := t.Name()
+	replicas := int32(3)
+	kogitoMongoDB := test.CreateFakeKogitoMongoDB(ns)
+	kogitoKafka := test.CreateFakeKogitoKafka(ns)
+	jobsService := test.CreateFakeJobsService(ns)
+	jobsService.Spec.Replicas = &replicas
+	jobsService.Spec.JobsService = &v1beta1.JobsServiceSpec{Storage: api.MongoDBJobsServiceStorage}
+	jobsService.GetSpec().AddInfra(kogitoMongoDB.GetName())
+	jobsService.GetSpec().AddInfra(kogitoKafka.GetName())
+	r, context := newJobsServiceResourceForTest(jobsService, kogitoMongoDB, kogitoKafka)
+
+	err := r.Reconcile()
+	assert.NoError(t, err)
+
+	deployment := &v1.Deployment{ObjectMeta: v13.ObjectMeta{Name: jobsService.Name, Namespace: ns}}
+	exists, err := kubernetes.ResourceC(context.Client).Fetch(deployment)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, replicas, *deployment.Spec.Replicas)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Image, JobsServiceMongoDBImageName)
+	assert.Equal(t, jobsService.Name, deployment.Spec.Template.Spec.ServiceAccountName)
+	container := &deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "true", framework.GetEnvVarFromContainer(jobsServiceLeaderElectionEnabledEnv, container))
+	assert.Equal(t, GetLeaderElectionLeaseName(jobsService.Name), framework.GetEnvVarFromContainer(jobsServiceLeaderElectionLeaseEnv, container))
+
+	for _, resource := range []client.Object{
+		&corev1.ServiceAccount{ObjectMeta: v13.ObjectMeta{Name: jobsService.Name, Namespace: ns}},
+		&rbacv1.Role{ObjectMeta: v13.ObjectMeta{Name: jobsService.Name + "-leader-election", Namespace: ns}},
+		&rbacv1.RoleBinding{ObjectMeta: v13.ObjectMeta{Name: jobsService.Name + "-leader-election", Namespace: ns}},
+	} {
+		exists, err = kubernetes.ResourceC(context.Client).Fetch(resource)
+		assert.NoError(t, err)
+		assert.True(t, exists, resource.GetName())
+	}
+
+	kafkaTopic, err := infrastructure.NewKafkaHandler(context).FetchKafkaTopic(types.NamespacedName{Name: jobsServiceStatusEventsTopic, Namespace: ns})
+	assert.NoError(t, err)
+	assert.NotNil(t, kafkaTopic)
+	assert.Equal(t, replicas, kafkaTopic.Spec.Partitions)
+
+	// a replica takes the leadership
+	lease := &coordinationv1.Lease{ObjectMeta: v13.ObjectMeta{Name: GetLeaderElectionLeaseName(jobsService.Name), Namespace: ns}}
+	exists, err = kubernetes.ResourceC(context.Client).Fetch(lease)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	holder := "jobs-service-7d9f8b-abcde"
+	lease.Spec.HolderIdentity = &holder
+	assert.NoError(t, kubernetes.ResourceC(context.Client).Update(lease))
+
+	err = r.Reconcile()
+	assert.NoError(t, err)
+	_, err = kubernetes.ResourceC(context.Client).Fetch(jobsService)
+	assert.NoError(t, err)
+	assert.Equal(t, holder, jobsService.Status.Leader)
+	assert.True(t, meta2.IsStatusConditionTrue(*jobsService.Status.Conditions, string(api.HighAvailabilityConditionType)))
+}
+
+func TestReconcileKogitoJobsService_MultipleReplicasWithoutPersistence(t *testing.T) {
+	ns := t.Name()
+	replicas := int32(2)
+	jobsService := test.CreateFakeJobsService(ns)
+	jobsService.Spec.Replicas = &replicas
+	r, context := newJobsServiceResourceForTest(jobsService)
+
+	err := r.Reconcile()
+	assert.NoError(t, err)
+
+	deployment := &v1.Deployment{ObjectMeta: v13.ObjectMeta{Name: jobsService.Name, Namespace: ns}}
+	exists, err := kubernetes.ResourceC(context.Client).Fetch(deployment)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+	assert.Empty(t, deployment.Spec.Template.Spec.ServiceAccountName)
+
+	exists, err = kubernetes.ResourceC(context.Client).Fetch(&coordinationv1.Lease{ObjectMeta: v13.ObjectMeta{Name: GetLeaderElectionLeaseName(jobsService.Name), Namespace: ns}})
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	condition := meta2.FindStatusCondition(*jobsService.Status.Conditions, string(api.HighAvailabilityConditionType))
+	assert.NotNil(t, condition)
+	assert.Equal(t, v13.ConditionFalse, condition.Status)
+	assert.Equal(t, string(infrastructure.ReplicasRequirePersistenceReason), condition.Reason)
+	events := &corev1.EventList{}
+	assert.NoError(t, kubernetes.ResourceC(context.Client).ListWithNamespace(ns, events))
+	assert.Len(t, events.Items, 1)
+	assert.Equal(t, string(infrastructure.ReplicasRequirePersistenceReason), events.Items[0].Reason)
+}
+
+func TestReconcileKogitoJobsService_MultipleReplicasWithoutStorage(t *testing.T) {
+	ns := t.Name()
+	replicas := int32(2)
+	kogitoPostgreSQL := createFakeKogitoPostgreSQL(ns)
+	jobsService := test.CreateFakeJobsService(ns)
+	jobsService.Spec.Replicas = &replicas
+	jobsService.GetSpec().AddInfra(kogitoPostgreSQL.GetName())
+	r, context := newJobsServiceResourceForTest(jobsService, kogitoPostgreSQL)
+
+	// the KogitoInfra without resource isn't taken for PostgreSQL
+	err := r.Reconcile()
+	assert.NoError(t, err)
+	deployment := &v1.Deployment{ObjectMeta: v13.ObjectMeta{Name: jobsService.Name, Namespace: ns}}
+	exists, err := kubernetes.ResourceC(context.Client).Fetch(deployment)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+	assert.NotContains(t, deployment.Spec.Template.Spec.Containers[0].Image, JobsServicePostgresqlImageName)
+	condition := meta2.FindStatusCondition(*jobsService.Status.Conditions, string(api.HighAvailabilityConditionType))
+	assert.NotNil(t, condition)
+	assert.Equal(t, string(infrastructure.ReplicasRequirePersistenceReason), condition.Reason)
+
+	jobsService = test.CreateFakeJobsService(ns)
+	jobsService.Spec.Replicas = &replicas
+	jobsService.Spec.JobsService = &v1beta1.JobsServiceSpec{Storage: api.PostgreSQLJobsServiceStorage}
+	jobsService.GetSpec().AddInfra(kogitoPostgreSQL.GetName())
+	r, context = newJobsServiceResourceForTest(jobsService, kogitoPostgreSQL)
+	err = r.Reconcile()
+	assert.NoError(t, err)
+	_, err = kubernetes.ResourceC(context.Client).Fetch(deployment)
+	assert.NoError(t, err)
+	assert.Equal(t, replicas, *deployment.Spec.Replicas)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Image, JobsServicePostgresqlImageName)
+}
@@ -17,6 +17,7 @@ package kogitosupportingservice
 import (
 	"github.com/kiegroup/kogito-operator/core/connector"
 	"github.com/kiegroup/kogito-operator/core/kogitoservice"
+	"github.com/kiegroup/kogito-operator/core/shared"
 	"k8s.io/apimachinery/pkg/types"
 	controller "sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -50,14 +51,45 @@ func initJobsServiceSupportingServiceResource(context supportingServiceContext)
 func (j *jobsServiceSupportingServiceResource) Reconcile() (err error) {
 	j.Log.Info("Reconciling for KogitoJobsService")
 
+	leaderElectionHandler := newJobsServiceLeaderElectionHandler(j.supportingServiceContext)
+	imageName, err := leaderElectionHandler.getPersistenceImageName()
+	if err != nil {
+		return
+	}
+	// more than one replica can only run along with a shared persistent storage
+	leaderElection := len(imageName) > 0
 	definition := kogitoservice.ServiceDefinition{
 		DefaultImageName: DefaultJobsServiceImageName,
 		Request:          controller.Request{NamespacedName: types.NamespacedName{Name: j.instance.GetName(), Namespace: j.instance.GetNamespace()}},
-		SingleReplica:    true,
+		SingleReplica:    !leaderElection,
+		Introspector:     j.introspector,
+		DigestResolver:   j.digestResolver,
+	}
+	if leaderElection {
+		if err = leaderElectionHandler.reconcileRBAC(); err != nil {
+			return
+		}
+		definition.DefaultImageName = imageName
+		definition.OnDeploymentCreate = leaderElectionHandler.onDeploymentCreate
 	}
 	if err = kogitoservice.NewServiceDeployer(j.Context, definition, j.instance, j.infraHandler).Deploy(); err != nil {
 		return
 	}
+	if err = shared.NewJobsServiceInfinispanCacheReconciler(j.Context, j.instance, j.infraHandler).Reconcile(); err != nil {
+		return
+	}
+
+	if leaderElection {
+		if err = leaderElectionHandler.reconcileKafkaTopics(); err != nil {
+			return
+		}
+	}
+	if err = leaderElectionHandler.updateLeaderStatus(leaderElection); err != nil {
+		return
+	}
+	if err = leaderElectionHandler.updateHighAvailabilityCondition(leaderElection); err != nil {
+		return
+	}
 
 	endpointConfigMapReconciler := newEndPointConfigMapReconciler(j.Context, j.instance, connector.JobsServicesHTTPRouteEnv, "")
 	if err = endpointConfigMapReconciler.Reconcile(); err != nil {
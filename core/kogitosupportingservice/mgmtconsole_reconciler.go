@@ -49,6 +49,8 @@ func (m *mgmtConsoleSupportingServiceResource) Reconcile() (err error) {
 		Request:            controller.Request{NamespacedName: types.NamespacedName{Name: m.instance.GetName(), Namespace: m.instance.GetNamespace()}},
 		SingleReplica:      false,
 		OnDeploymentCreate: m.mgmtConsoleOnDeploymentCreate,
+		Introspector:       m.introspector,
+		DigestResolver:     m.digestResolver,
 	}
 	return kogitoservice.NewServiceDeployer(m.Context, definition, m.instance, m.infraHandler).Deploy()
 }
@@ -16,8 +16,11 @@ package kogitosupportingservice
 
 import (
 	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/imagedigest"
+	"github.com/kiegroup/kogito-operator/core/introspection"
 	"github.com/kiegroup/kogito-operator/core/manager"
 	"github.com/kiegroup/kogito-operator/core/operator"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // Reconciler Interface to represent type of kogito supporting service resources like JobsService & MgmtConcole
@@ -31,6 +34,8 @@ type supportingServiceContext struct {
 	infraHandler             manager.KogitoInfraHandler
 	supportingServiceHandler manager.KogitoSupportingServiceHandler
 	runtimeHandler           manager.KogitoRuntimeHandler
+	introspector             *introspection.Introspector
+	digestResolver           *imagedigest.Resolver
 }
 
 // ReconcilerHandler ...
@@ -43,15 +48,20 @@ type reconcilerHandler struct {
 	infraHandler             manager.KogitoInfraHandler
 	supportingServiceHandler manager.KogitoSupportingServiceHandler
 	runtimeHandler           manager.KogitoRuntimeHandler
+	introspector             *introspection.Introspector
+	digestResolver           *imagedigest.Resolver
 }
 
-// NewReconcilerHandler ...
-func NewReconcilerHandler(context operator.Context, infraHandler manager.KogitoInfraHandler, supportingServiceHandler manager.KogitoSupportingServiceHandler, runtimeHandler manager.KogitoRuntimeHandler) ReconcilerHandler {
+// NewReconcilerHandler creates the handler of the supporting services, their endpoints are polled by the given introspector
+// and their image tags by the given digest resolver
+func NewReconcilerHandler(context operator.Context, infraHandler manager.KogitoInfraHandler, supportingServiceHandler manager.KogitoSupportingServiceHandler, runtimeHandler manager.KogitoRuntimeHandler, introspector *introspection.Introspector, digestResolver *imagedigest.Resolver) ReconcilerHandler {
 	return &reconcilerHandler{
 		Context:                  context,
 		infraHandler:             infraHandler,
 		supportingServiceHandler: supportingServiceHandler,
 		runtimeHandler:           runtimeHandler,
+		introspector:             introspector,
+		digestResolver:           digestResolver,
 	}
 }
 
@@ -64,6 +74,11 @@ func (k *reconcilerHandler) GetSupportingServiceReconciler(instance api.KogitoSu
 		infraHandler:             k.infraHandler,
 		supportingServiceHandler: k.supportingServiceHandler,
 		runtimeHandler:           k.runtimeHandler,
+		introspector:             k.introspector,
+		digestResolver:           k.digestResolver,
+	}
+	if instance.GetSupportingServiceSpec().IsExternal() {
+		return initExternalSupportingServiceResource(context)
 	}
 	return getSupportedResources(context)[instance.GetSupportingServiceSpec().GetServiceType()]
 }
@@ -77,5 +92,26 @@ func getSupportedResources(context supportingServiceContext) map[api.ServiceType
 		api.TaskConsole:    initTaskConsoleSupportingServiceResource(context),
 		api.TrustyAI:       initTrustyAISupportingServiceResource(context),
 		api.TrustyUI:       initTrustyUISupportingServiceResource(context),
+		api.TrustyStack:    initTrustyStackSupportingServiceResource(context),
+	}
+}
+
+// getReferencedInfraKinds returns the resource kinds of the KogitoInfra referenced by the service, KogitoInfra without resource have an empty kind
+func (s *supportingServiceContext) getReferencedInfraKinds() (map[string]bool, error) {
+	infraKinds := map[string]bool{}
+	for _, infraName := range s.instance.GetSpec().GetInfra() {
+		infra, err := s.infraHandler.FetchKogitoInfraInstance(types.NamespacedName{Name: infraName, Namespace: s.instance.GetNamespace()})
+		if err != nil {
+			return nil, err
+		}
+		if infra == nil {
+			continue
+		}
+		if infra.GetSpec().IsResourceEmpty() {
+			infraKinds[""] = true
+		} else {
+			infraKinds[infra.GetSpec().GetResource().GetKind()] = true
+		}
 	}
+	return infraKinds, nil
 }
@@ -49,6 +49,8 @@ func (t *taskConsoleSupportingServiceResource) Reconcile() (err error) {
 		Request:            controller.Request{NamespacedName: types.NamespacedName{Name: t.instance.GetName(), Namespace: t.instance.GetNamespace()}},
 		SingleReplica:      false,
 		OnDeploymentCreate: t.taskConsoleOnDeploymentCreate,
+		Introspector:       t.introspector,
+		DigestResolver:     t.digestResolver,
 	}
 	return kogitoservice.NewServiceDeployer(t.Context, definition, t.instance, t.infraHandler).Deploy()
 }
@@ -47,6 +47,8 @@ func (t *trustyAISupportingServiceResource) Reconcile() (err error) {
 	definition := kogitoservice.ServiceDefinition{
 		DefaultImageName: DefaultTrustyImageName,
 		Request:          controller.Request{NamespacedName: types.NamespacedName{Name: t.instance.GetName(), Namespace: t.instance.GetNamespace()}},
+		Introspector:     t.introspector,
+		DigestResolver:   t.digestResolver,
 	}
 	if err = kogitoservice.NewServiceDeployer(t.Context, definition, t.instance, t.infraHandler).Deploy(); err != nil {
 		return
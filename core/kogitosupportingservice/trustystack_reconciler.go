@@ -0,0 +1,244 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitosupportingservice
+
+import (
+	"fmt"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/kogitoservice"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// trustyStackKafkaTopics are the topics produced by the Kogito runtimes and consumed by Trusty AI, the topics exchanged by Trusty AI
+// and Explainability are declared by these services once the stack deploys them
+var trustyStackKafkaTopics = []string{
+	"kogito-tracing-decision",
+	"kogito-tracing-model",
+}
+
+// trustyStackService is a supporting service deployed by the Trusty stack
+type trustyStackService struct {
+	serviceType api.ServiceType
+	nameSuffix  string
+}
+
+// trustyStackServices are the services deployed by the Trusty stack, in deployment order
+var trustyStackServices = []trustyStackService{
+	{serviceType: api.TrustyAI, nameSuffix: DefaultTrustyName},
+	{serviceType: api.Explainability, nameSuffix: DefaultExplainabilityName},
+	{serviceType: api.TrustyUI, nameSuffix: DefaultTrustyUIName},
+}
+
+// trustyStackSupportingServiceResource deploys Trusty AI, Explainability and Trusty UI as KogitoSupportingServices owned by the stack.
+// The services share the infra referenced by the stack, each one is then reconciled on its own.
+type trustyStackSupportingServiceResource struct {
+	supportingServiceContext
+	kafkaHandler infrastructure.KafkaHandler
+	errorHandler infrastructure.ReconciliationErrorHandler
+}
+
+func initTrustyStackSupportingServiceResource(context supportingServiceContext) Reconciler {
+	context.Log = context.Log.WithValues("resource", "trusty-stack")
+	return &trustyStackSupportingServiceResource{
+		supportingServiceContext: context,
+		kafkaHandler:             infrastructure.NewKafkaHandler(context.Context),
+		errorHandler:             infrastructure.NewReconciliationErrorHandler(context.Context),
+	}
+}
+
+// Reconcile reconcile Trusty stack
+func (t *trustyStackSupportingServiceResource) Reconcile() (err error) {
+	t.Log.Info("Reconciling for Trusty stack")
+	defer t.updateStatus(&err)
+
+	kafkaInfra, err := t.validateInfra()
+	if err != nil {
+		return
+	}
+	if err = t.validateKafkaTopics(kafkaInfra); err != nil {
+		return
+	}
+
+	var notReady []string
+	for _, stackService := range trustyStackServices {
+		service, err := t.reconcileService(stackService)
+		if err != nil {
+			return err
+		}
+		if service.GetStatus().GetConditions() == nil || !meta.IsStatusConditionTrue(*service.GetStatus().GetConditions(), string(api.DeployedConditionType)) {
+			notReady = append(notReady, service.GetName())
+		}
+	}
+	if len(notReady) > 0 {
+		err = infrastructure.ErrorForTrustyStackServicesNotReady(t.instance.GetName(), notReady)
+	}
+	return
+}
+
+// validateInfra checks that the stack references the Infinispan used by Trusty AI as storage and the Kafka used to exchange the events,
+// it returns the Kafka infra
+func (t *trustyStackSupportingServiceResource) validateInfra() (api.KogitoInfraInterface, error) {
+	var kafkaInfra api.KogitoInfraInterface
+	infinispan := false
+	for _, infraName := range t.instance.GetSpec().GetInfra() {
+		infra, err := t.infraHandler.FetchKogitoInfraInstance(types.NamespacedName{Name: infraName, Namespace: t.instance.GetNamespace()})
+		if err != nil {
+			return nil, err
+		}
+		if infra == nil || infra.GetSpec().IsResourceEmpty() {
+			continue
+		}
+		if kogitoservice.IsKafkaResource(infra) {
+			kafkaInfra = infra
+		} else if infra.GetSpec().GetResource().GetKind() == infrastructure.InfinispanKind {
+			infinispan = true
+		}
+	}
+	if !infinispan {
+		return nil, infrastructure.ErrorForTrustyStackInvalidInfra(t.instance.GetName(), fmt.Sprintf("a KogitoInfra referencing an %s instance is required", infrastructure.InfinispanKind))
+	}
+	if kafkaInfra == nil {
+		return nil, infrastructure.ErrorForTrustyStackInvalidInfra(t.instance.GetName(), fmt.Sprintf("a KogitoInfra referencing a %s instance is required", infrastructure.KafkaKind))
+	}
+	return kafkaInfra, nil
+}
+
+// validateKafkaTopics checks that the topics produced by the Kogito runtimes for Trusty AI exist on the Kafka instance
+func (t *trustyStackSupportingServiceResource) validateKafkaTopics(kafkaInfra api.KogitoInfraInterface) error {
+	kafkaKey := infrastructure.GetInfraResourceKey(kafkaInfra)
+	if kafkaKey == nil {
+		return infrastructure.ErrorForTrustyStackInvalidInfra(t.instance.GetName(), fmt.Sprintf("KogitoInfra %s doesn't reference a %s instance", kafkaInfra.GetName(), infrastructure.KafkaKind))
+	}
+	var missingTopics []string
+	for _, topic := range trustyStackKafkaTopics {
+		kafkaTopic, err := t.kafkaHandler.FetchKafkaTopic(types.NamespacedName{Name: topic, Namespace: kafkaKey.Namespace})
+		if err != nil {
+			return err
+		}
+		if kafkaTopic == nil {
+			missingTopics = append(missingTopics, topic)
+		}
+	}
+	if len(missingTopics) > 0 {
+		return infrastructure.ErrorForTrustyStackMissingKafkaTopics(t.instance.GetName(), missingTopics)
+	}
+	return nil
+}
+
+// reconcileService creates the stack service if it doesn't exist yet and keeps the stack infra on it
+func (t *trustyStackSupportingServiceResource) reconcileService(stackService trustyStackService) (api.KogitoSupportingServiceInterface, error) {
+	key := types.NamespacedName{Name: fmt.Sprintf("%s-%s", t.instance.GetName(), stackService.nameSuffix), Namespace: t.instance.GetNamespace()}
+	service, err := t.supportingServiceHandler.FetchKogitoSupportingService(key)
+	if err != nil {
+		return nil, err
+	}
+	if service == nil {
+		service = t.supportingServiceHandler.CreateKogitoSupportingService()
+		service.SetName(key.Name)
+		service.SetNamespace(key.Namespace)
+		service.SetLabels(map[string]string{framework.LabelAppKey: t.instance.GetName()})
+		service.GetSupportingServiceSpec().SetServiceType(stackService.serviceType)
+		for _, infraName := range t.instance.GetSpec().GetInfra() {
+			service.GetSpec().AddInfra(infraName)
+		}
+		t.Log.Info("Creating Trusty stack service", "service", key.Name, "serviceType", stackService.serviceType)
+		if err = kubernetes.ResourceC(t.Client).CreateForOwner(service, t.instance, t.Scheme); err != nil {
+			return nil, err
+		}
+		return service, nil
+	}
+
+	if !metav1.IsControlledBy(service, t.instance) {
+		return nil, infrastructure.ErrorForTrustyStackInvalidInfra(t.instance.GetName(), fmt.Sprintf("KogitoSupportingService %s already exists and is not part of the stack", key.Name))
+	}
+	if service.GetSupportingServiceSpec().GetServiceType() != stackService.serviceType {
+		return nil, fmt.Errorf("KogitoSupportingService %s of the Trusty stack has type %s instead of %s ", key.Name, service.GetSupportingServiceSpec().GetServiceType(), stackService.serviceType)
+	}
+	updated := false
+	for _, infraName := range t.instance.GetSpec().GetInfra() {
+		if !containsInfra(service.GetSpec().GetInfra(), infraName) {
+			service.GetSpec().AddInfra(infraName)
+			updated = true
+		}
+	}
+	if updated {
+		t.Log.Info("Sharing Trusty stack infra with service", "service", key.Name)
+		if err = kubernetes.ResourceC(t.Client).Update(service); err != nil {
+			return nil, err
+		}
+	}
+	return service, nil
+}
+
+func containsInfra(infra []string, name string) bool {
+	for _, infraName := range infra {
+		if infraName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// updateStatus reports the aggregated readiness of the stack services
+func (t *trustyStackSupportingServiceResource) updateStatus(err *error) {
+	status := t.instance.GetStatus()
+	if status.GetConditions() == nil {
+		status.SetConditions(&[]metav1.Condition{})
+	}
+
+	reason := t.errorHandler.GetReasonForError(*err)
+	if *err != nil {
+		meta.SetStatusCondition(status.GetConditions(), metav1.Condition{
+			Type:    string(api.TrustyStackReadyConditionType),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(reason),
+			Message: (*err).Error(),
+		})
+		if reason != infrastructure.TrustyStackServicesNotReadyReason {
+			meta.SetStatusCondition(status.GetConditions(), metav1.Condition{
+				Type:    string(api.FailedConditionType),
+				Status:  metav1.ConditionTrue,
+				Reason:  string(reason),
+				Message: (*err).Error(),
+			})
+		}
+	} else {
+		meta.SetStatusCondition(status.GetConditions(), metav1.Condition{
+			Type:   string(api.TrustyStackReadyConditionType),
+			Status: metav1.ConditionTrue,
+			Reason: string(infrastructure.TrustyStackReadyReason),
+		})
+	}
+	if *err == nil || reason == infrastructure.TrustyStackServicesNotReadyReason {
+		if failedCondition := meta.FindStatusCondition(*status.GetConditions(), string(api.FailedConditionType)); failedCondition != nil {
+			meta.SetStatusCondition(status.GetConditions(), metav1.Condition{
+				Type:    string(api.FailedConditionType),
+				Status:  metav1.ConditionFalse,
+				Reason:  failedCondition.Reason,
+				Message: failedCondition.Message,
+			})
+		}
+	}
+
+	if statusErr := kubernetes.ResourceC(t.Client).UpdateStatus(t.instance); statusErr != nil {
+		t.Log.Error(statusErr, "Error while updating status for Trusty stack")
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kogitosupportingservice
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/infrastructure/kafka/v1beta2"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/internal/app"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	meta2 "k8s.io/apimachinery/pkg/api/meta"
+	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newTrustyStackResourceForTest(trustyStack *v1beta1.KogitoSupportingService, objects ...runtime.Object) (Reconciler, operator.Context) {
+	cli := test.NewFakeClientBuilder().AddK8sObjects(append(objects, trustyStack)...).Build()
+	context := operator.Context{
+		Client:  cli,
+		Log:     test.TestLogger,
+		Scheme:  meta.GetRegisteredSchema(),
+		Version: "1.0-SNAPSHOT",
+	}
+	r := NewReconcilerHandler(context, app.NewKogitoInfraHandler(context), app.NewKogitoSupportingServiceHandler(context), app.NewKogitoRuntimeHandler(context), nil, nil).
+		GetSupportingServiceReconciler(trustyStack)
+	return r, context
+}
+
+func createFakeTrustyStackKafkaTopics(namespace string) []runtime.Object {
+	var topics []runtime.Object
+	for _, topic := range trustyStackKafkaTopics {
+		topics = append(topics, &v1beta2.KafkaTopic{ObjectMeta: v13.ObjectMeta{Name: topic, Namespace: namespace}})
+	}
+	return topics
+}
+
+func TestReconcileTrustyStack_Reconcile(t *testing.T) {
+	ns := t.Name()
+	kogitoKafka := test.CreateFakeKogitoKafka(ns)
+	kogitoInfinispan := test.CreateFakeKogitoInfinispan(ns)
+	trustyStack := test.CreateFakeTrustyStack(ns)
+	trustyStack.GetSpec().AddInfra(kogitoKafka.GetName())
+	trustyStack.GetSpec().AddInfra(kogitoInfinispan.GetName())
+	r, context := newTrustyStackResourceForTest(trustyStack, append(createFakeTrustyStackKafkaTopics(ns), kogitoKafka, kogitoInfinispan)...)
+
+	err := r.Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, infrastructure.TrustyStackServicesNotReadyReason, infrastructure.NewReconciliationErrorHandler(context).GetReasonForError(err))
+
+	for _, stackService := range trustyStackServices {
+		service := &v1beta1.KogitoSupportingService{ObjectMeta: v13.ObjectMeta{Name: trustyStack.Name + "-" + stackService.nameSuffix, Namespace: ns}}
+		exists, err := kubernetes.ResourceC(context.Client).Fetch(service)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, stackService.serviceType, service.Spec.ServiceType)
+		assert.ElementsMatch(t, trustyStack.Spec.Infra, service.Spec.Infra)
+		assert.True(t, v13.IsControlledBy(service, trustyStack))
+
+		// the service reconciliation reports it as deployed
+		service.Status.Conditions = &[]v13.Condition{{Type: string(api.DeployedConditionType), Status: v13.ConditionTrue, Reason: string(infrastructure.SuccessfulDeployedReason)}}
+		assert.NoError(t, kubernetes.ResourceC(context.Client).UpdateStatus(service))
+	}
+
+	_, err = kubernetes.ResourceC(context.Client).Fetch(trustyStack)
+	assert.NoError(t, err)
+	condition := meta2.FindStatusCondition(*trustyStack.Status.Conditions, string(api.TrustyStackReadyConditionType))
+	assert.NotNil(t, condition)
+	assert.Equal(t, v13.ConditionFalse, condition.Status)
+	assert.Nil(t, meta2.FindStatusCondition(*trustyStack.Status.Conditions, string(api.FailedConditionType)))
+
+	err = r.Reconcile()
+	assert.NoError(t, err)
+	_, err = kubernetes.ResourceC(context.Client).Fetch(trustyStack)
+	assert.NoError(t, err)
+	assert.True(t, meta2.IsStatusConditionTrue(*trustyStack.Status.Conditions, string(api.TrustyStackReadyConditionType)))
+}
+
+func TestReconcileTrustyStack_MissingInfra(t *testing.T) {
+	ns := t.Name()
+	kogitoKafka := test.CreateFakeKogitoKafka(ns)
+	trustyStack := test.CreateFakeTrustyStack(ns)
+	trustyStack.GetSpec().AddInfra(kogitoKafka.GetName())
+	r, context := newTrustyStackResourceForTest(trustyStack, kogitoKafka)
+
+	err := r.Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, infrastructure.TrustyStackInvalidInfraReason, infrastructure.NewReconciliationErrorHandler(context).GetReasonForError(err))
+
+	_, err = kubernetes.ResourceC(context.Client).Fetch(trustyStack)
+	assert.NoError(t, err)
+	assert.True(t, meta2.IsStatusConditionTrue(*trustyStack.Status.Conditions, string(api.FailedConditionType)))
+}
+
+func TestReconcileTrustyStack_MissingKafkaTopics(t *testing.T) {
+	ns := t.Name()
+	kogitoKafka := test.CreateFakeKogitoKafka(ns)
+	kogitoInfinispan := test.CreateFakeKogitoInfinispan(ns)
+	trustyStack := test.CreateFakeTrustyStack(ns)
+	trustyStack.GetSpec().AddInfra(kogitoKafka.GetName())
+	trustyStack.GetSpec().AddInfra(kogitoInfinispan.GetName())
+	topics := createFakeTrustyStackKafkaTopics(ns)
+	r, context := newTrustyStackResourceForTest(trustyStack, topics[0], kogitoKafka, kogitoInfinispan)
+
+	err := r.Reconcile()
+	assert.Error(t, err)
+	assert.Equal(t, infrastructure.TrustyStackMissingKafkaTopicsReason, infrastructure.NewReconciliationErrorHandler(context).GetReasonForError(err))
+	assert.Contains(t, err.Error(), trustyStackKafkaTopics[1])
+	assert.NotContains(t, err.Error(), trustyStackKafkaTopics[0])
+
+	list, err := app.NewKogitoSupportingServiceHandler(context).FetchKogitoSupportingServiceList(ns)
+	assert.NoError(t, err)
+	assert.Len(t, list.GetItems(), 1)
+}
+
+func TestReconcileTrustyStack_ProvisionedKafka(t *testing.T) {
+	ns := t.Name()
+	kogitoKafka := test.CreateFakeKogitoKafka(ns).(*v1beta1.KogitoInfra)
+	kogitoKafka.Spec.Resource.Name = ""
+	kogitoKafka.Spec.Provision = &v1beta1.InfraProvision{}
+	kogitoInfinispan := test.CreateFakeKogitoInfinispan(ns)
+	trustyStack := test.CreateFakeTrustyStack(ns)
+	trustyStack.GetSpec().AddInfra(kogitoKafka.GetName())
+	trustyStack.GetSpec().AddInfra(kogitoInfinispan.GetName())
+	// only the topics produced by the runtimes are required, Trusty AI and Explainability declare the others
+	r, context := newTrustyStackResourceForTest(trustyStack, append(createFakeTrustyStackKafkaTopics(ns), kogitoKafka, kogitoInfinispan)...)
+
+	err := r.Reconcile()
+	assert.Equal(t, infrastructure.TrustyStackServicesNotReadyReason, infrastructure.NewReconciliationErrorHandler(context).GetReasonForError(err))
+	list, err := app.NewKogitoSupportingServiceHandler(context).FetchKogitoSupportingServiceList(ns)
+	assert.NoError(t, err)
+	assert.Len(t, list.GetItems(), len(trustyStackServices)+1)
+}
@@ -22,5 +22,6 @@ import (
 // KogitoBuildHandler ...
 type KogitoBuildHandler interface {
 	FetchKogitoBuildInstance(key types.NamespacedName) (api.KogitoBuildInterface, error)
+	FetchAllKogitoBuildInstances(namespace string) (api.KogitoBuildListInterface, error)
 	CreateBuild() api.BuildsInterface
 }
@@ -33,6 +33,7 @@ type KogitoInfraManager interface {
 // KogitoInfraHandler ...
 type KogitoInfraHandler interface {
 	FetchKogitoInfraInstance(key types.NamespacedName) (api.KogitoInfraInterface, error)
+	FetchAllKogitoInfraInstances(namespace string) (api.KogitoInfraListInterface, error)
 }
 
 type kogitoInfraManager struct {
@@ -35,6 +35,7 @@ type KogitoSupportingServiceManager interface {
 type KogitoSupportingServiceHandler interface {
 	FetchKogitoSupportingService(key types.NamespacedName) (api.KogitoSupportingServiceInterface, error)
 	FetchKogitoSupportingServiceList(namespace string) (api.KogitoSupportingServiceListInterface, error)
+	CreateKogitoSupportingService() api.KogitoSupportingServiceInterface
 }
 
 type kogitoSupportingServiceManager struct {
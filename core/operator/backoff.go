@@ -0,0 +1,80 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RequeueBackoff counts the consecutive reconciliation failures of each object to delay its next reconciliation,
+// the count restarts when the object fails with another reason and is forgotten once the object is reconciled successfully or deleted
+type RequeueBackoff struct {
+	config   *Config
+	mutex    sync.Mutex
+	failures map[string]backoffFailures
+	random   func() float64
+}
+
+type backoffFailures struct {
+	reason string
+	count  int
+}
+
+// NewRequeueBackoff creates an empty RequeueBackoff applying the backoff of the given configuration, the default one if nil
+func NewRequeueBackoff(config *Config) *RequeueBackoff {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	return &RequeueBackoff{
+		config:   config,
+		failures: map[string]backoffFailures{},
+		random:   rand.Float64, // #nosec G404 the jitter doesn't need a secure random
+	}
+}
+
+// Next records a new failure of the object with the given key and returns when it must be reconciled again,
+// the interval is the requeue interval of the first failure. A nil RequeueBackoff always returns the interval.
+func (b *RequeueBackoff) Next(key, reason string, interval time.Duration) time.Duration {
+	if b == nil {
+		return interval
+	}
+	b.mutex.Lock()
+	failures := b.failures[key]
+	if failures.reason != reason {
+		failures = backoffFailures{reason: reason}
+	}
+	failures.count++
+	b.failures[key] = failures
+	jitter := b.random()
+	b.mutex.Unlock()
+
+	backoff := b.config.GetBackoff(reason)
+	delay := backoff.Delay(interval, failures.count)
+	delay += time.Duration(backoff.GetJitter() * jitter * float64(delay))
+	requeueDelayMetric.WithLabelValues(reason).Observe(delay.Seconds())
+	return delay
+}
+
+// Forget removes the count of failures of the object with the given key
+func (b *RequeueBackoff) Forget(key string) {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.failures, key)
+}
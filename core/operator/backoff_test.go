@@ -0,0 +1,70 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestConfig(factor, jitter float64) *Config {
+	config := DefaultConfig()
+	config.Backoff[DefaultConfigKey] = Backoff{Factor: &factor, Jitter: &jitter, Max: &metav1.Duration{Duration: defaultBackoffMax}}
+	return config
+}
+
+func TestRequeueBackoff_Next(t *testing.T) {
+	backoff := NewRequeueBackoff(newTestConfig(2, 0))
+	backoff.random = func() float64 { return 0 }
+
+	assert.Equal(t, 10*time.Second, backoff.Next("KogitoRuntime/ns/app", "ServiceNotFound", 10*time.Second))
+	assert.Equal(t, 20*time.Second, backoff.Next("KogitoRuntime/ns/app", "ServiceNotFound", 10*time.Second))
+	assert.Equal(t, 10*time.Second, backoff.Next("KogitoRuntime/ns/other", "ServiceNotFound", 10*time.Second))
+
+	// another reason restarts the count
+	assert.Equal(t, 10*time.Second, backoff.Next("KogitoRuntime/ns/app", "DeploymentNotAvailable", 10*time.Second))
+	assert.Equal(t, 20*time.Second, backoff.Next("KogitoRuntime/ns/app", "DeploymentNotAvailable", 10*time.Second))
+
+	backoff.Forget("KogitoRuntime/ns/app")
+	assert.Equal(t, 10*time.Second, backoff.Next("KogitoRuntime/ns/app", "DeploymentNotAvailable", 10*time.Second))
+
+	backoff.Forget("KogitoRuntime/ns/app")
+	backoff.Forget("KogitoRuntime/ns/other")
+	assert.Empty(t, backoff.failures)
+}
+
+func TestRequeueBackoff_NextWithDefaultConfig(t *testing.T) {
+	backoff := NewRequeueBackoff(nil)
+
+	// the requeue interval isn't backed off unless configured
+	assert.Equal(t, 10*time.Second, backoff.Next("KogitoRuntime/ns/app", "ServiceNotFound", 10*time.Second))
+	assert.Equal(t, 10*time.Second, backoff.Next("KogitoRuntime/ns/app", "ServiceNotFound", 10*time.Second))
+	assert.Equal(t, 10*time.Second, backoff.Next("KogitoRuntime/ns/app", "ServiceNotFound", 10*time.Second))
+
+	var noBackoff *RequeueBackoff
+	assert.Equal(t, 10*time.Second, noBackoff.Next("KogitoRuntime/ns/app", "ServiceNotFound", 10*time.Second))
+	noBackoff.Forget("KogitoRuntime/ns/app")
+}
+
+func TestRequeueBackoff_NextWithJitter(t *testing.T) {
+	backoff := NewRequeueBackoff(newTestConfig(2, 0.1))
+	backoff.random = func() float64 { return 0.5 }
+
+	// the jitter adds up to 10% of the delay
+	assert.Equal(t, 10500*time.Millisecond, backoff.Next("KogitoRuntime/ns/app", "ServiceNotFound", 10*time.Second))
+}
@@ -0,0 +1,210 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultConfigKey is the key of the values applied to the controllers and reasons not listed in the Config
+	DefaultConfigKey = "default"
+
+	defaultQPS                     = 20
+	defaultBurst                   = 30
+	defaultMaxConcurrentReconciles = 1
+	defaultBackoffFactor           = 1
+	defaultBackoffJitter           = 0
+	defaultBackoffMax              = 5 * time.Minute
+)
+
+// Config tunes the load that the operator puts on the API server and how fast it reacts to the changes.
+// It's read from the file given to the operator, usually mounted from a ConfigMap, e.g.:
+//
+//	qps: 50
+//	burst: 100
+//	maxConcurrentReconciles:
+//	  default: 2
+//	  KogitoRuntime: 10
+//	backoff:
+//	  default:
+//	    factor: 2
+//	    jitter: 0.1
+//	    max: 5m
+//	  ImageDigestResolutionFailed:
+//	    max: 30m
+type Config struct {
+	// QPS is the maximum number of queries per second sent to the API server
+	QPS float32 `json:"qps,omitempty"`
+	// Burst is the maximum number of queries sent to the API server at once
+	Burst int `json:"burst,omitempty"`
+	// MaxConcurrentReconciles is the number of objects each controller reconciles in parallel, by controller name
+	MaxConcurrentReconciles map[string]int `json:"maxConcurrentReconciles,omitempty"`
+	// Backoff delays the reconciliation of the objects failing repeatedly, by reconciliation error reason
+	Backoff map[string]Backoff `json:"backoff,omitempty"`
+}
+
+// Backoff increases the requeue interval of an object each time its reconciliation fails again with the same reason
+type Backoff struct {
+	// Interval of the first requeue, defaults to the interval defined by the reconciliation error
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Factor multiplies the interval after each consecutive failure, 1 keeps the interval constant
+	Factor *float64 `json:"factor,omitempty"`
+	// Jitter adds a random delay up to the given fraction of the interval, so the objects failing together aren't requeued together.
+	// 0 disables the jitter of a reason even if the default backoff has one.
+	Jitter *float64 `json:"jitter,omitempty"`
+	// Max caps the interval, the interval of the first requeue is never reduced
+	Max *metav1.Duration `json:"max,omitempty"`
+}
+
+// DefaultConfig returns the configuration used when the operator isn't configured, the requeue interval isn't backed off
+func DefaultConfig() *Config {
+	return &Config{
+		QPS:                     defaultQPS,
+		Burst:                   defaultBurst,
+		MaxConcurrentReconciles: map[string]int{DefaultConfigKey: defaultMaxConcurrentReconciles},
+		Backoff: map[string]Backoff{
+			DefaultConfigKey: {
+				Factor: newFloat(defaultBackoffFactor),
+				Jitter: newFloat(defaultBackoffJitter),
+				Max:    &metav1.Duration{Duration: defaultBackoffMax},
+			},
+		},
+	}
+}
+
+// LoadConfig reads the configuration from the given YAML file, the missing values are defaulted
+func LoadConfig(path string) (*Config, error) {
+	loaded := DefaultConfig()
+	if len(path) == 0 {
+		return loaded, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file := &Config{}
+	if err = yaml.UnmarshalStrict(data, file); err != nil {
+		return nil, fmt.Errorf("invalid operator configuration %s: %v", path, err)
+	}
+	if file.QPS > 0 {
+		loaded.QPS = file.QPS
+	}
+	if file.Burst > 0 {
+		loaded.Burst = file.Burst
+	}
+	for controller, maxConcurrentReconciles := range file.MaxConcurrentReconciles {
+		if maxConcurrentReconciles < 1 {
+			return nil, fmt.Errorf("invalid operator configuration %s: maxConcurrentReconciles of %s must be positive", path, controller)
+		}
+		loaded.MaxConcurrentReconciles[controller] = maxConcurrentReconciles
+	}
+	defaultBackoff := loaded.Backoff[DefaultConfigKey]
+	if fileDefault, ok := file.Backoff[DefaultConfigKey]; ok {
+		defaultBackoff = fileDefault.withDefaults(defaultBackoff)
+		loaded.Backoff[DefaultConfigKey] = defaultBackoff
+	}
+	for reason, backoff := range file.Backoff {
+		if reason != DefaultConfigKey {
+			loaded.Backoff[reason] = backoff.withDefaults(defaultBackoff)
+		}
+	}
+	for reason, backoff := range loaded.Backoff {
+		if backoff.GetFactor() < 1 || backoff.GetJitter() < 0 {
+			return nil, fmt.Errorf("invalid operator configuration %s: backoff of %s must have a factor greater or equal to 1 and a positive jitter", path, reason)
+		}
+	}
+	return loaded, nil
+}
+
+// withDefaults fills the values missing in the backoff of a reason with the default backoff
+func (b Backoff) withDefaults(defaults Backoff) Backoff {
+	if b.Interval == nil {
+		b.Interval = defaults.Interval
+	}
+	if b.Factor == nil {
+		b.Factor = defaults.Factor
+	}
+	if b.Jitter == nil {
+		b.Jitter = defaults.Jitter
+	}
+	if b.Max == nil {
+		b.Max = defaults.Max
+	}
+	return b
+}
+
+// GetFactor returns the factor multiplying the interval, 1 if not set
+func (b Backoff) GetFactor() float64 {
+	if b.Factor == nil {
+		return defaultBackoffFactor
+	}
+	return *b.Factor
+}
+
+// GetJitter returns the fraction of the interval added randomly, 0 if not set
+func (b Backoff) GetJitter() float64 {
+	if b.Jitter == nil {
+		return defaultBackoffJitter
+	}
+	return *b.Jitter
+}
+
+// GetMaxConcurrentReconciles returns the number of objects the given controller reconciles in parallel
+func (c *Config) GetMaxConcurrentReconciles(controller string) int {
+	if maxConcurrentReconciles, ok := c.MaxConcurrentReconciles[controller]; ok {
+		return maxConcurrentReconciles
+	}
+	if maxConcurrentReconciles, ok := c.MaxConcurrentReconciles[DefaultConfigKey]; ok {
+		return maxConcurrentReconciles
+	}
+	return defaultMaxConcurrentReconciles
+}
+
+// GetBackoff returns the backoff of the given reconciliation error reason
+func (c *Config) GetBackoff(reason string) Backoff {
+	if backoff, ok := c.Backoff[reason]; ok {
+		return backoff
+	}
+	return c.Backoff[DefaultConfigKey]
+}
+
+// Delay returns the requeue interval after the given number of consecutive failures, without jitter
+func (b Backoff) Delay(interval time.Duration, failures int) time.Duration {
+	if b.Interval != nil {
+		interval = b.Interval.Duration
+	}
+	maxInterval := interval
+	if b.Max != nil && b.Max.Duration > maxInterval {
+		maxInterval = b.Max.Duration
+	}
+	delay := float64(interval)
+	for i := 1; i < failures && delay < float64(maxInterval); i++ {
+		delay *= b.GetFactor()
+	}
+	if delay > float64(maxInterval) {
+		return maxInterval
+	}
+	return time.Duration(delay)
+}
+
+func newFloat(value float64) *float64 {
+	return &value
+}
@@ -0,0 +1,115 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	config, err := LoadConfig("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultConfig(), config)
+	assert.Equal(t, defaultMaxConcurrentReconciles, config.GetMaxConcurrentReconciles("KogitoRuntime"))
+}
+
+func TestLoadConfig_MergesWithDefaults(t *testing.T) {
+	path := writeConfig(t, `
+qps: 50
+maxConcurrentReconciles:
+  default: 2
+  KogitoRuntime: 4
+backoff:
+  default:
+    max: 2m
+  ImageNotFound:
+    interval: 30s
+    factor: 3
+`)
+	config, err := LoadConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, float32(50), config.QPS)
+	assert.Equal(t, defaultBurst, config.Burst)
+	assert.Equal(t, 4, config.GetMaxConcurrentReconciles("KogitoRuntime"))
+	assert.Equal(t, 2, config.GetMaxConcurrentReconciles("KogitoInfra"))
+
+	defaultBackoff := config.GetBackoff("ServiceNotFound")
+	assert.Equal(t, float64(defaultBackoffFactor), defaultBackoff.GetFactor())
+	assert.Equal(t, 2*time.Minute, defaultBackoff.Max.Duration)
+
+	buildBackoff := config.GetBackoff("ImageNotFound")
+	assert.Equal(t, 30*time.Second, buildBackoff.Interval.Duration)
+	assert.Equal(t, float64(3), buildBackoff.GetFactor())
+	assert.Equal(t, float64(defaultBackoffJitter), buildBackoff.GetJitter())
+	assert.Equal(t, 2*time.Minute, buildBackoff.Max.Duration)
+}
+
+func TestLoadConfig_KeepsExplicitZeroJitter(t *testing.T) {
+	path := writeConfig(t, `
+backoff:
+  default:
+    factor: 2
+    jitter: 0.1
+  ImageNotFound:
+    jitter: 0
+`)
+	config, err := LoadConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.1, config.GetBackoff("ServiceNotFound").GetJitter())
+
+	buildBackoff := config.GetBackoff("ImageNotFound")
+	assert.Equal(t, float64(0), buildBackoff.GetJitter())
+	assert.Equal(t, float64(2), buildBackoff.GetFactor())
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	_, err := LoadConfig(writeConfig(t, "unknown: true"))
+	assert.Error(t, err)
+	_, err = LoadConfig(writeConfig(t, "maxConcurrentReconciles:\n  KogitoRuntime: 0"))
+	assert.Error(t, err)
+	_, err = LoadConfig(writeConfig(t, "backoff:\n  default:\n    factor: 0.5"))
+	assert.Error(t, err)
+	_, err = LoadConfig(writeConfig(t, "backoff:\n  ImageNotFound:\n    factor: 0"))
+	assert.Error(t, err)
+	_, err = LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestBackoff_Delay(t *testing.T) {
+	backoff := Backoff{Factor: newFloat(2), Max: &metav1.Duration{Duration: time.Minute}}
+	assert.Equal(t, 10*time.Second, backoff.Delay(10*time.Second, 1))
+	assert.Equal(t, 20*time.Second, backoff.Delay(10*time.Second, 2))
+	assert.Equal(t, 40*time.Second, backoff.Delay(10*time.Second, 3))
+	assert.Equal(t, time.Minute, backoff.Delay(10*time.Second, 4))
+	assert.Equal(t, time.Minute, backoff.Delay(10*time.Second, 100))
+
+	backoff.Interval = &metav1.Duration{Duration: 5 * time.Second}
+	assert.Equal(t, 10*time.Second, backoff.Delay(10*time.Second, 2))
+
+	// the requeue interval is never shortened by the max
+	assert.Equal(t, 2*time.Minute, Backoff{Factor: newFloat(2), Max: &metav1.Duration{Duration: time.Minute}}.Delay(2*time.Minute, 3))
+}
@@ -28,4 +28,6 @@ type Context struct {
 	Version              string
 	Labels               map[string]string
 	DeploymentIdentifier string
+	// Backoff delays the requeues of the objects failing repeatedly, the requeue interval isn't backed off if nil
+	Backoff *RequeueBackoff
 }
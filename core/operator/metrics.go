@@ -0,0 +1,83 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "kogito_operator"
+
+var (
+	apiQPSMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "api_qps",
+		Help:      "Maximum number of queries per second sent to the API server",
+	})
+	apiBurstMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "api_burst",
+		Help:      "Maximum number of queries sent to the API server at once",
+	})
+	maxConcurrentReconcilesMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "max_concurrent_reconciles",
+		Help:      "Number of objects reconciled in parallel by each controller",
+	}, []string{"controller"})
+	backoffFactorMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "backoff_factor",
+		Help:      "Factor multiplying the requeue interval after each consecutive failure, by reconciliation error reason",
+	}, []string{"reason"})
+	backoffJitterMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "backoff_jitter",
+		Help:      "Fraction of the requeue interval added as random delay, by reconciliation error reason",
+	}, []string{"reason"})
+	backoffMaxMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "backoff_max_seconds",
+		Help:      "Maximum requeue interval, by reconciliation error reason",
+	}, []string{"reason"})
+	requeueDelayMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "requeue_delay_seconds",
+		Help:      "Delay before the objects failing to reconcile are reconciled again, by reconciliation error reason",
+		Buckets:   []float64{5, 10, 30, 60, 120, 300, 600, 1800},
+	}, []string{"reason"})
+)
+
+// RegisterMetrics registers the given operator configuration and the requeue delays in the given metrics registry,
+// the configuration of the given controllers is reported
+func RegisterMetrics(registerer prometheus.Registerer, config *Config, controllers ...string) error {
+	for _, collector := range []prometheus.Collector{apiQPSMetric, apiBurstMetric, maxConcurrentReconcilesMetric, backoffFactorMetric, backoffJitterMetric, backoffMaxMetric, requeueDelayMetric} {
+		if err := registerer.Register(collector); err != nil {
+			return err
+		}
+	}
+	apiQPSMetric.Set(float64(config.QPS))
+	apiBurstMetric.Set(float64(config.Burst))
+	for _, controller := range controllers {
+		maxConcurrentReconcilesMetric.WithLabelValues(controller).Set(float64(config.GetMaxConcurrentReconciles(controller)))
+	}
+	for reason, backoff := range config.Backoff {
+		backoffFactorMetric.WithLabelValues(reason).Set(backoff.GetFactor())
+		backoffJitterMetric.WithLabelValues(reason).Set(backoff.GetJitter())
+		if backoff.Max != nil {
+			backoffMaxMetric.WithLabelValues(reason).Set(backoff.Max.Seconds())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimeprofile
+
+import (
+	api "github.com/kiegroup/kogito-operator/apis"
+)
+
+// InfraKind is the kind of infrastructure whose application properties are mapped by the profiles
+type InfraKind string
+
+const (
+	// KafkaInfra application properties of the Kafka infrastructure
+	KafkaInfra InfraKind = "kafka"
+	// InfinispanInfra application properties of the Infinispan infrastructure
+	InfinispanInfra InfraKind = "infinispan"
+	// MongoDBInfra application properties of the MongoDB infrastructure
+	MongoDBInfra InfraKind = "mongodb"
+)
+
+const (
+	// KafkaBootstrapServers property setting the Kafka bootstrap servers
+	KafkaBootstrapServers = "bootstrap-servers"
+	// KafkaSecurityProtocol property setting the protocol used to connect to Kafka
+	KafkaSecurityProtocol = "security-protocol"
+	// KafkaTrustStoreLocation property setting the path of the trust store
+	KafkaTrustStoreLocation = "truststore-location"
+	// KafkaTrustStoreType property setting the type of the trust store
+	KafkaTrustStoreType = "truststore-type"
+	// KafkaTrustStorePassword property setting the password of the trust store
+	KafkaTrustStorePassword = "truststore-password"
+	// KafkaKeyStoreLocation property setting the path of the key store
+	KafkaKeyStoreLocation = "keystore-location"
+	// KafkaKeyStoreType property setting the type of the key store
+	KafkaKeyStoreType = "keystore-type"
+	// KafkaKeyStorePassword property setting the password of the key store
+	KafkaKeyStorePassword = "keystore-password"
+	// KafkaSaslMechanism property setting the SASL mechanism
+	KafkaSaslMechanism = "sasl-mechanism"
+	// KafkaSaslJaasConfig property setting the JAAS configuration of the SASL mechanism
+	KafkaSaslJaasConfig = "sasl-jaas-config"
+	// KafkaStoreLocationPrefix isn't a property but the prefix of the store locations, e.g. "file:" for runtimes resolving them as resources
+	KafkaStoreLocationPrefix = "store-location-prefix"
+
+	// InfinispanServerList property setting the Infinispan servers
+	InfinispanServerList = "server-list"
+	// InfinispanUseAuth property enabling the Infinispan authentication
+	InfinispanUseAuth = "use-auth"
+	// InfinispanTrustStore property setting the path of the trust store
+	InfinispanTrustStore = "truststore"
+	// InfinispanTrustStoreType property setting the type of the trust store
+	InfinispanTrustStoreType = "truststore-type"
+	// InfinispanTrustStorePassword property setting the password of the trust store
+	InfinispanTrustStorePassword = "truststore-password"
+	// InfinispanUsername environment variable setting the Infinispan username
+	InfinispanUsername = "username-env"
+	// InfinispanPassword environment variable setting the Infinispan password
+	InfinispanPassword = "password-env"
+
+	// MongoDBURI property setting the connection string of MongoDB
+	MongoDBURI = "uri"
+	// MongoDBHost property setting the MongoDB host, for the runtimes not accepting a connection string with separate credentials
+	MongoDBHost = "host"
+	// MongoDBPort property setting the MongoDB port, for the runtimes not accepting a connection string with separate credentials
+	MongoDBPort = "port"
+	// MongoDBAuthDatabase environment variable setting the authentication database
+	MongoDBAuthDatabase = "auth-database-env"
+	// MongoDBUsername environment variable setting the MongoDB username
+	MongoDBUsername = "username-env"
+	// MongoDBPassword environment variable setting the MongoDB password
+	MongoDBPassword = "password-env"
+	// MongoDBDatabase environment variable setting the MongoDB database
+	MongoDBDatabase = "database-env"
+)
+
+// Profile describes how the operator deploys the services of a runtime and connects them to the infrastructure
+type Profile struct {
+	// Name of the profile, given as runtime of the services
+	Name api.RuntimeType `json:"name"`
+	// Extends is the name of a profile, built-in or defined before, whose values are used when not given in this profile
+	Extends api.RuntimeType `json:"extends,omitempty"`
+	// Probes default HTTP paths of the probes of the services
+	Probes Probes `json:"probes,omitempty"`
+	// MetricsPath default HTTP path scraped for the metrics of the services
+	MetricsPath string `json:"metricsPath,omitempty"`
+	// Properties names of the application properties and environment variables read by the runtime, by infra kind and property
+	Properties map[InfraKind]map[string]string `json:"properties,omitempty"`
+	// Images used to build the services when the KogitoBuild doesn't set them
+	Images Images `json:"images,omitempty"`
+}
+
+// Probes default HTTP paths of the probes, the startup probe uses the liveness path
+type Probes struct {
+	LivenessPath  string `json:"livenessPath,omitempty"`
+	ReadinessPath string `json:"readinessPath,omitempty"`
+}
+
+// Images used to build the services, e.g. quay.io/kiegroup/kogito-runtime-jvm:latest
+type Images struct {
+	Builder string `json:"builder,omitempty"`
+	Runtime string `json:"runtime,omitempty"`
+}
+
+// builtInProfiles returns the profiles of the runtimes supported out of the box.
+// For Quarkus: https://quarkus.io/guides/all-config
+// For Spring: https://docs.spring.io/spring-boot/docs/current/reference/html/application-properties.html
+func builtInProfiles() []*Profile {
+	return []*Profile{
+		{
+			Name: api.QuarkusRuntimeType,
+			Probes: Probes{
+				LivenessPath:  "/q/health/live",
+				ReadinessPath: "/q/health/ready",
+			},
+			MetricsPath: api.MonitoringDefaultPathQuarkus,
+			Properties: map[InfraKind]map[string]string{
+				KafkaInfra: {
+					KafkaBootstrapServers:   "kafka.bootstrap.servers",
+					KafkaSecurityProtocol:   "kafka.security.protocol",
+					KafkaTrustStoreLocation: "kafka.ssl.truststore.location",
+					KafkaTrustStoreType:     "kafka.ssl.truststore.type",
+					KafkaTrustStorePassword: "kafka.ssl.truststore.password",
+					KafkaKeyStoreLocation:   "kafka.ssl.keystore.location",
+					KafkaKeyStoreType:       "kafka.ssl.keystore.type",
+					KafkaKeyStorePassword:   "kafka.ssl.keystore.password",
+					KafkaSaslMechanism:      "kafka.sasl.mechanism",
+					KafkaSaslJaasConfig:     "kafka.sasl.jaas.config",
+				},
+				InfinispanInfra: {
+					InfinispanServerList:         "quarkus.infinispan-client.server-list",
+					InfinispanUseAuth:            "quarkus.infinispan-client.use-auth",
+					InfinispanTrustStore:         "quarkus.infinispan-client.trust-store",
+					InfinispanTrustStoreType:     "quarkus.infinispan-client.trust-store-type",
+					InfinispanTrustStorePassword: "quarkus.infinispan-client.trust-store-password",
+					InfinispanUsername:           "QUARKUS_INFINISPAN_CLIENT_AUTH_USERNAME",
+					InfinispanPassword:           "QUARKUS_INFINISPAN_CLIENT_AUTH_PASSWORD",
+				},
+				MongoDBInfra: {
+					MongoDBURI:          "quarkus.mongodb.connection-string",
+					MongoDBAuthDatabase: "QUARKUS_MONGODB_CREDENTIALS_AUTH_SOURCE",
+					MongoDBUsername:     "QUARKUS_MONGODB_CREDENTIALS_USERNAME",
+					MongoDBPassword:     "QUARKUS_MONGODB_CREDENTIALS_PASSWORD",
+					MongoDBDatabase:     "QUARKUS_MONGODB_DATABASE",
+				},
+			},
+		},
+		{
+			Name: api.SpringBootRuntimeType,
+			Probes: Probes{
+				LivenessPath:  "/actuator/health/liveness",
+				ReadinessPath: "/actuator/health/readiness",
+			},
+			MetricsPath: api.MonitoringDefaultPathSpringboot,
+			Properties: map[InfraKind]map[string]string{
+				KafkaInfra: {
+					KafkaBootstrapServers:   "spring.kafka.bootstrap-servers",
+					KafkaSecurityProtocol:   "spring.kafka.security.protocol",
+					KafkaTrustStoreLocation: "spring.kafka.ssl.trust-store-location",
+					KafkaTrustStoreType:     "spring.kafka.ssl.trust-store-type",
+					KafkaTrustStorePassword: "spring.kafka.ssl.trust-store-password",
+					KafkaKeyStoreLocation:   "spring.kafka.ssl.key-store-location",
+					KafkaKeyStoreType:       "spring.kafka.ssl.key-store-type",
+					KafkaKeyStorePassword:   "spring.kafka.ssl.key-store-password",
+					KafkaSaslMechanism:      "spring.kafka.properties.sasl.mechanism",
+					KafkaSaslJaasConfig:     "spring.kafka.properties.sasl.jaas.config",
+					// Spring resolves the stores as resources, they must be prefixed to be read from the file system
+					KafkaStoreLocationPrefix: "file:",
+				},
+				InfinispanInfra: {
+					InfinispanServerList:         "infinispan.remote.server-list",
+					InfinispanUseAuth:            "infinispan.remote.use-auth",
+					InfinispanTrustStore:         "infinispan.remote.trust-store-file-name",
+					InfinispanTrustStoreType:     "infinispan.remote.trust-store-type",
+					InfinispanTrustStorePassword: "infinispan.remote.trust-store-password",
+					InfinispanUsername:           "INFINISPAN_REMOTE_AUTH_USERNAME",
+					InfinispanPassword:           "INFINISPAN_REMOTE_AUTH_PASSWORD",
+				},
+				// URI cannot be used with credentials in Spring Boot:
+				// https://github.com/spring-projects/spring-boot/blob/b7fdf8fe87da1c01ff6aca041170a02f11280a1a/spring-boot-project/spring-boot-autoconfigure/src/main/java/org/springframework/boot/autoconfigure/mongo/MongoProperties.java#L61-L64
+				MongoDBInfra: {
+					MongoDBHost:         "spring.data.mongodb.host",
+					MongoDBPort:         "spring.data.mongodb.port",
+					MongoDBAuthDatabase: "SPRING_DATA_MONGODB_AUTHENTICATION_DATABASE",
+					MongoDBUsername:     "SPRING_DATA_MONGODB_USERNAME",
+					MongoDBPassword:     "SPRING_DATA_MONGODB_PASSWORD",
+					MongoDBDatabase:     "SPRING_DATA_MONGODB_DATABASE",
+				},
+			},
+		},
+	}
+}
+
+// GetLivenessPath returns the default HTTP path of the liveness and startup probes
+func (p *Profile) GetLivenessPath() string {
+	return p.Probes.LivenessPath
+}
+
+// GetReadinessPath returns the default HTTP path of the readiness probe
+func (p *Profile) GetReadinessPath() string {
+	return p.Probes.ReadinessPath
+}
+
+// GetMetricsPath returns the default HTTP path scraped for the metrics
+func (p *Profile) GetMetricsPath() string {
+	return p.MetricsPath
+}
+
+// GetProperty returns the name given by the runtime to the property of the infra kind, empty if the runtime doesn't support it
+func (p *Profile) GetProperty(kind InfraKind, property string) string {
+	return p.Properties[kind][property]
+}
+
+// MapProperties returns the given values by the names the runtime gives to the properties of the infra kind.
+// The properties the runtime doesn't support are left out.
+func (p *Profile) MapProperties(kind InfraKind, values map[string]string) map[string]string {
+	mapped := map[string]string{}
+	for property, value := range values {
+		if name := p.GetProperty(kind, property); len(name) > 0 {
+			mapped[name] = value
+		}
+	}
+	return mapped
+}
+
+// GetBuilderImage returns the image building the services, empty to use the default builder image
+func (p *Profile) GetBuilderImage() string {
+	return p.Images.Builder
+}
+
+// GetRuntimeImage returns the image running the built services, empty to use the default runtime image
+func (p *Profile) GetRuntimeImage() string {
+	return p.Images.Runtime
+}
+
+// extend fills the values missing in the profile with the ones of the given base profile
+func (p *Profile) extend(base *Profile) {
+	if len(p.Probes.LivenessPath) == 0 {
+		p.Probes.LivenessPath = base.Probes.LivenessPath
+	}
+	if len(p.Probes.ReadinessPath) == 0 {
+		p.Probes.ReadinessPath = base.Probes.ReadinessPath
+	}
+	if len(p.MetricsPath) == 0 {
+		p.MetricsPath = base.MetricsPath
+	}
+	if len(p.Images.Builder) == 0 {
+		p.Images.Builder = base.Images.Builder
+	}
+	if len(p.Images.Runtime) == 0 {
+		p.Images.Runtime = base.Images.Runtime
+	}
+	properties := map[InfraKind]map[string]string{}
+	for kind, baseProperties := range base.Properties {
+		properties[kind] = map[string]string{}
+		for property, name := range baseProperties {
+			properties[kind][property] = name
+		}
+	}
+	for kind, profileProperties := range p.Properties {
+		if properties[kind] == nil {
+			properties[kind] = map[string]string{}
+		}
+		for property, name := range profileProperties {
+			properties[kind][property] = name
+		}
+	}
+	p.Properties = properties
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimeprofile
+
+import (
+	"testing"
+
+	api "github.com/kiegroup/kogito-operator/apis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfile_MapProperties(t *testing.T) {
+	profile := DefaultRegistry().GetProfile(api.SpringBootRuntimeType)
+	mapped := profile.MapProperties(MongoDBInfra, map[string]string{
+		MongoDBURI:  "mongodb://mongodb:27017",
+		MongoDBHost: "mongodb",
+		MongoDBPort: "27017",
+	})
+	assert.Equal(t, map[string]string{
+		"spring.data.mongodb.host": "mongodb",
+		"spring.data.mongodb.port": "27017",
+	}, mapped)
+}
+
+func TestProfile_Extend(t *testing.T) {
+	base := &Profile{
+		Name:        "base",
+		Probes:      Probes{LivenessPath: "/live", ReadinessPath: "/ready"},
+		MetricsPath: "/metrics",
+		Properties: map[InfraKind]map[string]string{
+			KafkaInfra: {KafkaBootstrapServers: "kafka.bootstrap.servers", KafkaSecurityProtocol: "kafka.security.protocol"},
+		},
+		Images: Images{Builder: "builder", Runtime: "runtime"},
+	}
+	profile := &Profile{
+		Name:   "custom",
+		Probes: Probes{ReadinessPath: "/started"},
+		Properties: map[InfraKind]map[string]string{
+			KafkaInfra:      {KafkaBootstrapServers: "custom.kafka.servers"},
+			InfinispanInfra: {InfinispanServerList: "custom.infinispan.servers"},
+		},
+		Images: Images{Runtime: "custom-runtime"},
+	}
+	profile.extend(base)
+
+	assert.Equal(t, "/live", profile.GetLivenessPath())
+	assert.Equal(t, "/started", profile.GetReadinessPath())
+	assert.Equal(t, "/metrics", profile.GetMetricsPath())
+	assert.Equal(t, "custom.kafka.servers", profile.GetProperty(KafkaInfra, KafkaBootstrapServers))
+	assert.Equal(t, "kafka.security.protocol", profile.GetProperty(KafkaInfra, KafkaSecurityProtocol))
+	assert.Equal(t, "custom.infinispan.servers", profile.GetProperty(InfinispanInfra, InfinispanServerList))
+	assert.Equal(t, "builder", profile.GetBuilderImage())
+	assert.Equal(t, "custom-runtime", profile.GetRuntimeImage())
+	// the base profile is left untouched
+	assert.Equal(t, "kafka.bootstrap.servers", base.GetProperty(KafkaInfra, KafkaBootstrapServers))
+}
@@ -0,0 +1,178 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimeprofile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	api "github.com/kiegroup/kogito-operator/apis"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// Registry holds the runtime profiles known by the operator
+type Registry struct {
+	profiles map[api.RuntimeType]*Profile
+}
+
+// ProfilesKey is the key of the runtime profiles in their ConfigMap
+const ProfilesKey = "profiles.yaml"
+
+// profilesFile is the content read by LoadRegistry
+type profilesFile struct {
+	Profiles []*Profile `json:"profiles"`
+}
+
+var (
+	registry      = DefaultRegistry()
+	registryMutex sync.RWMutex
+)
+
+// DefaultRegistry returns the registry holding only the built-in profiles
+func DefaultRegistry() *Registry {
+	defaultRegistry := &Registry{profiles: map[api.RuntimeType]*Profile{}}
+	for _, profile := range builtInProfiles() {
+		defaultRegistry.profiles[profile.Name] = profile
+	}
+	return defaultRegistry
+}
+
+// LoadRegistry reads the profiles from the given YAML content, the ProfilesKey of their ConfigMap, and registers them with the built-in ones.
+// A profile named after a built-in one overrides its values, e.g.:
+//
+//	profiles:
+//	  - name: quarkus-native
+//	    extends: quarkus
+//	    images:
+//	      runtime: quay.io/kiegroup/kogito-runtime-native:latest
+//	  - name: micronaut
+//	    probes:
+//	      livenessPath: /health/liveness
+//	      readinessPath: /health/readiness
+//	    metricsPath: /prometheus
+//	    properties:
+//	      kafka:
+//	        bootstrap-servers: kafka.bootstrap.servers
+func LoadRegistry(content string) (*Registry, error) {
+	loaded := DefaultRegistry()
+	if len(strings.TrimSpace(content)) == 0 {
+		return loaded, nil
+	}
+	file := &profilesFile{}
+	if err := yaml.UnmarshalStrict([]byte(content), file); err != nil {
+		return nil, fmt.Errorf("invalid runtime profiles: %v", err)
+	}
+	if err := loaded.Register(file.Profiles...); err != nil {
+		return nil, fmt.Errorf("invalid runtime profiles: %v", err)
+	}
+	return loaded, nil
+}
+
+// Register validates the given profiles, extends them and adds them to the registry, in order
+func (r *Registry) Register(profiles ...*Profile) error {
+	defined := map[api.RuntimeType]bool{}
+	for _, profile := range profiles {
+		if err := r.register(profile, defined); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// register validates the given profile, not defined before in the same registration, extends it and adds it to the registry
+func (r *Registry) register(profile *Profile, defined map[api.RuntimeType]bool) error {
+	if errs := validation.IsDNS1123Label(string(profile.Name)); len(errs) > 0 {
+		return fmt.Errorf("profile name %q must be a DNS label: %s", profile.Name, strings.Join(errs, ", "))
+	}
+	if defined[profile.Name] {
+		return fmt.Errorf("profile %s is defined more than once", profile.Name)
+	}
+	defined[profile.Name] = true
+	for kind := range profile.Properties {
+		if kind != KafkaInfra && kind != InfinispanInfra && kind != MongoDBInfra {
+			return fmt.Errorf("profile %s maps the properties of the unknown infra kind %s, supported kinds are %s, %s and %s", profile.Name, kind, KafkaInfra, InfinispanInfra, MongoDBInfra)
+		}
+	}
+	base := profile.Extends
+	if len(base) == 0 {
+		base = profile.Name
+	}
+	if baseProfile, ok := r.profiles[base]; ok {
+		profile.extend(baseProfile)
+	} else if len(profile.Extends) > 0 {
+		return fmt.Errorf("profile %s extends %s, which is neither built-in nor defined before", profile.Name, profile.Extends)
+	}
+	if len(profile.GetLivenessPath()) == 0 || len(profile.GetReadinessPath()) == 0 || len(profile.GetMetricsPath()) == 0 {
+		return fmt.Errorf("profile %s must define the liveness and readiness probe paths and the metrics path, or extend a profile defining them", profile.Name)
+	}
+	r.profiles[profile.Name] = profile
+	return nil
+}
+
+// SetRegistry sets the runtime profiles known by the operator, replaced while the controllers run when the profiles are reloaded
+func SetRegistry(newRegistry *Registry) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	registry = newRegistry
+}
+
+// GetRegistry returns the runtime profiles known by the operator
+func GetRegistry() *Registry {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return registry
+}
+
+// GetProfile returns the profile of the given runtime, the Quarkus profile if empty.
+// The runtimes not registered also get the Quarkus profile, the services and builds using them are rejected before, see IsRegistered.
+func (r *Registry) GetProfile(runtime api.RuntimeType) *Profile {
+	if profile, ok := r.profiles[runtime]; ok {
+		return profile
+	}
+	return r.profiles[api.QuarkusRuntimeType]
+}
+
+// IsRegistered checks if the given runtime has a profile, an empty runtime stands for Quarkus
+func (r *Registry) IsRegistered(runtime api.RuntimeType) bool {
+	if len(runtime) == 0 {
+		return true
+	}
+	_, ok := r.profiles[runtime]
+	return ok
+}
+
+// GetProfiles returns all the profiles, sorted by name
+func (r *Registry) GetProfiles() []*Profile {
+	profiles := make([]*Profile, 0, len(r.profiles))
+	for _, profile := range r.profiles {
+		profiles = append(profiles, profile)
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+	return profiles
+}
+
+// GetProfileNames returns the names of all the profiles, sorted
+func (r *Registry) GetProfileNames() []string {
+	var names []string
+	for _, profile := range r.GetProfiles() {
+		names = append(names, string(profile.Name))
+	}
+	return names
+}
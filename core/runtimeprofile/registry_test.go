@@ -0,0 +1,100 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimeprofile
+
+import (
+	"testing"
+
+	api "github.com/kiegroup/kogito-operator/apis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadRegistry_Defaults(t *testing.T) {
+	registry, err := LoadRegistry("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultRegistry(), registry)
+	profiles := registry.GetProfiles()
+	assert.Len(t, profiles, 2)
+	assert.Equal(t, api.QuarkusRuntimeType, profiles[0].Name)
+	assert.Equal(t, api.SpringBootRuntimeType, profiles[1].Name)
+}
+
+func TestLoadRegistry_RegistersProfiles(t *testing.T) {
+	content := `
+profiles:
+  - name: quarkus-native
+    extends: quarkus
+    images:
+      runtime: quay.io/kiegroup/kogito-runtime-native:latest
+  - name: micronaut
+    probes:
+      livenessPath: /health/liveness
+      readinessPath: /health/readiness
+    metricsPath: /prometheus
+    properties:
+      kafka:
+        bootstrap-servers: kafka.bootstrap.servers
+  - name: springboot
+    metricsPath: /actuator/metrics
+`
+	registry, err := LoadRegistry(content)
+	assert.NoError(t, err)
+	assert.Len(t, registry.GetProfiles(), 4)
+
+	native := registry.GetProfile("quarkus-native")
+	assert.Equal(t, api.RuntimeType("quarkus-native"), native.Name)
+	assert.Equal(t, "/q/health/live", native.GetLivenessPath())
+	assert.Equal(t, api.MonitoringDefaultPathQuarkus, native.GetMetricsPath())
+	assert.Equal(t, "quarkus.infinispan-client.server-list", native.GetProperty(InfinispanInfra, InfinispanServerList))
+	assert.Equal(t, "quay.io/kiegroup/kogito-runtime-native:latest", native.GetRuntimeImage())
+	assert.Empty(t, native.GetBuilderImage())
+
+	micronaut := registry.GetProfile("micronaut")
+	assert.Equal(t, "/health/readiness", micronaut.GetReadinessPath())
+	assert.Equal(t, "kafka.bootstrap.servers", micronaut.GetProperty(KafkaInfra, KafkaBootstrapServers))
+	assert.Empty(t, micronaut.GetProperty(MongoDBInfra, MongoDBURI))
+
+	springBoot := registry.GetProfile(api.SpringBootRuntimeType)
+	assert.Equal(t, "/actuator/metrics", springBoot.GetMetricsPath())
+	assert.Equal(t, "/actuator/health/liveness", springBoot.GetLivenessPath())
+	assert.Equal(t, "spring.kafka.bootstrap-servers", springBoot.GetProperty(KafkaInfra, KafkaBootstrapServers))
+}
+
+func TestLoadRegistry_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"unknown field":       "profiles:\n- name: custom\n  extends: quarkus\n  metricPath: /metrics\n",
+		"invalid name":        "profiles:\n- name: Custom_Runtime\n  extends: quarkus\n",
+		"duplicated name":     "profiles:\n- name: custom\n  extends: quarkus\n- name: custom\n  extends: springboot\n",
+		"unknown base":        "profiles:\n- name: custom\n  extends: micronaut\n",
+		"base defined after":  "profiles:\n- name: custom\n  extends: other\n- name: other\n  extends: quarkus\n",
+		"unknown infra kind":  "profiles:\n- name: custom\n  extends: quarkus\n  properties:\n    postgresql:\n      url: quarkus.datasource.jdbc.url\n",
+		"missing probe paths": "profiles:\n- name: custom\n  metricsPath: /metrics\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadRegistry(content)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRegistry_GetProfile_FallsBackToQuarkus(t *testing.T) {
+	registry := DefaultRegistry()
+	assert.False(t, registry.IsRegistered("micronaut"))
+	assert.True(t, registry.IsRegistered(""))
+	assert.Equal(t, api.QuarkusRuntimeType, registry.GetProfile("micronaut").Name)
+	assert.Equal(t, api.QuarkusRuntimeType, registry.GetProfile("").Name)
+	assert.True(t, registry.IsRegistered(api.SpringBootRuntimeType))
+	assert.Equal(t, api.SpringBootRuntimeType, registry.GetProfile(api.SpringBootRuntimeType).Name)
+}
@@ -0,0 +1,191 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	"github.com/kiegroup/kogito-operator/core/kogitoservice"
+	"github.com/kiegroup/kogito-operator/core/manager"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// processIDOptionRegexp matches the option declaring the id of the process whose model is defined in a protobuf file generated by Kogito
+var processIDOptionRegexp = regexp.MustCompile(`option\s+kogito_id\s*=\s*"([^"]+)"\s*;`)
+
+const (
+	// InfinispanCachesFinalizer releases the Infinispan Caches used by a Kogito service before the service is deleted
+	InfinispanCachesFinalizer = "kogito.kie.org/infinispan-caches"
+)
+
+// InfinispanCacheReconciler creates the Infinispan Caches used by a Kogito service in the Infinispan clusters of its KogitoInfras
+type InfinispanCacheReconciler interface {
+	Reconcile() error
+}
+
+type infinispanCacheReconciler struct {
+	operator.Context
+	service api.KogitoService
+	// runtimeInstance is nil for the Jobs Service, which only uses the jobs cache
+	runtimeInstance          api.KogitoRuntimeInterface
+	infraHandler             manager.KogitoInfraHandler
+	protobufConfigMapHandler ProtoBufConfigMapHandler
+	infinispanCacheHandler   infrastructure.InfinispanCacheHandler
+}
+
+// NewInfinispanCacheReconciler ...
+func NewInfinispanCacheReconciler(context operator.Context, instance api.KogitoRuntimeInterface, infraHandler manager.KogitoInfraHandler) InfinispanCacheReconciler {
+	return &infinispanCacheReconciler{
+		Context:                  context,
+		service:                  instance,
+		runtimeInstance:          instance,
+		infraHandler:             infraHandler,
+		protobufConfigMapHandler: NewProtoBufConfigMapHandler(context),
+		infinispanCacheHandler:   infrastructure.NewInfinispanCacheHandler(context),
+	}
+}
+
+// NewJobsServiceInfinispanCacheReconciler creates the reconciler of the jobs cache used by the given Jobs Service
+func NewJobsServiceInfinispanCacheReconciler(context operator.Context, instance api.KogitoService, infraHandler manager.KogitoInfraHandler) InfinispanCacheReconciler {
+	return &infinispanCacheReconciler{
+		Context:                context,
+		service:                instance,
+		infraHandler:           infraHandler,
+		infinispanCacheHandler: infrastructure.NewInfinispanCacheHandler(context),
+	}
+}
+
+// Reconcile creates the jobs cache, and for the runtimes the process instances and Data Index domain caches of the processes declared
+// in their protobuf files, with the templates of the KogitoInfra. It must run before the protobuf files are provided to Data Index,
+// so the domain caches are created by the operator instead of Data Index. The caches no longer used by the service are released.
+func (i *infinispanCacheReconciler) Reconcile() error {
+	if !i.infinispanCacheHandler.IsInfinispanCacheAvailable() {
+		i.Log.Debug("Infinispan Cache API not available, the caches are created by the services")
+		return nil
+	}
+	infras, err := fetchInfinispanInfras(i.service, i.infraHandler)
+	if err != nil || len(infras) == 0 {
+		return err
+	}
+	processIDs, err := i.getProcessIDs()
+	if err != nil {
+		return err
+	}
+	if err = kogitoservice.AddFinalizer(i.Context, i.service, InfinispanCachesFinalizer); err != nil {
+		return err
+	}
+
+	for _, infra := range infras {
+		cluster := infrastructure.GetInfraResourceKey(infra)
+		if cluster == nil {
+			continue
+		}
+		config := infra.GetSpec().GetInfinispanCaches()
+		caches := map[string]api.InfinispanCacheTemplateInterface{
+			infrastructure.InfinispanJobsCacheName: config.GetTemplate(api.JobsCacheKind),
+		}
+		for _, processID := range processIDs {
+			caches[infrastructure.GetProcessInstancesCacheName(processID)] = config.GetTemplate(api.ProcessInstancesCacheKind)
+			caches[infrastructure.GetDataIndexDomainCacheName(processID)] = config.GetTemplate(api.DataIndexDomainCacheKind)
+		}
+		if err := i.infinispanCacheHandler.ReconcileInfinispanCaches(*cluster, caches, infra, getInfinispanCacheServiceName(i.service)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReleaseInfinispanCaches releases the Infinispan Caches used by the given deleted service in the Infinispan clusters of its KogitoInfras,
+// the caches no longer used by any service are deleted, then removes the finalizer of the service
+func ReleaseInfinispanCaches(context operator.Context, service api.KogitoService, infraHandler manager.KogitoInfraHandler) error {
+	if !controllerutil.ContainsFinalizer(service, InfinispanCachesFinalizer) {
+		return nil
+	}
+	infinispanCacheHandler := infrastructure.NewInfinispanCacheHandler(context)
+	if infinispanCacheHandler.IsInfinispanCacheAvailable() {
+		// the caches of a KogitoInfra already deleted are garbage collected along with it, or can't be resolved anymore
+		infras, err := fetchInfinispanInfras(service, infraHandler)
+		if err != nil {
+			return err
+		}
+		for _, infra := range infras {
+			cluster := infrastructure.GetInfraResourceKey(infra)
+			if cluster == nil {
+				continue
+			}
+			context.Log.Info("Releasing Infinispan Caches of the deleted service", "cluster", cluster)
+			if err = infinispanCacheHandler.ReleaseInfinispanCaches(*cluster, infra, getInfinispanCacheServiceName(service)); err != nil {
+				return err
+			}
+		}
+	}
+	return kogitoservice.RemoveFinalizer(context, service, InfinispanCachesFinalizer)
+}
+
+// fetchInfinispanInfras fetches the Infinispan KogitoInfras used by the service whose caches are not disabled
+func fetchInfinispanInfras(service api.KogitoService, infraHandler manager.KogitoInfraHandler) ([]api.KogitoInfraInterface, error) {
+	var infras []api.KogitoInfraInterface
+	for _, infraName := range service.GetSpec().GetInfra() {
+		infra, err := infraHandler.FetchKogitoInfraInstance(types.NamespacedName{Name: infraName, Namespace: service.GetNamespace()})
+		if err != nil {
+			return nil, err
+		}
+		if infra == nil || infra.GetSpec().IsResourceEmpty() ||
+			infra.GetSpec().GetResource().GetKind() != infrastructure.InfinispanKind ||
+			infra.GetSpec().GetResource().GetAPIVersion() != infrastructure.InfinispanAPIVersion ||
+			infra.GetSpec().GetInfinispanCaches().IsDisabled() {
+			continue
+		}
+		infras = append(infras, infra)
+	}
+	return infras, nil
+}
+
+func getInfinispanCacheServiceName(service api.KogitoService) string {
+	return fmt.Sprintf("%s/%s", service.GetNamespace(), service.GetName())
+}
+
+// getProcessIDs returns the ids of the processes declared in the protobuf ConfigMap of the runtime, sorted
+func (i *infinispanCacheReconciler) getProcessIDs() ([]string, error) {
+	if i.runtimeInstance == nil {
+		return nil, nil
+	}
+	configMap, err := i.protobufConfigMapHandler.FetchProtoBufConfigMap(i.runtimeInstance)
+	if err != nil || configMap == nil {
+		return nil, err
+	}
+	return getProtoBufProcessIDs(configMap.Data), nil
+}
+
+func getProtoBufProcessIDs(files map[string]string) []string {
+	ids := map[string]bool{}
+	for _, content := range files {
+		for _, match := range processIDOptionRegexp.FindAllStringSubmatch(content, -1) {
+			ids[match[1]] = true
+		}
+	}
+	processIDs := make([]string, 0, len(ids))
+	for id := range ids {
+		processIDs = append(processIDs, id)
+	}
+	sort.Strings(processIDs)
+	return processIDs
+}
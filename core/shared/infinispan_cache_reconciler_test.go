@@ -0,0 +1,201 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"testing"
+
+	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
+	ispnv2alpha1 "github.com/kiegroup/kogito-operator/core/infrastructure/infinispan/v2alpha1"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/test"
+	"github.com/kiegroup/kogito-operator/internal/app"
+	"github.com/kiegroup/kogito-operator/meta"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTravelsProtoBufConfigMap(runtime *v1beta1.KogitoRuntime) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      runtime.Name + "-protobuf-files",
+			Namespace: runtime.Namespace,
+			Labels: map[string]string{
+				ConfigMapProtoBufEnabledLabelKey: "true",
+				framework.LabelAppKey:            runtime.Name,
+			},
+		},
+		Data: map[string]string{"travels.proto": travelsProto},
+	}
+}
+
+func TestInfinispanCacheReconciler_Reconcile(t *testing.T) {
+	ns := t.Name()
+	infinispanInfra := test.CreateFakeKogitoInfinispan(ns).(*v1beta1.KogitoInfra)
+	infinispanInfra.UID = "infinispan-infra-uid"
+	infinispanInfra.Spec.InfinispanCaches.DataIndexDomain.TemplateName = "org.infinispan.DIST_SYNC"
+	runtime := test.CreateFakeKogitoRuntime(ns)
+	runtime.Spec.Infra = []string{infinispanInfra.Name}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(runtime, infinispanInfra, newTravelsProtoBufConfigMap(runtime)).SupportInfinispanCache().Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+
+	err := NewInfinispanCacheReconciler(context, runtime, app.NewKogitoInfraHandler(context)).Reconcile()
+	assert.NoError(t, err)
+
+	caches := &ispnv2alpha1.CacheList{}
+	assert.NoError(t, kubernetes.ResourceC(cli).ListWithNamespace(ns, caches))
+	assert.Len(t, caches.Items, 3)
+	cachesByName := map[string]ispnv2alpha1.Cache{}
+	for _, cache := range caches.Items {
+		cachesByName[cache.Name] = cache
+		assert.Equal(t, "kogito-infinispan", cache.Spec.ClusterName)
+		assert.Equal(t, infinispanInfra.Name, cache.OwnerReferences[0].Name)
+	}
+	assert.Equal(t, "travels_store", cachesByName["kogito-infinispan-travels-store"].Spec.Name)
+	assert.Contains(t, cachesByName["kogito-infinispan-travels-store"].Spec.Template, "file-store")
+	assert.Equal(t, "org.infinispan.DIST_SYNC", cachesByName["kogito-infinispan-travels-domain"].Spec.TemplateName)
+	assert.Empty(t, cachesByName["kogito-infinispan-travels-domain"].Spec.Template)
+	assert.Equal(t, "jobs", cachesByName["kogito-infinispan-jobs"].Spec.Name)
+
+	// the templates changed on the KogitoInfra are applied to the caches
+	infinispanInfra.Spec.InfinispanCaches.Defaults.Template = `{"distributed-cache":{"mode":"ASYNC"}}`
+	assert.NoError(t, kubernetes.ResourceC(cli).Update(infinispanInfra))
+	err = NewInfinispanCacheReconciler(context, runtime, app.NewKogitoInfraHandler(context)).Reconcile()
+	assert.NoError(t, err)
+	jobsCache := &ispnv2alpha1.Cache{ObjectMeta: metav1.ObjectMeta{Name: "kogito-infinispan-jobs", Namespace: ns}}
+	test.AssertFetchMustExist(t, cli, jobsCache)
+	assert.Equal(t, `{"distributed-cache":{"mode":"ASYNC"}}`, jobsCache.Spec.Template)
+}
+
+func TestInfinispanCacheReconciler_OtherNamespaceAndRelease(t *testing.T) {
+	ns := t.Name()
+	clusterNs := "infinispan-namespace"
+	infinispanInfra := test.CreateFakeKogitoInfinispan(ns).(*v1beta1.KogitoInfra)
+	infinispanInfra.UID = "infinispan-infra-uid"
+	infinispanInfra.Spec.Resource.Namespace = clusterNs
+	runtime := test.CreateFakeKogitoRuntime(ns)
+	runtime.Spec.Infra = []string{infinispanInfra.Name}
+	jobsService := test.CreateFakeJobsService(ns)
+	jobsService.Spec.Infra = []string{infinispanInfra.Name}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(runtime, jobsService, infinispanInfra, newTravelsProtoBufConfigMap(runtime)).SupportInfinispanCache().Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+
+	err := NewInfinispanCacheReconciler(context, runtime, app.NewKogitoInfraHandler(context)).Reconcile()
+	assert.NoError(t, err)
+	err = NewJobsServiceInfinispanCacheReconciler(context, jobsService, app.NewKogitoInfraHandler(context)).Reconcile()
+	assert.NoError(t, err)
+
+	caches := &ispnv2alpha1.CacheList{}
+	assert.NoError(t, kubernetes.ResourceC(cli).ListWithNamespace(clusterNs, caches))
+	assert.Len(t, caches.Items, 3)
+	for _, cache := range caches.Items {
+		// the KogitoInfra can't own the caches of another namespace, they're labeled after it
+		assert.Empty(t, cache.OwnerReferences)
+		assert.Equal(t, "infinispan-infra-uid", cache.Labels[infrastructure.InfinispanCacheOwnerLabel])
+	}
+	jobsCache := &ispnv2alpha1.Cache{ObjectMeta: metav1.ObjectMeta{Name: "kogito-infinispan-jobs", Namespace: clusterNs}}
+	test.AssertFetchMustExist(t, cli, jobsCache)
+	assert.Equal(t, []string{ns + "/" + jobsService.Name, ns + "/" + runtime.Name}, infrastructure.GetInfinispanCacheServices(jobsCache))
+
+	// the labeled caches are still managed by the operator
+	infinispanInfra.Spec.InfinispanCaches.Defaults.Template = `{"distributed-cache":{"mode":"ASYNC"}}`
+	assert.NoError(t, kubernetes.ResourceC(cli).Update(infinispanInfra))
+	err = NewInfinispanCacheReconciler(context, runtime, app.NewKogitoInfraHandler(context)).Reconcile()
+	assert.NoError(t, err)
+	test.AssertFetchMustExist(t, cli, jobsCache)
+	assert.Equal(t, `{"distributed-cache":{"mode":"ASYNC"}}`, jobsCache.Spec.Template)
+
+	// the caches of the deleted runtime are deleted, the jobs cache is still used by the Jobs Service
+	assert.Contains(t, runtime.Finalizers, InfinispanCachesFinalizer)
+	replicas := int32(0)
+	runtime.Spec.Replicas = &replicas
+	err = ReleaseInfinispanCaches(context, runtime, app.NewKogitoInfraHandler(context))
+	assert.NoError(t, err)
+	assert.NotContains(t, runtime.Finalizers, InfinispanCachesFinalizer)
+	// only the finalizers are written, not the spec changed during the reconciliation
+	deployedRuntime := test.CreateFakeKogitoRuntime(ns)
+	test.AssertFetchMustExist(t, cli, deployedRuntime)
+	assert.Empty(t, deployedRuntime.Finalizers)
+	assert.Equal(t, int32(1), *deployedRuntime.Spec.Replicas)
+	caches = &ispnv2alpha1.CacheList{}
+	assert.NoError(t, kubernetes.ResourceC(cli).ListWithNamespace(clusterNs, caches))
+	assert.Len(t, caches.Items, 1)
+	test.AssertFetchMustExist(t, cli, jobsCache)
+	assert.Equal(t, []string{ns + "/" + jobsService.Name}, infrastructure.GetInfinispanCacheServices(jobsCache))
+}
+
+func TestInfinispanCacheReconciler_CacheNotManaged(t *testing.T) {
+	ns := t.Name()
+	infinispanInfra := test.CreateFakeKogitoInfinispan(ns).(*v1beta1.KogitoInfra)
+	runtime := test.CreateFakeKogitoRuntime(ns)
+	runtime.Spec.Infra = []string{infinispanInfra.Name}
+	userCache := &ispnv2alpha1.Cache{
+		ObjectMeta: metav1.ObjectMeta{Name: "kogito-infinispan-jobs", Namespace: ns},
+		Spec:       ispnv2alpha1.CacheSpec{ClusterName: "kogito-infinispan", Name: "jobs", TemplateName: "org.infinispan.REPL_SYNC"},
+	}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(runtime, infinispanInfra, userCache).SupportInfinispanCache().Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+
+	err := NewInfinispanCacheReconciler(context, runtime, app.NewKogitoInfraHandler(context)).Reconcile()
+	assert.NoError(t, err)
+	test.AssertFetchMustExist(t, cli, userCache)
+	assert.Equal(t, "org.infinispan.REPL_SYNC", userCache.Spec.TemplateName)
+	assert.Empty(t, userCache.Spec.Template)
+}
+
+func TestInfinispanCacheReconciler_Disabled(t *testing.T) {
+	ns := t.Name()
+	infinispanInfra := test.CreateFakeKogitoInfinispan(ns).(*v1beta1.KogitoInfra)
+	infinispanInfra.Spec.InfinispanCaches.Disabled = true
+	runtime := test.CreateFakeKogitoRuntime(ns)
+	runtime.Spec.Infra = []string{infinispanInfra.Name}
+	cli := test.NewFakeClientBuilder().AddK8sObjects(runtime, infinispanInfra, newTravelsProtoBufConfigMap(runtime)).SupportInfinispanCache().Build()
+	context := operator.Context{
+		Client: cli,
+		Log:    test.TestLogger,
+		Scheme: meta.GetRegisteredSchema(),
+	}
+
+	err := NewInfinispanCacheReconciler(context, runtime, app.NewKogitoInfraHandler(context)).Reconcile()
+	assert.NoError(t, err)
+	caches := &ispnv2alpha1.CacheList{}
+	assert.NoError(t, kubernetes.ResourceC(cli).ListWithNamespace(ns, caches))
+	assert.Empty(t, caches.Items)
+}
+
+func Test_getProtoBufProcessIDs(t *testing.T) {
+	files := map[string]string{
+		"travels.proto":          travelsProto,
+		"visaApplications.proto": "option kogito_model = \"VisaApplications\";\noption kogito_id = \"visaApplications\";",
+		"kogito-index.proto":     "message KogitoMetadata {}",
+	}
+	assert.Equal(t, []string{"travels", "visaApplications"}, getProtoBufProcessIDs(files))
+}
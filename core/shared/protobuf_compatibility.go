@@ -0,0 +1,406 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	protoBufRequiredLabel = "required"
+	protoBufRepeatedLabel = "repeated"
+)
+
+var (
+	protoBufCommentsRegex = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*`)
+	protoBufTokenRegex    = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|[A-Za-z0-9_.\-+]+|[{}\[\]()<>;=,]`)
+)
+
+// protoBufField is a field declared in a protobuf message
+type protoBufField struct {
+	name     string
+	label    string
+	typeName string
+}
+
+// getType returns the type of the field as seen on the wire, repeated fields are not compatible with singular ones
+func (f protoBufField) getType() string {
+	typeName := f.typeName
+	if index := strings.LastIndex(typeName, "."); index >= 0 {
+		typeName = typeName[index+1:]
+	}
+	if f.label == protoBufRepeatedLabel {
+		return protoBufRepeatedLabel + " " + typeName
+	}
+	return typeName
+}
+
+// protoBufReservedRange is a range of field numbers reserved in a protobuf message
+type protoBufReservedRange struct {
+	from int
+	to   int
+}
+
+// protoBufMessage is a message declared in a protobuf file
+type protoBufMessage struct {
+	fields   map[int]protoBufField
+	reserved []protoBufReservedRange
+}
+
+func (m *protoBufMessage) isReserved(number int) bool {
+	for _, reserved := range m.reserved {
+		if number >= reserved.from && number <= reserved.to {
+			return true
+		}
+	}
+	return false
+}
+
+// protoBufSchema holds the messages declared in a set of protobuf files by fully qualified name
+type protoBufSchema map[string]*protoBufMessage
+
+// checkProtoBufCompatibility checks that the current protobuf files are backward compatible with the previous ones.
+// Both maps hold the content of the files by file name, as stored in the protobuf ConfigMap.
+// Returns the list of the backward compatibility issues found, if any.
+func checkProtoBufCompatibility(previousFiles map[string]string, currentFiles map[string]string) ([]string, error) {
+	previous, err := parseProtoBufFiles(previousFiles)
+	if err != nil {
+		return nil, err
+	}
+	current, err := parseProtoBufFiles(currentFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []string
+	for _, messageName := range previous.getMessageNames() {
+		previousMessage := previous[messageName]
+		currentMessage, exists := current[messageName]
+		if !exists {
+			continue
+		}
+		for _, number := range previousMessage.getFieldNumbers() {
+			previousField := previousMessage.fields[number]
+			currentField, exists := currentMessage.fields[number]
+			if !exists {
+				if previousField.label == protoBufRequiredLabel {
+					issues = append(issues, fmt.Sprintf("required field '%s' (%d) was removed from message %s", previousField.name, number, messageName))
+				}
+				continue
+			}
+			if currentField.name != previousField.name {
+				issues = append(issues, fmt.Sprintf("field number %d of message %s is reused by field '%s', previously '%s'", number, messageName, currentField.name, previousField.name))
+				continue
+			}
+			if currentField.getType() != previousField.getType() {
+				issues = append(issues, fmt.Sprintf("field '%s' (%d) of message %s changed type from '%s' to '%s'", currentField.name, number, messageName, previousField.getType(), currentField.getType()))
+			}
+		}
+		for _, number := range currentMessage.getFieldNumbers() {
+			if _, exists := previousMessage.fields[number]; !exists && previousMessage.isReserved(number) {
+				issues = append(issues, fmt.Sprintf("field '%s' of message %s reuses the reserved number %d", currentMessage.fields[number].name, messageName, number))
+			}
+		}
+	}
+	return issues, nil
+}
+
+func (s protoBufSchema) getMessageNames() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (m *protoBufMessage) getFieldNumbers() []int {
+	numbers := make([]int, 0, len(m.fields))
+	for number := range m.fields {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	return numbers
+}
+
+func parseProtoBufFiles(files map[string]string) (protoBufSchema, error) {
+	schema := protoBufSchema{}
+	for fileName, content := range files {
+		if !strings.HasSuffix(fileName, ".proto") {
+			continue
+		}
+		parser := &protoBufParser{
+			tokens: protoBufTokenRegex.FindAllString(protoBufCommentsRegex.ReplaceAllString(content, " "), -1),
+			schema: schema,
+		}
+		if err := parser.parseFile(); err != nil {
+			return nil, fmt.Errorf("Unable to parse protobuf file %s: %v ", fileName, err)
+		}
+	}
+	return schema, nil
+}
+
+// protoBufParser is a minimal parser for the protobuf files generated by Kogito, only messages and their fields are kept
+type protoBufParser struct {
+	tokens   []string
+	position int
+	schema   protoBufSchema
+}
+
+func (p *protoBufParser) next() (string, error) {
+	if p.position >= len(p.tokens) {
+		return "", fmt.Errorf("unexpected end of file")
+	}
+	token := p.tokens[p.position]
+	p.position++
+	return token, nil
+}
+
+func (p *protoBufParser) expect(expected string) error {
+	token, err := p.next()
+	if err != nil {
+		return err
+	}
+	if token != expected {
+		return fmt.Errorf("expected '%s' but found '%s'", expected, token)
+	}
+	return nil
+}
+
+func (p *protoBufParser) peek() string {
+	if p.position >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.position]
+}
+
+// skipStatement skips tokens up to the end of the current statement or block, whichever comes first
+func (p *protoBufParser) skipStatement() error {
+	depth := 0
+	for {
+		token, err := p.next()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case "{":
+			depth++
+		case "}":
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		case ";":
+			if depth == 0 {
+				return nil
+			}
+		}
+	}
+}
+
+func (p *protoBufParser) parseFile() error {
+	packageName := ""
+	for p.position < len(p.tokens) {
+		token, _ := p.next()
+		switch token {
+		case ";":
+		case "package":
+			name, err := p.next()
+			if err != nil {
+				return err
+			}
+			packageName = name
+			if err = p.expect(";"); err != nil {
+				return err
+			}
+		case "message":
+			if err := p.parseMessage(packageName); err != nil {
+				return err
+			}
+		case "syntax", "import", "option", "enum", "service", "extend":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unexpected token '%s'", token)
+		}
+	}
+	return nil
+}
+
+func (p *protoBufParser) parseMessage(scope string) error {
+	name, err := p.next()
+	if err != nil {
+		return err
+	}
+	if len(scope) > 0 {
+		name = scope + "." + name
+	}
+	message := &protoBufMessage{fields: map[int]protoBufField{}}
+	p.schema[name] = message
+	if err = p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		token, err := p.next()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case "}":
+			return nil
+		case ";":
+		case "message":
+			if err = p.parseMessage(name); err != nil {
+				return err
+			}
+		case "reserved":
+			if err = p.parseReserved(message); err != nil {
+				return err
+			}
+		case "oneof":
+			if err = p.parseOneOf(message); err != nil {
+				return err
+			}
+		case "option", "enum", "extend", "extensions":
+			if err = p.skipStatement(); err != nil {
+				return err
+			}
+		case "optional", protoBufRequiredLabel, protoBufRepeatedLabel:
+			typeName, err := p.next()
+			if err != nil {
+				return err
+			}
+			if err = p.parseField(message, token, typeName); err != nil {
+				return err
+			}
+		default:
+			if err = p.parseField(message, "", token); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *protoBufParser) parseOneOf(message *protoBufMessage) error {
+	if _, err := p.next(); err != nil {
+		return err
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		token, err := p.next()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case "}":
+			return nil
+		case ";":
+		case "option":
+			if err = p.skipStatement(); err != nil {
+				return err
+			}
+		default:
+			if err = p.parseField(message, "", token); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *protoBufParser) parseField(message *protoBufMessage, label string, typeName string) error {
+	if typeName == "map" {
+		var mapType strings.Builder
+		mapType.WriteString(typeName)
+		for {
+			token, err := p.next()
+			if err != nil {
+				return err
+			}
+			mapType.WriteString(token)
+			if token == ">" {
+				break
+			}
+		}
+		typeName = mapType.String()
+	}
+	name, err := p.next()
+	if err != nil {
+		return err
+	}
+	if err = p.expect("="); err != nil {
+		return err
+	}
+	numberToken, err := p.next()
+	if err != nil {
+		return err
+	}
+	number, err := strconv.Atoi(numberToken)
+	if err != nil {
+		return fmt.Errorf("invalid number '%s' for field '%s'", numberToken, name)
+	}
+	message.fields[number] = protoBufField{name: name, label: label, typeName: typeName}
+	if p.peek() == "[" {
+		for {
+			token, err := p.next()
+			if err != nil {
+				return err
+			}
+			if token == "]" {
+				break
+			}
+		}
+	}
+	return p.expect(";")
+}
+
+func (p *protoBufParser) parseReserved(message *protoBufMessage) error {
+	for {
+		token, err := p.next()
+		if err != nil {
+			return err
+		}
+		switch {
+		case token == ";":
+			return nil
+		case token == ",", strings.HasPrefix(token, "\""), strings.HasPrefix(token, "'"):
+		default:
+			from, err := strconv.Atoi(token)
+			if err != nil {
+				return fmt.Errorf("invalid reserved number '%s'", token)
+			}
+			reserved := protoBufReservedRange{from: from, to: from}
+			if p.peek() == "to" {
+				p.position++
+				toToken, err := p.next()
+				if err != nil {
+					return err
+				}
+				if toToken == "max" {
+					reserved.to = 536870911
+				} else if reserved.to, err = strconv.Atoi(toToken); err != nil {
+					return fmt.Errorf("invalid reserved number '%s'", toToken)
+				}
+			}
+			message.reserved = append(message.reserved, reserved)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+const travelsProto = `syntax = "proto2";
+package org.acme.travels.travels;
+import "kogito-index.proto";
+import "kogito-types.proto";
+option kogito_model = "Travels";
+option kogito_id = "travels";
+
+/* @Indexed */
+message Travels {
+	option java_package = "org.acme.travels.travels";
+	/* @Field(index = Index.YES, store = Store.YES) @SortableField */
+	optional string id = 1;
+	required string traveller = 2;
+	repeated Flight flights = 3 [default = "none"];
+	map<string, int32> counters = 4;
+	oneof payment {
+		string card = 5;
+		string voucher = 6;
+	}
+	reserved 10, 12 to 14;
+	reserved "legacy";
+	message Flight {
+		optional string number = 1;
+	}
+	optional org.kie.kogito.index.model.KogitoMetadata metadata = 20;
+}
+`
+
+func TestCheckProtoBufCompatibility(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		issues  []string
+	}{
+		{"SameSchema", travelsProto, nil},
+		{
+			"AddedOptionalField",
+			travelsProto + `message Hotel { optional string name = 1; }`,
+			nil,
+		},
+		{
+			"FieldNumberReused",
+			replaceOnce(travelsProto, "optional string id = 1;", "optional string code = 1;"),
+			[]string{"field number 1 of message org.acme.travels.travels.Travels is reused by field 'code', previously 'id'"},
+		},
+		{
+			"TypeChanged",
+			replaceOnce(travelsProto, "repeated Flight flights = 3", "optional Flight flights = 3"),
+			[]string{"field 'flights' (3) of message org.acme.travels.travels.Travels changed type from 'repeated Flight' to 'Flight'"},
+		},
+		{
+			"NestedTypeChanged",
+			replaceOnce(travelsProto, "optional string number = 1;", "optional int64 number = 1;"),
+			[]string{"field 'number' (1) of message org.acme.travels.travels.Travels.Flight changed type from 'string' to 'int64'"},
+		},
+		{
+			"RequiredFieldRemoved",
+			replaceOnce(travelsProto, "required string traveller = 2;", ""),
+			[]string{"required field 'traveller' (2) was removed from message org.acme.travels.travels.Travels"},
+		},
+		{
+			"ReservedNumberReused",
+			replaceOnce(travelsProto, "reserved \"legacy\";", "reserved \"legacy\";\n\toptional string legacy = 13;"),
+			[]string{"field 'legacy' of message org.acme.travels.travels.Travels reuses the reserved number 13"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := checkProtoBufCompatibility(map[string]string{"travels.proto": travelsProto}, map[string]string{"travels.proto": tt.current})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.issues, issues)
+		})
+	}
+}
+
+func TestCheckProtoBufCompatibility_InvalidFile(t *testing.T) {
+	_, err := checkProtoBufCompatibility(map[string]string{"travels.proto": travelsProto}, map[string]string{"travels.proto": "message Travels { optional string id; }"})
+	assert.Error(t, err)
+}
+
+func replaceOnce(content, old, new string) string {
+	return strings.Replace(content, old, new, 1)
+}
@@ -36,6 +36,9 @@ const (
 	DefaultProtobufMountPath = operator.KogitoHomeDir + "/data/protobufs"
 	// ConfigMapProtoBufEnabledLabelKey label key used by configMaps that are meant to hold protobuf files
 	ConfigMapProtoBufEnabledLabelKey = "kogito-protobuf"
+	// ProtoBufIncompatibleHashAnnotation hash of the protobuf files provided to Data Index even though they aren't backward compatible,
+	// the KogitoRuntime is reported incompatible until the files change again
+	ProtoBufIncompatibleHashAnnotation = "kogito.kie.org/protobuf-incompatible-hash"
 	// protobufConfigMapSuffix Suffix that is appended to Protobuf ConfigMap name
 	protobufConfigMapSuffix = "protobuf-files"
 	protobufSubdir          = "/persistence/protobuf/"
@@ -15,13 +15,20 @@
 package shared
 
 import (
+	"fmt"
 	api "github.com/kiegroup/kogito-operator/apis"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
 	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/framework/util"
 	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/record"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
 )
 
 // ProtoBufConfigMapReconciler ...
@@ -35,6 +42,7 @@ type protoBufConfigMapReconciler struct {
 	configMapHandler         infrastructure.ConfigMapHandler
 	protobufConfigMapHandler ProtoBufConfigMapHandler
 	deltaProcessor           infrastructure.DeltaProcessor
+	recorder                 record.EventRecorder
 }
 
 // NewProtoBufConfigMapReconciler ...
@@ -45,6 +53,7 @@ func NewProtoBufConfigMapReconciler(context operator.Context, instance api.Kogit
 		configMapHandler:         infrastructure.NewConfigMapHandler(context),
 		protobufConfigMapHandler: NewProtoBufConfigMapHandler(context),
 		deltaProcessor:           infrastructure.NewDeltaProcessor(context),
+		recorder:                 record.NewRecorder(context.Scheme, v1.EventSource{Component: instance.GetName(), Host: record.GetHostName()}),
 	}
 }
 
@@ -73,7 +82,9 @@ func (p *protoBufConfigMapReconciler) createRequiredResources(runtimeInstance ap
 		return nil, err
 	}
 	if protoBufConfigMap != nil {
-
+		if err := p.checkCompatibility(protoBufConfigMap); err != nil {
+			return nil, err
+		}
 		if err := framework.SetOwner(runtimeInstance, p.Scheme, protoBufConfigMap); err != nil {
 			return nil, err
 		}
@@ -114,3 +125,73 @@ func (p *protoBufConfigMapReconciler) processDelta(requestedResources map[reflec
 	}
 	return
 }
+
+// checkCompatibility checks the requested protobuf files against the ones already provided to Data Index.
+// Incompatibilities are reported on the KogitoRuntime and, when the service requires so, the previous files are kept.
+func (p *protoBufConfigMapReconciler) checkCompatibility(requestedConfigMap *v1.ConfigMap) error {
+	deployedConfigMap, err := p.protobufConfigMapHandler.FetchProtoBufConfigMap(p.runtimeInstance)
+	if err != nil {
+		return err
+	}
+	if deployedConfigMap == nil {
+		return p.setCompatibleCondition(metav1.ConditionTrue, infrastructure.ProtoBufCompatibleReason, "")
+	}
+	requestedHash := util.GenerateMD5Hash(requestedConfigMap.Data)
+	if reflect.DeepEqual(deployedConfigMap.Data, requestedConfigMap.Data) {
+		if deployedConfigMap.Annotations[ProtoBufIncompatibleHashAnnotation] == requestedHash {
+			// the incompatible files were provided anyway, the condition is kept until they change
+			setIncompatibleHash(requestedConfigMap, requestedHash)
+			return nil
+		}
+		return p.setCompatibleCondition(metav1.ConditionTrue, infrastructure.ProtoBufCompatibleReason, "")
+	}
+	issues, err := checkProtoBufCompatibility(deployedConfigMap.Data, requestedConfigMap.Data)
+	if err != nil {
+		p.Log.Error(err, "Unable to check backward compatibility of protobuf files, skipping the check")
+		return nil
+	}
+	if len(issues) == 0 {
+		return p.setCompatibleCondition(metav1.ConditionTrue, infrastructure.ProtoBufCompatibleReason, "")
+	}
+
+	message := fmt.Sprintf("Protobuf files are not backward compatible with the ones provided to Data Index: %s", strings.Join(issues, "; "))
+	if err = p.setCompatibleCondition(metav1.ConditionFalse, infrastructure.ProtoBufIncompatibleReason, message); err != nil {
+		return err
+	}
+	if p.runtimeInstance.GetRuntimeSpec().GetProtoBufCompatibility() == api.BlockProtoBufCompatibilityPolicy {
+		return infrastructure.ErrorForIncompatibleProtoBuf(p.runtimeInstance.GetName(), issues)
+	}
+	p.Log.Info("Protobuf files are not backward compatible, providing them to Data Index anyway", "issues", issues)
+	setIncompatibleHash(requestedConfigMap, requestedHash)
+	return nil
+}
+
+// setIncompatibleHash records on the ConfigMap the hash of the incompatible protobuf files provided to Data Index
+func setIncompatibleHash(configMap *v1.ConfigMap, hash string) {
+	if configMap.Annotations == nil {
+		configMap.Annotations = map[string]string{}
+	}
+	configMap.Annotations[ProtoBufIncompatibleHashAnnotation] = hash
+}
+
+// setCompatibleCondition updates the ProtoBufCompatible condition of the KogitoRuntime, an event is recorded when it becomes incompatible
+func (p *protoBufConfigMapReconciler) setCompatibleCondition(status metav1.ConditionStatus, reason infrastructure.ConditionReason, message string) error {
+	instanceStatus := p.runtimeInstance.GetStatus()
+	if instanceStatus.GetConditions() == nil {
+		instanceStatus.SetConditions(&[]metav1.Condition{})
+	}
+	condition := meta.FindStatusCondition(*instanceStatus.GetConditions(), string(api.ProtoBufCompatibleConditionType))
+	if condition != nil && condition.Status == status && condition.Message == message {
+		return nil
+	}
+	meta.SetStatusCondition(instanceStatus.GetConditions(), metav1.Condition{
+		Type:    string(api.ProtoBufCompatibleConditionType),
+		Status:  status,
+		Reason:  string(reason),
+		Message: message,
+	})
+	if status == metav1.ConditionFalse {
+		p.recorder.Event(p.Client, p.runtimeInstance, v1.EventTypeWarning, string(reason), message)
+	}
+	return kubernetes.ResourceC(p.Client).UpdateStatus(p.runtimeInstance)
+}
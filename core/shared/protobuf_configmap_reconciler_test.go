@@ -15,7 +15,10 @@
 package shared
 
 import (
+	api "github.com/kiegroup/kogito-operator/apis"
 	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/framework"
+	"github.com/kiegroup/kogito-operator/core/infrastructure"
 	"github.com/kiegroup/kogito-operator/core/kogitoservice"
 	"github.com/kiegroup/kogito-operator/core/operator"
 	"github.com/kiegroup/kogito-operator/core/test"
@@ -23,8 +26,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	meta2 "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -62,3 +67,77 @@ func TestCreateRequiredResources(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Empty(t, configMaps.Items)
 }
+
+func TestReconcileIncompatibleProtoBuf(t *testing.T) {
+	incompatibleProto := strings.Replace(travelsProto, "optional string id = 1;", "optional int32 id = 1;", 1)
+	server := test.MockKogitoSvcReplies(t,
+		test.ServerHandler{Path: "/persistence/protobuf/list.json", JSONResponse: `["travels.proto"]`},
+		test.ServerHandler{Path: "/persistence/protobuf/travels.proto", JSONResponse: incompatibleProto})
+	defer server.Close()
+	err := os.Setenv(kogitoservice.EnvVarKogitoServiceURL, server.URL)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		policy       api.ProtoBufCompatibilityPolicy
+		expectedData string
+	}{
+		{"Warn", api.WarnProtoBufCompatibilityPolicy, incompatibleProto},
+		{"Block", api.BlockProtoBufCompatibilityPolicy, travelsProto},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := t.Name()
+			runtimeService := test.CreateFakeKogitoRuntime(ns)
+			runtimeService.Spec.ProtoBufCompatibility = tt.policy
+			runtimeDeployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: runtimeService.Name, Namespace: ns},
+				Status:     appsv1.DeploymentStatus{AvailableReplicas: 1},
+			}
+			protoBufConfigMap := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      runtimeService.Name + "-" + protobufConfigMapSuffix,
+					Namespace: ns,
+					Labels:    map[string]string{ConfigMapProtoBufEnabledLabelKey: "true", framework.LabelAppKey: runtimeService.Name},
+				},
+				Data: map[string]string{"travels.proto": travelsProto},
+			}
+			cli := test.NewFakeClientBuilder().AddK8sObjects(runtimeService, runtimeDeployment, protoBufConfigMap).Build()
+			context := operator.Context{
+				Client: cli,
+				Log:    test.TestLogger,
+				Scheme: meta.GetRegisteredSchema(),
+			}
+
+			err := NewProtoBufConfigMapReconciler(context, runtimeService).Reconcile()
+			assert.Error(t, err)
+			if tt.policy == api.BlockProtoBufCompatibilityPolicy {
+				assert.Equal(t, infrastructure.ProtoBufIncompatibleReason, infrastructure.NewReconciliationErrorHandler(context).GetReasonForError(err))
+			}
+
+			_, err = kubernetes.ResourceC(cli).Fetch(protoBufConfigMap)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedData, protoBufConfigMap.Data["travels.proto"])
+
+			_, err = kubernetes.ResourceC(cli).Fetch(runtimeService)
+			assert.NoError(t, err)
+			condition := meta2.FindStatusCondition(*runtimeService.Status.Conditions, string(api.ProtoBufCompatibleConditionType))
+			assert.NotNil(t, condition)
+			assert.Equal(t, metav1.ConditionFalse, condition.Status)
+			assert.Contains(t, condition.Message, "field 'id' (1) of message org.acme.travels.travels.Travels changed type from 'string' to 'int32'")
+
+			events := &v1.EventList{}
+			err = kubernetes.ResourceC(cli).ListWithNamespace(ns, events)
+			assert.NoError(t, err)
+			assert.Len(t, events.Items, 1)
+			assert.Equal(t, v1.EventTypeWarning, events.Items[0].Type)
+
+			// the files are still reported incompatible on the next reconciliation
+			_ = NewProtoBufConfigMapReconciler(context, runtimeService).Reconcile()
+			_, err = kubernetes.ResourceC(cli).Fetch(runtimeService)
+			assert.NoError(t, err)
+			condition = meta2.FindStatusCondition(*runtimeService.Status.Conditions, string(api.ProtoBufCompatibleConditionType))
+			assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fakeApplyClient emulates server-side apply on top of the fake client, which doesn't support it.
+// Apply patches create the object if it doesn't exist, otherwise they're merged into the existing object.
+// Field ownership isn't tracked, so conflicts are never returned.
+type fakeApplyClient struct {
+	client.Client
+}
+
+func (c *fakeApplyClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	data, err := patch.Data(obj)
+	if err != nil {
+		return err
+	}
+	existing := obj.DeepCopyObject().(client.Object)
+	if err = c.Client.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if errors.IsNotFound(err) {
+			return c.Client.Create(ctx, obj)
+		}
+		return err
+	}
+	return c.Client.Patch(ctx, obj, client.RawPatch(types.MergePatchType, data))
+}
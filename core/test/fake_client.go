@@ -36,6 +36,8 @@ type FakeClientBuilder interface {
 	OnOpenShift() FakeClientBuilder
 	SupportPrometheus() FakeClientBuilder
 	SupportOLM() FakeClientBuilder
+	SupportExternalSecrets() FakeClientBuilder
+	SupportInfinispanCache() FakeClientBuilder
 	Build() *kogitocli.Client
 }
 
@@ -52,6 +54,10 @@ type fakeClientStruct struct {
 	openShift  bool
 	prometheus bool
 	olm        bool
+	// externalSecrets the External Secrets Operator API is available
+	externalSecrets bool
+	// infinispanCache the Infinispan Operator Cache API is available
+	infinispanCache bool
 }
 
 // AddK8sObjects ...
@@ -82,6 +88,18 @@ func (f *fakeClientStruct) SupportOLM() FakeClientBuilder {
 	return f
 }
 
+// SupportExternalSecrets ...
+func (f *fakeClientStruct) SupportExternalSecrets() FakeClientBuilder {
+	f.externalSecrets = true
+	return f
+}
+
+// SupportInfinispanCache ...
+func (f *fakeClientStruct) SupportInfinispanCache() FakeClientBuilder {
+	f.infinispanCache = true
+	return f
+}
+
 // OnOpenShift ...
 func (f *fakeClientStruct) OnOpenShift() FakeClientBuilder {
 	f.openShift = true
@@ -98,7 +116,7 @@ func (f *fakeClientStruct) Build() *kogitocli.Client {
 	buildCli := newBuildFake(f.buildObjs...)
 
 	return &kogitocli.Client{
-		ControlCli: cli,
+		ControlCli: &fakeApplyClient{Client: cli},
 		BuildCli:   buildCli,
 		ImageCli:   imgCli,
 		Discovery:  f.createFakeDiscoveryClient(),
@@ -146,6 +164,16 @@ func (f *fakeClientStruct) createFakeDiscoveryClient() discovery.DiscoveryInterf
 		disco.Fake.Resources = append(disco.Fake.Resources,
 			&metav1.APIResourceList{GroupVersion: "operators.coreos.com/v1"})
 	}
+
+	if f.externalSecrets {
+		disco.Fake.Resources = append(disco.Fake.Resources,
+			&metav1.APIResourceList{GroupVersion: "external-secrets.io/v1beta1"})
+	}
+
+	if f.infinispanCache {
+		disco.Fake.Resources = append(disco.Fake.Resources,
+			&metav1.APIResourceList{GroupVersion: "infinispan.org/v2alpha1"})
+	}
 	return disco
 }
 
@@ -50,6 +50,11 @@ func CreateFakeTrustyAIService(namespace string) *v1beta1.KogitoSupportingServic
 	return createFakeKogitoSupportingServiceInstance("trusty-ai", namespace, api.TrustyAI)
 }
 
+// CreateFakeTrustyStack ...
+func CreateFakeTrustyStack(namespace string) *v1beta1.KogitoSupportingService {
+	return createFakeKogitoSupportingServiceInstance("trusty-stack", namespace, api.TrustyStack)
+}
+
 // CreateFakeTrustyUIService ...
 func CreateFakeTrustyUIService(namespace string) *v1beta1.KogitoSupportingService {
 	return createFakeKogitoSupportingServiceInstance("trusty-ui", namespace, api.TrustyUI)
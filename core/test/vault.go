@@ -0,0 +1,132 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"fmt"
+
+	kogitocli "github.com/kiegroup/kogito-operator/core/client"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	externalsecrets "github.com/kiegroup/kogito-operator/core/infrastructure/externalsecrets/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DevVault is a stand-in for a HashiCorp Vault server in dev mode read by the External Secrets Operator.
+// It keeps the secrets in memory by path and materializes the Secrets of the ExternalSecrets like the External Secrets Operator does.
+type DevVault struct {
+	secrets map[string]map[string]string
+}
+
+// NewDevVault creates an empty DevVault
+func NewDevVault() *DevVault {
+	return &DevVault{secrets: map[string]map[string]string{}}
+}
+
+// Put writes the secret at the given path, replacing the previous one
+func (v *DevVault) Put(path string, data map[string]string) {
+	v.secrets[path] = data
+}
+
+// Sync materializes the Secrets of the ExternalSecrets of the given namespace, owned by their ExternalSecret.
+// ExternalSecrets reading a missing secret or key are set as not ready, as the External Secrets Operator does.
+func (v *DevVault) Sync(cli *kogitocli.Client, namespace string) error {
+	externalSecrets := &externalsecrets.ExternalSecretList{}
+	if err := kubernetes.ResourceC(cli).ListWithNamespace(namespace, externalSecrets); err != nil {
+		return err
+	}
+	for i := range externalSecrets.Items {
+		externalSecret := &externalSecrets.Items[i]
+		data, err := v.read(externalSecret)
+		if err != nil {
+			externalSecret.Status.Conditions = []externalsecrets.ExternalSecretStatusCondition{
+				{Type: externalsecrets.ExternalSecretReady, Status: corev1.ConditionFalse, Reason: "SecretSyncedError", Message: err.Error()},
+			}
+			if err := kubernetes.ResourceC(cli).UpdateStatus(externalSecret); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := v.materialize(cli, externalSecret, data); err != nil {
+			return err
+		}
+		externalSecret.Status.Conditions = []externalsecrets.ExternalSecretStatusCondition{
+			{Type: externalsecrets.ExternalSecretReady, Status: corev1.ConditionTrue, Reason: "SecretSynced"},
+		}
+		if err := kubernetes.ResourceC(cli).UpdateStatus(externalSecret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *DevVault) read(externalSecret *externalsecrets.ExternalSecret) (map[string][]byte, error) {
+	data := map[string][]byte{}
+	for _, dataFrom := range externalSecret.Spec.DataFrom {
+		if dataFrom.Extract == nil {
+			continue
+		}
+		secret, exists := v.secrets[dataFrom.Extract.Key]
+		if !exists {
+			return nil, fmt.Errorf("secret not found at path %s", dataFrom.Extract.Key)
+		}
+		for key, value := range secret {
+			data[key] = []byte(value)
+		}
+	}
+	for _, item := range externalSecret.Spec.Data {
+		value, exists := v.secrets[item.RemoteRef.Key][item.RemoteRef.Property]
+		if !exists {
+			return nil, fmt.Errorf("key %s not found in secret at path %s", item.RemoteRef.Property, item.RemoteRef.Key)
+		}
+		data[item.SecretKey] = []byte(value)
+	}
+	return data, nil
+}
+
+func (v *DevVault) materialize(cli *kogitocli.Client, externalSecret *externalsecrets.ExternalSecret, data map[string][]byte) error {
+	name := externalSecret.Spec.Target.Name
+	if len(name) == 0 {
+		name = externalSecret.Name
+	}
+	secret := &corev1.Secret{}
+	exists, err := kubernetes.ResourceC(cli).FetchWithKey(types.NamespacedName{Name: name, Namespace: externalSecret.Namespace}, secret)
+	if err != nil {
+		return err
+	}
+	if exists {
+		secret.Data = data
+		return kubernetes.ResourceC(cli).Update(secret)
+	}
+	isController := true
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: externalSecret.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: externalsecrets.SchemeGroupVersion.String(),
+					Kind:       "ExternalSecret",
+					Name:       externalSecret.Name,
+					UID:        externalSecret.UID,
+					Controller: &isController,
+				},
+			},
+		},
+		Data: data,
+	}
+	return kubernetes.ResourceC(cli).Create(secret)
+}
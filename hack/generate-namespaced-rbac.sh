@@ -0,0 +1,72 @@
+#!/bin/env bash
+# Copyright 2022 Red Hat, Inc. and/or its affiliates
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Generates a Role and a RoleBinding in each namespace watched by the operator from the manager ClusterRole
+# generated by controller-gen, so that the operator watching a single namespace or a list of namespaces
+# (WATCH_NAMESPACE) doesn't need any cluster-wide permission.
+# The CustomResourceDefinitions rule is dropped, the capabilities aren't refreshed by watching the CRDs in these modes.
+#
+# Usage: generate-namespaced-rbac.sh <role file> <name prefix> <operator namespace> <comma separated watched namespaces>
+set -e
+
+ROLE_FILE=$1
+NAME_PREFIX=$2
+OPERATOR_NAMESPACE=$3
+WATCH_NAMESPACE=$4
+
+if [ -z "${ROLE_FILE}" ] || [ -z "${OPERATOR_NAMESPACE}" ] || [ -z "${WATCH_NAMESPACE}" ]; then
+  echo "Usage: $0 <role file> <name prefix> <operator namespace> <comma separated watched namespaces>" >&2
+  exit 1
+fi
+
+# prints the rules of the ClusterRole but the CustomResourceDefinitions one
+function printRules() {
+  awk '
+    function flush() { if (rule != "" && rule !~ /customresourcedefinitions/) printf "%s", rule; rule = "" }
+    /^rules:/ { inRules = 1; print; next }
+    !inRules { next }
+    /^- / { flush() }
+    { rule = rule $0 "\n" }
+    END { flush() }
+  ' "${ROLE_FILE}"
+}
+
+for namespace in ${WATCH_NAMESPACE//,/ }; do
+  cat <<YAML
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: ${NAME_PREFIX}manager-role
+  namespace: ${namespace}
+YAML
+  printRules
+  cat <<YAML
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: ${NAME_PREFIX}manager-rolebinding
+  namespace: ${namespace}
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: ${NAME_PREFIX}manager-role
+subjects:
+- kind: ServiceAccount
+  name: ${NAME_PREFIX}controller-manager
+  namespace: ${OPERATOR_NAMESPACE}
+YAML
+done
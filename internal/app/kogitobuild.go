@@ -44,6 +44,15 @@ func (k *kogitoBuildHandler) FetchKogitoBuildInstance(key types.NamespacedName)
 	return instance, nil
 }
 
+func (k *kogitoBuildHandler) FetchAllKogitoBuildInstances(namespace string) (api.KogitoBuildListInterface, error) {
+	kogitoBuilds := &v1beta1.KogitoBuildList{}
+	if err := kubernetes.ResourceC(k.Client).ListWithNamespace(namespace, kogitoBuilds); err != nil {
+		return nil, err
+	}
+	k.Log.Debug("Found KogitoBuilds", "count", len(kogitoBuilds.Items))
+	return kogitoBuilds, nil
+}
+
 func (k *kogitoBuildHandler) CreateBuild() api.BuildsInterface {
 	return &v1beta1.Builds{}
 }
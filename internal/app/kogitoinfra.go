@@ -47,3 +47,13 @@ func (k *kogitoInfraHandler) FetchKogitoInfraInstance(key types.NamespacedName)
 		return instance, nil
 	}
 }
+
+// FetchAllKogitoInfraInstances lists the infra instances of the given namespace, of all the namespaces when empty
+func (k *kogitoInfraHandler) FetchAllKogitoInfraInstances(namespace string) (api.KogitoInfraListInterface, error) {
+	kogitoInfras := &v1beta1.KogitoInfraList{}
+	if err := kubernetes.ResourceC(k.Client).ListWithNamespace(namespace, kogitoInfras); err != nil {
+		return nil, err
+	}
+	k.Log.Debug("Found KogitoInfra instances", "count", len(kogitoInfras.Items))
+	return kogitoInfras, nil
+}
@@ -58,3 +58,8 @@ func (k kogitoSupportingServiceHandler) FetchKogitoSupportingServiceList(namespa
 	k.Log.Debug("Deployed kogito supporting service", "count", len(supportingServiceList.Items))
 	return supportingServiceList, nil
 }
+
+// CreateKogitoSupportingService provides an empty kogito supporting service instance
+func (k kogitoSupportingServiceHandler) CreateKogitoSupportingService() api.KogitoSupportingServiceInterface {
+	return &v1beta1.KogitoSupportingService{}
+}
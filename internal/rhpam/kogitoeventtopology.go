@@ -0,0 +1,60 @@
+// Copyright 2022 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rhpam
+
+import (
+	"github.com/kiegroup/kogito-operator/apis"
+	v1 "github.com/kiegroup/kogito-operator/apis/rhpam/v1"
+	"github.com/kiegroup/kogito-operator/core/client/kubernetes"
+	"github.com/kiegroup/kogito-operator/core/manager"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type kogitoEventTopologyHandler struct {
+	operator.Context
+}
+
+// NewKogitoEventTopologyHandler ...
+func NewKogitoEventTopologyHandler(context operator.Context) manager.KogitoEventTopologyHandler {
+	return &kogitoEventTopologyHandler{
+		context,
+	}
+}
+
+// FetchKogitoEventTopologyInstance loads a given event topology instance by name and namespace.
+// If the KogitoEventTopology resource is not present, nil will return.
+func (k *kogitoEventTopologyHandler) FetchKogitoEventTopologyInstance(key types.NamespacedName) (api.KogitoEventTopologyInterface, error) {
+	k.Log.Debug("going to fetch deployed kogito event topology instance")
+	instance := &v1.KogitoEventTopology{}
+	if exists, resultErr := kubernetes.ResourceC(k.Client).FetchWithKey(key, instance); resultErr != nil {
+		k.Log.Error(resultErr, "Error occurs while fetching deployed kogito event topology instance")
+		return nil, resultErr
+	} else if !exists {
+		return nil, nil
+	} else {
+		k.Log.Debug("Successfully fetch deployed kogito event topology reference")
+		return instance, nil
+	}
+}
+
+func (k *kogitoEventTopologyHandler) FetchAllKogitoEventTopologyInstances(namespace string) (api.KogitoEventTopologyListInterface, error) {
+	eventTopologies := &v1.KogitoEventTopologyList{}
+	if err := kubernetes.ResourceC(k.Client).ListWithNamespace(namespace, eventTopologies); err != nil {
+		return nil, err
+	}
+	k.Log.Debug("Found KogitoEventTopology instances", "count", len(eventTopologies.Items))
+	return eventTopologies, nil
+}
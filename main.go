@@ -18,10 +18,13 @@ package main
 
 import (
 	"flag"
+	"github.com/kiegroup/kogito-operator/controllers/common"
 	"github.com/kiegroup/kogito-operator/controllers/rhpam"
 	"github.com/kiegroup/kogito-operator/core/client"
 	"github.com/kiegroup/kogito-operator/core/framework/util"
 	"github.com/kiegroup/kogito-operator/core/logger"
+	"github.com/kiegroup/kogito-operator/core/operator"
+	"github.com/kiegroup/kogito-operator/core/runtimeprofile"
 	"github.com/kiegroup/kogito-operator/meta"
 	"os"
 	"strings"
@@ -34,17 +37,27 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/kiegroup/kogito-operator/controllers/app"
 	//+kubebuilder:scaffold:imports
 )
 
+const capabilitiesPath = "/capabilities"
+
 var (
 	scheme               *runtime.Scheme
 	setupLog             = logger.GetLogger("setup")
 	metricsAddr          string
 	enableLeaderElection bool
 	probeAddr            string
+	watchNamespaces      string
+	watchSelector        string
+	configFile           string
+	runtimeProfiles      string
+	apiQPS               float64
+	apiBurst             int
+	concurrentReconciles int
 )
 
 func init() {
@@ -55,6 +68,22 @@ func init() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", os.Getenv(client.WatchNamespaceEnvVar),
+		"Comma separated list of the namespaces watched by the operator, all namespaces if empty. "+
+			"Defaults to the "+client.WatchNamespaceEnvVar+" environment variable.")
+	flag.StringVar(&watchSelector, "watch-namespace-selector", os.Getenv(client.WatchNamespaceSelectorEnvVar),
+		"Label selector of the namespaces watched by the operator, evaluated again when the namespace labels change. "+
+			"Takes precedence over the namespaces list. Defaults to the "+client.WatchNamespaceSelectorEnvVar+" environment variable.")
+	flag.StringVar(&configFile, "config", "",
+		"Path of the operator configuration file, usually mounted from a ConfigMap, "+
+			"defining the API server QPS and burst, the concurrent reconciles per controller and the requeue backoff per reconciliation error reason.")
+	flag.StringVar(&runtimeProfiles, "runtime-profiles-configmap", "",
+		"Namespace/name of the ConfigMap holding the runtime profiles under the "+runtimeprofile.ProfilesKey+" key, reloaded when it changes, "+
+			"defining the probe and metrics paths, the infrastructure properties and the images of the runtimes added to Quarkus and Spring Boot.")
+	flag.Float64Var(&apiQPS, "kube-api-qps", 0, "Maximum queries per second sent to the API server, overrides the configuration file.")
+	flag.IntVar(&apiBurst, "kube-api-burst", 0, "Maximum queries sent to the API server at once, overrides the configuration file.")
+	flag.IntVar(&concurrentReconciles, "max-concurrent-reconciles", 0,
+		"Number of objects each controller reconciles in parallel, overrides the default of the configuration file.")
 }
 
 func main() {
@@ -65,63 +94,112 @@ func main() {
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	operatorConfig, err := loadOperatorConfig()
+	if err != nil {
+		setupLog.Error(err, "unable to load the operator configuration")
+		os.Exit(1)
+	}
+	watchOptions, err := client.NewWatchOptions(watchNamespaces, watchSelector)
+	if err != nil {
+		setupLog.Error(err, "invalid watched namespaces")
+		os.Exit(1)
+	}
+	managerOptions := ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
 		Port:                   9443,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "d1731e98.kiegroup.org",
-	})
+	}
+	watchOptions.ApplyToManager(&managerOptions)
+	setupLog.Info("Watching "+watchOptions.String(), "mode", watchOptions.GetMode())
+	restConfig := ctrl.GetConfigOrDie()
+	restConfig.QPS = operatorConfig.QPS
+	restConfig.Burst = operatorConfig.Burst
+	mgr, err := ctrl.NewManager(restConfig, managerOptions)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
-	kubeCli := client.NewForController(mgr)
+	kubeCli := client.NewForController(mgr, watchOptions)
+
+	if err := setupRuntimeProfiles(mgr, operatorConfig); err != nil {
+		setupLog.Error(err, "unable to load the runtime profiles")
+		os.Exit(1)
+	}
 
 	if !util.IsProductMode() {
-		if err = app.NewKogitoRuntimeReconciler(kubeCli, mgr.GetScheme()).SetupWithManager(mgr); err != nil {
+		if err = app.NewKogitoRuntimeReconciler(kubeCli, mgr.GetScheme(), operatorConfig).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "KogitoRuntime")
 			os.Exit(1)
 		}
-		if err = app.NewKogitoSupportingServiceReconciler(kubeCli, mgr.GetScheme()).SetupWithManager(mgr); err != nil {
+		if err = app.NewKogitoSupportingServiceReconciler(kubeCli, mgr.GetScheme(), operatorConfig).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "KogitoSupportingService")
 			os.Exit(1)
 		}
-		if err = app.NewKogitoBuildReconciler(kubeCli, mgr.GetScheme()).SetupWithManager(mgr); err != nil {
+		if err = app.NewKogitoBuildReconciler(kubeCli, mgr.GetScheme(), operatorConfig).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "KogitoBuild")
 			os.Exit(1)
 		}
-		if err = app.NewKogitoInfraReconciler(kubeCli, mgr.GetScheme()).SetupWithManager(mgr); err != nil {
+		if err = app.NewKogitoInfraReconciler(kubeCli, mgr.GetScheme(), operatorConfig).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "KogitoInfra")
 			os.Exit(1)
 		}
-		if err = app.NewKogitoRuntimeDeploymentReconciler(kubeCli, mgr.GetScheme()).SetupWithManager(mgr); err != nil {
+		if err = app.NewKogitoRuntimeDeploymentReconciler(kubeCli, mgr.GetScheme(), operatorConfig).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "KogitoRuntimeDeployment")
 			os.Exit(1)
 		}
+		if err = app.NewKogitoEventTopologyReconciler(kubeCli, mgr.GetScheme(), operatorConfig).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "KogitoEventTopology")
+			os.Exit(1)
+		}
+		if err = setupCapabilitiesReconciler(watchOptions, app.NewCapabilitiesReconciler(kubeCli, operatorConfig), mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Capabilities")
+			os.Exit(1)
+		}
 	} else {
-		if err = rhpam.NewKogitoRuntimeReconciler(kubeCli, mgr.GetScheme()).SetupWithManager(mgr); err != nil {
+		if err = rhpam.NewKogitoRuntimeReconciler(kubeCli, mgr.GetScheme(), operatorConfig).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "KogitoRuntime")
 			os.Exit(1)
 		}
-		if err = rhpam.NewKogitoSupportingServiceReconciler(kubeCli, mgr.GetScheme()).SetupWithManager(mgr); err != nil {
+		if err = rhpam.NewKogitoSupportingServiceReconciler(kubeCli, mgr.GetScheme(), operatorConfig).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "KogitoSupportingService")
 			os.Exit(1)
 		}
-		if err = rhpam.NewKogitoBuildReconciler(kubeCli, mgr.GetScheme()).SetupWithManager(mgr); err != nil {
+		if err = rhpam.NewKogitoBuildReconciler(kubeCli, mgr.GetScheme(), operatorConfig).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "KogitoBuild")
 			os.Exit(1)
 		}
-		if err = rhpam.NewKogitoInfraReconciler(kubeCli, mgr.GetScheme()).SetupWithManager(mgr); err != nil {
+		if err = rhpam.NewKogitoInfraReconciler(kubeCli, mgr.GetScheme(), operatorConfig).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "KogitoInfra")
 			os.Exit(1)
 		}
+		if err = rhpam.NewKogitoEventTopologyReconciler(kubeCli, mgr.GetScheme(), operatorConfig).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "KogitoEventTopology")
+			os.Exit(1)
+		}
+		if err = setupCapabilitiesReconciler(watchOptions, rhpam.NewCapabilitiesReconciler(kubeCli, operatorConfig), mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Capabilities")
+			os.Exit(1)
+		}
 	}
 
 	//+kubebuilder:scaffold:builder
 
+	if err := operator.RegisterMetrics(metrics.Registry, operatorConfig, common.ControllerNames...); err != nil {
+		setupLog.Error(err, "unable to register the operator metrics")
+		os.Exit(1)
+	}
+
+	// report the cluster capabilities detected through the discovery API, they are cached and shared by all the controllers
+	kubeCli.Capabilities.LogCapabilities()
+	if err := mgr.AddMetricsExtraHandler(capabilitiesPath, kubeCli.Capabilities); err != nil {
+		setupLog.Error(err, "unable to set up capabilities endpoint")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -138,6 +216,54 @@ func main() {
 	}
 }
 
+// loadOperatorConfig reads the configuration file, the flags given explicitly take precedence
+func loadOperatorConfig() (*operator.Config, error) {
+	config, err := operator.LoadConfig(configFile)
+	if err != nil {
+		return nil, err
+	}
+	if apiQPS > 0 {
+		config.QPS = float32(apiQPS)
+	}
+	if apiBurst > 0 {
+		config.Burst = apiBurst
+	}
+	if concurrentReconciles > 0 {
+		config.MaxConcurrentReconciles[operator.DefaultConfigKey] = concurrentReconciles
+	}
+	setupLog.Info("Operator configuration", "qps", config.QPS, "burst", config.Burst, "maxConcurrentReconciles", config.MaxConcurrentReconciles)
+	return config, nil
+}
+
+// setupCapabilitiesReconciler watches the CustomResourceDefinitions only if the operator has cluster scoped permissions,
+// otherwise the capabilities are refreshed once their cache expires
+func setupCapabilitiesReconciler(watchOptions *client.WatchOptions, reconciler *common.CapabilitiesReconciler, mgr ctrl.Manager) error {
+	if !watchOptions.IsClusterScoped() {
+		setupLog.Info("CustomResourceDefinitions not watched, the capabilities are refreshed every " + client.DefaultCapabilitiesTTL.String())
+		return nil
+	}
+	return reconciler.SetupWithManager(mgr)
+}
+
+// setupRuntimeProfiles registers the runtime profiles of the given ConfigMap with the built-in ones and reloads them when it changes
+func setupRuntimeProfiles(mgr ctrl.Manager, config *operator.Config) error {
+	if len(runtimeProfiles) == 0 {
+		setupLog.Info("Runtime profiles", "profiles", runtimeprofile.GetRegistry().GetProfileNames())
+		return nil
+	}
+	configMap, err := common.ParseRuntimeProfilesConfigMap(runtimeProfiles)
+	if err != nil {
+		return err
+	}
+	reconciler := &common.RuntimeProfilesReconciler{ConfigMap: configMap, Config: config}
+	registry, err := reconciler.LoadRuntimeProfiles(mgr.GetAPIReader())
+	if err != nil {
+		return err
+	}
+	setupLog.Info("Runtime profiles", "profiles", registry.GetProfileNames(), "configMap", configMap)
+	return reconciler.SetupWithManager(mgr)
+}
+
 func isDebugMode() bool {
 	var debug = "DEBUG"
 	devMode, _ := os.LookupEnv(debug)
@@ -18,8 +18,10 @@ import (
 	"github.com/kiegroup/kogito-operator/apis/app/v1beta1"
 	v1 "github.com/kiegroup/kogito-operator/apis/rhpam/v1"
 	"github.com/kiegroup/kogito-operator/core/framework/util"
+	externalsecrets "github.com/kiegroup/kogito-operator/core/infrastructure/externalsecrets/v1beta1"
 	grafana "github.com/kiegroup/kogito-operator/core/infrastructure/grafana/v1alpha1"
 	infinispan "github.com/kiegroup/kogito-operator/core/infrastructure/infinispan/v1"
+	infinispanv2alpha1 "github.com/kiegroup/kogito-operator/core/infrastructure/infinispan/v2alpha1"
 	"github.com/kiegroup/kogito-operator/core/infrastructure/kafka/v1beta2"
 	keycloakv1alpha1 "github.com/kiegroup/kogito-operator/core/infrastructure/keycloak/v1alpha1"
 	mongodb "github.com/kiegroup/kogito-operator/core/infrastructure/mongodb/v1"
@@ -62,11 +64,13 @@ func GetRegisteredSchema() *runtime.Scheme {
 	metav1.AddToGroupVersion(s, monv1.SchemeGroupVersion)
 	metav1.AddToGroupVersion(s, routev1.GroupVersion)
 	metav1.AddToGroupVersion(s, infinispan.SchemeGroupVersion)
+	metav1.AddToGroupVersion(s, infinispanv2alpha1.SchemeGroupVersion)
 	metav1.AddToGroupVersion(s, mongodb.SchemeBuilder.GroupVersion)
 	metav1.AddToGroupVersion(s, v1beta2.SchemeGroupVersion)
 	metav1.AddToGroupVersion(s, grafana.GroupVersion)
 	metav1.AddToGroupVersion(s, eventingv1.SchemeGroupVersion)
 	metav1.AddToGroupVersion(s, sourcesv1.SchemeGroupVersion)
+	metav1.AddToGroupVersion(s, externalsecrets.SchemeGroupVersion)
 	return s
 }
 
@@ -88,8 +92,10 @@ func getRegisteredSchemeBuilder() runtime.SchemeBuilder {
 		v1beta2.SchemeBuilder.AddToScheme,
 		mongodb.SchemeBuilder.AddToScheme,
 		infinispan.AddToScheme,
+		infinispanv2alpha1.SchemeBuilder.AddToScheme,
 		keycloakv1alpha1.SchemeBuilder.AddToScheme,
 		monv1.SchemeBuilder.AddToScheme,
 		eventingv1.AddToScheme, sourcesv1.AddToScheme,
-		grafana.AddToScheme)
+		grafana.AddToScheme,
+		externalsecrets.SchemeBuilder.AddToScheme)
 }